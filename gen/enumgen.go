@@ -514,6 +514,59 @@ func main() {
 				Key:    "spell_prereq",
 				String: "spell(s)",
 			},
+			{
+				Name:   "Points",
+				Key:    "points_prereq",
+				String: "points spent",
+			},
+			{
+				Name:   "CampaignSetting",
+				Key:    "campaign_setting_prereq",
+				String: "the campaign setting",
+			},
+		},
+	})
+	processSourceTemplate(enumTmpl, &enumInfo{
+		Pkg:  "model",
+		Name: "points_category",
+		Desc: "holds the category of spent points a prerequisite will examine",
+		Values: []enumValue{
+			{
+				Key:    "total",
+				String: "in total",
+			},
+			{
+				Key:    "ancestry",
+				String: "on ancestry",
+			},
+			{
+				Key:    "attributes",
+				String: "on attributes",
+			},
+			{
+				Key:    "advantages",
+				String: "on advantages",
+			},
+			{
+				Key:    "disadvantages",
+				String: "on disadvantages",
+			},
+			{
+				Key:    "quirks",
+				String: "on quirks",
+			},
+			{
+				Key:    "skills",
+				String: "on skills",
+			},
+			{
+				Key:    "spells",
+				String: "on spells",
+			},
+			{
+				Key:    "tag",
+				String: "on items tagged",
+			},
 		},
 	})
 	processSourceTemplate(enumTmpl, &enumInfo{
@@ -570,6 +623,36 @@ func main() {
 			},
 		},
 	})
+	processSourceTemplate(enumTmpl, &enumInfo{
+		Pkg:  "model",
+		Name: "skill_kind",
+		Desc: "holds the kind of skill a prerequisite will match",
+		Values: []enumValue{
+			{
+				Key:    "either",
+				String: "a skill or technique",
+			},
+			{
+				Name:   "NonTechnique",
+				Key:    "skill",
+				String: "a skill",
+			},
+			{
+				Key:    "technique",
+				String: "a technique",
+			},
+		},
+	})
+	processSourceTemplate(enumTmpl, &enumInfo{
+		Pkg:  "model",
+		Name: "aggregation",
+		Desc: "holds the way the values in a table column are combined into a single value",
+		Values: []enumValue{
+			{Key: "sum"},
+			{Key: "average"},
+			{Key: "count"},
+		},
+	})
 	processSourceTemplate(enumTmpl, &enumInfo{
 		Pkg:  "model",
 		Name: "spell_comparison_type",
@@ -898,6 +981,25 @@ func main() {
 			{Key: "markov_chain"},
 		},
 	})
+	processSourceTemplate(enumTmpl, &enumInfo{
+		Pkg:  "model",
+		Name: "decimal_separator",
+		Desc: "holds the character used to separate the whole and fractional parts of numbers",
+		Values: []enumValue{
+			{
+				Key:    "locale",
+				String: "Based on Locale",
+			},
+			{
+				Key:    "period",
+				String: "Period (1.5)",
+			},
+			{
+				Key:    "comma",
+				String: "Comma (1,5)",
+			},
+		},
+	})
 }
 
 func removeExistingGenFiles() {
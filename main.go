@@ -39,15 +39,22 @@ Translations dir: "%s"`), model.SettingsPath(), i18n.Dir)
 	var convertFiles bool
 	cl.NewGeneralOption(&convertFiles).SetName("convert").SetSingle('c').
 		SetUsage(i18n.Text("Converts all files specified on the command line to the current data format. If a directory is specified, it will be traversed recursively and all files found will be converted. This operation is intended to easily bring files up to the current version's data format. After all files have been processed, GCS will exit"))
+	var checkOnly bool
+	cl.NewGeneralOption(&checkOnly).SetName("check").
+		SetUsage(i18n.Text("When used with --convert, reports the files that would be changed without modifying them. GCS will exit with a non-zero status if any would be changed"))
 	cl.NewGeneralOption(&dbg.VariableResolver).SetName("debug-variable-resolver")
 	fileList := jotrotate.ParseAndSetup(cl)
 	ux.RegisterKnownFileTypes()
 	model.GlobalSettings() // Here to force early initialization
 	switch {
 	case convertFiles:
-		if err := model.Convert(fileList...); err != nil {
+		changed, err := model.Convert(checkOnly, fileList...)
+		if err != nil {
 			cl.FatalMsg(err.Error())
 		}
+		if checkOnly && len(changed) != 0 {
+			atexit.Exit(1)
+		}
 	case textTmplPath != "":
 		if len(fileList) == 0 {
 			cl.FatalMsg(i18n.Text("No files to process."))
@@ -0,0 +1,54 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import "github.com/richardwilkes/gcs/v5/model/fxp"
+
+// Aggregate combines the values.
+func (enum Aggregation) Aggregate(values []fxp.Int) fxp.Int {
+	switch enum.EnsureValid() {
+	case AverageAggregation:
+		if len(values) == 0 {
+			return 0
+		}
+		return enum.sum(values).Div(fxp.From(len(values)))
+	case CountAggregation:
+		return fxp.From(len(values))
+	default:
+		return enum.sum(values)
+	}
+}
+
+func (enum Aggregation) sum(values []fxp.Int) fxp.Int {
+	var total fxp.Int
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// AggregateColumn combines the values found in the column with the given ID for each of the nodes. Containers are
+// skipped, since their cells typically already summarize their children.
+func AggregateColumn[T NodeTypes](enum Aggregation, columnID int, nodes []T) fxp.Int {
+	values := make([]fxp.Int, 0, len(nodes))
+	for _, one := range nodes {
+		node := AsNode(one)
+		if node.Container() {
+			continue
+		}
+		var data CellData
+		node.CellData(columnID, &data)
+		v, _ := fxp.Extract(data.Primary)
+		values = append(values, v)
+	}
+	return enum.Aggregate(values)
+}
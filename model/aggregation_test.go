@@ -0,0 +1,42 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregate(t *testing.T) {
+	values := []fxp.Int{fxp.One, fxp.Two, fxp.From(6)}
+	require.Equal(t, fxp.From(9), SumAggregation.Aggregate(values))
+	require.Equal(t, fxp.Three, AverageAggregation.Aggregate(values))
+	require.Equal(t, fxp.Three, CountAggregation.Aggregate(values))
+	require.Equal(t, fxp.Int(0), AverageAggregation.Aggregate(nil))
+}
+
+func TestAggregateColumn(t *testing.T) {
+	entity := NewEntity(PC)
+	container := NewSpell(entity, nil, true)
+	for _, pts := range []int{1, 4} {
+		spell := NewSpell(entity, container, false)
+		spell.Points = fxp.From(pts)
+		container.Children = append(container.Children, spell)
+	}
+	spell := NewSpell(entity, nil, false)
+	spell.Points = fxp.Two
+	nodes := []*Spell{container, container.Children[0], container.Children[1], spell}
+	require.Equal(t, fxp.From(7), AggregateColumn(SumAggregation, SpellPointsColumn, nodes))
+	require.Equal(t, fxp.Three, AggregateColumn(CountAggregation, SpellPointsColumn, nodes))
+}
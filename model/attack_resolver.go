@@ -0,0 +1,111 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/rand"
+)
+
+// Possible AttackOutcome values.
+const (
+	AttackMiss AttackOutcome = iota
+	AttackHit
+	AttackCriticalHit
+	AttackCriticalMiss
+)
+
+// AttackOutcome describes the result of an attack roll.
+type AttackOutcome byte
+
+// String implements fmt.Stringer.
+func (o AttackOutcome) String() string {
+	switch o {
+	case AttackHit:
+		return i18n.Text("Hit")
+	case AttackCriticalHit:
+		return i18n.Text("Critical Hit")
+	case AttackCriticalMiss:
+		return i18n.Text("Critical Miss")
+	default:
+		return i18n.Text("Miss")
+	}
+}
+
+// Hit returns true if the outcome was a hit of some kind.
+func (o AttackOutcome) Hit() bool {
+	return o == AttackHit || o == AttackCriticalHit
+}
+
+// AttackResult holds the result of resolving an attack.
+type AttackResult struct {
+	BaseSkill      int
+	Modifier       int
+	EffectiveSkill int
+	Roll           RollResult
+	Outcome        AttackOutcome
+	// Damage is only set when the attack hits.
+	Damage *ResolvedWeaponDamage
+}
+
+// ResolveAttack resolves a single attack with the weapon. The base skill is the best of the weapon's defaults for the
+// entity plus any skill bonuses that apply to the weapon; the situational modifier is then added to produce the
+// effective skill, against which 3d6 are rolled. Critical successes and failures follow the standard rules (B347).
+//
+// Unlike Weapon.SkillLevel(), the penalty for being below the weapon's minimum ST and the encumbrance penalty applied
+// to fencing weapons are not included, as these are handled separately at the table; the latter only affects parry.
+// If rnd is nil, a cryptographically secure randomizer will be used.
+func ResolveAttack(w *Weapon, entity *Entity, modifier int, rnd rand.Randomizer) (*AttackResult, error) {
+	if w == nil || entity == nil {
+		return nil, errs.New(i18n.Text("a weapon and an entity are required to resolve an attack"))
+	}
+	best := fxp.Min
+	for _, def := range w.Defaults {
+		if level := def.SkillLevelFast(entity, false, nil, true); level != fxp.Min && best < level {
+			best = level
+		}
+	}
+	if best == fxp.Min {
+		return nil, errs.New(i18n.Text("the weapon has no usable skill defaults"))
+	}
+	best += w.skillBonusAdjustment(entity, nil)
+	if best < 0 {
+		best = 0
+	}
+	result := &AttackResult{
+		BaseSkill: fxp.As[int](best),
+		Modifier:  modifier,
+	}
+	result.EffectiveSkill = result.BaseSkill + modifier
+	result.Roll = Roll(&dice.Dice{Count: 3, Sides: 6, Multiplier: 1}, rnd)
+	result.Outcome = attackOutcome(result.Roll.Total, result.EffectiveSkill)
+	if result.Outcome.Hit() {
+		result.Damage = w.Damage.Resolve(nil)
+	}
+	return result, nil
+}
+
+func attackOutcome(roll, skill int) AttackOutcome {
+	switch {
+	case roll <= 4, roll == 5 && skill >= 15, roll == 6 && skill >= 16:
+		return AttackCriticalHit
+	case roll == 18, roll == 17 && skill <= 15, roll >= skill+10:
+		return AttackCriticalMiss
+	case roll <= skill && roll < 17:
+		return AttackHit
+	default:
+		return AttackMiss
+	}
+}
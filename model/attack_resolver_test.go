@@ -0,0 +1,54 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveAttack(t *testing.T) {
+	entity := NewEntity(PC)
+	trait := NewTrait(entity, nil, false)
+	entity.Traits = append(entity.Traits, trait)
+	w := NewWeapon(trait, MeleeWeaponType)
+	w.Defaults = []*SkillDefault{{DefaultType: "dx"}}
+	w.MinimumStrength = "20"
+	trait.Weapons = append(trait.Weapons, w)
+	entity.Recalculate()
+
+	result, err := ResolveAttack(w, entity, -2, &fixedRandomizer{2, 2, 2})
+	require.NoError(t, err)
+	require.Equal(t, 10, result.BaseSkill, "minimum ST must not penalize the attack")
+	require.Equal(t, 8, result.EffectiveSkill)
+	require.Equal(t, 9, result.Roll.Total)
+	require.Equal(t, AttackMiss, result.Outcome)
+	require.Nil(t, result.Damage)
+
+	result, err = ResolveAttack(w, entity, 0, &fixedRandomizer{0, 0, 1})
+	require.NoError(t, err)
+	require.Equal(t, AttackCriticalHit, result.Outcome)
+	require.NotNil(t, result.Damage)
+
+	result, err = ResolveAttack(w, entity, 0, &fixedRandomizer{5, 5, 4})
+	require.NoError(t, err)
+	require.Equal(t, AttackCriticalMiss, result.Outcome)
+
+	w.Defaults = nil
+	_, err = ResolveAttack(w, entity, 0, nil)
+	require.Error(t, err)
+	require.Equal(t, AttackHit, attackOutcome(16, 16))
+	require.Equal(t, AttackMiss, attackOutcome(17, 16))
+	require.Equal(t, AttackCriticalHit, attackOutcome(6, 16))
+	require.Equal(t, AttackCriticalMiss, attackOutcome(15, 5))
+}
@@ -158,7 +158,12 @@ func (a *AttributeDefs) List(omitSeparators bool) []*AttributeDef {
 		}
 		list = append(list, v)
 	}
-	sort.Slice(list, func(i, j int) bool { return list[i].Order < list[j].Order })
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Order != list[j].Order {
+			return list[i].Order < list[j].Order
+		}
+		return list[i].DefID < list[j].DefID
+	})
 	return list
 }
 
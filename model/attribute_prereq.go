@@ -27,6 +27,8 @@ type AttributePrereq struct {
 	CombinedWith      string          `json:"combined_with,omitempty"`
 	QualifierCriteria NumericCriteria `json:"qualifier,omitempty"`
 	Which             string          `json:"which"`
+	// UseMaximum only affects pool attributes, causing their maximum value to be used rather than their current value.
+	UseMaximum bool `json:"use_maximum,omitempty"`
 }
 
 // NewAttributePrereq creates a new AttributePrereq. 'entity' may be nil.
@@ -71,9 +73,9 @@ func (a *AttributePrereq) ApplyNameableKeys(_ map[string]string) {
 
 // Satisfied implements Prereq.
 func (a *AttributePrereq) Satisfied(entity *Entity, _ any, tooltip *xio.ByteBuffer, prefix string, _ *bool) bool {
-	value := entity.ResolveAttributeCurrent(a.Which)
+	value := a.resolveValue(entity, a.Which)
 	if a.CombinedWith != "" {
-		value += entity.ResolveAttributeCurrent(a.CombinedWith)
+		value += a.resolveValue(entity, a.CombinedWith)
 	}
 	satisfied := a.QualifierCriteria.Matches(value)
 	if !a.Has {
@@ -88,8 +90,56 @@ func (a *AttributePrereq) Satisfied(entity *Entity, _ any, tooltip *xio.ByteBuff
 			tooltip.WriteByte('+')
 			tooltip.WriteString(entity.ResolveAttributeName(a.CombinedWith))
 		}
+		if a.UseMaximum && a.IsPool(entity) {
+			tooltip.WriteString(i18n.Text(" maximum"))
+		}
 		tooltip.WriteString(i18n.Text(" which "))
 		tooltip.WriteString(a.QualifierCriteria.String())
 	}
 	return satisfied
 }
+
+// Description implements Prereq.
+func (a *AttributePrereq) Description(entity *Entity) string {
+	label := ResolveAttributeName(entity, a.Which)
+	if a.CombinedWith != "" {
+		label += "+" + ResolveAttributeName(entity, a.CombinedWith)
+	}
+	if a.UseMaximum && a.IsPool(entity) {
+		label += i18n.Text(" maximum")
+	}
+	return compactPrereq(a.Has, label, a.QualifierCriteria.CompactString())
+}
+
+// Equal implements Prereq.
+func (a *AttributePrereq) Equal(other Prereq) bool {
+	o, ok := other.(*AttributePrereq)
+	return ok && a.Type == o.Type && a.Has == o.Has && a.Which == o.Which && a.CombinedWith == o.CombinedWith &&
+		a.QualifierCriteria == o.QualifierCriteria && a.UseMaximum == o.UseMaximum
+}
+
+// IsPool returns true if either of the attributes this prereq references is a pool. 'entity' may be nil.
+func (a *AttributePrereq) IsPool(entity *Entity) bool {
+	defs := AttributeDefsFor(entity)
+	for _, id := range []string{a.Which, a.CombinedWith} {
+		if def := defs.Set[id]; def != nil && def.Pool() {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *AttributePrereq) currentValue(entity *Entity, _ any) string {
+	value := a.resolveValue(entity, a.Which)
+	if a.CombinedWith != "" {
+		value += a.resolveValue(entity, a.CombinedWith)
+	}
+	return value.String()
+}
+
+func (a *AttributePrereq) resolveValue(entity *Entity, attrID string) fxp.Int {
+	if a.UseMaximum && entity != nil && entity.Type == PC {
+		return entity.Attributes.Maximum(attrID)
+	}
+	return entity.ResolveAttributeCurrent(attrID)
+}
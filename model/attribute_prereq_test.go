@@ -0,0 +1,34 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttributePrereqPoolMaximum(t *testing.T) {
+	entity := NewEntity(PC)
+	entity.Attributes.Set["fp"].Damage = fxp.From(5)
+	prereq := NewAttributePrereq(entity)
+	prereq.Which = "fp"
+	require.True(t, prereq.IsPool(entity))
+	require.False(t, prereq.Satisfied(entity, nil, nil, "", nil))
+	prereq.UseMaximum = true
+	require.True(t, prereq.Satisfied(entity, nil, nil, "", nil))
+
+	prereq.Which = "st"
+	require.False(t, prereq.IsPool(entity))
+	require.True(t, prereq.Satisfied(entity, nil, nil, "", nil))
+}
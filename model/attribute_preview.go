@@ -0,0 +1,111 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strconv"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// PreviewAttributeChange returns the changes that setting the attribute with the given ID to value would cause to the
+// entity's attributes, derived values, skill levels and spell levels. The entity itself is not modified; the changes are
+// computed on a copy of it.
+func PreviewAttributeChange(entity *Entity, attrID string, value fxp.Int) ([]Change, error) {
+	if entity == nil {
+		return nil, errs.New(i18n.Text("an entity is required"))
+	}
+	attr, ok := entity.Attributes.Set[attrID]
+	if !ok {
+		return nil, errs.Newf(i18n.Text("unable to locate attribute data for '%s'"), attrID)
+	}
+	if attr.Maximum() == value {
+		return nil, nil
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var whatIf Entity
+	if err = json.Unmarshal(data, &whatIf); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	whatIf.Recalculate()
+	if whatIfAttr, exists := whatIf.Attributes.Set[attrID]; exists {
+		whatIfAttr.SetMaximum(value)
+	}
+	whatIf.Recalculate()
+	entity.Recalculate()
+	changes := diffAttributes(entity, &whatIf)
+	changes = append(changes, diffDerivedValues(entity, &whatIf)...)
+	changes = append(changes, diffNodes(i18n.Text("Skill"), entity.Skills, whatIf.Skills, func(s *Skill) diffItem {
+		return diffItem{
+			id:      s.ID,
+			name:    s.String(),
+			summary: s.LevelData.LevelAsString(false),
+		}
+	})...)
+	changes = append(changes, diffNodes(i18n.Text("Spell"), entity.Spells, whatIf.Spells, func(s *Spell) diffItem {
+		return diffItem{
+			id:      s.ID,
+			name:    s.String(),
+			summary: s.LevelData.LevelAsString(false),
+		}
+	})...)
+	return changes, nil
+}
+
+func diffDerivedValues(a, b *Entity) []Change {
+	type derived struct {
+		name  string
+		value func(e *Entity) string
+	}
+	list := []derived{
+		{
+			name:  i18n.Text("Basic Lift"),
+			value: func(e *Entity) string { return e.SheetSettings.DefaultWeightUnits.Format(e.BasicLift()) },
+		},
+		{
+			name:  i18n.Text("Thrust"),
+			value: func(e *Entity) string { return e.Thrust().String() },
+		},
+		{
+			name:  i18n.Text("Swing"),
+			value: func(e *Entity) string { return e.Swing().String() },
+		},
+		{
+			name:  i18n.Text("Dodge"),
+			value: func(e *Entity) string { return strconv.Itoa(e.Dodge(NoEncumbrance)) },
+		},
+		{
+			name:  i18n.Text("Move"),
+			value: func(e *Entity) string { return strconv.Itoa(e.Move(NoEncumbrance)) },
+		},
+	}
+	category := i18n.Text("Derived")
+	var changes []Change
+	for _, one := range list {
+		if before, after := one.value(a), one.value(b); before != after {
+			changes = append(changes, Change{
+				Kind:     ModifiedChange,
+				Category: category,
+				Name:     one.name,
+				Before:   before,
+				After:    after,
+			})
+		}
+	}
+	return changes
+}
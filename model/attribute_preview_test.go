@@ -0,0 +1,60 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewAttributeChange(t *testing.T) {
+	e := NewEntity(PC)
+	skill := NewSkill(e, nil, false)
+	skill.Name = "Acrobatics"
+	skill.Points = fxp.One
+	e.Skills = append(e.Skills, skill)
+	e.Recalculate()
+	levelBefore := skill.LevelData.Level
+
+	changes, err := PreviewAttributeChange(e, DexterityID, fxp.From(14))
+	require.NoError(t, err)
+	found := make(map[string]Change)
+	for _, one := range changes {
+		found[one.Category+":"+one.Name] = one
+	}
+	require.Contains(t, found, "Skill:Acrobatics")
+	require.Equal(t, ModifiedChange, found["Skill:Acrobatics"].Kind)
+	require.Contains(t, found, "Derived:Dodge")
+	require.NotContains(t, found, "Derived:Thrust")
+	require.Equal(t, fxp.Ten, e.Attributes.Set[DexterityID].Maximum(), "the entity itself must not change")
+	require.Equal(t, levelBefore, skill.LevelData.Level)
+
+	changes, err = PreviewAttributeChange(e, StrengthID, fxp.Fifteen)
+	require.NoError(t, err)
+	found = make(map[string]Change)
+	for _, one := range changes {
+		found[one.Category+":"+one.Name] = one
+	}
+	require.Contains(t, found, "Derived:Thrust")
+	require.Contains(t, found, "Derived:Swing")
+	require.Contains(t, found, "Derived:Basic Lift")
+	require.NotContains(t, found, "Skill:Acrobatics")
+
+	changes, err = PreviewAttributeChange(e, StrengthID, fxp.Ten)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	_, err = PreviewAttributeChange(e, "nonexistent", fxp.Ten)
+	require.Error(t, err)
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import "github.com/richardwilkes/gcs/v5/model/fxp"
+
+// AttributeState holds a snapshot of an attribute's value and, for pools, its active threshold.
+type AttributeState struct {
+	AttrID    string
+	Name      string
+	Pool      bool
+	Current   fxp.Int
+	Maximum   fxp.Int
+	Threshold *PoolThreshold
+}
+
+// State returns the name of the active threshold state, e.g. "Collapse", or an empty string if there is none.
+func (s *AttributeState) State() string {
+	if s.Threshold == nil {
+		return ""
+	}
+	return s.Threshold.State
+}
+
+// HasOp returns true if the active threshold applies the given operation.
+func (s *AttributeState) HasOp(op ThresholdOp) bool {
+	return s.Threshold != nil && s.Threshold.ContainsOp(op)
+}
+
+// AttributeStates returns the state of each attribute, in display order. Separators are omitted.
+func (e *Entity) AttributeStates() []*AttributeState {
+	list := e.Attributes.List()
+	states := make([]*AttributeState, 0, len(list))
+	for _, attr := range list {
+		if state := attr.State(); state != nil {
+			states = append(states, state)
+		}
+	}
+	return states
+}
+
+// AttributeState returns the state of the attribute with the given ID, or nil if there is no such attribute.
+func (e *Entity) AttributeState(attrID string) *AttributeState {
+	if attr, ok := e.Attributes.Set[attrID]; ok {
+		return attr.State()
+	}
+	return nil
+}
+
+// State returns a snapshot of the attribute's value and active threshold. Returns nil for separators.
+func (a *Attribute) State() *AttributeState {
+	def := a.AttributeDef()
+	if def == nil || def.IsSeparator() {
+		return nil
+	}
+	return &AttributeState{
+		AttrID:    a.AttrID,
+		Name:      def.Name,
+		Pool:      def.Type == PoolAttributeType,
+		Current:   a.Current(),
+		Maximum:   a.Maximum(),
+		Threshold: a.CurrentThreshold(),
+	}
+}
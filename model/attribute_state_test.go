@@ -0,0 +1,44 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttributeStates(t *testing.T) {
+	entity := NewEntity(PC)
+	fp := entity.AttributeState("fp")
+	require.NotNil(t, fp)
+	require.True(t, fp.Pool)
+	require.Equal(t, "Rested", fp.State())
+
+	entity.Attributes.Set["fp"].Damage = fxp.From(10)
+	fp = entity.AttributeState("fp")
+	require.Equal(t, fxp.Int(0), fp.Current)
+	require.Equal(t, "Collapse", fp.State())
+
+	st := entity.AttributeState("st")
+	require.NotNil(t, st)
+	require.False(t, st.Pool)
+	require.Equal(t, "", st.State())
+	require.Nil(t, entity.AttributeState("nonexistent"))
+
+	states := entity.AttributeStates()
+	require.NotEmpty(t, states)
+	for _, one := range states {
+		require.NotNil(t, one)
+	}
+}
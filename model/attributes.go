@@ -73,7 +73,10 @@ func (a *Attributes) List() []*Attribute {
 		list = append(list, v)
 	}
 	sort.Slice(list, func(i, j int) bool {
-		return list[i].Order < list[j].Order
+		if list[i].Order != list[j].Order {
+			return list[i].Order < list[j].Order
+		}
+		return list[i].AttrID < list[j].AttrID
 	})
 	return list
 }
@@ -16,6 +16,7 @@ import (
 	"embed"
 	"io/fs"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/jio"
@@ -23,6 +24,7 @@ import (
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/log/jot"
 	"github.com/richardwilkes/toolbox/txt"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -100,6 +102,7 @@ func (b *Body) Clone(entity *Entity, owningLocation *HitLocation) *Body {
 		Name:           b.Name,
 		Roll:           dice.New(b.Roll.String()),
 		Locations:      make([]*HitLocation, len(b.Locations)),
+		KeyPrefix:      b.KeyPrefix,
 		owningLocation: owningLocation,
 	}
 	for i, one := range b.Locations {
@@ -160,6 +163,37 @@ func (b *Body) AddLocation(loc *HitLocation) {
 	loc.owningTable = b
 }
 
+// DuplicateLocation inserts a copy of the HitLocation immediately after it, with its hit penalty adjusted by
+// penaltyDelta. The copy is given an ID not otherwise in use within the body type and does not include any sub-table.
+// Returns nil if the location isn't in this table.
+func (b *Body) DuplicateLocation(entity *Entity, loc *HitLocation, penaltyDelta int, prefixProvider func() string) *HitLocation {
+	i := slices.Index(b.Locations, loc)
+	if i == -1 {
+		return nil
+	}
+	root := b
+	for root.owningLocation != nil && root.owningLocation.owningTable != nil {
+		root = root.owningLocation.owningTable
+	}
+	used := make(map[string]bool)
+	for _, one := range root.AllHitLocations() {
+		used[one.LocID] = true
+	}
+	dup := loc.Clone(entity, b)
+	dup.SubTable = nil
+	dup.HitPenalty += penaltyDelta
+	for n := 2; ; n++ {
+		if id := loc.LocID + strconv.Itoa(n); !used[id] {
+			dup.LocID = id
+			break
+		}
+	}
+	dup.ResetTargetKeyPrefixes(prefixProvider)
+	b.Locations = slices.Insert(b.Locations, i+1, dup)
+	b.Update(entity)
+	return dup
+}
+
 // RemoveLocation removes a HitLocation.
 func (b *Body) RemoveLocation(loc *HitLocation) {
 	for i, one := range b.Locations {
@@ -172,6 +206,19 @@ func (b *Body) RemoveLocation(loc *HitLocation) {
 	}
 }
 
+// AllHitLocations returns all of the hit locations in table order, including those within sub-tables, which
+// immediately follow their owning location.
+func (b *Body) AllHitLocations() []*HitLocation {
+	var list []*HitLocation
+	for _, loc := range b.Locations {
+		list = append(list, loc)
+		if loc.SubTable != nil {
+			list = append(list, loc.SubTable.AllHitLocations()...)
+		}
+	}
+	return list
+}
+
 // UniqueHitLocations returns the list of unique hit locations.
 func (b *Body) UniqueHitLocations(entity *Entity) []*HitLocation {
 	if len(b.locationLookup) == 0 {
@@ -0,0 +1,110 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+// PaperDollSize is the width and height of the schematic silhouette that PaperDoll regions are placed within. Locations
+// that aren't part of the silhouette are placed in a column to its right.
+const PaperDollSize = 100
+
+// PaperDollRegion is an area of a schematic body silhouette that represents a hit location.
+type PaperDollRegion struct {
+	Location *HitLocation
+	X        float32
+	Y        float32
+	Width    float32
+	Height   float32
+}
+
+// Contains returns true if the point lies within the region.
+func (r *PaperDollRegion) Contains(x, y float32) bool {
+	return x >= r.X && y >= r.Y && x < r.X+r.Width && y < r.Y+r.Height
+}
+
+type paperDollShape struct {
+	x, y, width, height float32
+}
+
+// Shapes for the standard humanoid locations. Those with two shapes are paired; the first is the right side of the
+// body, which is drawn on the viewer's left.
+var paperDollShapes = map[string][]paperDollShape{
+	"skull":  {{40, 0, 20, 6}},
+	"eye":    {{41, 6, 7, 4}, {52, 6, 7, 4}},
+	"face":   {{40, 10, 20, 5}},
+	"neck":   {{45, 15, 10, 4}},
+	"torso":  {{35, 19, 30, 24}},
+	"vitals": {{42, 23, 16, 11}},
+	"groin":  {{38, 43, 24, 7}},
+	"arm":    {{22, 19, 12, 26}, {66, 19, 12, 26}},
+	"hand":   {{21, 45, 12, 8}, {67, 45, 12, 8}},
+	"leg":    {{36, 50, 13, 38}, {51, 50, 13, 38}},
+	"foot":   {{33, 88, 16, 8}, {51, 88, 16, 8}},
+}
+
+// PaperDoll returns the regions of a schematic body silhouette for the body type's top-level hit locations, in drawing
+// order. A paired location, such as an arm, that appears only once is shown on both sides. Hit locations that aren't
+// recognized are stacked in a column to the right of the silhouette.
+func (b *Body) PaperDoll() []*PaperDollRegion {
+	var regions, overlays, extras []*PaperDollRegion
+	used := make(map[string]int)
+	count := make(map[string]int)
+	for _, loc := range b.Locations {
+		count[loc.LocID]++
+	}
+	for _, loc := range b.Locations {
+		shapes, ok := paperDollShapes[loc.LocID]
+		if !ok {
+			extras = append(extras, &PaperDollRegion{
+				Location: loc,
+				X:        PaperDollSize + 4,
+				Y:        float32(len(extras)) * 10,
+				Width:    24,
+				Height:   8,
+			})
+			continue
+		}
+		if len(shapes) > 1 && count[loc.LocID] > 1 {
+			i := used[loc.LocID]
+			used[loc.LocID]++
+			if i >= len(shapes) {
+				continue
+			}
+			shapes = shapes[i : i+1]
+		}
+		for _, shape := range shapes {
+			region := &PaperDollRegion{
+				Location: loc,
+				X:        shape.x,
+				Y:        shape.y,
+				Width:    shape.width,
+				Height:   shape.height,
+			}
+			if loc.LocID == "vitals" {
+				// Vitals lie within the torso, so must be drawn after it.
+				overlays = append(overlays, region)
+			} else {
+				regions = append(regions, region)
+			}
+		}
+	}
+	regions = append(regions, overlays...)
+	return append(regions, extras...)
+}
+
+// PaperDollRegionAt returns the topmost region containing the point, or nil.
+func PaperDollRegionAt(regions []*PaperDollRegion, x, y float32) *PaperDollRegion {
+	for i := len(regions) - 1; i >= 0; i-- {
+		if regions[i].Contains(x, y) {
+			return regions[i]
+		}
+	}
+	return nil
+}
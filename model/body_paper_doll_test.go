@@ -0,0 +1,56 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyPaperDoll(t *testing.T) {
+	body := FactoryBody()
+	regions := body.PaperDoll()
+	shown := make(map[*HitLocation]int)
+	for _, r := range regions {
+		shown[r.Location]++
+		require.LessOrEqual(t, r.X+r.Width, float32(PaperDollSize), r.Location.LocID)
+		require.LessOrEqual(t, r.Y+r.Height, float32(PaperDollSize), r.Location.LocID)
+	}
+	for _, loc := range body.Locations {
+		require.NotZero(t, shown[loc], loc.LocID)
+	}
+
+	var arms []*HitLocation
+	for _, loc := range body.Locations {
+		if loc.LocID == "arm" {
+			arms = append(arms, loc)
+		}
+	}
+	require.Len(t, arms, 2)
+	require.Equal(t, 1, shown[arms[0]], "each of a pair of arms gets its own side")
+	require.Equal(t, 2, shown[body.LookupLocationByID(nil, "hand")], "a lone hand is shown on both sides")
+
+	vitals := PaperDollRegionAt(regions, 50, 28)
+	require.NotNil(t, vitals)
+	require.Equal(t, "vitals", vitals.Location.LocID, "vitals should be on top of the torso")
+	require.Equal(t, "torso", PaperDollRegionAt(regions, 36, 40).Location.LocID)
+	require.Nil(t, PaperDollRegionAt(regions, 1, 1))
+
+	tail := NewHitLocation(nil, "")
+	tail.LocID = "tail"
+	body.AddLocation(tail)
+	regions = body.PaperDoll()
+	last := regions[len(regions)-1]
+	require.Same(t, tail, last.Location)
+	require.GreaterOrEqual(t, last.X, float32(PaperDollSize))
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/toolbox/txt"
+	"golang.org/x/exp/slices"
+)
+
+// BodyTemplate holds a named Body that can be used as the starting point for a new body type.
+type BodyTemplate struct {
+	Name string `json:"name"`
+	Body *Body  `json:"body"`
+}
+
+// BodyTemplates holds a list of BodyTemplate, sorted by name.
+type BodyTemplates []*BodyTemplate
+
+// EnsureValidity checks the current templates for validity and if they aren't valid, makes them so.
+func (t *BodyTemplates) EnsureValidity() {
+	list := make(BodyTemplates, 0, len(*t))
+	for _, one := range *t {
+		one.Name = strings.TrimSpace(one.Name)
+		if one.Name != "" && one.Body != nil && list.Lookup(one.Name) == nil {
+			one.Body.Update(nil)
+			list = append(list, one)
+		}
+	}
+	list.sort()
+	*t = list
+}
+
+// Lookup returns the BodyTemplate with the given name, or nil.
+func (t BodyTemplates) Lookup(name string) *BodyTemplate {
+	for _, one := range t {
+		if strings.EqualFold(one.Name, name) {
+			return one
+		}
+	}
+	return nil
+}
+
+// Set adds a copy of the Body as a template with the given name, replacing any existing template with the same name.
+func (t *BodyTemplates) Set(name string, body *Body) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return
+	}
+	if existing := t.Lookup(name); existing != nil {
+		existing.Name = name
+		existing.Body = body.Clone(nil, nil)
+		return
+	}
+	*t = append(*t, &BodyTemplate{
+		Name: name,
+		Body: body.Clone(nil, nil),
+	})
+	t.sort()
+}
+
+// Remove the template with the given name.
+func (t *BodyTemplates) Remove(name string) {
+	if i := slices.IndexFunc(*t, func(one *BodyTemplate) bool { return strings.EqualFold(one.Name, name) }); i != -1 {
+		*t = slices.Delete(*t, i, i+1)
+	}
+}
+
+func (t BodyTemplates) sort() {
+	slices.SortFunc(t, func(a, b *BodyTemplate) bool { return txt.NaturalLess(a.Name, b.Name, true) })
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/json"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyTemplates(t *testing.T) {
+	var templates BodyTemplates
+	body := FactoryBody()
+	templates.Set("Quadruped", body)
+	templates.Set("humanoid", body)
+	templates.Set("  ", body)
+	require.Len(t, templates, 2)
+	require.Equal(t, "humanoid", templates[0].Name)
+	require.NotSame(t, body, templates[0].Body)
+
+	templates.Set("Humanoid", body)
+	require.Len(t, templates, 2)
+	require.Equal(t, "Humanoid", templates.Lookup("HUMANOID").Name)
+
+	data, err := json.Marshal(templates)
+	require.NoError(t, err)
+	var loaded BodyTemplates
+	require.NoError(t, json.Unmarshal(data, &loaded))
+	loaded.EnsureValidity()
+	require.Len(t, loaded, 2)
+	require.Equal(t, len(body.Locations), len(loaded.Lookup("quadruped").Body.Locations))
+
+	loaded.Remove("quadruped")
+	require.Len(t, loaded, 1)
+	require.Nil(t, loaded.Lookup("Quadruped"))
+}
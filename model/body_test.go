@@ -0,0 +1,60 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyAllHitLocations(t *testing.T) {
+	body := FactoryBody()
+	all := body.AllHitLocations()
+	require.GreaterOrEqual(t, len(all), len(body.Locations))
+	i := 0
+	for _, loc := range body.Locations {
+		require.Same(t, loc, all[i])
+		i++
+		if loc.SubTable != nil {
+			i += len(loc.SubTable.AllHitLocations())
+		}
+	}
+	require.Equal(t, len(all), i)
+}
+
+func TestBodyDuplicateLocation(t *testing.T) {
+	body := FactoryBody()
+	body.Update(nil)
+	original := body.Locations[1]
+	count := len(body.Locations)
+	prefixes := 0
+	dup := body.DuplicateLocation(nil, original, -2, func() string {
+		prefixes++
+		return "test."
+	})
+	require.NotNil(t, dup)
+	require.Len(t, body.Locations, count+1)
+	require.Same(t, dup, body.Locations[2])
+	require.Same(t, body, dup.OwningTable())
+	require.Equal(t, original.LocID+"2", dup.LocID)
+	require.Equal(t, original.HitPenalty-2, dup.HitPenalty)
+	require.Equal(t, original.DRBonus, dup.DRBonus)
+	require.Equal(t, original.TableName, dup.TableName)
+	require.Nil(t, dup.SubTable)
+	require.Equal(t, 1, prefixes)
+	require.Same(t, dup, body.LookupLocationByID(nil, dup.LocID))
+
+	again := body.DuplicateLocation(nil, original, 0, func() string { return "test." })
+	require.Equal(t, original.LocID+"3", again.LocID)
+	require.Nil(t, body.DuplicateLocation(nil, NewHitLocation(nil, ""), 0, func() string { return "" }))
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio"
+)
+
+var _ Prereq = &CampaignSettingPrereq{}
+
+// CampaignSettingPrereq holds a prerequisite for a boolean flag in the sheet settings being set.
+type CampaignSettingPrereq struct {
+	Parent *PrereqList `json:"-"`
+	Type   PrereqType  `json:"type"`
+	Has    bool        `json:"has"`
+	Which  string      `json:"which"`
+}
+
+// NewCampaignSettingPrereq creates a new CampaignSettingPrereq.
+func NewCampaignSettingPrereq() *CampaignSettingPrereq {
+	return &CampaignSettingPrereq{
+		Type:  CampaignSettingPrereqType,
+		Has:   true,
+		Which: SheetSettingsFlags()[0].Key,
+	}
+}
+
+// PrereqType implements Prereq.
+func (c *CampaignSettingPrereq) PrereqType() PrereqType {
+	return c.Type
+}
+
+// ParentList implements Prereq.
+func (c *CampaignSettingPrereq) ParentList() *PrereqList {
+	return c.Parent
+}
+
+// Clone implements Prereq.
+func (c *CampaignSettingPrereq) Clone(parent *PrereqList) Prereq {
+	clone := *c
+	clone.Parent = parent
+	return &clone
+}
+
+// FillWithNameableKeys implements Prereq.
+func (c *CampaignSettingPrereq) FillWithNameableKeys(_ map[string]string) {
+}
+
+// ApplyNameableKeys implements Prereq.
+func (c *CampaignSettingPrereq) ApplyNameableKeys(_ map[string]string) {
+}
+
+// Satisfied implements Prereq.
+func (c *CampaignSettingPrereq) Satisfied(entity *Entity, _ any, tooltip *xio.ByteBuffer, prefix string, _ *bool) bool {
+	flag := SheetSettingsFlagFor(c.Which)
+	satisfied := flag != nil && flag.Enabled(SheetSettingsFor(entity))
+	if !c.Has {
+		satisfied = !satisfied
+	}
+	if !satisfied && tooltip != nil {
+		tooltip.WriteString(prefix)
+		tooltip.WriteString(HasText(c.Has))
+		tooltip.WriteString(i18n.Text(" the campaign setting "))
+		tooltip.WriteString(c.flagTitle())
+		tooltip.WriteString(i18n.Text(" enabled"))
+	}
+	return satisfied
+}
+
+func (c *CampaignSettingPrereq) flagTitle() string {
+	if flag := SheetSettingsFlagFor(c.Which); flag != nil {
+		return flag.Title
+	}
+	return c.Which
+}
+
+// Description implements Prereq.
+func (c *CampaignSettingPrereq) Description(_ *Entity) string {
+	return compactPrereq(c.Has, i18n.Text("Setting: ")+c.flagTitle(), "")
+}
+
+// Equal implements Prereq.
+func (c *CampaignSettingPrereq) Equal(other Prereq) bool {
+	o, ok := other.(*CampaignSettingPrereq)
+	return ok && c.Type == o.Type && c.Has == o.Has && c.Which == o.Which
+}
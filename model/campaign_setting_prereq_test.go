@@ -0,0 +1,37 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCampaignSettingPrereq(t *testing.T) {
+	entity := NewEntity(PC)
+	pr := NewCampaignSettingPrereq()
+	pr.Which = "use_half_stat_defaults"
+	entity.SheetSettings.UseHalfStatDefaults = false
+	require.False(t, pr.Satisfied(entity, nil, nil, "", nil))
+	entity.SheetSettings.UseHalfStatDefaults = true
+	require.True(t, pr.Satisfied(entity, nil, nil, "", nil))
+	pr.Has = false
+	require.False(t, pr.Satisfied(entity, nil, nil, "", nil))
+	require.Equal(t, "NOT Setting: Use Half-Stat Defaults", pr.Description(entity))
+
+	pr.Which = "unknown"
+	require.True(t, pr.Satisfied(entity, nil, nil, "", nil))
+	for _, one := range SheetSettingsFlags() {
+		require.Equal(t, one.Title, SheetSettingsFlagFor(one.Key).Title)
+	}
+}
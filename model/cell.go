@@ -11,7 +11,12 @@
 
 package model
 
-import "github.com/richardwilkes/unison"
+import (
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/unison"
+)
 
 // PageRefCellAlias is used an alias to request the page reference cell, if any.
 const PageRefCellAlias = -10
@@ -26,8 +31,13 @@ type CellData struct {
 	Primary           string
 	Secondary         string
 	Tooltip           string
+	InfoPop           string
 	UnsatisfiedReason string
 	TemplateInfo      string
+	LibraryInfo       string
+	SortKey           string
+	// Heading is set for the text of a row that only serves as a heading for the rows that follow it.
+	Heading bool
 }
 
 // ForSort returns a string that can be used to sort or search against for this data.
@@ -47,3 +57,13 @@ func (c *CellData) ForSort() string {
 	}
 	return ""
 }
+
+// leadingNumberSortKey returns a sort key made from the number at the start of the text, such as the 2 in
+// "2 per minute", or an empty string if the text doesn't start with a number.
+func leadingNumberSortKey(text string) string {
+	text = strings.TrimSpace(text)
+	if value, remainder := fxp.Extract(text); remainder != text {
+		return value.String()
+	}
+	return ""
+}
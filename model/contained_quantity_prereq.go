@@ -67,15 +67,11 @@ func (c *ContainedQuantityPrereq) ApplyNameableKeys(_ map[string]string) {
 }
 
 // Satisfied implements Prereq.
-func (c *ContainedQuantityPrereq) Satisfied(_ *Entity, exclude any, tooltip *xio.ByteBuffer, prefix string, _ *bool) bool {
+func (c *ContainedQuantityPrereq) Satisfied(entity *Entity, exclude any, tooltip *xio.ByteBuffer, prefix string, _ *bool) bool {
 	satisfied := false
 	if eqp, ok := exclude.(*Equipment); ok {
 		if satisfied = !eqp.Container(); !satisfied {
-			var qty fxp.Int
-			for _, child := range eqp.Children {
-				qty += child.Quantity
-			}
-			satisfied = c.QualifierCriteria.Matches(qty)
+			satisfied = c.QualifierCriteria.Matches(entity.ContainerAggregates(eqp).Quantity)
 		}
 	}
 	if !c.Has {
@@ -89,3 +85,14 @@ func (c *ContainedQuantityPrereq) Satisfied(_ *Entity, exclude any, tooltip *xio
 	}
 	return satisfied
 }
+
+// Description implements Prereq.
+func (c *ContainedQuantityPrereq) Description(_ *Entity) string {
+	return compactPrereq(c.Has, i18n.Text("Contained quantity"), c.QualifierCriteria.CompactString())
+}
+
+// Equal implements Prereq.
+func (c *ContainedQuantityPrereq) Equal(other Prereq) bool {
+	o, ok := other.(*ContainedQuantityPrereq)
+	return ok && c.Type == o.Type && c.Has == o.Has && c.QualifierCriteria == o.QualifierCriteria
+}
@@ -26,7 +26,8 @@ type ContainedWeightPrereq struct {
 	WeightCriteria WeightCriteria `json:"qualifier,omitempty"`
 }
 
-// NewContainedWeightPrereq creates a new ContainedWeightPrereq.
+// NewContainedWeightPrereq creates a new ContainedWeightPrereq. 'entity' may be nil. The initial weight is 5 of the
+// default weight units in effect for the entity.
 func NewContainedWeightPrereq(entity *Entity) *ContainedWeightPrereq {
 	return &ContainedWeightPrereq{
 		Type: ContainedWeightPrereqType,
@@ -70,9 +71,7 @@ func (c *ContainedWeightPrereq) Satisfied(entity *Entity, exclude any, tooltip *
 	satisfied := false
 	if eqp, ok := exclude.(*Equipment); ok {
 		if satisfied = !eqp.Container(); !satisfied {
-			units := SheetSettingsFor(entity).DefaultWeightUnits
-			weight := eqp.ExtendedWeight(false, units) - eqp.AdjustedWeight(false, units)
-			satisfied = c.WeightCriteria.Matches(weight)
+			satisfied = c.WeightCriteria.Matches(entity.ContainerAggregates(eqp).Weight)
 		}
 	}
 	if !c.Has {
@@ -82,7 +81,19 @@ func (c *ContainedWeightPrereq) Satisfied(entity *Entity, exclude any, tooltip *
 		tooltip.WriteString(prefix)
 		tooltip.WriteString(HasText(c.Has))
 		tooltip.WriteString(i18n.Text(" a contained weight which "))
-		tooltip.WriteString(c.WeightCriteria.String())
+		tooltip.WriteString(c.WeightCriteria.Describe(SheetSettingsFor(entity).DefaultWeightUnits))
 	}
 	return satisfied
 }
+
+// Description implements Prereq.
+func (c *ContainedWeightPrereq) Description(entity *Entity) string {
+	return compactPrereq(c.Has, i18n.Text("Contained weight"),
+		c.WeightCriteria.CompactString(SheetSettingsFor(entity).DefaultWeightUnits))
+}
+
+// Equal implements Prereq.
+func (c *ContainedWeightPrereq) Equal(other Prereq) bool {
+	o, ok := other.(*ContainedWeightPrereq)
+	return ok && c.Type == o.Type && c.Has == o.Has && c.WeightCriteria == o.WeightCriteria
+}
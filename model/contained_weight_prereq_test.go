@@ -0,0 +1,45 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/json"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainedWeightPrereqUnits(t *testing.T) {
+	entity := NewEntity(PC)
+	entity.SheetSettings.DefaultWeightUnits = Kilogram
+	pr := NewContainedWeightPrereq(entity)
+	require.Equal(t, WeightFromInteger(10, Pound), pr.WeightCriteria.Qualifier)
+	require.Equal(t, "Contained weight ≤ 5 kg", pr.Description(entity))
+	require.Equal(t, "is at most 5 kg", pr.WeightCriteria.Describe(Kilogram))
+	require.Equal(t, "is at most 10 lb", pr.WeightCriteria.String())
+
+	data, err := json.Marshal(pr)
+	require.NoError(t, err)
+	var loaded ContainedWeightPrereq
+	require.NoError(t, json.Unmarshal(data, &loaded))
+	require.Equal(t, pr.WeightCriteria, loaded.WeightCriteria)
+
+	entity.SheetSettings.DefaultWeightUnits = Pound
+	require.Equal(t, "Contained weight ≤ 10 lb", loaded.Description(entity))
+
+	for _, text := range []string{"7 kg", "14 lb", "14"} {
+		w, err := WeightFromString(text, Pound)
+		require.NoError(t, err)
+		require.Equal(t, "7 kg", Kilogram.Format(w))
+		require.Equal(t, "14 lb", Pound.Format(w))
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import "github.com/richardwilkes/gcs/v5/model/fxp"
+
+// ContainerAggregates holds the totals for the contents of an equipment container.
+type ContainerAggregates struct {
+	// Quantity is the sum of the quantities of the container's immediate children.
+	Quantity fxp.Int
+	// Weight is the extended weight of the contents, after any contained weight reductions.
+	Weight Weight
+	// Value is the extended value of the contents.
+	Value fxp.Int
+}
+
+type containerAggregatesKey struct {
+	eqp   *Equipment
+	units WeightUnits
+}
+
+// ContainerAggregates returns the totals for the contents of the equipment. While prerequisites are being evaluated, the
+// results are cached per container so that repeated checks don't recompute them. entity may be nil.
+func (e *Entity) ContainerAggregates(eqp *Equipment) ContainerAggregates {
+	units := SheetSettingsFor(e).DefaultWeightUnits
+	if e == nil || e.containerAggregatesCache == nil {
+		return computeContainerAggregates(eqp, units)
+	}
+	key := containerAggregatesKey{eqp: eqp, units: units}
+	agg, ok := e.containerAggregatesCache[key]
+	if !ok {
+		agg = computeContainerAggregates(eqp, units)
+		e.containerAggregatesCache[key] = agg
+	}
+	return agg
+}
+
+func computeContainerAggregates(eqp *Equipment, units WeightUnits) ContainerAggregates {
+	var agg ContainerAggregates
+	if eqp == nil || !eqp.Container() {
+		return agg
+	}
+	for _, child := range eqp.Children {
+		agg.Quantity += child.Quantity
+		agg.Value += child.ExtendedValue()
+	}
+	agg.Weight = eqp.ExtendedWeight(false, units) - eqp.AdjustedWeight(false, units)
+	return agg
+}
+
+func (e *Entity) beginContainerAggregatesCache() {
+	e.containerAggregatesCache = make(map[containerAggregatesKey]ContainerAggregates)
+}
+
+func (e *Entity) endContainerAggregatesCache() {
+	e.containerAggregatesCache = nil
+}
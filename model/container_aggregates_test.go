@@ -0,0 +1,61 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerAggregates(t *testing.T) {
+	entity := NewEntity(PC)
+	entity.SheetSettings.DefaultWeightUnits = Pound
+	bag := NewEquipment(entity, nil, true)
+	for i := 0; i < 2; i++ {
+		item := NewEquipment(entity, bag, false)
+		item.Quantity = fxp.Two
+		item.Weight = WeightFromInteger(3, Pound)
+		item.Value = fxp.Ten
+		bag.Children = append(bag.Children, item)
+	}
+	entity.CarriedEquipment = []*Equipment{bag}
+
+	expected := ContainerAggregates{
+		Quantity: fxp.From(4),
+		Weight:   WeightFromInteger(12, Pound),
+		Value:    fxp.From(40),
+	}
+	require.Equal(t, expected, entity.ContainerAggregates(bag))
+	require.Equal(t, expected, (*Entity)(nil).ContainerAggregates(bag))
+	require.Equal(t, ContainerAggregates{}, entity.ContainerAggregates(bag.Children[0]))
+
+	entity.beginContainerAggregatesCache()
+	require.Equal(t, expected, entity.ContainerAggregates(bag))
+	bag.Children[0].Quantity = fxp.One
+	require.Equal(t, expected, entity.ContainerAggregates(bag), "cached result should be reused during a pass")
+	entity.endContainerAggregatesCache()
+	require.Equal(t, fxp.Three, entity.ContainerAggregates(bag).Quantity)
+
+	prereq := NewContainedQuantityPrereq()
+	prereq.QualifierCriteria.Compare = AtLeastNumber
+	prereq.QualifierCriteria.Qualifier = fxp.Three
+	bag.Prereq = NewPrereqList()
+	bag.Prereq.Prereqs = append(bag.Prereq.Prereqs, prereq)
+	entity.Recalculate()
+	require.Empty(t, bag.UnsatisfiedReason)
+	require.Nil(t, entity.containerAggregatesCache)
+	bag.Children[0].Quantity = 0
+	entity.Recalculate()
+	require.NotEmpty(t, bag.UnsatisfiedReason)
+}
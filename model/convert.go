@@ -12,6 +12,7 @@
 package model
 
 import (
+	"bytes"
 	"fmt"
 	iofs "io/fs"
 	"os"
@@ -19,18 +20,20 @@ import (
 	"strings"
 
 	"github.com/richardwilkes/toolbox/collection"
+	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/txt"
 	"github.com/richardwilkes/toolbox/xio/fs"
 	"github.com/yookoala/realpath"
 )
 
-// Convert the GCS files found in the given paths to the current file format.
-func Convert(paths ...string) error {
-	var err error
+// Convert the GCS files found in the given paths to the current file format. The paths of the files whose contents
+// changed are returned. If checkOnly is true, the files are left untouched and the paths of the files that would have
+// changed are returned instead.
+func Convert(checkOnly bool, paths ...string) (changed []string, err error) {
 	paths, err = fs.UniquePaths(paths...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	extSet := collection.NewSet(GCSExtensions()...)
 	extSet.Add(GCSSecondaryExtensions()...)
@@ -43,154 +46,16 @@ func Convert(paths ...string) error {
 	txt.SortStringsNaturalAscending(list)
 	for _, p := range list {
 		fmt.Printf(i18n.Text("Processing %s\n"), p)
-		switch strings.ToLower(filepath.Ext(p)) {
-		case TraitsExt:
-			var data []*Trait
-			if data, err = NewTraitsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveTraits(data, p); err != nil {
-				return err
-			}
-		case TraitModifiersExt:
-			var data []*TraitModifier
-			if data, err = NewTraitModifiersFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveTraitModifiers(data, p); err != nil {
-				return err
-			}
-		case EquipmentExt:
-			var data []*Equipment
-			if data, err = NewEquipmentFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveEquipment(data, p); err != nil {
-				return err
-			}
-		case EquipmentModifiersExt:
-			var data []*EquipmentModifier
-			if data, err = NewEquipmentModifiersFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveEquipmentModifiers(data, p); err != nil {
-				return err
-			}
-		case SkillsExt:
-			var data []*Skill
-			if data, err = NewSkillsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveSkills(data, p); err != nil {
-				return err
-			}
-		case SpellsExt:
-			var data []*Spell
-			if data, err = NewSpellsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveSpells(data, p); err != nil {
-				return err
-			}
-		case NotesExt:
-			var data []*Note
-			if data, err = NewNotesFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveNotes(data, p); err != nil {
-				return err
-			}
-		case TemplatesExt:
-			var tmpl *Template
-			if tmpl, err = NewTemplateFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = tmpl.Save(p); err != nil {
-				return err
-			}
-		case SheetExt:
-			var entity *Entity
-			if entity, err = NewEntityFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = entity.Save(p); err != nil {
-				return err
-			}
-		case AncestryExt:
-			var data *Ancestry
-			if data, err = NewAncestryFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case AttributesExt, AttributesExtAlt1, AttributesExtAlt2:
-			var data *AttributeDefs
-			if data, err = NewAttributeDefsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case BodyExt, BodyExtAlt:
-			var data *Body
-			if data, err = NewBodyFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case CalendarExt:
-			// Currently have no version info, so nothing to update
-		case ColorSettingsExt:
-			var data *Colors
-			if data, err = NewColorsFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case FontSettingsExt:
-			var data *Fonts
-			if data, err = NewFontsFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case GeneralSettingsExt:
-			var data *GeneralSheetSettings
-			if data, err = NewGeneralSheetSettingsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case KeySettingsExt:
-			var data *KeyBindings
-			if data, err = NewKeyBindingsFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case NamesExt:
-			// Currently have no version info, so nothing to update
-		case PageRefSettingsExt:
-			var data *PageRefs
-			if data, err = NewPageRefsFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case SheetSettingsExt:
-			var data *SheetSettings
-			if data, err = NewSheetSettingsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
+		var different bool
+		if different, err = convertAndCompare(p, checkOnly); err != nil {
+			return changed, err
+		}
+		if different {
+			changed = append(changed, p)
+			if checkOnly {
+				fmt.Println(i18n.Text("  would be changed"))
+			} else {
+				fmt.Println(i18n.Text("  changed"))
 			}
 		}
 	}
@@ -199,7 +64,208 @@ func Convert(paths ...string) error {
 	} else {
 		fmt.Printf(i18n.Text("Processed %d files\n"), len(list))
 	}
-	return nil
+	switch {
+	case checkOnly && len(changed) == 1:
+		fmt.Println(i18n.Text("1 file would be changed"))
+	case checkOnly:
+		fmt.Printf(i18n.Text("%d files would be changed\n"), len(changed))
+	case len(changed) == 1:
+		fmt.Println(i18n.Text("1 file was changed"))
+	default:
+		fmt.Printf(i18n.Text("%d files were changed\n"), len(changed))
+	}
+	return changed, nil
+}
+
+// convertAndCompare converts the file at path p, returning true if the converted form differs from the original. If
+// checkOnly is true, the converted form is written to a temporary file instead of replacing the original.
+func convertAndCompare(p string, checkOnly bool) (bool, error) {
+	original, err := os.ReadFile(p)
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	dest := p
+	if checkOnly {
+		var tmp *os.File
+		if tmp, err = os.CreateTemp("", "gcs-convert-*"+filepath.Ext(p)); err != nil {
+			return false, errs.Wrap(err)
+		}
+		dest = tmp.Name()
+		defer func() { _ = os.Remove(dest) }() //nolint:errcheck // Nothing useful can be done if this fails
+		if err = tmp.Close(); err != nil {
+			return false, errs.Wrap(err)
+		}
+	}
+	var written bool
+	if written, err = convertFile(p, dest); err != nil || !written {
+		return false, err
+	}
+	var converted []byte
+	if converted, err = os.ReadFile(dest); err != nil {
+		return false, errs.Wrap(err)
+	}
+	return !bytes.Equal(original, converted), nil
+}
+
+// convertFile loads the file at path p and saves it in the current file format to dest. Returns false if the file's
+// type has nothing to convert, in which case nothing was written.
+func convertFile(p, dest string) (bool, error) {
+	var err error
+	switch strings.ToLower(filepath.Ext(p)) {
+	case TraitsExt:
+		var data []*Trait
+		if data, err = NewTraitsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = SaveTraits(data, dest); err != nil {
+			return false, err
+		}
+	case TraitModifiersExt:
+		var data []*TraitModifier
+		if data, err = NewTraitModifiersFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = SaveTraitModifiers(data, dest); err != nil {
+			return false, err
+		}
+	case EquipmentExt:
+		var data []*Equipment
+		if data, err = NewEquipmentFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = SaveEquipment(data, dest); err != nil {
+			return false, err
+		}
+	case EquipmentModifiersExt:
+		var data []*EquipmentModifier
+		if data, err = NewEquipmentModifiersFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = SaveEquipmentModifiers(data, dest); err != nil {
+			return false, err
+		}
+	case SkillsExt:
+		var data []*Skill
+		if data, err = NewSkillsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = SaveSkills(data, dest); err != nil {
+			return false, err
+		}
+	case SpellsExt:
+		var data []*Spell
+		if data, err = NewSpellsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = SaveSpells(data, dest); err != nil {
+			return false, err
+		}
+	case NotesExt:
+		var data []*Note
+		if data, err = NewNotesFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = SaveNotes(data, dest); err != nil {
+			return false, err
+		}
+	case TemplatesExt:
+		var tmpl *Template
+		if tmpl, err = NewTemplateFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = tmpl.Save(dest); err != nil {
+			return false, err
+		}
+	case SheetExt:
+		var entity *Entity
+		if entity, err = NewEntityFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = entity.Save(dest); err != nil {
+			return false, err
+		}
+	case AncestryExt:
+		var data *Ancestry
+		if data, err = NewAncestryFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dest); err != nil {
+			return false, err
+		}
+	case AttributesExt, AttributesExtAlt1, AttributesExtAlt2:
+		var data *AttributeDefs
+		if data, err = NewAttributeDefsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dest); err != nil {
+			return false, err
+		}
+	case BodyExt, BodyExtAlt:
+		var data *Body
+		if data, err = NewBodyFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dest); err != nil {
+			return false, err
+		}
+	case CalendarExt:
+		// Currently have no version info, so nothing to update
+		return false, nil
+	case ColorSettingsExt:
+		var data *Colors
+		if data, err = NewColorsFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dest); err != nil {
+			return false, err
+		}
+	case FontSettingsExt:
+		var data *Fonts
+		if data, err = NewFontsFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dest); err != nil {
+			return false, err
+		}
+	case GeneralSettingsExt:
+		var data *GeneralSheetSettings
+		if data, err = NewGeneralSheetSettingsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dest); err != nil {
+			return false, err
+		}
+	case KeySettingsExt:
+		var data *KeyBindings
+		if data, err = NewKeyBindingsFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dest); err != nil {
+			return false, err
+		}
+	case NamesExt:
+		// Currently have no version info, so nothing to update
+		return false, nil
+	case PageRefSettingsExt:
+		var data *PageRefs
+		if data, err = NewPageRefsFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dest); err != nil {
+			return false, err
+		}
+	case SheetSettingsExt:
+		var data *SheetSettings
+		if data, err = NewSheetSettingsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return false, err
+		}
+		if err = data.Save(dest); err != nil {
+			return false, err
+		}
+	default:
+		return false, nil
+	}
+	return true, nil
 }
 
 func convertWalker(pathSet, extSet collection.Set[string]) func(path string, d iofs.DirEntry, err error) error {
@@ -0,0 +1,60 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertReportsChanges(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, "test"+BodyExt)
+	require.NoError(t, FactoryBody().Save(p))
+	canonical, err := os.ReadFile(p)
+	require.NoError(t, err)
+	var compact bytes.Buffer
+	require.NoError(t, json.Compact(&compact, canonical))
+	require.NoError(t, os.WriteFile(p, compact.Bytes(), 0o640))
+
+	changed, err := Convert(true, dir)
+	require.NoError(t, err)
+	require.Len(t, changed, 1)
+	require.Equal(t, filepath.Base(p), filepath.Base(changed[0]))
+	data, err := os.ReadFile(p)
+	require.NoError(t, err)
+	require.Equal(t, compact.Bytes(), data)
+
+	changed, err = Convert(false, dir)
+	require.NoError(t, err)
+	require.Len(t, changed, 1)
+	data, err = os.ReadFile(p)
+	require.NoError(t, err)
+	require.Equal(t, canonical, data)
+
+	changed, err = Convert(false, dir)
+	require.NoError(t, err)
+	require.Empty(t, changed)
+	changed, err = Convert(true, dir)
+	require.NoError(t, err)
+	require.Empty(t, changed)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "test"+NamesExt), []byte("{}"), 0o640))
+	changed, err = Convert(true, dir)
+	require.NoError(t, err)
+	require.Empty(t, changed)
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// DefaultDieSides is the number of sides assumed when dice notation omits the die size, e.g. "2d+1".
+const DefaultDieSides = 6
+
+var diceNotationRegex = regexp.MustCompile(`^(?:(\d*)[dD](\d*))?([+-]?\d+)?(?:[xX](\d+))?$`)
+
+// ParseDice parses dice notation such as "2d", "3d6+1", "d10" or "2d4-1x2". The die size may be omitted, in which case
+// it defaults to DefaultDieSides. Unlike dice.New, text that isn't valid dice notation results in an error rather than
+// being silently reinterpreted.
+func ParseDice(spec string) (*dice.Dice, error) {
+	spec = strings.ReplaceAll(strings.TrimSpace(spec), " ", "")
+	parts := diceNotationRegex.FindStringSubmatch(spec)
+	if spec == "" || parts == nil {
+		return nil, errs.Newf(i18n.Text("invalid dice notation: %q"), spec)
+	}
+	hasDie := strings.ContainsAny(spec, "dD")
+	switch {
+	case hasDie && parts[1] == "" && parts[2] == "":
+		return nil, errs.Newf(i18n.Text("invalid dice notation: %q"), spec)
+	case !hasDie && parts[3] == "":
+		return nil, errs.Newf(i18n.Text("invalid dice notation: %q"), spec)
+	case hasDie && parts[2] == "0":
+		return nil, errs.Newf(i18n.Text("dice must have at least one side: %q"), spec)
+	}
+	return dice.New(spec), nil
+}
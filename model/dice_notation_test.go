@@ -0,0 +1,62 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDice(t *testing.T) {
+	for _, one := range []struct {
+		spec string
+		want dice.Dice
+	}{
+		{"2d", dice.Dice{Count: 2, Sides: 6, Multiplier: 1}},
+		{"3d6+1", dice.Dice{Count: 3, Sides: 6, Modifier: 1, Multiplier: 1}},
+		{"d10", dice.Dice{Count: 1, Sides: 10, Multiplier: 1}},
+		{"2d4-1x2", dice.Dice{Count: 2, Sides: 4, Modifier: -1, Multiplier: 2}},
+		{" 1D20 + 3 ", dice.Dice{Count: 1, Sides: 20, Modifier: 3, Multiplier: 1}},
+		{"5", dice.Dice{Modifier: 5, Multiplier: 1}},
+	} {
+		d, err := ParseDice(one.spec)
+		require.NoError(t, err, one.spec)
+		require.Equal(t, one.want, *d, one.spec)
+	}
+	for _, spec := range []string{"", "d", "2d0", "x2", "abc", "2d6+", "2e6"} {
+		_, err := ParseDice(spec)
+		require.Error(t, err, spec)
+	}
+}
+
+func TestDiceSerializationOmitsDefaultSides(t *testing.T) {
+	saved := dice.GURPSFormat
+	dice.GURPSFormat = true
+	t.Cleanup(func() { dice.GURPSFormat = saved })
+	for spec, expected := range map[string]string{
+		"2d6+1": `{"type":"","base":"2d+1"}`,
+		"2d10":  `{"type":"","base":"2d10"}`,
+		"d4-1":  `{"type":"","base":"1d4-1"}`,
+	} {
+		d, err := ParseDice(spec)
+		require.NoError(t, err)
+		data, err := json.Marshal(&WeaponDamageData{Base: d})
+		require.NoError(t, err)
+		require.JSONEq(t, expected, string(data))
+		var loaded WeaponDamageData
+		require.NoError(t, json.Unmarshal(data, &loaded))
+		require.True(t, loaded.Base.IsEquivalent(d), spec)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/richardwilkes/toolbox/xmath/rand"
+)
+
+// RollResult holds the detailed result of rolling dice.
+type RollResult struct {
+	Dice     dice.Dice
+	Rolls    []int
+	Modifier int
+	Total    int
+	// Critical is true when the dice are a plain 3d6 success roll that came up as a critical regardless of the skill
+	// being rolled against, i.e. a 3 or 4 (success) or a 17 or 18 (failure) (B347).
+	Critical bool
+}
+
+// Roll the dice, using the provided randomizer. If rnd is nil, a cryptographically secure randomizer will be used.
+// The multiplier, if any, is applied to the total after the modifier has been added.
+func Roll(d *dice.Dice, rnd rand.Randomizer) RollResult {
+	if rnd == nil {
+		rnd = rand.NewCryptoRand()
+	}
+	result := RollResult{Dice: *d}
+	result.Dice.Normalize()
+	count := result.Dice.Count
+	result.Modifier = result.Dice.Modifier
+	sum := 0
+	for i := 0; i < count && result.Dice.Sides > 0; i++ {
+		value := 1
+		if result.Dice.Sides > 1 {
+			value += rnd.Intn(result.Dice.Sides)
+		}
+		result.Rolls = append(result.Rolls, value)
+		sum += value
+	}
+	result.Total = (sum + result.Modifier) * result.Dice.Multiplier
+	result.Critical = result.Dice.Count == 3 && result.Dice.Sides == 6 && result.Modifier == 0 &&
+		result.Dice.Multiplier == 1 && (result.Total <= 4 || result.Total >= 17)
+	return result
+}
+
+// String returns a description of the roll, e.g. "[3, 5, 1] + 2 = 11".
+func (r RollResult) String() string {
+	var buffer strings.Builder
+	buffer.WriteByte('[')
+	for i, one := range r.Rolls {
+		if i != 0 {
+			buffer.WriteString(", ")
+		}
+		buffer.WriteString(strconv.Itoa(one))
+	}
+	buffer.WriteByte(']')
+	switch {
+	case r.Modifier > 0:
+		buffer.WriteString(" + ")
+		buffer.WriteString(strconv.Itoa(r.Modifier))
+	case r.Modifier < 0:
+		buffer.WriteString(" - ")
+		buffer.WriteString(strconv.Itoa(-r.Modifier))
+	}
+	if r.Dice.Multiplier != 1 {
+		buffer.WriteString(" × ")
+		buffer.WriteString(strconv.Itoa(r.Dice.Multiplier))
+	}
+	buffer.WriteString(" = ")
+	buffer.WriteString(strconv.Itoa(r.Total))
+	return buffer.String()
+}
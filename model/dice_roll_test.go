@@ -0,0 +1,62 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedRandomizer []int
+
+func (r *fixedRandomizer) Intn(_ int) int {
+	v := (*r)[0]
+	*r = (*r)[1:]
+	return v
+}
+
+func TestRoll(t *testing.T) {
+	rnd := &fixedRandomizer{2, 4, 0}
+	result := Roll(dice.New("3d+2"), rnd)
+	require.Equal(t, []int{3, 5, 1}, result.Rolls)
+	require.Equal(t, 11, result.Total)
+	require.False(t, result.Critical)
+	require.Equal(t, "[3, 5, 1] + 2 = 11", result.String())
+
+	rnd = &fixedRandomizer{5, 5, 5}
+	result = Roll(dice.New("3d-1"), rnd)
+	require.Equal(t, 17, result.Total)
+	require.False(t, result.Critical)
+
+	rnd = &fixedRandomizer{5, 5, 4}
+	result = Roll(dice.New("3d"), rnd)
+	require.Equal(t, 17, result.Total)
+	require.True(t, result.Critical)
+
+	rnd = &fixedRandomizer{0, 1, 0}
+	result = Roll(dice.New("3d"), rnd)
+	require.Equal(t, 4, result.Total)
+	require.True(t, result.Critical)
+
+	rnd = &fixedRandomizer{0}
+	result = Roll(dice.New("1d"), rnd)
+	require.Equal(t, 1, result.Total)
+	require.False(t, result.Critical)
+
+	rnd = &fixedRandomizer{0, 0}
+	result = Roll(dice.New("2d6x3"), rnd)
+	require.Equal(t, 6, result.Total)
+	require.False(t, result.Critical)
+	require.Equal(t, "[1, 1] × 3 = 6", result.String())
+}
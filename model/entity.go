@@ -67,6 +67,7 @@ type EntityData struct {
 	Version          int             `json:"version"`
 	ID               uuid.UUID       `json:"id"`
 	TotalPoints      fxp.Int         `json:"total_points"`
+	PointBudget      fxp.Int         `json:"point_budget,omitempty"`
 	PointsRecord     []*PointsRecord `json:"points_record,omitempty"`
 	Profile          *Profile        `json:"profile,omitempty"`
 	SheetSettings    *SheetSettings  `json:"settings,omitempty"`
@@ -109,6 +110,7 @@ type Entity struct {
 	cachedEncumbranceLevel          Encumbrance
 	cachedEncumbranceLevelForSkills Encumbrance
 	cachedVariables                 map[string]string
+	containerAggregatesCache        map[containerAggregatesKey]ContainerAggregates
 }
 
 // NewEntityFromFile loads an Entity from a file.
@@ -236,7 +238,7 @@ func (e *Entity) UnmarshalJSON(data []byte) error {
 			When:   jio.Now(),
 			Reason: i18n.Text("Reconciliation"),
 		})
-		sort.Slice(e.PointsRecord, func(i, j int) bool { return e.PointsRecord[i].When.After(e.PointsRecord[j].When) })
+		sort.SliceStable(e.PointsRecord, func(i, j int) bool { return e.PointsRecord[i].When.After(e.PointsRecord[j].When) })
 	}
 	e.Recalculate()
 	return nil
@@ -392,13 +394,14 @@ func (e *Entity) processFeature(owner fmt.Stringer, f Feature, levels fxp.Int) {
 func (e *Entity) processPrereqs() {
 	const prefix = "\n● "
 	notMetPrefix := i18n.Text("Prerequisites have not been met:")
+	e.beginContainerAggregatesCache()
+	defer e.endContainerAggregatesCache()
 	Traverse(func(a *Trait) bool {
 		a.UnsatisfiedReason = ""
 		if !a.Container() && a.Prereq != nil {
-			var tooltip xio.ByteBuffer
 			var eqpPenalty bool
-			if !a.Prereq.Satisfied(e, a, &tooltip, prefix, &eqpPenalty) {
-				a.UnsatisfiedReason = notMetPrefix + tooltip.String()
+			if !a.Prereq.Satisfied(e, a, nil, prefix, &eqpPenalty) {
+				a.UnsatisfiedReason = notMetPrefix + prereqFailureTooltip(e, a, a.Prereq)
 			}
 		}
 		return false
@@ -410,7 +413,10 @@ func (e *Entity) processPrereqs() {
 			satisfied := true
 			if s.Prereq != nil {
 				var eqpPenalty bool
-				satisfied = s.Prereq.Satisfied(e, s, &tooltip, prefix, &eqpPenalty)
+				satisfied = s.Prereq.Satisfied(e, s, nil, prefix, &eqpPenalty)
+				if !satisfied {
+					tooltip.WriteString(prereqFailureTooltip(e, s, s.Prereq))
+				}
 				if eqpPenalty {
 					penalty := NewSkillBonus()
 					penalty.NameCriteria.Qualifier = s.Name
@@ -441,7 +447,10 @@ func (e *Entity) processPrereqs() {
 			satisfied := true
 			if s.Prereq != nil {
 				var eqpPenalty bool
-				satisfied = s.Prereq.Satisfied(e, s, &tooltip, prefix, &eqpPenalty)
+				satisfied = s.Prereq.Satisfied(e, s, nil, prefix, &eqpPenalty)
+				if !satisfied {
+					tooltip.WriteString(prereqFailureTooltip(e, s, s.Prereq))
+				}
 				if eqpPenalty {
 					penalty := NewSpellBonus()
 					penalty.NameCriteria.Qualifier = s.Name
@@ -466,10 +475,9 @@ func (e *Entity) processPrereqs() {
 	equipmentFunc := func(eqp *Equipment) bool {
 		eqp.UnsatisfiedReason = ""
 		if eqp.Prereq != nil {
-			var tooltip xio.ByteBuffer
 			var eqpPenalty bool
-			if !eqp.Prereq.Satisfied(e, eqp, &tooltip, prefix, &eqpPenalty) {
-				eqp.UnsatisfiedReason = notMetPrefix + tooltip.String()
+			if !eqp.Prereq.Satisfied(e, eqp, nil, prefix, &eqpPenalty) {
+				eqp.UnsatisfiedReason = notMetPrefix + prereqFailureTooltip(e, eqp, eqp.Prereq)
 			}
 		}
 		return false
@@ -516,45 +524,73 @@ func (e *Entity) SetUnspentPoints(unspent fxp.Int) {
 
 // PointsBreakdown returns the point breakdown for spent points.
 func (e *Entity) PointsBreakdown() *PointsBreakdown {
+	return e.pointsBreakdown(nil, nil)
+}
+
+// pointsBreakdown returns the breakdown of the points spent, leaving out exclude. If filter is not nil, attributes and
+// any trait, skill or spell whose tags it rejects are also left out.
+func (e *Entity) pointsBreakdown(exclude any, filter func(tags []string) bool) *PointsBreakdown {
 	var pb PointsBreakdown
-	for _, attr := range e.Attributes.Set {
-		pb.Attributes += attr.PointCost()
+	if filter == nil {
+		for _, attr := range e.Attributes.Set {
+			pb.Attributes += attr.PointCost()
+		}
 	}
 	for _, one := range e.Traits {
-		calculateSingleTraitPoints(one, &pb)
+		calculateSingleTraitPoints(one, &pb, exclude, filter, nil)
 	}
 	Traverse(func(s *Skill) bool {
-		pb.Skills += s.Points
+		if exclude != s && (filter == nil || filter(s.Tags)) {
+			pb.Skills += s.Points
+		}
 		return false
 	}, false, true, e.Skills...)
 	Traverse(func(s *Spell) bool {
-		pb.Spells += s.Points
+		if exclude != s && (filter == nil || filter(s.Tags)) {
+			pb.Spells += s.Points
+		}
 		return false
 	}, false, true, e.Spells...)
 	return &pb
 }
 
-func calculateSingleTraitPoints(t *Trait, pb *PointsBreakdown) {
-	if t.Disabled {
+// calculateSingleTraitPoints adds the points for the trait to the breakdown. If into is not nil, the points are added
+// to it rather than to the category the trait would otherwise fall into.
+func calculateSingleTraitPoints(t *Trait, pb *PointsBreakdown, exclude any, filter func(tags []string) bool,
+	into *fxp.Int) {
+	if t.Disabled || exclude == t {
 		return
 	}
-	if t.Container() {
-		switch t.ContainerType {
-		case GroupContainerType:
+	if filter != nil {
+		if !filter(t.Tags) {
 			for _, child := range t.Children {
-				calculateSingleTraitPoints(child, pb)
+				calculateSingleTraitPoints(child, pb, exclude, filter, into)
 			}
 			return
-		case RaceContainerType:
-			pb.Race += t.AdjustedPoints()
-			return
-		case AttributesContainerType:
-			pb.Attributes += t.AdjustedPoints()
+		}
+		filter = nil
+	}
+	if t.Container() {
+		if into == nil {
+			switch t.ContainerType {
+			case RaceContainerType:
+				into = &pb.Race
+			case AttributesContainerType:
+				into = &pb.Attributes
+			}
+		}
+		// Containers holding the excluded trait have to be broken down into their children to leave it out
+		if t.ContainerType == GroupContainerType || traitContains(t, exclude) {
+			for _, child := range t.Children {
+				calculateSingleTraitPoints(child, pb, exclude, nil, into)
+			}
 			return
 		}
 	}
 	pts := t.AdjustedPoints()
 	switch {
+	case into != nil:
+		*into += pts
 	case pts == -fxp.One:
 		pb.Quirks += pts
 	case pts > 0:
@@ -564,6 +600,19 @@ func calculateSingleTraitPoints(t *Trait, pb *PointsBreakdown) {
 	}
 }
 
+func traitContains(t *Trait, target any) bool {
+	other, ok := target.(*Trait)
+	if !ok {
+		return false
+	}
+	found := false
+	Traverse(func(one *Trait) bool {
+		found = one == other
+		return found
+	}, false, false, t.Children...)
+	return found
+}
+
 // WealthCarried returns the current wealth being carried.
 func (e *Entity) WealthCarried() fxp.Int {
 	var value fxp.Int
@@ -1364,7 +1413,7 @@ func (e *Entity) CRC64() uint64 {
 // SetPointsRecord sets a new points record list, adjusting the total points.
 func (e *Entity) SetPointsRecord(record []*PointsRecord) {
 	e.PointsRecord = ClonePointsRecordList(record)
-	sort.Slice(e.PointsRecord, func(i, j int) bool { return e.PointsRecord[i].When.After(e.PointsRecord[j].When) })
+	sort.SliceStable(e.PointsRecord, func(i, j int) bool { return e.PointsRecord[i].When.After(e.PointsRecord[j].When) })
 	e.TotalPoints = 0
 	for _, rec := range record {
 		e.TotalPoints += rec.Points
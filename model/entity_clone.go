@@ -0,0 +1,88 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// CloneWithNewIDs returns a deep copy of this entity in which the entity itself and all of its items, modifiers and
+// weapons have been given new IDs. Items refer to one another by name rather than by ID, so weapon defaults and
+// prerequisites remain consistent within the copy. If prefixProvider is not nil, it is used to assign new target key
+// prefixes to the copy's attribute definitions and body type.
+func (e *Entity) CloneWithNewIDs(prefixProvider func() string) (*Entity, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var clone Entity
+	if err = json.Unmarshal(data, &clone); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	clone.ID = NewUUID()
+	clone.CreatedOn = jio.Now()
+	clone.ModifiedOn = clone.CreatedOn
+	Traverse(func(t *Trait) bool {
+		t.ID = NewUUID()
+		resetModifierIDs(t.Modifiers)
+		resetWeaponIDs(t.Weapons)
+		return false
+	}, false, false, clone.Traits...)
+	Traverse(func(s *Skill) bool {
+		s.ID = NewUUID()
+		resetWeaponIDs(s.Weapons)
+		return false
+	}, false, false, clone.Skills...)
+	Traverse(func(s *Spell) bool {
+		s.ID = NewUUID()
+		resetWeaponIDs(s.Weapons)
+		return false
+	}, false, false, clone.Spells...)
+	for _, list := range [][]*Equipment{clone.CarriedEquipment, clone.OtherEquipment} {
+		Traverse(func(eqp *Equipment) bool {
+			eqp.ID = NewUUID()
+			resetModifierIDs(eqp.Modifiers)
+			resetWeaponIDs(eqp.Weapons)
+			return false
+		}, false, false, list...)
+	}
+	Traverse(func(n *Note) bool {
+		n.ID = NewUUID()
+		return false
+	}, false, false, clone.Notes...)
+	if prefixProvider != nil {
+		clone.SheetSettings.Attributes.ResetTargetKeyPrefixes(prefixProvider)
+		clone.SheetSettings.BodyType.ResetTargetKeyPrefixes(prefixProvider)
+	}
+	clone.Recalculate()
+	return &clone, nil
+}
+
+func resetModifierIDs[T *TraitModifier | *EquipmentModifier](modifiers []T) {
+	Traverse(func(m T) bool {
+		switch one := any(m).(type) {
+		case *TraitModifier:
+			one.ID = NewUUID()
+		case *EquipmentModifier:
+			one.ID = NewUUID()
+		}
+		return false
+	}, false, false, modifiers...)
+}
+
+func resetWeaponIDs(weapons []*Weapon) {
+	for _, w := range weapons {
+		w.ID = NewUUID()
+	}
+}
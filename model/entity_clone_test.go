@@ -0,0 +1,80 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityCloneWithNewIDs(t *testing.T) {
+	entity := NewEntity(PC)
+	entity.Profile.Name = "Original"
+
+	skill := NewSkill(entity, nil, false)
+	skill.Name = "Broadsword"
+	skill.Points = fxp.Four
+	entity.SetSkillList([]*Skill{skill})
+
+	trait := NewTrait(entity, nil, false)
+	trait.Name = "Weapon Master"
+	trait.Prereq = NewPrereqList()
+	prereq := NewSkillPrereq()
+	prereq.Parent = trait.Prereq
+	prereq.NameCriteria.Qualifier = "Broadsword"
+	trait.Prereq.Prereqs = append(trait.Prereq.Prereqs, prereq)
+	trait.Modifiers = []*TraitModifier{NewTraitModifier(entity, nil, false)}
+	entity.SetTraitList([]*Trait{trait})
+
+	eqp := NewEquipment(entity, nil, false)
+	eqp.Name = "Broadsword"
+	weapon := NewWeapon(eqp, MeleeWeaponType)
+	def := &SkillDefault{DefaultType: SkillID, Name: "Broadsword"}
+	weapon.Defaults = []*SkillDefault{def}
+	eqp.Weapons = []*Weapon{weapon}
+	entity.SetCarriedEquipmentList([]*Equipment{eqp})
+
+	count := 0
+	clone, err := entity.CloneWithNewIDs(func() string {
+		count++
+		return strconv.Itoa(count) + ":"
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, entity.ID, clone.ID)
+	require.Equal(t, "Original", clone.Profile.Name)
+	require.NotZero(t, count)
+
+	require.Len(t, clone.Traits, 1)
+	require.NotEqual(t, trait.ID, clone.Traits[0].ID)
+	require.NotEqual(t, trait.Modifiers[0].ID, clone.Traits[0].Modifiers[0].ID)
+	require.True(t, clone.Traits[0].Prereq.Equal(trait.Prereq))
+	require.Empty(t, clone.Traits[0].UnsatisfiedReason)
+
+	require.Len(t, clone.Skills, 1)
+	require.NotEqual(t, skill.ID, clone.Skills[0].ID)
+	require.Equal(t, skill.LevelData.Level, clone.Skills[0].LevelData.Level)
+
+	require.Len(t, clone.CarriedEquipment, 1)
+	clonedEqp := clone.CarriedEquipment[0]
+	require.NotEqual(t, eqp.ID, clonedEqp.ID)
+	require.Len(t, clonedEqp.Weapons, 1)
+	clonedWeapon := clonedEqp.Weapons[0]
+	require.NotEqual(t, weapon.ID, clonedWeapon.ID)
+	require.Same(t, clonedEqp, clonedWeapon.Owner)
+	require.Equal(t, weapon.SkillLevel(nil), clonedWeapon.SkillLevel(nil))
+
+	require.Same(t, entity, skill.Entity)
+	require.Same(t, clone, clone.Skills[0].Entity)
+}
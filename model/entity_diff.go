@@ -0,0 +1,204 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// Possible ChangeKind values.
+const (
+	AddedChange ChangeKind = iota
+	RemovedChange
+	ModifiedChange
+)
+
+// ChangeKind identifies the type of a Change.
+type ChangeKind byte
+
+// String implements fmt.Stringer.
+func (k ChangeKind) String() string {
+	switch k {
+	case AddedChange:
+		return i18n.Text("Added")
+	case RemovedChange:
+		return i18n.Text("Removed")
+	case ModifiedChange:
+		return i18n.Text("Changed")
+	default:
+		return ""
+	}
+}
+
+// Change holds a single difference found between two entities. Before is empty for additions and After is empty for
+// removals.
+type Change struct {
+	Kind     ChangeKind
+	Category string
+	Name     string
+	Before   string
+	After    string
+}
+
+type diffItem struct {
+	id      uuid.UUID
+	name    string
+	summary string
+	matched bool
+}
+
+// DiffEntities compares two entities, typically two saved versions of the same character, and reports the traits,
+// skills and spells that were added, removed or changed between them, along with any changes to attribute values and
+// the points spent on them. Items are matched by ID where possible and by name otherwise.
+func DiffEntities(a, b *Entity) ([]Change, error) {
+	if a == nil || b == nil {
+		return nil, errs.New(i18n.Text("two entities are required for comparison"))
+	}
+	a.Recalculate()
+	b.Recalculate()
+	changes := diffAttributes(a, b)
+	changes = append(changes, diffNodes(i18n.Text("Trait"), a.Traits, b.Traits, func(t *Trait) diffItem {
+		return diffItem{
+			id:      t.ID,
+			name:    t.Description(),
+			summary: diffSummary(t.String(), t.AdjustedPoints()),
+		}
+	})...)
+	changes = append(changes, diffNodes(i18n.Text("Skill"), a.Skills, b.Skills, func(s *Skill) diffItem {
+		return diffItem{
+			id:      s.ID,
+			name:    s.String(),
+			summary: diffSummary(s.LevelData.LevelAsString(false), s.AdjustedPoints(nil)),
+		}
+	})...)
+	changes = append(changes, diffNodes(i18n.Text("Spell"), a.Spells, b.Spells, func(s *Spell) diffItem {
+		return diffItem{
+			id:      s.ID,
+			name:    s.String(),
+			summary: diffSummary(s.LevelData.LevelAsString(false), s.AdjustedPoints(nil)),
+		}
+	})...)
+	return changes, nil
+}
+
+func diffSummary(value string, points fmt.Stringer) string {
+	return fmt.Sprintf(i18n.Text("%s [%s pts]"), value, points)
+}
+
+func diffAttributes(a, b *Entity) []Change {
+	var changes []Change
+	category := i18n.Text("Attribute")
+	describe := func(attr *Attribute) (name, summary string) {
+		if def := attr.AttributeDef(); def != nil {
+			name = def.CombinedName()
+		} else {
+			name = attr.AttrID
+		}
+		return name, diffSummary(attr.Maximum().String(), attr.PointCost())
+	}
+	for _, before := range a.Attributes.List() {
+		if def := before.AttributeDef(); def != nil && def.IsSeparator() {
+			continue
+		}
+		name, beforeSummary := describe(before)
+		after, ok := b.Attributes.Set[before.AttrID]
+		if !ok {
+			changes = append(changes, Change{Kind: RemovedChange, Category: category, Name: name, Before: beforeSummary})
+			continue
+		}
+		if _, afterSummary := describe(after); afterSummary != beforeSummary {
+			changes = append(changes, Change{
+				Kind:     ModifiedChange,
+				Category: category,
+				Name:     name,
+				Before:   beforeSummary,
+				After:    afterSummary,
+			})
+		}
+	}
+	for _, after := range b.Attributes.List() {
+		if def := after.AttributeDef(); def != nil && def.IsSeparator() {
+			continue
+		}
+		if _, ok := a.Attributes.Set[after.AttrID]; !ok {
+			name, afterSummary := describe(after)
+			changes = append(changes, Change{Kind: AddedChange, Category: category, Name: name, After: afterSummary})
+		}
+	}
+	return changes
+}
+
+func diffNodes[T NodeTypes](category string, before, after []T, describe func(T) diffItem) []Change {
+	beforeItems := collectDiffItems(before, describe)
+	afterItems := collectDiffItems(after, describe)
+	byID := make(map[uuid.UUID]*diffItem, len(afterItems))
+	for _, one := range afterItems {
+		byID[one.id] = one
+	}
+	pairs := make(map[*diffItem]*diffItem, len(beforeItems))
+	for _, one := range beforeItems {
+		if other, ok := byID[one.id]; ok && !other.matched {
+			other.matched = true
+			one.matched = true
+			pairs[one] = other
+		}
+	}
+	for _, one := range beforeItems {
+		if one.matched {
+			continue
+		}
+		for _, other := range afterItems {
+			if !other.matched && other.name == one.name {
+				other.matched = true
+				one.matched = true
+				pairs[one] = other
+				break
+			}
+		}
+	}
+	var changes []Change
+	for _, one := range beforeItems {
+		other, ok := pairs[one]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Kind: RemovedChange, Category: category, Name: one.name, Before: one.summary})
+		case other.summary != one.summary:
+			changes = append(changes, Change{
+				Kind:     ModifiedChange,
+				Category: category,
+				Name:     other.name,
+				Before:   one.summary,
+				After:    other.summary,
+			})
+		}
+	}
+	for _, other := range afterItems {
+		if !other.matched {
+			changes = append(changes, Change{Kind: AddedChange, Category: category, Name: other.name, After: other.summary})
+		}
+	}
+	return changes
+}
+
+func collectDiffItems[T NodeTypes](list []T, describe func(T) diffItem) []*diffItem {
+	var items []*diffItem
+	Traverse(func(one T) bool {
+		item := describe(one)
+		items = append(items, &item)
+		return false
+	}, false, true, list...)
+	return items
+}
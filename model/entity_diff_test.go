@@ -0,0 +1,71 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffEntities(t *testing.T) {
+	a := NewEntity(PC)
+	b := NewEntity(PC)
+
+	// Matched by ID, with changed points
+	fit := NewTrait(a, nil, false)
+	fit.Name = "Fit"
+	fit.BasePoints = fxp.Five
+	a.Traits = append(a.Traits, fit)
+	fit2 := NewTrait(b, nil, false)
+	fit2.ID = fit.ID
+	fit2.Name = "Very Fit"
+	fit2.BasePoints = fxp.Fifteen
+	b.Traits = append(b.Traits, fit2)
+
+	// Matched by name, unchanged
+	climbing := NewSkill(a, nil, false)
+	climbing.Name = "Climbing"
+	climbing.Points = fxp.One
+	a.Skills = append(a.Skills, climbing)
+	climbing2 := NewSkill(b, nil, false)
+	climbing2.Name = "Climbing"
+	climbing2.Points = fxp.One
+	b.Skills = append(b.Skills, climbing2)
+
+	// Removed & added
+	stealth := NewSkill(a, nil, false)
+	stealth.Name = "Stealth"
+	a.Skills = append(a.Skills, stealth)
+	spell := NewSpell(b, nil, false)
+	spell.Name = "Light"
+	b.Spells = append(b.Spells, spell)
+
+	b.Attributes.Set["st"].Adjustment = fxp.One
+
+	changes, err := DiffEntities(a, b)
+	require.NoError(t, err)
+	kinds := make(map[string]ChangeKind)
+	for _, one := range changes {
+		kinds[one.Category+":"+one.Name] = one.Kind
+	}
+	require.Equal(t, ModifiedChange, kinds["Trait:Very Fit"])
+	require.Equal(t, RemovedChange, kinds["Skill:Stealth"])
+	require.Equal(t, AddedChange, kinds["Spell:Light"])
+	require.Equal(t, ModifiedChange, kinds["Attribute:Strength (ST)"])
+	_, ok := kinds["Skill:Climbing"]
+	require.False(t, ok)
+
+	_, err = DiffEntities(a, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntitySaveIsStable(t *testing.T) {
+	entity := NewEntity(PC)
+	entity.Profile.Name = "Stable"
+	for _, def := range entity.SheetSettings.Attributes.Set {
+		def.Order = 1
+	}
+	entity.ThirdParty = map[string]any{"zeta": 1.5, "alpha": map[string]any{"b": "<&>", "a": 2}}
+	trait := NewTrait(entity, nil, false)
+	trait.Name = "Trait"
+	entity.SetTraitList([]*Trait{trait})
+
+	var first bytes.Buffer
+	require.NoError(t, jio.Save(context.Background(), &first, entity))
+	for i := 0; i < 10; i++ {
+		var loaded Entity
+		require.NoError(t, jio.Load(context.Background(), bytes.NewReader(first.Bytes()), &loaded))
+		var again bytes.Buffer
+		require.NoError(t, jio.Save(context.Background(), &again, &loaded))
+		require.Equal(t, first.String(), again.String())
+	}
+
+	compressed, err := jio.SerializeAndCompress(entity)
+	require.NoError(t, err)
+	var decompressed Entity
+	require.NoError(t, jio.DecompressAndDeserialize(compressed, &decompressed))
+	var fromCompressed bytes.Buffer
+	require.NoError(t, jio.Save(context.Background(), &fromCompressed, &decompressed))
+	require.Equal(t, first.String(), fromCompressed.String())
+	again, err := jio.SerializeAndCompress(&decompressed)
+	require.NoError(t, err)
+	require.Equal(t, compressed, again)
+}
@@ -328,6 +328,21 @@ func (e *Equipment) TagList() []string {
 	return e.Tags
 }
 
+// SetTagList sets the list of tags.
+func (e *Equipment) SetTagList(tags []string) {
+	e.Tags = tags
+}
+
+// PageReference returns the page reference.
+func (e *Equipment) PageReference() string {
+	return e.PageRef
+}
+
+// SetPageReference sets the page reference.
+func (e *Equipment) SetPageReference(ref string) {
+	e.PageRef = ref
+}
+
 // AdjustedValue returns the value after adjustments for any modifiers. Does not include the value of children.
 func (e *Equipment) AdjustedValue() fxp.Int {
 	return ValueAdjustedForModifiers(e.Value, e.Modifiers)
@@ -144,6 +144,21 @@ func (m *EquipmentModifier) TagList() []string {
 	return m.Tags
 }
 
+// SetTagList sets the list of tags.
+func (m *EquipmentModifier) SetTagList(tags []string) {
+	m.Tags = tags
+}
+
+// PageReference returns the page reference.
+func (m *EquipmentModifier) PageReference() string {
+	return m.PageRef
+}
+
+// SetPageReference sets the page reference.
+func (m *EquipmentModifier) SetPageReference(ref string) {
+	m.PageRef = ref
+}
+
 // CellData returns the cell data information for the given column.
 func (m *EquipmentModifier) CellData(columnID int, data *CellData) {
 	switch columnID {
@@ -14,6 +14,7 @@ package model
 import (
 	"fmt"
 
+	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/xio"
 )
@@ -22,20 +23,35 @@ var _ Prereq = &EquippedEquipmentPrereq{}
 
 // EquippedEquipmentPrereq holds a prerequisite for an equipped piece of equipment.
 type EquippedEquipmentPrereq struct {
-	Parent       *PrereqList    `json:"-"`
-	Type         PrereqType     `json:"type"`
-	NameCriteria StringCriteria `json:"name,omitempty"`
+	Parent           *PrereqList     `json:"-"`
+	Type             PrereqType      `json:"type"`
+	Has              bool            `json:"has"`
+	NameCriteria     StringCriteria  `json:"name,omitempty"`
+	TagCriteria      StringCriteria  `json:"tags,omitempty"`
+	QuantityCriteria NumericCriteria `json:"quantity,omitempty"`
 }
 
 // NewEquippedEquipmentPrereq creates a new EquippedEquipmentPrereq.
 func NewEquippedEquipmentPrereq() *EquippedEquipmentPrereq {
 	return &EquippedEquipmentPrereq{
 		Type: EquippedEquipmentPrereqType,
+		Has:  true,
 		NameCriteria: StringCriteria{
 			StringCriteriaData: StringCriteriaData{
 				Compare: IsString,
 			},
 		},
+		TagCriteria: StringCriteria{
+			StringCriteriaData: StringCriteriaData{
+				Compare: AnyString,
+			},
+		},
+		QuantityCriteria: NumericCriteria{
+			NumericCriteriaData: NumericCriteriaData{
+				Compare:   AtLeastNumber,
+				Qualifier: fxp.One,
+			},
+		},
 	}
 }
 
@@ -59,25 +75,58 @@ func (e *EquippedEquipmentPrereq) Clone(parent *PrereqList) Prereq {
 // FillWithNameableKeys implements Prereq.
 func (e *EquippedEquipmentPrereq) FillWithNameableKeys(m map[string]string) {
 	Extract(e.NameCriteria.Qualifier, m)
+	Extract(e.TagCriteria.Qualifier, m)
 }
 
 // ApplyNameableKeys implements Prereq.
 func (e *EquippedEquipmentPrereq) ApplyNameableKeys(m map[string]string) {
 	e.NameCriteria.Qualifier = Apply(e.NameCriteria.Qualifier, m)
+	e.TagCriteria.Qualifier = Apply(e.TagCriteria.Qualifier, m)
 }
 
 // Satisfied implements Prereq.
 func (e *EquippedEquipmentPrereq) Satisfied(entity *Entity, exclude any, tooltip *xio.ByteBuffer, prefix string, hasEquipmentPenalty *bool) bool {
-	satisfied := false
+	count := 0
 	Traverse(func(eqp *Equipment) bool {
-		satisfied = exclude != eqp && eqp.Equipped && eqp.Quantity > 0 && e.NameCriteria.Matches(eqp.Name)
-		return satisfied
+		if exclude != eqp && eqp.Equipped && eqp.Quantity > 0 && e.NameCriteria.Matches(eqp.Name) &&
+			e.TagCriteria.MatchesList(eqp.Tags...) {
+			count++
+		}
+		return false
 	}, false, false, entity.CarriedEquipment...)
+	satisfied := e.QuantityCriteria.Matches(fxp.From(count))
+	if !e.Has {
+		satisfied = !satisfied
+	}
 	if !satisfied {
 		*hasEquipmentPenalty = true
 		if tooltip != nil {
-			fmt.Fprintf(tooltip, i18n.Text("%sHas equipment which is equipped and whose name %s"), prefix, e.NameCriteria.String())
+			fmt.Fprintf(tooltip, i18n.Text("%s%s %s equipped equipment whose name %s"), prefix, HasText(e.Has),
+				e.QuantityCriteria.String(), e.NameCriteria.String())
+			if e.TagCriteria.Compare != AnyString {
+				fmt.Fprintf(tooltip, i18n.Text(" and at least one tag %s"), e.TagCriteria.String())
+			}
 		}
 	}
 	return satisfied
 }
+
+// Description implements Prereq.
+func (e *EquippedEquipmentPrereq) Description(_ *Entity) string {
+	label := i18n.Text("Equipped: ") + e.NameCriteria.CompactString()
+	if e.TagCriteria.Compare.EnsureValid() != AnyString {
+		label += " [" + e.TagCriteria.CompactString() + "]"
+	}
+	var quantity string
+	if e.QuantityCriteria.Compare != AtLeastNumber || e.QuantityCriteria.Qualifier != fxp.One {
+		quantity = e.QuantityCriteria.CompactString()
+	}
+	return compactPrereq(e.Has, label, quantity)
+}
+
+// Equal implements Prereq.
+func (e *EquippedEquipmentPrereq) Equal(other Prereq) bool {
+	o, ok := other.(*EquippedEquipmentPrereq)
+	return ok && e.Type == o.Type && e.Has == o.Has && e.NameCriteria == o.NameCriteria &&
+		e.TagCriteria == o.TagCriteria && e.QuantityCriteria == o.QuantityCriteria
+}
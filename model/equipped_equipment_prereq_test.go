@@ -0,0 +1,58 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/json"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEquippedEquipmentPrereqLegacyData(t *testing.T) {
+	var prereqs Prereqs
+	require.NoError(t, json.Unmarshal([]byte(`[{"type":"equipped_equipment","name":{"compare":"is","qualifier":"Sword"}}]`),
+		&prereqs))
+	require.Len(t, prereqs, 1)
+	pr, ok := prereqs[0].(*EquippedEquipmentPrereq)
+	require.True(t, ok)
+	require.True(t, pr.Has)
+	require.Equal(t, AnyString, pr.TagCriteria.Compare)
+	require.Equal(t, AtLeastNumber, pr.QuantityCriteria.Compare)
+	require.Equal(t, fxp.One, pr.QuantityCriteria.Qualifier)
+}
+
+func TestEquippedEquipmentPrereqTagsAndQuantity(t *testing.T) {
+	entity := NewEntity(PC)
+	for i := 0; i < 2; i++ {
+		eqp := NewEquipment(entity, nil, false)
+		eqp.Name = "Amulet"
+		eqp.Tags = []string{"Holy"}
+		entity.CarriedEquipment = append(entity.CarriedEquipment, eqp)
+	}
+	pr := NewEquippedEquipmentPrereq()
+	pr.NameCriteria.Compare = AnyString
+	pr.TagCriteria.Compare = IsString
+	pr.TagCriteria.Qualifier = "Holy"
+	pr.QuantityCriteria.Qualifier = fxp.Two
+	var penalty bool
+	require.True(t, pr.Satisfied(entity, nil, nil, "", &penalty))
+	require.False(t, penalty)
+
+	entity.CarriedEquipment[1].Equipped = false
+	require.False(t, pr.Satisfied(entity, nil, nil, "", &penalty))
+	require.True(t, penalty)
+
+	pr.Has = false
+	require.True(t, pr.Satisfied(entity, nil, nil, "", &penalty))
+}
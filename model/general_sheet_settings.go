@@ -53,23 +53,27 @@ var (
 
 // GeneralSheetSettings holds general settings for a sheet.
 type GeneralSheetSettings struct {
-	DefaultPlayerName     string  `json:"default_player_name,omitempty"`
-	DefaultTechLevel      string  `json:"default_tech_level,omitempty"`
-	CalendarName          string  `json:"calendar_ref,omitempty"`
-	ExternalPDFCmdLine    string  `json:"external_pdf_cmd_line,omitempty"`
-	InitialPoints         fxp.Int `json:"initial_points"`
-	TooltipDelay          fxp.Int `json:"tooltip_delay"`
-	TooltipDismissal      fxp.Int `json:"tooltip_dismissal"`
-	ScrollWheelMultiplier fxp.Int `json:"scroll_wheel_multiplier"`
-	NavigatorUIScale      int     `json:"navigator_scale"`
-	InitialListUIScale    int     `json:"initial_list_scale"`
-	InitialEditorUIScale  int     `json:"initial_editor_scale"`
-	InitialSheetUIScale   int     `json:"initial_sheet_scale"`
-	MaximumAutoColWidth   int     `json:"maximum_auto_col_width"`
-	ImageResolution       int     `json:"image_resolution"`
-	AutoFillProfile       bool    `json:"auto_fill_profile"`
-	AutoAddNaturalAttacks bool    `json:"add_natural_attacks"`
-	GroupContainersOnSort bool    `json:"group_containers_on_sort"`
+	DefaultPlayerName     string           `json:"default_player_name,omitempty"`
+	DefaultTechLevel      string           `json:"default_tech_level,omitempty"`
+	CalendarName          string           `json:"calendar_ref,omitempty"`
+	ExternalPDFCmdLine    string           `json:"external_pdf_cmd_line,omitempty"`
+	InitialPoints         fxp.Int          `json:"initial_points"`
+	TooltipDelay          fxp.Int          `json:"tooltip_delay"`
+	TooltipDismissal      fxp.Int          `json:"tooltip_dismissal"`
+	ScrollWheelMultiplier fxp.Int          `json:"scroll_wheel_multiplier"`
+	NavigatorUIScale      int              `json:"navigator_scale"`
+	InitialListUIScale    int              `json:"initial_list_scale"`
+	InitialEditorUIScale  int              `json:"initial_editor_scale"`
+	InitialSheetUIScale   int              `json:"initial_sheet_scale"`
+	MaximumAutoColWidth   int              `json:"maximum_auto_col_width"`
+	ImageResolution       int              `json:"image_resolution"`
+	DecimalSeparator      DecimalSeparator `json:"decimal_separator,omitempty"`
+	AutoFillProfile       bool             `json:"auto_fill_profile"`
+	AutoAddNaturalAttacks bool             `json:"add_natural_attacks"`
+	GroupContainersOnSort bool             `json:"group_containers_on_sort"`
+	ShowMaintenanceRate   bool             `json:"show_maintenance_rate,omitempty"`
+	AccessibilityMode     bool             `json:"accessibility_mode,omitempty"`
+	ShowRecentOnStartup   bool             `json:"show_recent_on_startup,omitempty"`
 }
 
 // NewGeneralSheetSettings creates settings with factory defaults.
@@ -146,5 +150,6 @@ func (s *GeneralSheetSettings) EnsureValidity() {
 	s.InitialEditorUIScale = fxp.ResetIfOutOfRangeInt(s.InitialEditorUIScale, InitialUIScaleMin, InitialUIScaleMax, InitialEditorUIScaleDef)
 	s.InitialSheetUIScale = fxp.ResetIfOutOfRangeInt(s.InitialSheetUIScale, InitialUIScaleMin, InitialUIScaleMax, InitialSheetUIScaleDef)
 	s.MaximumAutoColWidth = fxp.ResetIfOutOfRangeInt(s.MaximumAutoColWidth, AutoColWidthMin, AutoColWidthMax, MaximumAutoColWidthDef)
+	s.DecimalSeparator = s.DecimalSeparator.EnsureValid()
 	s.UpdateToolTipTiming()
 }
@@ -0,0 +1,75 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// HitLocationSuggestion holds the conventional values for one of the standard hit locations.
+type HitLocationSuggestion struct {
+	ID         string
+	Name       string
+	Slots      int
+	HitPenalty int
+	DRBonus    int
+}
+
+// StandardHitLocations returns the standard humanoid hit locations, with their names localized.
+func StandardHitLocations() []*HitLocationSuggestion {
+	return []*HitLocationSuggestion{
+		{ID: "eye", Name: i18n.Text("Eyes"), HitPenalty: -9},
+		{ID: "skull", Name: i18n.Text("Skull"), Slots: 2, HitPenalty: -7, DRBonus: 2},
+		{ID: "face", Name: i18n.Text("Face"), Slots: 1, HitPenalty: -5},
+		{ID: "neck", Name: i18n.Text("Neck"), Slots: 2, HitPenalty: -5},
+		{ID: "torso", Name: i18n.Text("Torso"), Slots: 2},
+		{ID: "vitals", Name: i18n.Text("Vitals"), HitPenalty: -3},
+		{ID: "groin", Name: i18n.Text("Groin"), Slots: 1, HitPenalty: -3},
+		{ID: "arm", Name: i18n.Text("Arm"), Slots: 1, HitPenalty: -2},
+		{ID: "hand", Name: i18n.Text("Hand"), Slots: 1, HitPenalty: -4},
+		{ID: "leg", Name: i18n.Text("Leg"), Slots: 2, HitPenalty: -2},
+		{ID: "foot", Name: i18n.Text("Foot"), Slots: 1, HitPenalty: -4},
+	}
+}
+
+// StandardHitLocationNames returns the names of the standard hit locations.
+func StandardHitLocationNames() []string {
+	list := StandardHitLocations()
+	names := make([]string, len(list))
+	for i, one := range list {
+		names[i] = one.Name
+	}
+	return names
+}
+
+// LookupStandardHitLocation returns the standard hit location with the given name, ignoring case, or nil.
+func LookupStandardHitLocation(name string) *HitLocationSuggestion {
+	name = strings.TrimSpace(name)
+	for _, one := range StandardHitLocations() {
+		if strings.EqualFold(one.Name, name) {
+			return one
+		}
+	}
+	return nil
+}
+
+// ApplyTo sets the hit location's ID, names, slots, hit penalty and DR bonus to the suggested values.
+func (s *HitLocationSuggestion) ApplyTo(loc *HitLocation) {
+	loc.SetID(s.ID)
+	loc.ChoiceName = s.Name
+	loc.TableName = s.Name
+	loc.Slots = s.Slots
+	loc.HitPenalty = s.HitPenalty
+	loc.DRBonus = s.DRBonus
+}
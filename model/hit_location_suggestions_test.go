@@ -0,0 +1,44 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStandardHitLocationsMatchFactoryBody(t *testing.T) {
+	body := FactoryBody()
+	for _, one := range StandardHitLocations() {
+		loc := body.LookupLocationByID(nil, one.ID)
+		require.NotNil(t, loc, one.ID)
+		require.Equal(t, loc.HitPenalty, one.HitPenalty, one.ID)
+		require.Equal(t, loc.Slots, one.Slots, one.ID)
+		require.Equal(t, loc.DRBonus, one.DRBonus, one.ID)
+	}
+}
+
+func TestLookupStandardHitLocation(t *testing.T) {
+	require.Nil(t, LookupStandardHitLocation("Tail"))
+	suggestion := LookupStandardHitLocation(" skull ")
+	require.NotNil(t, suggestion)
+	loc := NewHitLocation(nil, "")
+	suggestion.ApplyTo(loc)
+	require.Equal(t, "skull", loc.LocID)
+	require.Equal(t, "Skull", loc.TableName)
+	require.Equal(t, "Skull", loc.ChoiceName)
+	require.Equal(t, 2, loc.Slots)
+	require.Equal(t, -7, loc.HitPenalty)
+	require.Equal(t, 2, loc.DRBonus)
+	require.Contains(t, StandardHitLocationNames(), "Vitals")
+}
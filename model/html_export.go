@@ -0,0 +1,158 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"bufio"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+const defaultHTMLTemplatePath = "embedded_data/Default Sheet.html"
+
+// CellDataProvider defines the method needed to retrieve the visual representation of a column for a row.
+type CellDataProvider interface {
+	CellData(columnID int, data *CellData)
+}
+
+// ExportTemplateData holds the data made available to templates used for exporting an Entity. The node lists are
+// flattened in display order; use the Depth() method on each item to determine its nesting level.
+type ExportTemplateData struct {
+	Entity           *Entity
+	Profile          *Profile
+	Points           *PointsBreakdown
+	Attributes       []*Attribute
+	Traits           []*Trait
+	Skills           []*Skill
+	Spells           []*Spell
+	CarriedEquipment []*Equipment
+	OtherEquipment   []*Equipment
+	Notes            []*Note
+	MeleeWeapons     []*Weapon
+	RangedWeapons    []*Weapon
+}
+
+// NewExportTemplateData collects the data for the entity that will be made available to an export template.
+func NewExportTemplateData(entity *Entity) *ExportTemplateData {
+	entity.Recalculate()
+	return &ExportTemplateData{
+		Entity:           entity,
+		Profile:          entity.Profile,
+		Points:           entity.PointsBreakdown(),
+		Attributes:       entity.Attributes.List(),
+		Traits:           flattenNodes(entity.Traits),
+		Skills:           flattenNodes(entity.Skills),
+		Spells:           flattenNodes(entity.SpellList()),
+		CarriedEquipment: flattenNodes(entity.CarriedEquipment),
+		OtherEquipment:   flattenNodes(entity.OtherEquipment),
+		Notes:            flattenNodes(entity.Notes),
+		MeleeWeapons:     entity.EquippedWeapons(MeleeWeaponType),
+		RangedWeapons:    entity.EquippedWeapons(RangedWeaponType),
+	}
+}
+
+func flattenNodes[T NodeTypes](list []T) []T {
+	var result []T
+	Traverse(func(one T) bool {
+		result = append(result, one)
+		return false
+	}, false, false, list...)
+	return result
+}
+
+// ExportTemplateFuncs returns the functions made available to export templates for the given entity:
+//
+//	fxp:    formats a fixed-point value
+//	signed: formats a fixed-point value, always including its sign
+//	dice:   formats a dice specification
+//	weight: formats a weight using the entity's default weight units
+//	attr:   returns the attribute with the given ID, or nil
+//	cell:   returns the primary text for a column, as displayed on the sheet
+func ExportTemplateFuncs(entity *Entity) template.FuncMap {
+	return template.FuncMap{
+		"fxp":    func(value fxp.Int) string { return value.String() },
+		"signed": func(value fxp.Int) string { return value.StringWithSign() },
+		"dice": func(d *dice.Dice) string {
+			if d == nil {
+				return ""
+			}
+			return d.String()
+		},
+		"weight": func(w Weight) string { return SheetSettingsFor(entity).DefaultWeightUnits.Format(w) },
+		"attr": func(attrID string) *Attribute {
+			if entity == nil || entity.Attributes == nil {
+				return nil
+			}
+			return entity.Attributes.Set[attrID]
+		},
+		"cell": func(item CellDataProvider, columnID int) string {
+			var data CellData
+			item.CellData(columnID, &data)
+			return data.Primary
+		},
+	}
+}
+
+// ExportSheetToHTML renders the entity with the Go text/template found at templatePath and writes the result to w. If
+// templatePath is empty, the built-in default template is used.
+func ExportSheetToHTML(entity *Entity, templatePath string, w io.Writer) error {
+	var fileSystem fs.FS
+	var name string
+	if templatePath == "" {
+		fileSystem = embeddedFS
+		name = defaultHTMLTemplatePath
+	} else {
+		fileSystem = os.DirFS(filepath.Dir(templatePath))
+		name = filepath.Base(templatePath)
+	}
+	data, err := fs.ReadFile(fileSystem, name)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	var tmpl *template.Template
+	if tmpl, err = template.New(filepath.Base(name)).Funcs(ExportTemplateFuncs(entity)).Parse(string(data)); err != nil {
+		return errs.Wrap(err)
+	}
+	if err = tmpl.Execute(w, NewExportTemplateData(entity)); err != nil {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+// ExportSheetToHTMLFile calls ExportSheetToHTML, writing the result to the file at exportPath.
+func ExportSheetToHTMLFile(entity *Entity, templatePath, exportPath string) error {
+	return exportToFile(exportPath, func(w io.Writer) error { return ExportSheetToHTML(entity, templatePath, w) })
+}
+
+func exportToFile(exportPath string, exporter func(w io.Writer) error) (err error) {
+	var f *os.File
+	if f, err = os.Create(exportPath); err != nil {
+		return errs.Wrap(err)
+	}
+	out := bufio.NewWriter(f)
+	defer func() {
+		if flushErr := out.Flush(); flushErr != nil && err == nil {
+			err = errs.Wrap(flushErr)
+		}
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = errs.Wrap(closeErr)
+		}
+	}()
+	return exporter(out)
+}
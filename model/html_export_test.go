@@ -0,0 +1,35 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportSheetToHTMLDefaultTemplate(t *testing.T) {
+	entity := NewEntity(PC)
+	entity.Profile.Name = "Test <Name>"
+	entity.Traits = append(entity.Traits, NewTrait(entity, nil, false))
+	entity.Skills = append(entity.Skills, NewSkill(entity, nil, false))
+	entity.Spells = append(entity.Spells, NewSpell(entity, nil, false))
+	entity.CarriedEquipment = append(entity.CarriedEquipment, NewEquipment(entity, nil, false))
+	entity.Notes = append(entity.Notes, NewNote(entity, nil, false))
+	var buffer bytes.Buffer
+	require.NoError(t, ExportSheetToHTML(entity, "", &buffer))
+	out := buffer.String()
+	require.Contains(t, out, "<h1>Test &lt;Name&gt;</h1>")
+	require.Contains(t, out, "<h2>Skills</h2>")
+	require.Contains(t, out, "<h2>Melee Weapons</h2>")
+}
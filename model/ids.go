@@ -29,6 +29,7 @@ const (
 	SizeModifierID     = "sm"
 	SkillID            = "skill"
 	SpellID            = "spell"
+	SpellHeadingID     = "spell_heading"
 	StrengthID         = "st"
 	TechniqueID        = "technique"
 )
@@ -62,7 +62,9 @@ func DecompressAndDeserialize(data []byte, result any) error {
 	if err != nil {
 		return errs.Wrap(err)
 	}
-	if err = json.NewDecoder(gz).Decode(result); err != nil {
+	decoder := json.NewDecoder(gz)
+	decoder.UseNumber()
+	if err = decoder.Decode(result); err != nil {
 		return errs.Wrap(err)
 	}
 	return nil
@@ -37,7 +37,8 @@ func SaveToFile(ctx context.Context, path string, data any) error {
 	return nil
 }
 
-// Save writes the data as JSON.
+// Save writes the data as JSON. Struct fields are written in declaration order and map keys are sorted, so saving
+// unchanged data always produces byte-identical output.
 func Save(ctx context.Context, w io.Writer, data any) error {
 	encoder := json.NewEncoder(w)
 	encoder.SetContext(ctx)
@@ -46,11 +47,14 @@ func Save(ctx context.Context, w io.Writer, data any) error {
 	return errs.Wrap(encoder.Encode(data))
 }
 
-// SerializeAndCompress writes the data as JSON into a buffer, then compresses it.
+// SerializeAndCompress writes the data as JSON into a buffer, then compresses it. As with Save, the output is
+// deterministic: struct fields are written in declaration order and map keys are sorted.
 func SerializeAndCompress(data any) ([]byte, error) {
 	var buffer bytes.Buffer
 	gz := gzip.NewWriter(&buffer)
-	if err := json.NewEncoder(gz).Encode(data); err != nil {
+	encoder := json.NewEncoder(gz)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(data); err != nil {
 		return nil, errs.Wrap(err)
 	}
 	if err := gz.Close(); err != nil {
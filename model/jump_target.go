@@ -0,0 +1,132 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/exp/slices"
+)
+
+// JumpTarget is an item within one of an entity's lists that can be located by name.
+type JumpTarget struct {
+	Kind      string
+	Name      string
+	Item      any
+	score     int
+	substring bool
+}
+
+func (t *JumpTarget) String() string {
+	return t.Name + " (" + t.Kind + ")"
+}
+
+// JumpTargets returns an index of the traits, skills, spells, equipment and equipped weapons of the entity.
+func (e *Entity) JumpTargets() []*JumpTarget {
+	var targets []*JumpTarget
+	Traverse(func(t *Trait) bool {
+		targets = appendJumpTarget(targets, t)
+		return false
+	}, false, false, e.Traits...)
+	Traverse(func(s *Skill) bool {
+		targets = appendJumpTarget(targets, s)
+		return false
+	}, false, false, e.Skills...)
+	Traverse(func(s *Spell) bool {
+		targets = appendJumpTarget(targets, s)
+		return false
+	}, false, false, e.Spells...)
+	Traverse(func(eqp *Equipment) bool {
+		targets = appendJumpTarget(targets, eqp)
+		return false
+	}, false, false, e.CarriedEquipment...)
+	Traverse(func(eqp *Equipment) bool {
+		targets = appendJumpTarget(targets, eqp)
+		return false
+	}, false, false, e.OtherEquipment...)
+	for _, weaponType := range AllWeaponType {
+		for _, w := range e.EquippedWeapons(weaponType) {
+			name := w.String()
+			if w.Usage != "" {
+				name += " (" + w.Usage + ")"
+			}
+			targets = append(targets, &JumpTarget{
+				Kind: w.Kind(),
+				Name: name,
+				Item: w,
+			})
+		}
+	}
+	return targets
+}
+
+func appendJumpTarget[T NodeTypes](targets []*JumpTarget, item T) []*JumpTarget {
+	node := AsNode(item)
+	return append(targets, &JumpTarget{
+		Kind: node.Kind(),
+		Name: node.String(),
+		Item: item,
+	})
+}
+
+// FilterJumpTargets returns the targets whose names contain the characters of the query in order, best matches first.
+// Names containing the query as a contiguous piece rank ahead of those that only match when gaps are allowed. An empty
+// query returns all of the targets.
+func FilterJumpTargets(targets []*JumpTarget, query string) []*JumpTarget {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return targets
+	}
+	result := make([]*JumpTarget, 0, len(targets))
+	for _, one := range targets {
+		if score, substring, ok := fuzzyMatchScore(query, strings.ToLower(one.Name)); ok {
+			one.score = score
+			one.substring = substring
+			result = append(result, one)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b *JumpTarget) bool {
+		if a.substring != b.substring {
+			return a.substring
+		}
+		return a.score < b.score
+	})
+	return result
+}
+
+// fuzzyMatchScore returns a score for how well the query matches the text, with lower values being better, and whether
+// the query was found as a contiguous piece of the text. Scores are only comparable between matches of the same kind.
+// Both are expected to already be lowercase.
+func fuzzyMatchScore(query, text string) (score int, substring, ok bool) {
+	if i := strings.Index(text, query); i != -1 {
+		return i, true, true
+	}
+	score = len(text)
+	last := -1
+	pos := 0
+	for _, ch := range query {
+		i := strings.IndexRune(text[pos:], ch)
+		if i == -1 {
+			return 0, false, false
+		}
+		i += pos
+		if last != -1 {
+			score += i - last - 1
+		} else {
+			score += i
+		}
+		last = i
+		pos = i + utf8.RuneLen(ch)
+	}
+	return score, false, true
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJumpTargets(t *testing.T) {
+	entity := NewEntity(PC)
+	trait := NewTrait(entity, nil, false)
+	trait.Name = "Fire Breath"
+	w := NewWeapon(trait, MeleeWeaponType)
+	w.Usage = "Cone"
+	trait.Weapons = append(trait.Weapons, w)
+	entity.Traits = []*Trait{trait}
+	skill := NewSkill(entity, nil, false)
+	skill.Name = "Firearms"
+	entity.Skills = append(entity.Skills, skill)
+	spell := NewSpell(entity, nil, false)
+	spell.Name = "Create Fire"
+	entity.Spells = append(entity.Spells, spell)
+	eqp := NewEquipment(entity, nil, false)
+	eqp.Name = "Rope"
+	entity.OtherEquipment = append(entity.OtherEquipment, eqp)
+
+	targets := entity.JumpTargets()
+	require.Len(t, targets, 5)
+	require.Same(t, w, targets[4].Item)
+	require.Equal(t, "Fire Breath (Cone)", targets[4].Name)
+
+	matches := FilterJumpTargets(targets, "fire")
+	require.Len(t, matches, 4)
+	require.Same(t, trait, matches[0].Item)
+	require.Same(t, skill, matches[1].Item)
+	require.Same(t, spell, matches[3].Item)
+
+	matches = FilterJumpTargets(targets, "frb")
+	require.Len(t, matches, 2)
+	require.Same(t, trait, matches[0].Item)
+
+	require.Empty(t, FilterJumpTargets(targets, "xyz"))
+	require.Len(t, FilterJumpTargets(targets, " "), 5)
+
+	grease := &JumpTarget{Kind: "Equipment", Name: "Axle Grease"}
+	longsword := &JumpTarget{Kind: "Equipment", Name: "Longsword of the Battle Axe"}
+	matches = FilterJumpTargets([]*JumpTarget{grease, longsword}, "axe")
+	require.Equal(t, []*JumpTarget{longsword, grease}, matches)
+}
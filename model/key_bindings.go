@@ -186,3 +186,20 @@ func (b *KeyBindings) ResetOne(id string) {
 		delete(b.data, id)
 	}
 }
+
+// Conflicts returns the other bindings that currently share the given key binding, which is about to be used for id.
+// An empty key binding never conflicts.
+func (b *KeyBindings) Conflicts(id string, binding unison.KeyBinding) []*Binding {
+	if binding.KeyCode == 0 {
+		return nil
+	}
+	var list []*Binding
+	for _, one := range CurrentBindings() {
+		if one.ID != id {
+			if one.KeyBinding = b.Current(one.ID); one.KeyBinding == binding {
+				list = append(list, one)
+			}
+		}
+	}
+	return list
+}
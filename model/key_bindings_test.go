@@ -0,0 +1,38 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/unison"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyBindingConflicts(t *testing.T) {
+	first := unison.KeyBinding{KeyCode: unison.KeyF9, Modifiers: unison.ShiftModifier}
+	second := unison.KeyBinding{KeyCode: unison.KeyF10, Modifiers: unison.ShiftModifier}
+	RegisterKeyBinding("test.conflict.first", &unison.Action{Title: "First", KeyBinding: first})
+	RegisterKeyBinding("test.conflict.second", &unison.Action{Title: "Second", KeyBinding: second})
+
+	var b KeyBindings
+	require.Empty(t, b.Conflicts("test.conflict.first", first))
+	conflicts := b.Conflicts("test.conflict.second", first)
+	require.Len(t, conflicts, 1)
+	require.Equal(t, "test.conflict.first", conflicts[0].ID)
+	require.Empty(t, b.Conflicts("test.conflict.second", unison.KeyBinding{}))
+
+	b.Set("test.conflict.first", unison.KeyBinding{})
+	require.Empty(t, b.Conflicts("test.conflict.second", first))
+	b.Set("test.conflict.first", second)
+	require.Len(t, b.Conflicts("test.conflict.second", second), 1)
+}
@@ -927,7 +927,7 @@ func (ex *legacyExporter) processSpellsLoop(buffer []byte) {
 					ex.writeEncodedText(parent.ID.String())
 				}
 			case typeExportKey:
-				if s.Container() {
+				if s.Container() || s.Heading() {
 					ex.writeEncodedText("GROUP")
 				} else {
 					ex.writeEncodedText("ITEM")
@@ -953,7 +953,7 @@ func (ex *legacyExporter) processSpellsLoop(buffer []byte) {
 			case "COLLEGE":
 				ex.writeEncodedText(strings.Join(s.College, ", "))
 			case "MANA_CAST":
-				ex.writeEncodedText(s.CastingCost)
+				ex.writeEncodedText(s.EffectiveCastingCost())
 			case "MANA_MAINTAIN":
 				ex.writeEncodedText(s.MaintenanceCost)
 			case "TIME_CAST":
@@ -0,0 +1,927 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/log/jot"
+)
+
+// LegacyV4ThirdPartyKey is the key used within an Entity's ThirdParty data to hold top-level elements from a legacy v4
+// file that have no equivalent in the current data model.
+const LegacyV4ThirdPartyKey = "legacy_v4"
+
+var legacyV4AttributeIDs = map[string]string{
+	"ST":         "st",
+	"DX":         "dx",
+	"IQ":         "iq",
+	"HT":         "ht",
+	"HP":         "hp",
+	"FP":         "fp",
+	"will":       "will",
+	"perception": "per",
+	"speed":      "basic_speed",
+	"move":       "basic_move",
+}
+
+type legacyV4Node struct {
+	Name     string
+	Attrs    map[string]string
+	Text     string
+	Children []*legacyV4Node
+}
+
+type legacyV4Loader struct {
+	entity *Entity
+	path   string
+}
+
+// LoadLegacyV4 loads an Entity from a character sheet written by the 4.x series of GCS. Both the older XML format and
+// the early JSON format (data versions prior to MinimumDataVersion) are accepted. Top-level data that has no equivalent
+// in the current data model is preserved in the Entity's ThirdParty data; anything else that cannot be mapped is logged
+// and dropped.
+func LoadLegacyV4(fileSystem fs.FS, path string) (*Entity, error) {
+	data, err := fs.ReadFile(fileSystem, path)
+	if err != nil {
+		return nil, errs.NewWithCause(invalidFileDataMsg(), err)
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '<' {
+		return loadLegacyV4JSON(data, path)
+	}
+	var root *legacyV4Node
+	if root, err = parseLegacyV4XML(data); err != nil {
+		return nil, errs.NewWithCause(invalidFileDataMsg(), err)
+	}
+	if root.Name != "character" {
+		return nil, errs.New(unexpectedFileDataMsg())
+	}
+	l := &legacyV4Loader{path: path}
+	l.entity = &Entity{
+		EntityData: EntityData{
+			Type:      PC,
+			ID:        NewUUID(),
+			Profile:   &Profile{},
+			CreatedOn: jio.Now(),
+		},
+	}
+	l.entity.SheetSettings = GlobalSettings().SheetSettings().Clone(l.entity)
+	l.entity.Attributes = NewAttributes(l.entity)
+	l.entity.ModifiedOn = l.entity.CreatedOn
+	l.loadCharacter(root)
+	l.entity.Version = CurrentDataVersion
+	l.entity.PointsRecord = []*PointsRecord{
+		{
+			Points: l.entity.TotalPoints,
+			When:   jio.Now(),
+			Reason: i18n.Text("Imported from legacy v4 data"),
+		},
+	}
+	l.entity.Recalculate()
+	return l.entity, nil
+}
+
+func loadLegacyV4JSON(data []byte, path string) (*Entity, error) {
+	var entity Entity
+	if err := jio.Load(context.Background(), bytes.NewReader(data), &entity); err != nil {
+		return nil, errs.NewWithCause(invalidFileDataMsg(), err)
+	}
+	if entity.Version > CurrentDataVersion {
+		return nil, CheckVersion(entity.Version)
+	}
+	if entity.Version < MinimumDataVersion {
+		jot.Warnf("%s: loaded legacy data version %d; data that no longer exists in the current format was dropped",
+			path, entity.Version)
+		entity.Version = CurrentDataVersion
+	}
+	return &entity, nil
+}
+
+func parseLegacyV4XML(data []byte) (*legacyV4Node, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	var stack []*legacyV4Node
+	var root *legacyV4Node
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errs.Wrap(err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &legacyV4Node{
+				Name:  t.Name.Local,
+				Attrs: make(map[string]string, len(t.Attr)),
+			}
+			for _, attr := range t.Attr {
+				node.Attrs[attr.Name.Local] = attr.Value
+			}
+			if len(stack) == 0 {
+				if root != nil {
+					return nil, errs.New("multiple root elements")
+				}
+				root = node
+			} else {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, node)
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			if len(stack) != 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			if len(stack) != 0 {
+				stack[len(stack)-1].Text += string(t)
+			}
+		}
+	}
+	if root == nil {
+		return nil, errs.New("no root element")
+	}
+	return root, nil
+}
+
+func (n *legacyV4Node) text() string {
+	return strings.TrimSpace(n.Text)
+}
+
+func (n *legacyV4Node) fxpValue() fxp.Int {
+	return fxp.FromStringForced(n.text())
+}
+
+func (n *legacyV4Node) int() int {
+	return fxp.As[int](n.fxpValue())
+}
+
+func (n *legacyV4Node) boolAttr(name string, def bool) bool {
+	if v, ok := n.Attrs[name]; ok {
+		v = strings.ToLower(strings.TrimSpace(v))
+		return v == "yes" || v == "true" || v == "1"
+	}
+	return def
+}
+
+func (n *legacyV4Node) fxpAttr(name string) fxp.Int {
+	return fxp.FromStringForced(strings.TrimSpace(n.Attrs[name]))
+}
+
+// toThirdParty converts the node into a form suitable for storage in a ThirdParty map.
+func (n *legacyV4Node) toThirdParty() any {
+	if len(n.Attrs) == 0 && len(n.Children) == 0 {
+		return n.text()
+	}
+	m := make(map[string]any)
+	for k, v := range n.Attrs {
+		m["@"+k] = v
+	}
+	if text := n.text(); text != "" {
+		m["#text"] = text
+	}
+	for _, child := range n.Children {
+		value := child.toThirdParty()
+		switch existing := m[child.Name].(type) {
+		case nil:
+			m[child.Name] = value
+		case []any:
+			m[child.Name] = append(existing, value)
+		default:
+			m[child.Name] = []any{existing, value}
+		}
+	}
+	return m
+}
+
+func (l *legacyV4Loader) unknown(context string, node *legacyV4Node) {
+	jot.Warnf("%s: ignoring unsupported legacy element <%s> within <%s>", l.path, node.Name, context)
+}
+
+func (l *legacyV4Loader) preserve(node *legacyV4Node) {
+	if l.entity.ThirdParty == nil {
+		l.entity.ThirdParty = make(map[string]any)
+	}
+	m, ok := l.entity.ThirdParty[LegacyV4ThirdPartyKey].(map[string]any)
+	if !ok {
+		m = make(map[string]any)
+		l.entity.ThirdParty[LegacyV4ThirdPartyKey] = m
+	}
+	m[node.Name] = node.toThirdParty()
+}
+
+func (l *legacyV4Loader) loadCharacter(root *legacyV4Node) {
+	attrValues := make(map[string]fxp.Int)
+	for _, child := range root.Children {
+		switch child.Name {
+		case "created_date", "modified_date":
+			if t, err := jio.NewTimeFrom(child.text()); err == nil {
+				if child.Name == "created_date" {
+					l.entity.CreatedOn = t
+				} else {
+					l.entity.ModifiedOn = t
+				}
+			} else {
+				l.preserve(child)
+			}
+		case "profile":
+			l.loadProfile(child)
+		case "total_points":
+			l.entity.TotalPoints = child.fxpValue()
+		case "ST", "DX", "IQ", "HT", "HP", "FP", "will", "perception", "speed", "move":
+			attrValues[legacyV4AttributeIDs[child.Name]] = child.fxpValue()
+		case "HP_damage", "FP_damage":
+			if attr, ok := l.entity.Attributes.Set[strings.ToLower(strings.TrimSuffix(child.Name, "_damage"))]; ok {
+				attr.Damage = child.fxpValue()
+			}
+		case "settings":
+			l.loadSettings(child)
+		case "advantage_list":
+			l.entity.Traits = loadLegacyV4List(l, child, nil, l.loadTrait)
+		case "skill_list":
+			l.entity.Skills = loadLegacyV4List(l, child, nil, l.loadSkill)
+		case "spell_list":
+			l.entity.Spells = loadLegacyV4List(l, child, nil, l.loadSpell)
+		case "equipment_list":
+			l.entity.CarriedEquipment = loadLegacyV4List(l, child, nil, l.loadEquipment)
+		case "other_equipment_list":
+			l.entity.OtherEquipment = loadLegacyV4List(l, child, nil, l.loadEquipment)
+		case "note_list":
+			l.entity.Notes = loadLegacyV4List(l, child, nil, l.loadNote)
+		default:
+			l.preserve(child)
+		}
+	}
+	// The primary attributes were stored as absolute values, while the rest were stored as adjustments.
+	for id, value := range attrValues {
+		attr, ok := l.entity.Attributes.Set[id]
+		if !ok {
+			continue
+		}
+		switch id {
+		case "st", "dx", "iq", "ht":
+			if def := attr.AttributeDef(); def != nil {
+				value -= def.BaseValue(l.entity)
+			}
+		}
+		attr.Adjustment = value
+	}
+}
+
+func (l *legacyV4Loader) loadProfile(node *legacyV4Node) {
+	p := l.entity.Profile
+	for _, child := range node.Children {
+		switch child.Name {
+		case "player_name":
+			p.PlayerName = child.text()
+		case "name":
+			p.Name = child.text()
+		case "title":
+			p.Title = child.text()
+		case "organization":
+			p.Organization = child.text()
+		case "religion":
+			p.Religion = child.text()
+		case "age":
+			p.Age = child.text()
+		case "birthday":
+			p.Birthday = child.text()
+		case "eyes":
+			p.Eyes = child.text()
+		case "hair":
+			p.Hair = child.text()
+		case "skin":
+			p.Skin = child.text()
+		case "handedness":
+			p.Handedness = child.text()
+		case "gender":
+			p.Gender = child.text()
+		case "tech_level":
+			p.TechLevel = child.text()
+		case "height":
+			p.Height = LengthFromStringForced(child.text(), Inch)
+		case "weight":
+			p.Weight = WeightFromStringForced(child.text(), Pound)
+		case "SM", "size_modifier":
+			p.SizeModifier = child.int()
+		case "portrait":
+			text := strings.Join(strings.Fields(child.text()), "")
+			if data, err := base64.StdEncoding.DecodeString(text); err == nil {
+				p.PortraitData = data
+			} else {
+				jot.Warnf("%s: unable to decode legacy portrait: %v", l.path, err)
+			}
+		default:
+			l.unknown(node.Name, child)
+		}
+	}
+}
+
+func (l *legacyV4Loader) loadSettings(node *legacyV4Node) {
+	for _, child := range node.Children {
+		switch child.Name {
+		case "hit_locations", "body_type":
+			if body := l.loadBody(child); len(body.Locations) != 0 {
+				body.Rewrap()
+				body.Update(l.entity)
+				l.entity.SheetSettings.BodyType = body
+			}
+		default:
+			l.unknown(node.Name, child)
+		}
+	}
+}
+
+func (l *legacyV4Loader) loadBody(node *legacyV4Node) *Body {
+	body := &Body{
+		Name: node.Attrs["name"],
+		Roll: dice.New("3d"),
+	}
+	for _, child := range node.Children {
+		switch child.Name {
+		case "name", "id":
+			body.Name = child.text()
+		case "roll":
+			body.Roll = dice.New(child.text())
+		case "location", "hit_location":
+			loc := NewHitLocation(l.entity, "")
+			for _, one := range child.Children {
+				switch one.Name {
+				case "id":
+					loc.LocID = one.text()
+				case "choice_name":
+					loc.ChoiceName = one.text()
+				case "table_name":
+					loc.TableName = one.text()
+				case "slots":
+					loc.Slots = one.int()
+				case "hit_penalty":
+					loc.HitPenalty = one.int()
+				case "dr_bonus":
+					loc.DRBonus = one.int()
+				case "description":
+					loc.Description = one.text()
+				case "sub_table":
+					loc.SubTable = l.loadBody(one)
+					loc.SubTable.SetOwningLocation(loc)
+				default:
+					l.unknown(child.Name, one)
+				}
+			}
+			body.AddLocation(loc)
+		default:
+			l.unknown(node.Name, child)
+		}
+	}
+	return body
+}
+
+func loadLegacyV4List[T NodeTypes](l *legacyV4Loader, node *legacyV4Node, parent T, loader func(node *legacyV4Node, parent T) (T, bool)) []T {
+	var list []T
+	for _, child := range node.Children {
+		if one, ok := loader(child, parent); ok {
+			list = append(list, one)
+		} else {
+			l.unknown(node.Name, child)
+		}
+	}
+	return list
+}
+
+func legacyV4Tags(node *legacyV4Node) []string {
+	var tags []string
+	for _, child := range node.Children {
+		if child.Name == "category" {
+			if text := child.text(); text != "" {
+				tags = append(tags, text)
+			}
+		}
+	}
+	return tags
+}
+
+func legacyV4ContainerType(text string) ContainerType {
+	text = strings.ToLower(strings.TrimSpace(text))
+	text = strings.NewReplacer("-", "_", " ", "_").Replace(text)
+	return ExtractContainerType(text)
+}
+
+func (l *legacyV4Loader) loadTrait(node *legacyV4Node, parent *Trait) (*Trait, bool) {
+	var isContainer bool
+	switch node.Name {
+	case "advantage":
+	case "advantage_container":
+		isContainer = true
+	default:
+		return nil, false
+	}
+	t := NewTrait(l.entity, parent, isContainer)
+	t.IsOpen = node.boolAttr("open", isContainer)
+	t.Disabled = !node.boolAttr("enabled", true)
+	t.RoundCostDown = node.boolAttr("round_down", false)
+	if isContainer {
+		t.ContainerType = legacyV4ContainerType(node.Attrs["type"])
+	} else {
+		t.Prereq = NewPrereqList()
+		for _, one := range strings.Split(node.Attrs["type"], ",") {
+			if one = strings.TrimSpace(one); one != "" {
+				t.Tags = append(t.Tags, one)
+			}
+		}
+	}
+	for _, child := range node.Children {
+		switch child.Name {
+		case "name":
+			t.Name = child.text()
+		case "reference":
+			t.PageRef = child.text()
+		case "notes":
+			t.LocalNotes = child.text()
+		case "vtt_notes":
+			t.VTTNotes = child.text()
+		case "ancestry":
+			t.Ancestry = child.text()
+		case "userdesc":
+			t.UserDesc = child.text()
+		case "categories":
+			t.Tags = append(t.Tags, legacyV4Tags(child)...)
+		case "base_points":
+			t.BasePoints = child.fxpValue()
+		case "levels":
+			t.Levels = child.fxpValue()
+			t.CanLevel = true
+		case "points_per_level":
+			t.PointsPerLevel = child.fxpValue()
+			t.CanLevel = true
+		case "cr":
+			t.CR = SelfControlRoll(child.int())
+			if adj, ok := child.Attrs["adj"]; ok {
+				t.CRAdj = ExtractSelfControlRollAdj(strings.ToLower(adj))
+			}
+		case "modifier", "modifier_container":
+			if mod, ok := l.loadTraitModifier(child, nil); ok {
+				t.Modifiers = append(t.Modifiers, mod)
+			} else {
+				l.unknown(node.Name, child)
+			}
+		case "prereq_list":
+			if !isContainer {
+				t.Prereq = l.loadPrereqList(child, nil)
+			} else {
+				l.unknown(node.Name, child)
+			}
+		case "advantage", "advantage_container":
+			if one, ok := l.loadTrait(child, t); ok && isContainer {
+				t.Children = append(t.Children, one)
+			} else {
+				l.unknown(node.Name, child)
+			}
+		default:
+			l.unknown(node.Name, child)
+		}
+	}
+	return t, true
+}
+
+func (l *legacyV4Loader) loadTraitModifier(node *legacyV4Node, parent *TraitModifier) (*TraitModifier, bool) {
+	var isContainer bool
+	switch node.Name {
+	case "modifier":
+	case "modifier_container":
+		isContainer = true
+	default:
+		return nil, false
+	}
+	mod := NewTraitModifier(l.entity, parent, isContainer)
+	mod.IsOpen = node.boolAttr("open", isContainer)
+	mod.Disabled = !node.boolAttr("enabled", true)
+	for _, child := range node.Children {
+		switch child.Name {
+		case "name":
+			mod.Name = child.text()
+		case "reference":
+			mod.PageRef = child.text()
+		case "notes":
+			mod.LocalNotes = child.text()
+		case "categories":
+			mod.Tags = legacyV4Tags(child)
+		case "cost":
+			mod.Cost = child.fxpValue()
+			mod.CostType = ExtractTraitModifierCostType(strings.ToLower(child.Attrs["type"]))
+		case "levels":
+			mod.Levels = child.fxpValue()
+		case "affects":
+			mod.Affects = ExtractAffects(strings.ToLower(child.text()))
+		case "modifier", "modifier_container":
+			if one, ok := l.loadTraitModifier(child, mod); ok && isContainer {
+				mod.Children = append(mod.Children, one)
+			} else {
+				l.unknown(node.Name, child)
+			}
+		default:
+			l.unknown(node.Name, child)
+		}
+	}
+	return mod, true
+}
+
+func (l *legacyV4Loader) loadSkill(node *legacyV4Node, parent *Skill) (*Skill, bool) {
+	var s *Skill
+	switch node.Name {
+	case "skill":
+		s = NewSkill(l.entity, parent, false)
+	case "skill_container":
+		s = NewSkill(l.entity, parent, true)
+		s.IsOpen = node.boolAttr("open", true)
+	case "technique":
+		s = NewTechnique(l.entity, parent, "")
+		if _, ok := node.Attrs["limit"]; ok {
+			limit := node.fxpAttr("limit")
+			s.TechniqueLimitModifier = &limit
+		}
+	default:
+		return nil, false
+	}
+	if !s.Container() {
+		s.Prereq = NewPrereqList()
+	}
+	for _, child := range node.Children {
+		switch child.Name {
+		case "name":
+			s.Name = child.text()
+		case "specialization":
+			s.Specialization = child.text()
+		case "tech_level":
+			tl := child.text()
+			s.TechLevel = &tl
+		case "difficulty":
+			parts := strings.SplitN(child.text(), "/", 2)
+			if len(parts) == 2 {
+				s.Difficulty.Attribute = strings.ToLower(strings.TrimSpace(parts[0]))
+				s.Difficulty.Difficulty = ExtractDifficulty(strings.TrimSpace(parts[1]))
+			} else {
+				s.Difficulty.Difficulty = ExtractDifficulty(strings.TrimSpace(parts[0]))
+			}
+		case "points":
+			s.Points = child.fxpValue()
+		case "encumbrance_penalty_multiplier":
+			s.EncumbrancePenaltyMultiplier = child.fxpValue()
+		case "reference":
+			s.PageRef = child.text()
+		case "notes":
+			s.LocalNotes = child.text()
+		case "vtt_notes":
+			s.VTTNotes = child.text()
+		case "categories":
+			s.Tags = legacyV4Tags(child)
+		case "default":
+			def := l.loadSkillDefault(child)
+			if s.TechniqueDefault != nil {
+				s.TechniqueDefault = def
+			} else {
+				s.Defaults = append(s.Defaults, def)
+			}
+		case "prereq_list":
+			if s.Prereq != nil {
+				s.Prereq = l.loadPrereqList(child, nil)
+			} else {
+				l.unknown(node.Name, child)
+			}
+		case "skill", "skill_container", "technique":
+			if one, ok := l.loadSkill(child, s); ok && s.Container() {
+				s.Children = append(s.Children, one)
+			} else {
+				l.unknown(node.Name, child)
+			}
+		default:
+			l.unknown(node.Name, child)
+		}
+	}
+	return s, true
+}
+
+func (l *legacyV4Loader) loadSkillDefault(node *legacyV4Node) *SkillDefault {
+	def := &SkillDefault{}
+	for _, child := range node.Children {
+		switch child.Name {
+		case "type":
+			def.DefaultType = strings.ToLower(child.text())
+		case "name":
+			def.Name = child.text()
+		case "specialization":
+			def.Specialization = child.text()
+		case "modifier":
+			def.Modifier = child.fxpValue()
+		default:
+			l.unknown(node.Name, child)
+		}
+	}
+	return def
+}
+
+func (l *legacyV4Loader) loadSpell(node *legacyV4Node, parent *Spell) (*Spell, bool) {
+	var isContainer bool
+	switch node.Name {
+	case "spell":
+	case "spell_container":
+		isContainer = true
+	default:
+		return nil, false
+	}
+	s := NewSpell(l.entity, parent, isContainer)
+	if isContainer {
+		s.IsOpen = node.boolAttr("open", true)
+	} else {
+		s.Prereq = NewPrereqList()
+		s.Difficulty.Attribute = "iq"
+		if node.boolAttr("very_hard", false) {
+			s.Difficulty.Difficulty = VeryHard
+		} else {
+			s.Difficulty.Difficulty = Hard
+		}
+	}
+	for _, child := range node.Children {
+		switch child.Name {
+		case "name":
+			s.Name = child.text()
+		case "tech_level":
+			tl := child.text()
+			s.TechLevel = &tl
+		case "college":
+			for _, one := range strings.Split(child.text(), "/") {
+				if one = strings.TrimSpace(one); one != "" {
+					s.College = append(s.College, one)
+				}
+			}
+		case "power_source":
+			s.PowerSource = child.text()
+		case "spell_class", "class":
+			s.Class = child.text()
+		case "resist":
+			s.Resist = child.text()
+		case "casting_cost":
+			s.CastingCost = child.text()
+		case "maintenance_cost":
+			s.MaintenanceCost = child.text()
+		case "casting_time":
+			s.CastingTime = child.text()
+		case "duration":
+			s.Duration = child.text()
+		case "points":
+			s.Points = child.fxpValue()
+		case "reference":
+			s.PageRef = child.text()
+		case "notes":
+			s.LocalNotes = child.text()
+		case "vtt_notes":
+			s.VTTNotes = child.text()
+		case "categories":
+			s.Tags = legacyV4Tags(child)
+		case "prereq_list":
+			if !isContainer {
+				s.Prereq = l.loadPrereqList(child, nil)
+			} else {
+				l.unknown(node.Name, child)
+			}
+		case "spell", "spell_container":
+			if one, ok := l.loadSpell(child, s); ok && isContainer {
+				s.Children = append(s.Children, one)
+			} else {
+				l.unknown(node.Name, child)
+			}
+		default:
+			l.unknown(node.Name, child)
+		}
+	}
+	return s, true
+}
+
+func (l *legacyV4Loader) loadEquipment(node *legacyV4Node, parent *Equipment) (*Equipment, bool) {
+	var isContainer bool
+	switch node.Name {
+	case "equipment":
+	case "equipment_container":
+		isContainer = true
+	default:
+		return nil, false
+	}
+	e := NewEquipment(l.entity, parent, isContainer)
+	e.IsOpen = node.boolAttr("open", isContainer)
+	e.Equipped = node.boolAttr("equipped", true)
+	e.Quantity = fxp.One
+	if _, ok := node.Attrs["quantity"]; ok {
+		e.Quantity = node.fxpAttr("quantity")
+	}
+	e.Prereq = NewPrereqList()
+	for _, child := range node.Children {
+		switch child.Name {
+		case "description", "name":
+			e.Name = child.text()
+		case "quantity":
+			e.Quantity = child.fxpValue()
+		case "tech_level":
+			e.TechLevel = child.text()
+		case "legality_class":
+			e.LegalityClass = child.text()
+		case "value":
+			e.Value = child.fxpValue()
+		case "weight":
+			e.Weight = WeightFromStringForced(child.text(), Pound)
+		case "uses":
+			e.Uses = child.int()
+		case "max_uses":
+			e.MaxUses = child.int()
+		case "reference":
+			e.PageRef = child.text()
+		case "notes":
+			e.LocalNotes = child.text()
+		case "vtt_notes":
+			e.VTTNotes = child.text()
+		case "categories":
+			e.Tags = legacyV4Tags(child)
+		case "prereq_list":
+			e.Prereq = l.loadPrereqList(child, nil)
+		case "equipment", "equipment_container":
+			if one, ok := l.loadEquipment(child, e); ok && isContainer {
+				e.Children = append(e.Children, one)
+			} else {
+				l.unknown(node.Name, child)
+			}
+		default:
+			l.unknown(node.Name, child)
+		}
+	}
+	return e, true
+}
+
+func (l *legacyV4Loader) loadNote(node *legacyV4Node, parent *Note) (*Note, bool) {
+	var isContainer bool
+	switch node.Name {
+	case "note":
+	case "note_container":
+		isContainer = true
+	default:
+		return nil, false
+	}
+	n := NewNote(l.entity, parent, isContainer)
+	n.IsOpen = node.boolAttr("open", isContainer)
+	n.Text = ""
+	for _, child := range node.Children {
+		switch child.Name {
+		case "text":
+			n.Text = child.text()
+		case "reference":
+			n.PageRef = child.text()
+		case "note", "note_container":
+			if one, ok := l.loadNote(child, n); ok && isContainer {
+				n.Children = append(n.Children, one)
+			} else {
+				l.unknown(node.Name, child)
+			}
+		default:
+			l.unknown(node.Name, child)
+		}
+	}
+	return n, true
+}
+
+func (l *legacyV4Loader) loadPrereqList(node *legacyV4Node, parent *PrereqList) *PrereqList {
+	list := NewPrereqList()
+	list.Parent = parent
+	list.All = node.boolAttr("all", true)
+	for _, child := range node.Children {
+		var prereq Prereq
+		switch child.Name {
+		case "when_tl":
+			list.WhenTL = legacyV4NumericCriteria(child)
+			continue
+		case "prereq_list":
+			prereq = l.loadPrereqList(child, list)
+		case "advantage_prereq":
+			p := NewTraitPrereq()
+			p.Parent = list
+			p.Has = child.boolAttr("has", true)
+			for _, one := range child.Children {
+				switch one.Name {
+				case "name":
+					p.NameCriteria = legacyV4StringCriteria(one)
+				case "level":
+					p.LevelCriteria = legacyV4NumericCriteria(one)
+				case "notes":
+					p.NotesCriteria = legacyV4StringCriteria(one)
+				default:
+					l.unknown(child.Name, one)
+				}
+			}
+			prereq = p
+		case "attribute_prereq":
+			p := NewAttributePrereq(l.entity)
+			p.Parent = list
+			p.Has = child.boolAttr("has", true)
+			p.Which = strings.ToLower(strings.TrimSpace(child.Attrs["which"]))
+			p.CombinedWith = strings.ToLower(strings.TrimSpace(child.Attrs["combined_with"]))
+			p.QualifierCriteria = legacyV4NumericCriteria(child)
+			prereq = p
+		case "skill_prereq":
+			p := NewSkillPrereq()
+			p.Parent = list
+			p.Has = child.boolAttr("has", true)
+			for _, one := range child.Children {
+				switch one.Name {
+				case "name":
+					p.NameCriteria = legacyV4StringCriteria(one)
+				case "level":
+					p.LevelCriteria = legacyV4NumericCriteria(one)
+				case "specialization":
+					p.SpecializationCriteria = legacyV4StringCriteria(one)
+				default:
+					l.unknown(child.Name, one)
+				}
+			}
+			prereq = p
+		case "spell_prereq":
+			p := NewSpellPrereq()
+			p.Parent = list
+			p.Has = child.boolAttr("has", true)
+			for _, one := range child.Children {
+				switch one.Name {
+				case "quantity":
+					p.QuantityCriteria = legacyV4NumericCriteria(one)
+				case "name":
+					p.SubType = NameSpellComparisonType
+					p.QualifierCriteria = legacyV4StringCriteria(one)
+				case "category":
+					p.SubType = TagSpellComparisonType
+					p.QualifierCriteria = legacyV4StringCriteria(one)
+				case "college":
+					p.SubType = CollegeSpellComparisonType
+					p.QualifierCriteria = legacyV4StringCriteria(one)
+				case "college_count":
+					p.SubType = CollegeCountSpellComparisonType
+				case "any":
+					p.SubType = AnySpellComparisonType
+				default:
+					l.unknown(child.Name, one)
+				}
+			}
+			prereq = p
+		case "contained_weight_prereq":
+			p := NewContainedWeightPrereq(l.entity)
+			p.Parent = list
+			p.Has = child.boolAttr("has", true)
+			p.WeightCriteria.Compare = NumericCompareType(strings.ToLower(child.Attrs["compare"])).EnsureValid()
+			p.WeightCriteria.Qualifier = WeightFromStringForced(child.text(), Pound)
+			prereq = p
+		case "contained_quantity_prereq":
+			p := NewContainedQuantityPrereq()
+			p.Parent = list
+			p.Has = child.boolAttr("has", true)
+			p.QualifierCriteria = legacyV4NumericCriteria(child)
+			prereq = p
+		default:
+			l.unknown(node.Name, child)
+			continue
+		}
+		list.Prereqs = append(list.Prereqs, prereq)
+	}
+	return list
+}
+
+func legacyV4StringCriteria(node *legacyV4Node) StringCriteria {
+	return StringCriteria{
+		StringCriteriaData: StringCriteriaData{
+			Compare:   StringCompareType(strings.ToLower(node.Attrs["compare"])).EnsureValid(),
+			Qualifier: node.text(),
+		},
+	}
+}
+
+func legacyV4NumericCriteria(node *legacyV4Node) NumericCriteria {
+	return NumericCriteria{
+		NumericCriteriaData: NumericCriteriaData{
+			Compare:   NumericCompareType(strings.ToLower(node.Attrs["compare"])).EnsureValid(),
+			Qualifier: node.fxpValue(),
+		},
+	}
+}
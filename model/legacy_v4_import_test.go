@@ -0,0 +1,93 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+const legacyV4Sample = `<?xml version="1.0" encoding="UTF-8"?>
+<character version="4">
+	<profile>
+		<name>Legacy Larry</name>
+		<height>5' 10"</height>
+		<weight>170 lb</weight>
+	</profile>
+	<total_points>100</total_points>
+	<ST>12</ST>
+	<HP>2</HP>
+	<perception>1</perception>
+	<advantage_list>
+		<advantage_container type="Meta-Trait" open="yes">
+			<name>Group</name>
+			<advantage type="Physical">
+				<name>Fit</name>
+				<base_points>5</base_points>
+				<prereq_list all="no">
+					<attribute_prereq has="yes" which="ST" compare="at_least">11</attribute_prereq>
+					<advantage_prereq has="no"><name compare="is">Unfit</name></advantage_prereq>
+				</prereq_list>
+			</advantage>
+		</advantage_container>
+	</advantage_list>
+	<skill_list>
+		<skill><name>Brawling</name><difficulty>DX/E</difficulty><points>2</points></skill>
+	</skill_list>
+	<note_list><note><text>Hello</text></note></note_list>
+	<campaign>Castle Falkenstein</campaign>
+</character>
+`
+
+func TestLoadLegacyV4XML(t *testing.T) {
+	fileSystem := fstest.MapFS{"larry.gcs": &fstest.MapFile{Data: []byte(legacyV4Sample)}}
+	entity, err := LoadLegacyV4(fileSystem, "larry.gcs")
+	require.NoError(t, err)
+	require.Equal(t, "Legacy Larry", entity.Profile.Name)
+	require.Equal(t, WeightFromInteger(170, Pound), entity.Profile.Weight)
+	require.Equal(t, fxp.From(100), entity.TotalPoints)
+	require.Equal(t, fxp.From(12), entity.Attributes.Current("st"))
+	require.Equal(t, fxp.From(14), entity.Attributes.Maximum("hp"))
+	require.Equal(t, fxp.From(11), entity.Attributes.Current("per"))
+
+	require.Len(t, entity.Traits, 1)
+	group := entity.Traits[0]
+	require.True(t, group.Container())
+	require.Equal(t, MetaTraitContainerType, group.ContainerType)
+	require.Len(t, group.Children, 1)
+	fit := group.Children[0]
+	require.Equal(t, "Fit", fit.Name)
+	require.Equal(t, []string{"Physical"}, fit.Tags)
+	require.False(t, fit.Prereq.All)
+	require.Len(t, fit.Prereq.Prereqs, 2)
+	attrPrereq, ok := fit.Prereq.Prereqs[0].(*AttributePrereq)
+	require.True(t, ok)
+	require.Equal(t, "st", attrPrereq.Which)
+	require.Equal(t, fxp.From(11), attrPrereq.QualifierCriteria.Qualifier)
+	traitPrereq, ok := fit.Prereq.Prereqs[1].(*TraitPrereq)
+	require.True(t, ok)
+	require.False(t, traitPrereq.Has)
+	require.Equal(t, "Unfit", traitPrereq.NameCriteria.Qualifier)
+
+	require.Len(t, entity.Skills, 1)
+	require.Equal(t, "dx", entity.Skills[0].Difficulty.Attribute)
+	require.Equal(t, Easy, entity.Skills[0].Difficulty.Difficulty)
+	require.Len(t, entity.Notes, 1)
+	require.Equal(t, "Hello", entity.Notes[0].Text)
+
+	legacy, ok := entity.ThirdParty[LegacyV4ThirdPartyKey].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "Castle Falkenstein", legacy["campaign"])
+}
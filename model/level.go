@@ -31,3 +31,12 @@ func (l Level) LevelAsString(forContainer bool) string {
 	}
 	return level.String()
 }
+
+// LevelSortKey returns a value that sorts numerically in the same order as the levels, with no level sorting lowest.
+func (l Level) LevelSortKey() string {
+	level := l.Level.Trunc()
+	if level <= 0 {
+		return fxp.Min.String()
+	}
+	return level.String()
+}
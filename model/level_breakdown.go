@@ -0,0 +1,64 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// LevelStep is one of the contributions that make up a computed level.
+type LevelStep struct {
+	Description string
+	Amount      fxp.Int
+}
+
+// LevelBreakdown describes how a level was derived, as a series of steps that sum to the final level.
+type LevelBreakdown struct {
+	Steps []LevelStep
+}
+
+func (b *LevelBreakdown) add(description string, amount fxp.Int) {
+	if b != nil {
+		b.Steps = append(b.Steps, LevelStep{Description: description, Amount: amount})
+	}
+}
+
+// Level returns the sum of the steps.
+func (b *LevelBreakdown) Level() fxp.Int {
+	var level fxp.Int
+	for _, step := range b.Steps {
+		level += step.Amount
+	}
+	return level
+}
+
+// String returns the steps one per line, with the first step as the starting value and the remainder as adjustments,
+// followed by the resulting level.
+func (b *LevelBreakdown) String() string {
+	var buffer strings.Builder
+	for i, step := range b.Steps {
+		buffer.WriteString(step.Description)
+		buffer.WriteByte(' ')
+		if i == 0 {
+			buffer.WriteString(step.Amount.String())
+		} else {
+			buffer.WriteString(step.Amount.StringWithSign())
+		}
+		buffer.WriteByte('\n')
+	}
+	buffer.WriteString(i18n.Text("Level "))
+	buffer.WriteString(b.Level().String())
+	return buffer.String()
+}
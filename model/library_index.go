@@ -0,0 +1,122 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+)
+
+var libraryIndexes = struct {
+	lock sync.Mutex
+	list []interface{ invalidate() }
+}{}
+
+// backgroundLibraryIndex holds an index of the configured libraries that is built in the background, so that the
+// libraries are never scanned on the caller's thread.
+type backgroundLibraryIndex[T any] struct {
+	lock     sync.Mutex
+	build    func(libraries Libraries) T
+	current  T
+	started  bool
+	building bool
+	stale    bool
+}
+
+func newBackgroundLibraryIndex[T any](empty T, build func(libraries Libraries) T) *backgroundLibraryIndex[T] {
+	x := &backgroundLibraryIndex[T]{
+		build:   build,
+		current: empty,
+	}
+	libraryIndexes.lock.Lock()
+	libraryIndexes.list = append(libraryIndexes.list, x)
+	libraryIndexes.lock.Unlock()
+	return x
+}
+
+// get returns the most recently built index, starting a build if one has never been made. Until the first build
+// completes, the empty index is returned.
+func (x *backgroundLibraryIndex[T]) get() T {
+	x.lock.Lock()
+	defer x.lock.Unlock()
+	if !x.started {
+		x.started = true
+		x.startBuild()
+	}
+	return x.current
+}
+
+func (x *backgroundLibraryIndex[T]) invalidate() {
+	x.lock.Lock()
+	defer x.lock.Unlock()
+	if x.started {
+		x.startBuild()
+	}
+}
+
+// startBuild must be called with the lock held.
+func (x *backgroundLibraryIndex[T]) startBuild() {
+	if x.building {
+		x.stale = true
+		return
+	}
+	x.building = true
+	libs := make(Libraries)
+	for k, v := range GlobalSettings().LibrarySet {
+		libs[k] = v
+	}
+	go func() {
+		index := x.build(libs)
+		x.lock.Lock()
+		defer x.lock.Unlock()
+		x.current = index
+		x.building = false
+		if x.stale {
+			x.stale = false
+			x.startBuild()
+		}
+	}()
+}
+
+// InvalidateLibraryIndexes causes any library indexes that have been built to be rebuilt in the background. Should be
+// called whenever the configured libraries or their contents change.
+func InvalidateLibraryIndexes() {
+	libraryIndexes.lock.Lock()
+	list := append([]interface{ invalidate() }(nil), libraryIndexes.list...)
+	libraryIndexes.lock.Unlock()
+	for _, one := range list {
+		one.invalidate()
+	}
+}
+
+// walkLibraryFiles calls f for each file with the given extension found within the directory, recursively. Hidden
+// files and directories are skipped.
+func walkLibraryFiles(fileSystem fs.FS, dirPath, ext string, f func(filePath string)) {
+	_ = fs.WalkDir(fileSystem, dirPath, func(p string, d fs.DirEntry, err error) error { //nolint:errcheck // Intentionally ignored the error result
+		if err != nil {
+			return nil
+		}
+		name := d.Name()
+		if strings.HasPrefix(name, ".") && p != dirPath {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() && strings.EqualFold(path.Ext(name), ext) {
+			f(p)
+		}
+		return nil
+	})
+}
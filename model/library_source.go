@@ -0,0 +1,325 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/log/jot"
+)
+
+var (
+	_ LibrarySourced = &Spell{}
+	_ LibrarySourced = &Trait{}
+)
+
+var libraryFileCache = struct {
+	lock  sync.Mutex
+	files map[string]*cachedLibraryFile
+}{files: make(map[string]*cachedLibraryFile)}
+
+type cachedLibraryFile struct {
+	modTime time.Time
+	items   map[uuid.UUID]any
+}
+
+// LibrarySource identifies the library item that an item was copied from.
+type LibrarySource struct {
+	Library   string            `json:"library"`
+	Path      string            `json:"path"`
+	ID        uuid.UUID         `json:"id"`
+	Nameables map[string]string `json:"nameables,omitempty"`
+}
+
+// LibrarySourced is implemented by items that remember the library item they were copied from.
+type LibrarySourced interface {
+	LibrarySource() *LibrarySource
+	SetLibrarySource(source *LibrarySource)
+}
+
+// SourceFor returns a source referring to the item with the given ID in the file at filePath, or nil if the file isn't
+// within one of the libraries.
+func (l Libraries) SourceFor(filePath string, id uuid.UUID) *LibrarySource {
+	for _, lib := range l.List() {
+		rel, err := filepath.Rel(lib.PathOnDisk, filePath)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel) {
+			return &LibrarySource{
+				Library: lib.Key(),
+				Path:    filepath.ToSlash(rel),
+				ID:      id,
+			}
+		}
+	}
+	return nil
+}
+
+// FilePath returns the path on disk of the library file the source refers to, or an empty string if its library is no
+// longer configured.
+func (s *LibrarySource) FilePath(libs Libraries) string {
+	if lib, ok := libs[s.Library]; ok {
+		return filepath.Join(lib.PathOnDisk, filepath.FromSlash(s.Path))
+	}
+	return ""
+}
+
+// LibraryItem returns the current version of the library item the source refers to. Library files are loaded with
+// loader and cached until they change on disk.
+func LibraryItem[T NodeTypes](source *LibrarySource, libs Libraries, loader func(filePath string) ([]T, error)) (T, bool) {
+	var zero T
+	if source == nil {
+		return zero, false
+	}
+	filePath := source.FilePath(libs)
+	if filePath == "" {
+		return zero, false
+	}
+	item, found := libraryFileItems(filePath, loader)[source.ID].(T)
+	return item, found
+}
+
+func libraryFileItems[T NodeTypes](filePath string, loader func(filePath string) ([]T, error)) map[uuid.UUID]any {
+	fi, err := os.Stat(filePath)
+	if err != nil {
+		return nil
+	}
+	libraryFileCache.lock.Lock()
+	defer libraryFileCache.lock.Unlock()
+	cached, ok := libraryFileCache.files[filePath]
+	if !ok || !cached.modTime.Equal(fi.ModTime()) {
+		var list []T
+		if list, err = loader(filePath); err != nil {
+			jot.Warn(errs.NewWithCause(filePath, err))
+			return nil
+		}
+		cached = &cachedLibraryFile{
+			modTime: fi.ModTime(),
+			items:   make(map[uuid.UUID]any),
+		}
+		Traverse(func(one T) bool {
+			cached.items[AsNode(one).UUID()] = one
+			return false
+		}, false, false, list...)
+		libraryFileCache.files[filePath] = cached
+	}
+	return cached.items
+}
+
+// LibraryVersion returns the current library version of the item, if it remembers its library source and that item can
+// still be found.
+func LibraryVersion[T NodeTypes](item T) (T, bool) {
+	var zero T
+	sourced, ok := any(item).(LibrarySourced)
+	if !ok || sourced.LibrarySource() == nil {
+		return zero, false
+	}
+	loader, ok := libraryLoader[T]()
+	if !ok {
+		return zero, false
+	}
+	return LibraryItem(sourced.LibrarySource(), GlobalSettings().LibrarySet, loader)
+}
+
+func libraryLoader[T NodeTypes]() (loader func(filePath string) ([]T, error), ok bool) {
+	var zero T
+	switch any(zero).(type) {
+	case *Spell:
+		loader, ok = any(loadSpellLibrary).(func(filePath string) ([]T, error))
+	case *Trait:
+		loader, ok = any(loadTraitLibrary).(func(filePath string) ([]T, error))
+	}
+	return loader, ok
+}
+
+// RefreshLibraryStatus updates the cached library-modified state of the items and their children. Each library file is
+// checked at most once per call.
+func RefreshLibraryStatus[T NodeTypes](items ...T) {
+	loader, ok := libraryLoader[T]()
+	if !ok {
+		return
+	}
+	libs := GlobalSettings().LibrarySet
+	files := make(map[string]map[uuid.UUID]any)
+	Traverse(func(one T) bool {
+		modified := false
+		if source := any(one).(LibrarySourced).LibrarySource(); source != nil {
+			if filePath := source.FilePath(libs); filePath != "" {
+				fileItems, seen := files[filePath]
+				if !seen {
+					fileItems = libraryFileItems(filePath, loader)
+					files[filePath] = fileItems
+				}
+				if libraryItem, found := fileItems[source.ID].(T); found {
+					modified = DiffersFromLibrary(one, libraryItem)
+				}
+			}
+		}
+		switch item := any(one).(type) {
+		case *Spell:
+			item.libraryModified = modified
+		case *Trait:
+			item.libraryModified = modified
+		}
+		return false
+	}, false, false, items...)
+}
+
+// RefreshLibraryStatus updates the cached library-modified state of the entity's traits and spells.
+func (e *Entity) RefreshLibraryStatus() {
+	RefreshLibraryStatus(e.Traits...)
+	RefreshLibraryStatus(e.Spells...)
+}
+
+// RefreshLibraryStatus updates the cached library-modified state of the template's traits and spells.
+func (t *Template) RefreshLibraryStatus() {
+	RefreshLibraryStatus(t.Traits...)
+	RefreshLibraryStatus(t.Spells...)
+}
+
+// ModifiedFromLibrary returns true if the item differed from its library version when RefreshLibraryStatus was last
+// called for it.
+func ModifiedFromLibrary[T NodeTypes](item T) bool {
+	switch one := any(item).(type) {
+	case *Spell:
+		return one.libraryModified
+	case *Trait:
+		return one.libraryModified
+	default:
+		return false
+	}
+}
+
+func libraryInfo[T NodeTypes](item T) string {
+	if ModifiedFromLibrary(item) {
+		sourced := any(item).(LibrarySourced)
+		return fmt.Sprintf(i18n.Text("Differs from the version in %s"), sourced.LibrarySource().Path)
+	}
+	return ""
+}
+
+// RevertToLibrary returns a replacement for the item made from its current library version. The replacement keeps the
+// item's identity, parent, library source and the fields that reflect its use on the sheet, plus its notes if
+// keepLocalNotes is true. Returns false if no library version is available or the item is a container, since reverting
+// one would discard the usage of its children.
+func RevertToLibrary[T NodeTypes](item T, keepLocalNotes bool) (T, bool) {
+	if AsNode(item).Container() {
+		var zero T
+		return zero, false
+	}
+	libraryItem, ok := LibraryVersion(item)
+	if !ok {
+		return libraryItem, false
+	}
+	return revertTo(item, libraryItem, keepLocalNotes), true
+}
+
+func revertTo[T NodeTypes](item, libraryItem T, keepLocalNotes bool) T {
+	node := AsNode(item)
+	replacement := AsNode(libraryItem).Clone(node.OwningEntity(), node.Parent(), false)
+	switch r := any(replacement).(type) {
+	case *Spell:
+		original := any(item).(*Spell)
+		r.ID = original.ID
+		r.Source = original.Source
+		r.Points = original.Points
+		r.Prepared = original.Prepared
+		if original.Source != nil && len(original.Source.Nameables) != 0 {
+			r.ApplyNameableKeys(original.Source.Nameables)
+		}
+		if keepLocalNotes {
+			r.LocalNotes = original.LocalNotes
+			r.VTTNotes = original.VTTNotes
+		}
+	case *Trait:
+		original := any(item).(*Trait)
+		r.ID = original.ID
+		r.Source = original.Source
+		r.Levels = original.Levels
+		r.Disabled = original.Disabled
+		if original.Source != nil && len(original.Source.Nameables) != 0 {
+			r.ApplyNameableKeys(original.Source.Nameables)
+		}
+		disabled := make(map[uuid.UUID]bool)
+		Traverse(func(mod *TraitModifier) bool {
+			disabled[mod.ID] = mod.Disabled
+			return false
+		}, false, false, original.Modifiers...)
+		Traverse(func(mod *TraitModifier) bool {
+			if d, ok := disabled[mod.ID]; ok {
+				mod.Disabled = d
+			}
+			return false
+		}, false, false, r.Modifiers...)
+		if keepLocalNotes {
+			r.LocalNotes = original.LocalNotes
+			r.VTTNotes = original.VTTNotes
+		}
+	}
+	return replacement
+}
+
+func loadSpellLibrary(filePath string) ([]*Spell, error) {
+	return NewSpellsFromFile(os.DirFS(filepath.Dir(filePath)), filepath.Base(filePath))
+}
+
+func loadTraitLibrary(filePath string) ([]*Trait, error) {
+	return NewTraitsFromFile(os.DirFS(filepath.Dir(filePath)), filepath.Base(filePath))
+}
+
+// DiffersFromLibrary returns true if the item's own data differs from its library version, ignoring its identity, its
+// children and the fields that are expected to change once it is in use on a sheet.
+func DiffersFromLibrary[T NodeTypes](item, libraryItem T) bool {
+	expected := revertTo(item, libraryItem, false)
+	switch one := any(item).(type) {
+	case *Spell:
+		a := *one
+		a.Children = nil
+		b := *any(expected).(*Spell)
+		b.Children = nil
+		return !a.Equal(&b)
+	case *Trait:
+		a := *one
+		a.Children = nil
+		b := *any(expected).(*Trait)
+		b.Children = nil
+		return !a.Equal(&b)
+	default:
+		return false
+	}
+}
+
+// LibrarySource implements LibrarySourced.
+func (s *Spell) LibrarySource() *LibrarySource {
+	return s.Source
+}
+
+// SetLibrarySource implements LibrarySourced.
+func (s *Spell) SetLibrarySource(source *LibrarySource) {
+	s.Source = source
+}
+
+// LibrarySource implements LibrarySourced.
+func (a *Trait) LibrarySource() *LibrarySource {
+	return a.Source
+}
+
+// SetLibrarySource implements LibrarySourced.
+func (a *Trait) SetLibrarySource(source *LibrarySource) {
+	a.Source = source
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLibrarySource(t *testing.T) {
+	dir := t.TempDir()
+	lib := NewLibrary("Test", "tester", "", "test_library", dir)
+	libs := Libraries{lib.Key(): lib}
+
+	spell := NewSpell(nil, nil, false)
+	spell.Name = "Light"
+	spell.Points = fxp.One
+	filePath := filepath.Join(dir, "Magic", "Basic"+SpellsExt)
+	require.NoError(t, SaveSpells([]*Spell{spell}, filePath))
+
+	source := libs.SourceFor(filePath, spell.ID)
+	require.NotNil(t, source)
+	require.Equal(t, lib.Key(), source.Library)
+	require.Equal(t, "Magic/Basic"+SpellsExt, source.Path)
+	require.Equal(t, filePath, source.FilePath(libs))
+	require.Nil(t, libs.SourceFor(filepath.Join(t.TempDir(), "Other"+SpellsExt), spell.ID))
+
+	libraryItem, ok := LibraryItem(source, libs, loadSpellLibrary)
+	require.True(t, ok)
+	require.Equal(t, spell.Name, libraryItem.Name)
+	_, ok = LibraryItem(&LibrarySource{Library: source.Library, Path: source.Path, ID: uuid.New()}, libs, loadSpellLibrary)
+	require.False(t, ok)
+
+	onSheet := spell.Clone(nil, nil, false)
+	onSheet.Source = source
+	onSheet.Points = fxp.Two
+	onSheet.Prepared = true
+	require.NotEqual(t, spell.ID, onSheet.ID)
+	require.False(t, DiffersFromLibrary(onSheet, libraryItem), "identity and usage fields should be ignored")
+
+	onSheet.Name = "Greater Light"
+	require.True(t, DiffersFromLibrary(onSheet, libraryItem))
+}
+
+func TestRevertToLibraryKeepsUsage(t *testing.T) {
+	libraryItem := NewTrait(nil, nil, false)
+	libraryItem.Name = "Luck"
+	libraryItem.LocalNotes = "Library notes"
+	source := &LibrarySource{Library: "tester/test_library", Path: "Traits" + TraitsExt, ID: libraryItem.ID}
+
+	item := libraryItem.Clone(nil, nil, false)
+	item.Source = source
+	item.Name = "Extraordinary Luck"
+	item.LocalNotes = "My notes"
+	item.Disabled = true
+
+	reverted := revertTo(item, libraryItem, false)
+	require.Equal(t, item.ID, reverted.ID)
+	require.Equal(t, source, reverted.Source)
+	require.Equal(t, "Luck", reverted.Name)
+	require.Equal(t, "Library notes", reverted.LocalNotes)
+	require.True(t, reverted.Disabled)
+	require.False(t, DiffersFromLibrary(reverted, libraryItem))
+
+	reverted = revertTo(item, libraryItem, true)
+	require.Equal(t, "Luck", reverted.Name)
+	require.Equal(t, "My notes", reverted.LocalNotes)
+}
+
+func TestDiffersFromLibraryIgnoresSubstitutions(t *testing.T) {
+	libraryItem := NewTrait(nil, nil, false)
+	libraryItem.Name = "Enemy (@Who@)"
+	mod := NewTraitModifier(nil, nil, false)
+	mod.Name = "Hunter"
+	libraryItem.Modifiers = []*TraitModifier{mod}
+
+	item := libraryItem.Clone(nil, nil, false)
+	item.Source = &LibrarySource{ID: libraryItem.ID}
+	item.ApplyNameableKeys(map[string]string{"Who": "Orcs"})
+	require.True(t, DiffersFromLibrary(item, libraryItem))
+	item.Source.Nameables = map[string]string{"Who": "Orcs"}
+	require.False(t, DiffersFromLibrary(item, libraryItem))
+
+	item.Modifiers[0].Disabled = true
+	require.False(t, DiffersFromLibrary(item, libraryItem), "modifier enablement should be ignored")
+	item.Modifiers[0].Name = "Stalker"
+	require.True(t, DiffersFromLibrary(item, libraryItem))
+}
+
+func TestRefreshLibraryStatus(t *testing.T) {
+	dir := t.TempDir()
+	lib := NewLibrary("Test", "tester", "", "test_library", dir)
+	settings := GlobalSettings()
+	saved := settings.LibrarySet
+	settings.LibrarySet = Libraries{lib.Key(): lib}
+	defer func() { settings.LibrarySet = saved }()
+
+	container := NewSpell(nil, nil, true)
+	container.Name = "Light Spells"
+	child := NewSpell(nil, container, false)
+	child.Name = "Light"
+	container.Children = []*Spell{child}
+	filePath := filepath.Join(dir, "Light"+SpellsExt)
+	require.NoError(t, SaveSpells([]*Spell{container}, filePath))
+
+	onSheet := container.Clone(nil, nil, false)
+	onSheet.Source = settings.LibrarySet.SourceFor(filePath, container.ID)
+	onSheet.Children[0].Source = settings.LibrarySet.SourceFor(filePath, child.ID)
+	RefreshLibraryStatus(onSheet)
+	require.False(t, ModifiedFromLibrary(onSheet))
+	require.False(t, ModifiedFromLibrary(onSheet.Children[0]))
+
+	onSheet.Children[0].Name = "Continual Light"
+	require.False(t, ModifiedFromLibrary(onSheet.Children[0]), "state is only updated on refresh")
+	RefreshLibraryStatus(onSheet)
+	require.False(t, ModifiedFromLibrary(onSheet), "children are not part of a container's comparison")
+	require.True(t, ModifiedFromLibrary(onSheet.Children[0]))
+}
@@ -0,0 +1,246 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+type markdownColumn struct {
+	title string
+	id    int
+}
+
+type markdownExporter struct {
+	entity *Entity
+	out    *bufio.Writer
+}
+
+// ExportSheetToMarkdown writes a Markdown representation of the entity to w. The tables use the same columns that are
+// shown for the entity's lists on the character sheet.
+func ExportSheetToMarkdown(entity *Entity, w io.Writer) error {
+	entity.Recalculate()
+	ex := &markdownExporter{
+		entity: entity,
+		out:    bufio.NewWriter(w),
+	}
+	ex.writeProfile()
+	ex.writeAttributes()
+	writeMarkdownTable(ex, i18n.Text("Traits"), []markdownColumn{
+		{i18n.Text("Trait"), TraitDescriptionColumn},
+		{i18n.Text("Pts"), TraitPointsColumn},
+		{i18n.Text("Ref"), TraitReferenceColumn},
+	}, entity.Traits)
+	writeMarkdownTable(ex, i18n.Text("Skills"), []markdownColumn{
+		{i18n.Text("Skill / Technique"), SkillDescriptionColumn},
+		{i18n.Text("SL"), SkillLevelColumn},
+		{i18n.Text("RSL"), SkillRelativeLevelColumn},
+		{i18n.Text("Pts"), SkillPointsColumn},
+		{i18n.Text("Ref"), SkillReferenceColumn},
+	}, entity.Skills)
+	writeMarkdownTable(ex, i18n.Text("Spells"), []markdownColumn{
+		{i18n.Text("Spell"), SpellDescriptionForPageColumn},
+		{i18n.Text("SL"), SpellLevelColumn},
+		{i18n.Text("RSL"), SpellRelativeLevelColumn},
+		{i18n.Text("Pts"), SpellPointsColumn},
+		{i18n.Text("Ref"), SpellReferenceColumn},
+	}, entity.Spells)
+	writeMarkdownTable(ex, i18n.Text("Melee Weapons"), []markdownColumn{
+		{MeleeWeaponType.String(), WeaponDescriptionColumn},
+		{i18n.Text("Usage"), WeaponUsageColumn},
+		{i18n.Text("SL"), WeaponSLColumn},
+		{i18n.Text("Parry"), WeaponParryColumn},
+		{i18n.Text("Block"), WeaponBlockColumn},
+		{i18n.Text("Damage"), WeaponDamageColumn},
+		{i18n.Text("Reach"), WeaponReachColumn},
+		{i18n.Text("ST"), WeaponSTColumn},
+	}, entity.EquippedWeapons(MeleeWeaponType))
+	writeMarkdownTable(ex, i18n.Text("Ranged Weapons"), []markdownColumn{
+		{RangedWeaponType.String(), WeaponDescriptionColumn},
+		{i18n.Text("Usage"), WeaponUsageColumn},
+		{i18n.Text("SL"), WeaponSLColumn},
+		{i18n.Text("Acc"), WeaponAccColumn},
+		{i18n.Text("Damage"), WeaponDamageColumn},
+		{i18n.Text("Range"), WeaponRangeColumn},
+		{i18n.Text("RoF"), WeaponRoFColumn},
+		{i18n.Text("Shots"), WeaponShotsColumn},
+		{i18n.Text("Bulk"), WeaponBulkColumn},
+		{i18n.Text("Recoil"), WeaponRecoilColumn},
+		{i18n.Text("ST"), WeaponSTColumn},
+	}, entity.EquippedWeapons(RangedWeaponType))
+	equipmentColumns := func(includeEquipped bool) []markdownColumn {
+		var columns []markdownColumn
+		if includeEquipped {
+			columns = append(columns, markdownColumn{i18n.Text("Equipped"), EquipmentEquippedColumn})
+		}
+		return append(columns,
+			markdownColumn{"#", EquipmentQuantityColumn},
+			markdownColumn{i18n.Text("Equipment"), EquipmentDescriptionColumn},
+			markdownColumn{i18n.Text("Uses"), EquipmentUsesColumn},
+			markdownColumn{i18n.Text("TL"), EquipmentTLColumn},
+			markdownColumn{i18n.Text("LC"), EquipmentLCColumn},
+			markdownColumn{"$", EquipmentCostColumn},
+			markdownColumn{i18n.Text("Weight"), EquipmentWeightColumn},
+			markdownColumn{i18n.Text("Sum $"), EquipmentExtendedCostColumn},
+			markdownColumn{i18n.Text("Sum Weight"), EquipmentExtendedWeightColumn},
+			markdownColumn{i18n.Text("Ref"), EquipmentReferenceColumn},
+		)
+	}
+	writeMarkdownTable(ex, i18n.Text("Carried Equipment"), equipmentColumns(true), entity.CarriedEquipment)
+	writeMarkdownTable(ex, i18n.Text("Other Equipment"), equipmentColumns(false), entity.OtherEquipment)
+	ex.writeNotes()
+	return errs.Wrap(ex.out.Flush())
+}
+
+// ExportSheetToMarkdownFile calls ExportSheetToMarkdown, writing the result to the file at exportPath.
+func ExportSheetToMarkdownFile(entity *Entity, exportPath string) error {
+	return exportToFile(exportPath, func(w io.Writer) error { return ExportSheetToMarkdown(entity, w) })
+}
+
+func (ex *markdownExporter) writeProfile() {
+	p := ex.entity.Profile
+	name := p.Name
+	if name == "" {
+		name = i18n.Text("Unnamed")
+	}
+	fmt.Fprintf(ex.out, "# %s\n\n", markdownEscape(name))
+	ex.writeField(i18n.Text("Player"), p.PlayerName)
+	ex.writeField(i18n.Text("Title"), p.Title)
+	ex.writeField(i18n.Text("Organization"), p.Organization)
+	ex.writeField(i18n.Text("Religion"), p.Religion)
+	ex.writeField(i18n.Text("Gender"), p.Gender)
+	ex.writeField(i18n.Text("Age"), p.Age)
+	ex.writeField(i18n.Text("Height"), p.Height.String())
+	ex.writeField(i18n.Text("Weight"), SheetSettingsFor(ex.entity).DefaultWeightUnits.Format(p.Weight))
+	ex.writeField(i18n.Text("TL"), p.TechLevel)
+	ex.writeField(i18n.Text("Points"), ex.entity.TotalPoints.String())
+	ex.writeField(i18n.Text("Unspent Points"), ex.entity.UnspentPoints().String())
+	ex.out.WriteByte('\n')
+}
+
+func (ex *markdownExporter) writeField(title, value string) {
+	if value != "" {
+		fmt.Fprintf(ex.out, "- **%s:** %s\n", title, markdownEscape(value))
+	}
+}
+
+func (ex *markdownExporter) writeAttributes() {
+	fmt.Fprintf(ex.out, "## %s\n\n", i18n.Text("Attributes"))
+	fmt.Fprintf(ex.out, "| %s | %s | %s |\n|:---|---:|---:|\n", i18n.Text("Attribute"), i18n.Text("Value"),
+		i18n.Text("Pts"))
+	for _, attr := range ex.entity.Attributes.List() {
+		def := attr.AttributeDef()
+		if def == nil || def.IsSeparator() {
+			continue
+		}
+		value := attr.Maximum().String()
+		if def.Pool() {
+			value = attr.Current().String() + "/" + value
+		}
+		fmt.Fprintf(ex.out, "| %s | %s | %s |\n", markdownEscape(def.CombinedName()), value, attr.PointCost().String())
+	}
+	ex.out.WriteByte('\n')
+	fmt.Fprintf(ex.out, "- **%s:** %s\n", i18n.Text("Basic Lift"),
+		SheetSettingsFor(ex.entity).DefaultWeightUnits.Format(ex.entity.BasicLift()))
+	fmt.Fprintf(ex.out, "- **%s:** %s\n", i18n.Text("Thrust"), ex.entity.Thrust().String())
+	fmt.Fprintf(ex.out, "- **%s:** %s\n\n", i18n.Text("Swing"), ex.entity.Swing().String())
+}
+
+func writeMarkdownTable[T NodeTypes](ex *markdownExporter, title string, columns []markdownColumn, list []T) {
+	if len(list) == 0 {
+		return
+	}
+	fmt.Fprintf(ex.out, "## %s\n\n|", markdownEscape(title))
+	for _, col := range columns {
+		fmt.Fprintf(ex.out, " %s |", markdownEscape(col.title))
+	}
+	ex.out.WriteString("\n|")
+	for range columns {
+		ex.out.WriteString(" --- |")
+	}
+	ex.out.WriteByte('\n')
+	Traverse(func(row T) bool {
+		ex.out.WriteByte('|')
+		for i, col := range columns {
+			var data CellData
+			AsNode(row).CellData(col.id, &data)
+			text := MarkdownCellText(&data)
+			if i == 0 {
+				if depth := markdownDepth(row); depth > 0 {
+					text = strings.Repeat("&nbsp;&nbsp;", depth) + text
+				}
+			}
+			fmt.Fprintf(ex.out, " %s |", text)
+		}
+		ex.out.WriteByte('\n')
+		return false
+	}, false, false, list...)
+	ex.out.WriteByte('\n')
+}
+
+func markdownDepth(row any) int {
+	if d, ok := row.(interface{ Depth() int }); ok {
+		return d.Depth()
+	}
+	return 0
+}
+
+// MarkdownCellText returns the cell data formatted for use within a Markdown table cell.
+func MarkdownCellText(data *CellData) string {
+	switch data.Type {
+	case ToggleCellType:
+		if data.Checked {
+			return "✓"
+		}
+		return ""
+	case TextCellType:
+		text := markdownEscape(data.Primary)
+		if data.Heading && text != "" {
+			text = "**" + text + "**"
+		}
+		if data.Secondary != "" {
+			text += "<br>*" + markdownEscape(data.Secondary) + "*"
+		}
+		if data.UnsatisfiedReason != "" {
+			text += "<br>**" + markdownEscape(data.UnsatisfiedReason) + "**"
+		}
+		return text
+	default:
+		return markdownEscape(data.Primary)
+	}
+}
+
+func (ex *markdownExporter) writeNotes() {
+	if len(ex.entity.Notes) == 0 {
+		return
+	}
+	fmt.Fprintf(ex.out, "## %s\n\n", i18n.Text("Notes"))
+	Traverse(func(n *Note) bool {
+		if n.Text != "" {
+			ex.out.WriteString(n.Text)
+			ex.out.WriteString("\n\n")
+		}
+		return false
+	}, false, false, ex.entity.Notes...)
+}
+
+func markdownEscape(text string) string {
+	text = strings.TrimSpace(text)
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
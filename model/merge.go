@@ -0,0 +1,122 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strings"
+)
+
+// Mergeable is the set of types whose lists can be merged.
+type Mergeable[T any] interface {
+	*Spell | *TraitModifier
+	Equal(other T) bool
+}
+
+// MergeConflict holds a pair of items, one from each of the lists being merged, that share the same identity but have
+// differing data. The merged list initially contains Ours.
+type MergeConflict[T Mergeable[T]] struct {
+	Key     string
+	Ours    T
+	Theirs  T
+	replace func(T)
+}
+
+// UseTheirs replaces the item from the first list with the one from the second list within the merged list.
+func (c *MergeConflict[T]) UseTheirs() {
+	c.replace(c.Theirs)
+}
+
+// UseOurs restores the item from the first list within the merged list.
+func (c *MergeConflict[T]) UseOurs() {
+	c.replace(c.Ours)
+}
+
+// MergeLists combines two lists of the same type into a new list, leaving the originals untouched. Items are matched by
+// the identity returned from key. Items only found in theirs are appended and items that match an item in ours with the
+// same data are dropped. Matching containers have their children merged in the same way. Any remaining matches are
+// reported as conflicts, with the item from ours kept in the merged list until the conflict is resolved.
+func MergeLists[T Mergeable[T]](ours, theirs []T, key func(T) string) (merged []T, conflicts []*MergeConflict[T]) {
+	var zero T
+	merged = cloneMergeList(ours, zero)
+	conflicts = mergeInto(&merged, zero, theirs, key)
+	return merged, conflicts
+}
+
+func cloneMergeList[T Mergeable[T]](list []T, parent T) []T {
+	clones := make([]T, len(list))
+	for i, one := range list {
+		clones[i] = AsNode(one).Clone(nil, parent, true)
+	}
+	return clones
+}
+
+func mergeInto[T Mergeable[T]](target *[]T, parent T, theirs []T, key func(T) string) []*MergeConflict[T] {
+	var conflicts []*MergeConflict[T]
+	index := make(map[string]int, len(*target))
+	for i, one := range *target {
+		k := mergeKey(one, key)
+		if _, exists := index[k]; !exists {
+			index[k] = i
+		}
+	}
+	for _, one := range theirs {
+		k := mergeKey(one, key)
+		i, exists := index[k]
+		if !exists {
+			*target = append(*target, AsNode(one).Clone(nil, parent, true))
+			continue
+		}
+		ours := (*target)[i]
+		node := AsNode(ours)
+		if node.Container() {
+			children := node.NodeChildren()
+			conflicts = append(conflicts, mergeInto(&children, ours, AsNode(one).NodeChildren(), key)...)
+			node.SetChildren(children)
+			continue
+		}
+		if ours.Equal(one) {
+			continue
+		}
+		slot := target
+		conflicts = append(conflicts, &MergeConflict[T]{
+			Key:    key(ours),
+			Ours:   ours,
+			Theirs: AsNode(one).Clone(nil, parent, true),
+			replace: func(replacement T) {
+				if parent != nil {
+					children := AsNode(parent).NodeChildren()
+					children[i] = replacement
+					return
+				}
+				(*slot)[i] = replacement
+			},
+		})
+	}
+	return conflicts
+}
+
+func mergeKey[T Mergeable[T]](item T, key func(T) string) string {
+	if AsNode(item).Container() {
+		return "c:" + key(item)
+	}
+	return "i:" + key(item)
+}
+
+// SpellMergeKey returns the identity used when merging spell lists: the name plus the power source.
+func SpellMergeKey(s *Spell) string {
+	return strings.ToLower(s.Name) + "\n" + strings.ToLower(s.PowerSource)
+}
+
+// TraitModifierMergeKey returns the identity used when merging trait modifier lists: the name plus the notes.
+func TraitModifierMergeKey(m *TraitModifier) string {
+	return strings.ToLower(m.Name) + "\n" + strings.ToLower(m.LocalNotes)
+}
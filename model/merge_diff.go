@@ -0,0 +1,128 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/i18n"
+	"golang.org/x/exp/slices"
+)
+
+// MergeDifference describes a field whose value differs between the two sides of a MergeConflict.
+type MergeDifference struct {
+	Field  string
+	Ours   string
+	Theirs string
+}
+
+type mergeDifferences []*MergeDifference
+
+func (d *mergeDifferences) add(field, ours, theirs string) {
+	if ours != theirs {
+		*d = append(*d, &MergeDifference{Field: field, Ours: ours, Theirs: theirs})
+	}
+}
+
+// addCompound records a difference for a field whose summaries may be identical even though the underlying data isn't.
+func (d *mergeDifferences) addCompound(field string, equal bool, ours, theirs string) {
+	if !equal {
+		if ours == theirs {
+			ours = i18n.Text("(differs)")
+			theirs = ours
+		}
+		*d = append(*d, &MergeDifference{Field: field, Ours: ours, Theirs: theirs})
+	}
+}
+
+// Differences returns the fields that differ between the two sides of the conflict.
+func (c *MergeConflict[T]) Differences() []*MergeDifference {
+	switch ours := any(c.Ours).(type) {
+	case *Spell:
+		return spellDifferences(ours, any(c.Theirs).(*Spell))
+	case *TraitModifier:
+		return traitModifierDifferences(ours, any(c.Theirs).(*TraitModifier))
+	default:
+		return nil
+	}
+}
+
+func spellDifferences(ours, theirs *Spell) []*MergeDifference {
+	var d mergeDifferences
+	d.add(i18n.Text("Type"), ours.Kind(), theirs.Kind())
+	d.add(i18n.Text("Name"), ours.Name, theirs.Name)
+	d.add(i18n.Text("Page Reference"), ours.PageRef, theirs.PageRef)
+	d.add(i18n.Text("Notes"), ours.LocalNotes, theirs.LocalNotes)
+	d.add(i18n.Text("VTT Notes"), ours.VTTNotes, theirs.VTTNotes)
+	d.add(i18n.Text("Tags"), CombineTags(ours.Tags), CombineTags(theirs.Tags))
+	d.add(i18n.Text("Tech Level"), techLevelText(ours.TechLevel), techLevelText(theirs.TechLevel))
+	d.add(i18n.Text("Difficulty"), ours.Difficulty.Description(nil), theirs.Difficulty.Description(nil))
+	d.add(i18n.Text("College"), strings.Join(ours.College, ", "), strings.Join(theirs.College, ", "))
+	d.add(i18n.Text("Power Source"), ours.PowerSource, theirs.PowerSource)
+	d.add(i18n.Text("Class"), ours.Class, theirs.Class)
+	d.add(i18n.Text("Resistance"), ours.Resist, theirs.Resist)
+	d.add(i18n.Text("Casting Cost"), ours.CastingCost, theirs.CastingCost)
+	d.add(i18n.Text("Cost Override"), ours.CostOverride, theirs.CostOverride)
+	d.add(i18n.Text("Maintenance Cost"), ours.MaintenanceCost, theirs.MaintenanceCost)
+	d.add(i18n.Text("Casting Time"), ours.CastingTime, theirs.CastingTime)
+	d.add(i18n.Text("Duration"), ours.Duration, theirs.Duration)
+	d.add(i18n.Text("Base Skill"), ours.RitualSkillName, theirs.RitualSkillName)
+	d.add(i18n.Text("Prerequisite Count"), strconv.Itoa(ours.RitualPrereqCount), strconv.Itoa(theirs.RitualPrereqCount))
+	d.add(i18n.Text("Points"), ours.Points.String(), theirs.Points.String())
+	d.add(i18n.Text("Prepared"), strconv.FormatBool(ours.Prepared), strconv.FormatBool(theirs.Prepared))
+	d.addCompound(i18n.Text("Prerequisites"), prereqListsEqual(ours.Prereq, theirs.Prereq),
+		prereqListText(ours.Prereq), prereqListText(theirs.Prereq))
+	d.addCompound(i18n.Text("Weapons"), weaponsEqual(ours.Weapons, theirs.Weapons), strconv.Itoa(len(ours.Weapons)),
+		strconv.Itoa(len(theirs.Weapons)))
+	d.addCompound(i18n.Text("Study"), studiesEqual(ours.Study, theirs.Study), strconv.Itoa(len(ours.Study)),
+		strconv.Itoa(len(theirs.Study)))
+	d.addCompound(i18n.Text("Template Picker"), templatePickersEqual(ours.TemplatePicker, theirs.TemplatePicker), "",
+		"")
+	d.addCompound(i18n.Text("Children"), slices.EqualFunc(ours.Children, theirs.Children,
+		func(a, b *Spell) bool { return a.Equal(b) }), strconv.Itoa(len(ours.Children)),
+		strconv.Itoa(len(theirs.Children)))
+	return d
+}
+
+func traitModifierDifferences(ours, theirs *TraitModifier) []*MergeDifference {
+	var d mergeDifferences
+	d.add(i18n.Text("Name"), ours.Name, theirs.Name)
+	d.add(i18n.Text("Page Reference"), ours.PageRef, theirs.PageRef)
+	d.add(i18n.Text("Notes"), ours.LocalNotes, theirs.LocalNotes)
+	d.add(i18n.Text("VTT Notes"), ours.VTTNotes, theirs.VTTNotes)
+	d.add(i18n.Text("Tags"), CombineTags(ours.Tags), CombineTags(theirs.Tags))
+	d.addCompound(i18n.Text("Cost"), ours.Cost == theirs.Cost && ours.Levels == theirs.Levels &&
+		ours.CostType == theirs.CostType, ours.CostDescription(), theirs.CostDescription())
+	d.add(i18n.Text("Affects"), ours.Affects.String(), theirs.Affects.String())
+	d.add(i18n.Text("Enabled"), strconv.FormatBool(!ours.Disabled), strconv.FormatBool(!theirs.Disabled))
+	d.addCompound(i18n.Text("Features"), featuresEqual(ours.Features, theirs.Features),
+		strconv.Itoa(len(ours.Features)), strconv.Itoa(len(theirs.Features)))
+	d.addCompound(i18n.Text("Children"), slices.EqualFunc(ours.Children, theirs.Children,
+		func(a, b *TraitModifier) bool { return a.Equal(b) }), strconv.Itoa(len(ours.Children)),
+		strconv.Itoa(len(theirs.Children)))
+	return d
+}
+
+func techLevelText(tl *string) string {
+	if tl == nil {
+		return ""
+	}
+	return *tl
+}
+
+func prereqListText(list *PrereqList) string {
+	if list == nil {
+		return ""
+	}
+	return list.Description(nil)
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newMergeSpell(name, source, difficulty string) *Spell {
+	s := NewSpell(nil, nil, false)
+	s.Name = name
+	s.PowerSource = source
+	s.Resist = difficulty
+	return s
+}
+
+func TestMergeSpellLists(t *testing.T) {
+	ours := []*Spell{
+		newMergeSpell("Light", "Arcane", ""),
+		newMergeSpell("Shield", "Arcane", ""),
+		newMergeSpell("Heal", "Divine", ""),
+	}
+	theirs := []*Spell{
+		newMergeSpell("Light", "Arcane", ""),
+		newMergeSpell("Shield", "Arcane", "HT"),
+		newMergeSpell("Heal", "Arcane", ""),
+		newMergeSpell("Flight", "Arcane", ""),
+	}
+	merged, conflicts := MergeLists(ours, theirs, SpellMergeKey)
+	require.Len(t, merged, 5)
+	require.Equal(t, "Heal", merged[3].Name)
+	require.Equal(t, "Arcane", merged[3].PowerSource)
+	require.Equal(t, "Flight", merged[4].Name)
+	require.Len(t, conflicts, 1)
+	require.Equal(t, SpellMergeKey(ours[1]), conflicts[0].Key)
+	require.Empty(t, merged[1].Resist)
+
+	conflicts[0].UseTheirs()
+	require.Equal(t, "HT", merged[1].Resist)
+	conflicts[0].UseOurs()
+	require.Empty(t, merged[1].Resist)
+
+	require.NotSame(t, ours[0], merged[0])
+	require.Len(t, ours, 3)
+}
+
+func TestMergeContainers(t *testing.T) {
+	ourContainer := NewTraitModifier(nil, nil, true)
+	ourContainer.Name = "Group"
+	child := NewTraitModifier(nil, ourContainer, false)
+	child.Name = "Costly"
+	ourContainer.Children = []*TraitModifier{child}
+
+	theirContainer := NewTraitModifier(nil, nil, true)
+	theirContainer.Name = "Group"
+	changed := NewTraitModifier(nil, theirContainer, false)
+	changed.Name = "Costly"
+	changed.LocalNotes = ""
+	changed.Disabled = true
+	added := NewTraitModifier(nil, theirContainer, false)
+	added.Name = "Cheap"
+	theirContainer.Children = []*TraitModifier{changed, added}
+
+	merged, conflicts := MergeLists([]*TraitModifier{ourContainer}, []*TraitModifier{theirContainer},
+		TraitModifierMergeKey)
+	require.Len(t, merged, 1)
+	require.Len(t, merged[0].Children, 2)
+	require.Equal(t, "Cheap", merged[0].Children[1].Name)
+	require.Same(t, merged[0], merged[0].Children[1].parent)
+	require.Len(t, conflicts, 1)
+	conflicts[0].UseTheirs()
+	require.True(t, merged[0].Children[0].Disabled)
+	require.Len(t, ourContainer.Children, 1)
+	require.False(t, ourContainer.Children[0].Disabled)
+}
+
+func TestMergeConflictDifferences(t *testing.T) {
+	ours := []*Spell{newMergeSpell("Shield", "Arcane", "")}
+	theirs := []*Spell{newMergeSpell("Shield", "Arcane", "HT")}
+	theirs[0].Tags = []string{"Protection"}
+	_, conflicts := MergeLists(ours, theirs, SpellMergeKey)
+	require.Len(t, conflicts, 1)
+	differences := conflicts[0].Differences()
+	require.Len(t, differences, 2)
+	require.Equal(t, "Tags", differences[0].Field)
+	require.Equal(t, "", differences[0].Ours)
+	require.Equal(t, "Protection", differences[0].Theirs)
+	require.Equal(t, "Resistance", differences[1].Field)
+	require.Equal(t, "HT", differences[1].Theirs)
+}
+
+func TestSpellEqual(t *testing.T) {
+	spell := newMergeSpell("Light", "Arcane", "")
+	spell.Prereq = NewPrereqList()
+	spell.Prereq.Prereqs = append(spell.Prereq.Prereqs, NewSpellPrereq())
+	clone := spell.Clone(nil, nil, false)
+	require.NotEqual(t, spell.ID, clone.ID)
+	require.True(t, spell.Equal(clone))
+	clone.Prereq.Prereqs[0].(*SpellPrereq).QuantityCriteria.Qualifier = fxp.From(3)
+	require.False(t, spell.Equal(clone))
+
+	mod := NewTraitModifier(nil, nil, false)
+	mod.Name = "Costly"
+	modClone := mod.Clone(nil, nil, false)
+	require.True(t, mod.Equal(modClone))
+	modClone.Features = append(modClone.Features, NewAttributeBonus("st"))
+	require.False(t, mod.Equal(modClone))
+}
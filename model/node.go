@@ -81,3 +81,20 @@ func CloneNodes[T NodeTypes](newEntity *Entity, newParent T, preserveID bool, no
 	}
 	return clones
 }
+
+// HasUnmetPrereqs returns true if the data has prerequisites that were found to be unsatisfied during the last
+// recalculation of its owning entity.
+func HasUnmetPrereqs(data any) bool {
+	switch one := data.(type) {
+	case *Trait:
+		return one.UnsatisfiedReason != ""
+	case *Skill:
+		return one.UnsatisfiedReason != ""
+	case *Spell:
+		return one.UnsatisfiedReason != ""
+	case *Equipment:
+		return one.UnsatisfiedReason != ""
+	default:
+		return false
+	}
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"bytes"
+
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/rpgtools/dice"
+	"golang.org/x/exp/slices"
+)
+
+// Equal returns true if the spell and its children have the same data as the other spell and its children. IDs,
+// parents, open state and library sources are ignored.
+func (s *Spell) Equal(other *Spell) bool {
+	if s == other {
+		return true
+	}
+	if s == nil || other == nil || s.Type != other.Type || s.Name != other.Name || s.PageRef != other.PageRef ||
+		s.LocalNotes != other.LocalNotes || s.VTTNotes != other.VTTNotes || !slices.Equal(s.Tags, other.Tags) ||
+		!stringPtrsEqual(s.TechLevel, other.TechLevel) || s.Difficulty.Attribute != other.Difficulty.Attribute ||
+		s.Difficulty.Difficulty != other.Difficulty.Difficulty || !slices.Equal(s.College, other.College) ||
+		s.PowerSource != other.PowerSource || s.Class != other.Class || s.Resist != other.Resist ||
+		s.CastingCost != other.CastingCost || s.CostOverride != other.CostOverride ||
+		s.MaintenanceCost != other.MaintenanceCost || s.CastingTime != other.CastingTime ||
+		s.Duration != other.Duration || s.RitualSkillName != other.RitualSkillName ||
+		s.RitualPrereqCount != other.RitualPrereqCount || s.Points != other.Points || s.Prepared != other.Prepared ||
+		!prereqListsEqual(s.Prereq, other.Prereq) || !weaponsEqual(s.Weapons, other.Weapons) ||
+		!studiesEqual(s.Study, other.Study) || !templatePickersEqual(s.TemplatePicker, other.TemplatePicker) ||
+		len(s.Children) != len(other.Children) {
+		return false
+	}
+	for i, child := range s.Children {
+		if !child.Equal(other.Children[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true if the trait and its children have the same data as the other trait and its children. IDs,
+// parents, open state and library sources are ignored.
+func (a *Trait) Equal(other *Trait) bool {
+	if a == other {
+		return true
+	}
+	if a == nil || other == nil || a.Type != other.Type || a.Name != other.Name || a.PageRef != other.PageRef ||
+		a.LocalNotes != other.LocalNotes || a.VTTNotes != other.VTTNotes || a.Ancestry != other.Ancestry ||
+		a.UserDesc != other.UserDesc || !slices.Equal(a.Tags, other.Tags) ||
+		!slices.EqualFunc(a.Modifiers, other.Modifiers, func(m1, m2 *TraitModifier) bool { return m1.Equal(m2) }) ||
+		a.BasePoints != other.BasePoints || a.Levels != other.Levels || a.PointsPerLevel != other.PointsPerLevel ||
+		!prereqListsEqual(a.Prereq, other.Prereq) || !weaponsEqual(a.Weapons, other.Weapons) ||
+		!featuresEqual(a.Features, other.Features) || !studiesEqual(a.Study, other.Study) ||
+		!templatePickersEqual(a.TemplatePicker, other.TemplatePicker) || a.CR != other.CR || a.CRAdj != other.CRAdj ||
+		a.ContainerType != other.ContainerType || a.Disabled != other.Disabled ||
+		a.RoundCostDown != other.RoundCostDown || a.CanLevel != other.CanLevel ||
+		len(a.Children) != len(other.Children) {
+		return false
+	}
+	for i, child := range a.Children {
+		if !child.Equal(other.Children[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true if the trait modifier and its children have the same data as the other trait modifier and its
+// children. IDs, parents and open state are ignored.
+func (m *TraitModifier) Equal(other *TraitModifier) bool {
+	if m == other {
+		return true
+	}
+	if m == nil || other == nil || m.Type != other.Type || m.Name != other.Name || m.PageRef != other.PageRef ||
+		m.LocalNotes != other.LocalNotes || m.VTTNotes != other.VTTNotes || !slices.Equal(m.Tags, other.Tags) ||
+		m.Cost != other.Cost || m.Levels != other.Levels || m.Affects != other.Affects ||
+		m.CostType != other.CostType || m.Disabled != other.Disabled || !featuresEqual(m.Features, other.Features) ||
+		len(m.Children) != len(other.Children) {
+		return false
+	}
+	for i, child := range m.Children {
+		if !child.Equal(other.Children[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func stringPtrsEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func dicePtrsEqual(a, b *dice.Dice) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// prereqListsEqual treats a missing list as equal to an empty one, since cloning may create one.
+func prereqListsEqual(a, b *PrereqList) bool {
+	switch {
+	case a == nil && b == nil:
+		return true
+	case a == nil:
+		return len(b.Prereqs) == 0
+	case b == nil:
+		return len(a.Prereqs) == 0
+	default:
+		return a.Equal(b)
+	}
+}
+
+func weaponsEqual(a, b []*Weapon) bool {
+	return slices.EqualFunc(a, b, func(w1, w2 *Weapon) bool {
+		d1 := &w1.WeaponData
+		d2 := &w2.WeaponData
+		return d1.Type == d2.Type && d1.Damage.Type == d2.Damage.Type &&
+			d1.Damage.StrengthType == d2.Damage.StrengthType && dicePtrsEqual(d1.Damage.Base, d2.Damage.Base) &&
+			d1.Damage.ArmorDivisor == d2.Damage.ArmorDivisor &&
+			dicePtrsEqual(d1.Damage.Fragmentation, d2.Damage.Fragmentation) &&
+			d1.Damage.FragmentationArmorDivisor == d2.Damage.FragmentationArmorDivisor &&
+			d1.Damage.FragmentationType == d2.Damage.FragmentationType &&
+			d1.Damage.ModifierPerDie == d2.Damage.ModifierPerDie && d1.MinimumStrength == d2.MinimumStrength &&
+			d1.Usage == d2.Usage && d1.UsageNotes == d2.UsageNotes && d1.Reach == d2.Reach &&
+			d1.ReachTwoHanded == d2.ReachTwoHanded && d1.ReachNotes == d2.ReachNotes && d1.Parry == d2.Parry &&
+			d1.ParryNotes == d2.ParryNotes && d1.Block == d2.Block && d1.Accuracy == d2.Accuracy &&
+			d1.Range == d2.Range && d1.RateOfFire == d2.RateOfFire && d1.Shots == d2.Shots && d1.Bulk == d2.Bulk &&
+			d1.Recoil == d2.Recoil && slices.EqualFunc(d1.Defaults, d2.Defaults,
+			func(s1, s2 *SkillDefault) bool { return *s1 == *s2 })
+	})
+}
+
+func studiesEqual(a, b []*Study) bool {
+	return slices.EqualFunc(a, b, func(s1, s2 *Study) bool { return *s1 == *s2 })
+}
+
+// templatePickersEqual treats a missing picker as equal to an empty one, since cloning may create one.
+func templatePickersEqual(a, b *TemplatePicker) bool {
+	if a == nil {
+		a = &TemplatePicker{}
+	}
+	if b == nil {
+		b = &TemplatePicker{}
+	}
+	return *a == *b
+}
+
+// featuresEqual compares features by their serialized form, since the many feature types only share an interface and
+// hold a back-reference to their owner that mustn't take part in the comparison.
+func featuresEqual(a, b Features) bool {
+	return slices.EqualFunc(a, b, func(f1, f2 Feature) bool {
+		d1, err := json.Marshal(f1)
+		if err != nil {
+			return false
+		}
+		var d2 []byte
+		if d2, err = json.Marshal(f2); err != nil {
+			return false
+		}
+		return bytes.Equal(d1, d2)
+	})
+}
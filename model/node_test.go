@@ -0,0 +1,39 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasUnmetPrereqs(t *testing.T) {
+	entity := NewEntity(PC)
+	trait := NewTrait(entity, nil, false)
+	trait.Name = "Needs Magery"
+	trait.Prereq = NewPrereqList()
+	prereq := NewTraitPrereq()
+	prereq.Parent = trait.Prereq
+	prereq.NameCriteria.Qualifier = "Magery"
+	trait.Prereq.Prereqs = append(trait.Prereq.Prereqs, prereq)
+	entity.Traits = append(entity.Traits, trait)
+	entity.Recalculate()
+	require.True(t, HasUnmetPrereqs(trait))
+
+	magery := NewTrait(entity, nil, false)
+	magery.Name = "Magery"
+	entity.Traits = append(entity.Traits, magery)
+	entity.Recalculate()
+	require.False(t, HasUnmetPrereqs(trait))
+	require.False(t, HasUnmetPrereqs(NewNote(entity, nil, false)))
+}
@@ -168,6 +168,16 @@ func (n *Note) Enabled() bool {
 	return true
 }
 
+// PageReference returns the page reference.
+func (n *Note) PageReference() string {
+	return n.PageRef
+}
+
+// SetPageReference sets the page reference.
+func (n *Note) SetPageReference(ref string) {
+	n.PageRef = ref
+}
+
 // FillWithNameableKeys adds any nameable keys found to the provided map.
 func (n *Note) FillWithNameableKeys(m map[string]string) {
 	Extract(n.Text, m)
@@ -0,0 +1,106 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// NotesReplacement holds a pending replacement within a single notes field.
+type NotesReplacement struct {
+	Category string
+	Name     string
+	Before   string
+	After    string
+	field    *string
+}
+
+// Apply stores the replacement text into the notes field.
+func (r *NotesReplacement) Apply() {
+	*r.field = r.After
+}
+
+// Revert restores the original text of the notes field.
+func (r *NotesReplacement) Revert() {
+	*r.field = r.Before
+}
+
+// FindNotesReplacements searches the notes of the entity's traits, skills, spells, equipment and notes, as well as the
+// usage notes of any weapons they hold, returning the replacements that would be made. When useRegex is true, find is
+// treated as a regular expression and replace may refer to its capture groups. Nothing is modified until Apply() is
+// called on the returned replacements.
+func FindNotesReplacements(entity *Entity, find, replace string, useRegex bool) ([]*NotesReplacement, error) {
+	if find == "" {
+		return nil, nil
+	}
+	var replacer func(string) string
+	if useRegex {
+		re, err := regexp.Compile(find)
+		if err != nil {
+			return nil, errs.NewWithCause(i18n.Text("invalid regular expression"), err)
+		}
+		replacer = func(in string) string { return re.ReplaceAllString(in, replace) }
+	} else {
+		replacer = func(in string) string { return strings.ReplaceAll(in, find, replace) }
+	}
+	var list []*NotesReplacement
+	check := func(category, name string, field *string) {
+		if *field == "" {
+			return
+		}
+		if after := replacer(*field); after != *field {
+			list = append(list, &NotesReplacement{
+				Category: category,
+				Name:     name,
+				Before:   *field,
+				After:    after,
+				field:    field,
+			})
+		}
+	}
+	checkWeapons := func(weapons []*Weapon) {
+		for _, w := range weapons {
+			check(w.Type.String(), w.String(), &w.UsageNotes)
+		}
+	}
+	Traverse(func(t *Trait) bool {
+		check(i18n.Text("Trait"), t.Description(), &t.LocalNotes)
+		checkWeapons(t.Weapons)
+		return false
+	}, false, false, entity.Traits...)
+	Traverse(func(s *Skill) bool {
+		check(i18n.Text("Skill"), s.String(), &s.LocalNotes)
+		checkWeapons(s.Weapons)
+		return false
+	}, false, false, entity.Skills...)
+	Traverse(func(s *Spell) bool {
+		check(i18n.Text("Spell"), s.String(), &s.LocalNotes)
+		checkWeapons(s.Weapons)
+		return false
+	}, false, false, entity.Spells...)
+	checkEquipment := func(e *Equipment) bool {
+		check(i18n.Text("Equipment"), e.Description(), &e.LocalNotes)
+		checkWeapons(e.Weapons)
+		return false
+	}
+	Traverse(checkEquipment, false, false, entity.CarriedEquipment...)
+	Traverse(checkEquipment, false, false, entity.OtherEquipment...)
+	Traverse(func(n *Note) bool {
+		check(i18n.Text("Note"), "", &n.Text)
+		return false
+	}, false, false, entity.Notes...)
+	return list, nil
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindNotesReplacements(t *testing.T) {
+	entity := NewEntity(PC)
+	trait := NewTrait(entity, nil, false)
+	trait.LocalNotes = "Fire 3"
+	w := NewWeapon(trait, MeleeWeaponType)
+	w.UsageNotes = "fire only"
+	trait.Weapons = append(trait.Weapons, w)
+	entity.Traits = append(entity.Traits, trait)
+	skill := NewSkill(entity, nil, false)
+	skill.LocalNotes = "no match"
+	entity.Skills = append(entity.Skills, skill)
+
+	list, err := FindNotesReplacements(entity, "fire", "ice", false)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "ice only", list[0].After)
+
+	list, err = FindNotesReplacements(entity, `(?i)fire (\d)`, "Ice $1", true)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	list[0].Apply()
+	require.Equal(t, "Ice 3", trait.LocalNotes)
+	list[0].Revert()
+	require.Equal(t, "Fire 3", trait.LocalNotes)
+
+	_, err = FindNotesReplacements(entity, "(", "", true)
+	require.Error(t, err)
+}
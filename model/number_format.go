@@ -0,0 +1,132 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// decimalCommaLanguages holds the languages whose locales use a comma to separate the whole and fractional parts of
+// numbers.
+var decimalCommaLanguages = map[string]bool{
+	"bg": true,
+	"ca": true,
+	"cs": true,
+	"da": true,
+	"de": true,
+	"el": true,
+	"es": true,
+	"et": true,
+	"fi": true,
+	"fr": true,
+	"hr": true,
+	"hu": true,
+	"id": true,
+	"it": true,
+	"lt": true,
+	"lv": true,
+	"nb": true,
+	"nl": true,
+	"nn": true,
+	"no": true,
+	"pl": true,
+	"pt": true,
+	"ro": true,
+	"ru": true,
+	"sk": true,
+	"sl": true,
+	"sr": true,
+	"sv": true,
+	"tr": true,
+	"uk": true,
+	"vi": true,
+}
+
+// UsesComma returns true if the comma should be used as the decimal separator. For LocaleDecimalSeparator, this is
+// determined by the language of the current locale.
+func (enum DecimalSeparator) UsesComma() bool {
+	switch enum {
+	case PeriodDecimalSeparator:
+		return false
+	case CommaDecimalSeparator:
+		return true
+	default:
+		return localeUsesDecimalComma(i18n.Language)
+	}
+}
+
+func localeUsesDecimalComma(locale string) bool {
+	language := strings.ToLower(locale)
+	if i := strings.IndexAny(language, "_-."); i != -1 {
+		language = language[:i]
+	}
+	return decimalCommaLanguages[language]
+}
+
+// NumberFormatter formats numbers for display and extracts them from user input, using a specific decimal separator.
+type NumberFormatter struct {
+	comma bool
+}
+
+// NumberFormatterFor returns a NumberFormatter that uses the given decimal separator.
+func NumberFormatterFor(separator DecimalSeparator) NumberFormatter {
+	return NumberFormatter{comma: separator.UsesComma()}
+}
+
+// DisplayNumberFormatter returns the NumberFormatter to use for numbers presented in the user interface, honoring the
+// decimal separator chosen in the settings.
+func DisplayNumberFormatter() NumberFormatter {
+	return NumberFormatterFor(GlobalSettings().General.DecimalSeparator)
+}
+
+// Format returns the text for the value.
+func (f NumberFormatter) Format(value fxp.Int) string {
+	return f.localize(value.String())
+}
+
+// FormatWithSign returns the text for the value, always including a leading sign.
+func (f NumberFormatter) FormatWithSign(value fxp.Int) string {
+	return f.localize(value.StringWithSign())
+}
+
+// Extract a value from the text. Periods are always accepted as the decimal separator, in addition to commas when the
+// formatter uses them.
+func (f NumberFormatter) Extract(text string) (fxp.Int, error) {
+	return fxp.FromString(f.delocalize(text))
+}
+
+// FormatWeight returns the text for the weight in the given units.
+func (f NumberFormatter) FormatWeight(weight Weight, units WeightUnits) string {
+	return f.localize(units.Format(weight))
+}
+
+// ExtractWeight extracts a weight from the text, using the given units if none are present in the text.
+func (f NumberFormatter) ExtractWeight(text string, defaultUnits WeightUnits) (Weight, error) {
+	return WeightFromString(f.delocalize(text), defaultUnits)
+}
+
+func (f NumberFormatter) localize(text string) string {
+	if f.comma {
+		return strings.ReplaceAll(text, ".", ",")
+	}
+	return text
+}
+
+func (f NumberFormatter) delocalize(text string) string {
+	if f.comma {
+		return strings.ReplaceAll(text, ",", ".")
+	}
+	return text
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumberFormatter(t *testing.T) {
+	value := fxp.FromStringForced("1.5")
+
+	period := NumberFormatterFor(PeriodDecimalSeparator)
+	require.Equal(t, "1.5", period.Format(value))
+	require.Equal(t, "+1.5", period.FormatWithSign(value))
+	v, err := period.Extract("1.5")
+	require.NoError(t, err)
+	require.Equal(t, value, v)
+	_, err = period.Extract("1,5")
+	require.Error(t, err)
+
+	comma := NumberFormatterFor(CommaDecimalSeparator)
+	require.Equal(t, "1,5", comma.Format(value))
+	require.Equal(t, "-1,5", comma.FormatWithSign(-value))
+	v, err = comma.Extract("1,5")
+	require.NoError(t, err)
+	require.Equal(t, value, v)
+	v, err = comma.Extract("1.5")
+	require.NoError(t, err)
+	require.Equal(t, value, v)
+
+	weight := Weight(fxp.FromStringForced("2.25"))
+	require.Equal(t, "2,25 lb", comma.FormatWeight(weight, Pound))
+	w, err := comma.ExtractWeight("2,25", Pound)
+	require.NoError(t, err)
+	require.Equal(t, weight, w)
+}
+
+func TestLocaleUsesDecimalComma(t *testing.T) {
+	require.True(t, localeUsesDecimalComma("de_DE.UTF-8"))
+	require.True(t, localeUsesDecimalComma("pt-BR"))
+	require.True(t, localeUsesDecimalComma("fr"))
+	require.False(t, localeUsesDecimalComma("en_US.UTF-8"))
+	require.False(t, localeUsesDecimalComma("ja_JP"))
+	require.False(t, localeUsesDecimalComma(""))
+}
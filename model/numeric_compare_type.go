@@ -25,6 +25,7 @@ const (
 	NotEqualsNumber = NumericCompareType("is_not")
 	AtLeastNumber   = NumericCompareType("at_least")
 	AtMostNumber    = NumericCompareType("at_most")
+	BetweenNumber   = NumericCompareType("between")
 )
 
 // AllNumericCompareTypes is the complete set of NumericCompareType values.
@@ -34,8 +35,12 @@ var AllNumericCompareTypes = []NumericCompareType{
 	NotEqualsNumber,
 	AtLeastNumber,
 	AtMostNumber,
+	BetweenNumber,
 }
 
+// SingleValueNumericCompareTypes is the set of NumericCompareType values that only need a single qualifier.
+var SingleValueNumericCompareTypes = AllNumericCompareTypes[:len(AllNumericCompareTypes)-1]
+
 // NumericCompareType holds the type for a numeric comparison.
 type NumericCompareType string
 
@@ -49,6 +54,14 @@ func (n NumericCompareType) EnsureValid() NumericCompareType {
 	return AllNumericCompareTypes[0]
 }
 
+// EnsureValidSingleValue ensures this is of a known value that only needs a single qualifier.
+func (n NumericCompareType) EnsureValidSingleValue() NumericCompareType {
+	if n = n.EnsureValid(); n == BetweenNumber {
+		return AnyNumber
+	}
+	return n
+}
+
 // AltString returns an alternate string for this.
 func (n NumericCompareType) AltString() string {
 	switch n {
@@ -62,11 +75,29 @@ func (n NumericCompareType) AltString() string {
 		return i18n.Text("at least")
 	case AtMostNumber:
 		return i18n.Text("at most")
+	case BetweenNumber:
+		return i18n.Text("between")
 	default:
 		return AnyNumber.String()
 	}
 }
 
+// Symbol returns the mathematical symbol for this, or an empty string if any value matches.
+func (n NumericCompareType) Symbol() string {
+	switch n {
+	case EqualsNumber:
+		return "="
+	case NotEqualsNumber:
+		return "≠"
+	case AtLeastNumber:
+		return "≥"
+	case AtMostNumber:
+		return "≤"
+	default:
+		return ""
+	}
+}
+
 // String implements fmt.Stringer.
 func (n NumericCompareType) String() string {
 	switch n {
@@ -80,6 +111,8 @@ func (n NumericCompareType) String() string {
 		return i18n.Text("is at least")
 	case AtMostNumber:
 		return i18n.Text("is at most")
+	case BetweenNumber:
+		return i18n.Text("is between")
 	default:
 		return AnyNumber.String()
 	}
@@ -107,7 +140,8 @@ func (n NumericCompareType) AltDescribe(qualifier fxp.Int) string {
 	return result + qualifier.String()
 }
 
-// Matches performs a comparison and returns true if the data matches.
+// Matches performs a comparison and returns true if the data matches. Since BetweenNumber needs a second qualifier, it
+// never matches here; use NumericCriteria.Matches() to check the full range.
 func (n NumericCompareType) Matches(qualifier, data fxp.Int) bool {
 	switch n {
 	case AnyNumber:
@@ -118,6 +152,8 @@ func (n NumericCompareType) Matches(qualifier, data fxp.Int) bool {
 		return data != qualifier
 	case AtLeastNumber:
 		return data >= qualifier
+	case BetweenNumber:
+		return false
 	case AtMostNumber:
 		return data <= qualifier
 	default:
@@ -136,9 +172,9 @@ func ExtractNumericCompareTypeIndex(str string) int {
 }
 
 // PrefixedNumericCompareTypeChoices returns the set of NumericCompareType choices as strings with a prefix.
-func PrefixedNumericCompareTypeChoices(prefix string) []string {
-	choices := make([]string, len(AllNumericCompareTypes))
-	for i, choice := range AllNumericCompareTypes {
+func PrefixedNumericCompareTypeChoices(prefix string, types []NumericCompareType) []string {
+	choices := make([]string, len(types))
+	for i, choice := range types {
 		choices[i] = prefix + " " + choice.String()
 	}
 	return choices
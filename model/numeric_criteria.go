@@ -12,8 +12,11 @@
 package model
 
 import (
+	"fmt"
+
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/i18n"
 )
 
 // NumericCriteria holds the criteria for matching a number.
@@ -21,10 +24,12 @@ type NumericCriteria struct {
 	NumericCriteriaData
 }
 
-// NumericCriteriaData holds the criteria for matching a number that should be written to disk.
+// NumericCriteriaData holds the criteria for matching a number that should be written to disk. MaxQualifier is only used
+// by BetweenNumber, which matches the closed range from Qualifier to MaxQualifier.
 type NumericCriteriaData struct {
-	Compare   NumericCompareType `json:"compare,omitempty"`
-	Qualifier fxp.Int            `json:"qualifier,omitempty"`
+	Compare      NumericCompareType `json:"compare,omitempty"`
+	Qualifier    fxp.Int            `json:"qualifier,omitempty"`
+	MaxQualifier fxp.Int            `json:"max_qualifier,omitempty"`
 }
 
 // ShouldOmit implements json.Omitter.
@@ -36,19 +41,44 @@ func (n NumericCriteria) ShouldOmit() bool {
 func (n *NumericCriteria) UnmarshalJSON(data []byte) error {
 	err := json.Unmarshal(data, &n.NumericCriteriaData)
 	n.Compare = n.Compare.EnsureValid()
+	if n.Compare == BetweenNumber && n.MaxQualifier < n.Qualifier {
+		// A missing or inverted upper bound would otherwise never match
+		n.MaxQualifier = n.Qualifier
+	}
 	return err
 }
 
 // Matches performs a comparison and returns true if the data matches.
 func (n NumericCriteria) Matches(value fxp.Int) bool {
+	if n.Compare == BetweenNumber {
+		return value >= n.Qualifier && value <= n.MaxQualifier
+	}
 	return n.Compare.Matches(n.Qualifier, value)
 }
 
 func (n NumericCriteria) String() string {
+	if n.Compare == BetweenNumber {
+		return fmt.Sprintf(i18n.Text("is between %s and %s"), n.Qualifier.String(), n.MaxQualifier.String())
+	}
 	return n.Compare.Describe(n.Qualifier)
 }
 
+// CompactString returns a compact description, such as "≥ 12" or "10–12", or an empty string if any value matches.
+func (n NumericCriteria) CompactString() string {
+	if n.Compare == BetweenNumber {
+		return n.Qualifier.String() + "–" + n.MaxQualifier.String()
+	}
+	symbol := n.Compare.EnsureValid().Symbol()
+	if symbol == "" {
+		return ""
+	}
+	return symbol + " " + n.Qualifier.String()
+}
+
 // AltString returns the alternate description.
 func (n NumericCriteria) AltString() string {
+	if n.Compare == BetweenNumber {
+		return fmt.Sprintf(i18n.Text("between %s and %s"), n.Qualifier.String(), n.MaxQualifier.String())
+	}
 	return n.Compare.AltDescribe(n.Qualifier)
 }
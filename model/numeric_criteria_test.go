@@ -0,0 +1,64 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/json"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumericCriteriaBetween(t *testing.T) {
+	var criteria NumericCriteria
+	criteria.Compare = BetweenNumber
+	criteria.Qualifier = fxp.From(10)
+	criteria.MaxQualifier = fxp.From(12)
+	require.False(t, criteria.Matches(fxp.From(9)))
+	require.True(t, criteria.Matches(fxp.From(10)))
+	require.True(t, criteria.Matches(fxp.From(12)))
+	require.False(t, criteria.Matches(fxp.From(13)))
+	require.Equal(t, "10–12", criteria.CompactString())
+	require.Equal(t, "is between 10 and 12", criteria.String())
+
+	data, err := json.Marshal(&criteria)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"compare":"between","qualifier":10,"max_qualifier":12}`, string(data))
+	var loaded NumericCriteria
+	require.NoError(t, json.Unmarshal(data, &loaded))
+	require.Equal(t, criteria, loaded)
+
+	entity := NewEntity(PC)
+	prereq := NewAttributePrereq(entity)
+	prereq.QualifierCriteria = criteria
+	require.Equal(t, "ST 10–12", prereq.Description(entity))
+	require.True(t, prereq.Satisfied(entity, nil, nil, "", nil))
+	prereq.QualifierCriteria.MaxQualifier = fxp.From(9)
+	require.False(t, prereq.Satisfied(entity, nil, nil, "", nil))
+}
+
+func TestNumericCriteriaBetweenBounds(t *testing.T) {
+	require.False(t, BetweenNumber.Matches(fxp.From(10), fxp.From(20)))
+
+	var criteria NumericCriteria
+	require.NoError(t, json.Unmarshal([]byte(`{"compare":"between","qualifier":10}`), &criteria))
+	require.Equal(t, fxp.From(10), criteria.MaxQualifier)
+	require.True(t, criteria.Matches(fxp.From(10)))
+	require.False(t, criteria.Matches(fxp.From(20)))
+}
+
+func TestWeightCriteriaRejectsBetween(t *testing.T) {
+	var criteria WeightCriteria
+	require.NoError(t, json.Unmarshal([]byte(`{"compare":"between","qualifier":"5 lb"}`), &criteria))
+	require.Equal(t, AnyNumber, criteria.Compare)
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// BrokenPageRef describes a page reference that can't be followed.
+type BrokenPageRef struct {
+	Kind    string
+	Name    string
+	Item    any
+	Ref     string
+	Problem string
+}
+
+// ExtractPageRefs splits a comma or semicolon-separated list of page references into its individual references.
+func ExtractPageRefs(s string) []string {
+	var list []string
+	for _, one := range strings.FieldsFunc(s, func(ch rune) bool { return ch == ',' || ch == ';' }) {
+		if one = strings.TrimSpace(one); one != "" {
+			list = append(list, one)
+		}
+	}
+	return list
+}
+
+// PageRefProblem returns a description of what is wrong with a single page reference, or an empty string if nothing
+// is. Links and markdown references are always accepted. The known function is used to check the key of all others.
+func PageRefProblem(ref string, known func(key string) bool) string {
+	if IsLinkPageRef(ref) {
+		return ""
+	}
+	key, _, ok := SplitPageRef(ref)
+	if !ok {
+		return i18n.Text("Not a valid page reference")
+	}
+	if !known(key) {
+		return fmt.Sprintf(i18n.Text("Unknown book code \"%s\""), key)
+	}
+	return ""
+}
+
+// FindBrokenPageRefs returns the page references within the items and their descendants that can't be followed.
+func FindBrokenPageRefs[T NodeTypes](known func(key string) bool, items ...T) []*BrokenPageRef {
+	var list []*BrokenPageRef
+	Traverse(func(item T) bool {
+		if editor, ok := any(item).(PageRefEditor); ok {
+			for _, ref := range ExtractPageRefs(editor.PageReference()) {
+				if problem := PageRefProblem(ref, known); problem != "" {
+					node := AsNode(item)
+					list = append(list, &BrokenPageRef{
+						Kind:    node.Kind(),
+						Name:    node.String(),
+						Item:    item,
+						Ref:     ref,
+						Problem: problem,
+					})
+				}
+			}
+		}
+		return false
+	}, false, false, items...)
+	return list
+}
+
+// BrokenPageRefs returns the page references within the traits, skills, spells, equipment, their modifiers and notes
+// of the entity that can't be followed. Those found in a modifier refer to the trait or equipment that holds it.
+func (e *Entity) BrokenPageRefs(known func(key string) bool) []*BrokenPageRef {
+	list := FindBrokenPageRefs(known, e.Traits...)
+	Traverse(func(trait *Trait) bool {
+		list = append(list, brokenModifierPageRefs(trait, FindBrokenPageRefs(known, trait.Modifiers...))...)
+		return false
+	}, false, false, e.Traits...)
+	list = append(list, FindBrokenPageRefs(known, e.Skills...)...)
+	list = append(list, FindBrokenPageRefs(known, e.Spells...)...)
+	for _, equipment := range [][]*Equipment{e.CarriedEquipment, e.OtherEquipment} {
+		list = append(list, FindBrokenPageRefs(known, equipment...)...)
+		Traverse(func(eqp *Equipment) bool {
+			list = append(list, brokenModifierPageRefs(eqp, FindBrokenPageRefs(known, eqp.Modifiers...))...)
+			return false
+		}, false, false, equipment...)
+	}
+	return append(list, FindBrokenPageRefs(known, e.Notes...)...)
+}
+
+func brokenModifierPageRefs(owner any, refs []*BrokenPageRef) []*BrokenPageRef {
+	for _, ref := range refs {
+		ref.Item = owner
+	}
+	return refs
+}
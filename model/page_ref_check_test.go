@@ -0,0 +1,74 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func knownTestPageRefKey(key string) bool {
+	return key == "B" || key == "MA"
+}
+
+func TestPageRefProblem(t *testing.T) {
+	for _, ref := range []string{"B123", "MA45", "https://example.com/1", "HTTP://example.com", "md:Help/Spell"} {
+		require.Empty(t, PageRefProblem(ref, knownTestPageRefKey), ref)
+	}
+	for _, ref := range []string{"B", "123", "page five", "X12"} {
+		require.NotEmpty(t, PageRefProblem(ref, knownTestPageRefKey), ref)
+	}
+}
+
+func TestBrokenPageRefs(t *testing.T) {
+	entity := NewEntity(PC)
+	trait := NewTrait(entity, nil, true)
+	trait.Name = "Group"
+	trait.PageRef = "B12"
+	child := NewTrait(entity, trait, false)
+	child.Name = "Child"
+	child.PageRef = "B14, X3; oops"
+	trait.Children = append(trait.Children, child)
+	traitMod := NewTraitModifier(entity, nil, false)
+	traitMod.Name = "Trait Mod"
+	traitMod.PageRef = "X7"
+	child.Modifiers = append(child.Modifiers, traitMod)
+	entity.Traits = append(entity.Traits, trait)
+	eqp := NewEquipment(entity, nil, false)
+	eqp.Name = "Sword"
+	eqp.PageRef = "B274"
+	eqpMod := NewEquipmentModifier(entity, nil, false)
+	eqpMod.Name = "Eqp Mod"
+	eqpMod.PageRef = "B; MA9"
+	eqp.Modifiers = append(eqp.Modifiers, eqpMod)
+	entity.OtherEquipment = append(entity.OtherEquipment, eqp)
+	note := NewNote(entity, nil, false)
+	note.PageRef = "MA"
+	entity.Notes = append(entity.Notes, note)
+
+	broken := entity.BrokenPageRefs(knownTestPageRefKey)
+	require.Len(t, broken, 5)
+	require.Equal(t, child, broken[0].Item)
+	require.Equal(t, "Child", broken[0].Name)
+	require.Equal(t, "X3", broken[0].Ref)
+	require.Equal(t, child, broken[1].Item)
+	require.Equal(t, "oops", broken[1].Ref)
+	require.Equal(t, child, broken[2].Item)
+	require.Equal(t, "Trait Mod", broken[2].Name)
+	require.Equal(t, "X7", broken[2].Ref)
+	require.Equal(t, eqp, broken[3].Item)
+	require.Equal(t, "Eqp Mod", broken[3].Name)
+	require.Equal(t, "B", broken[3].Ref)
+	require.Equal(t, note, broken[4].Item)
+	require.Equal(t, "MA", broken[4].Ref)
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strconv"
+	"strings"
+)
+
+// PageRefEditor defines the methods required for data whose page references can be edited.
+type PageRefEditor interface {
+	PageReference() string
+	SetPageReference(ref string)
+}
+
+// SplitPageRef splits a single page reference, such as "B123", into its key and page number. Returns false if the
+// reference doesn't consist of a key followed by a page number.
+func SplitPageRef(ref string) (key string, page int, ok bool) {
+	i := len(ref)
+	for i > 0 && ref[i-1] >= '0' && ref[i-1] <= '9' {
+		i--
+	}
+	if i == 0 || i == len(ref) {
+		return "", 0, false
+	}
+	var err error
+	if page, err = strconv.Atoi(ref[i:]); err != nil {
+		return "", 0, false
+	}
+	return ref[:i], page, true
+}
+
+// IsLinkPageRef returns true if the page reference is a web link or a markdown reference rather than a book key
+// followed by a page number.
+func IsLinkPageRef(ref string) bool {
+	lowerRef := strings.ToLower(ref)
+	return strings.HasPrefix(lowerRef, "http://") || strings.HasPrefix(lowerRef, "https://") ||
+		strings.HasPrefix(lowerRef, "md:")
+}
+
+// RebasePageRefs adds the offset to the page number of each reference within refs that uses the key. Other references,
+// links, those whose page would drop below 1, and the separators between references are left untouched. Returns true if
+// anything changed.
+func RebasePageRefs(refs, key string, offset int) (string, bool) {
+	if offset == 0 || key == "" {
+		return refs, false
+	}
+	var buffer strings.Builder
+	changed := false
+	start := 0
+	for i := 0; i <= len(refs); i++ {
+		if i < len(refs) && refs[i] != ',' && refs[i] != ';' {
+			continue
+		}
+		part := refs[start:i]
+		trimmed := strings.TrimSpace(part)
+		if k, page, ok := SplitPageRef(trimmed); ok && k == key && page+offset > 0 && !IsLinkPageRef(trimmed) {
+			lead := strings.Index(part, trimmed)
+			buffer.WriteString(part[:lead])
+			buffer.WriteString(k)
+			buffer.WriteString(strconv.Itoa(page + offset))
+			buffer.WriteString(part[lead+len(trimmed):])
+			changed = true
+		} else {
+			buffer.WriteString(part)
+		}
+		if i < len(refs) {
+			buffer.WriteByte(refs[i])
+		}
+		start = i + 1
+	}
+	if !changed {
+		return refs, false
+	}
+	return buffer.String(), true
+}
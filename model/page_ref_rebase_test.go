@@ -0,0 +1,68 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitPageRef(t *testing.T) {
+	key, page, ok := SplitPageRef("B123")
+	require.True(t, ok)
+	require.Equal(t, "B", key)
+	require.Equal(t, 123, page)
+
+	key, page, ok = SplitPageRef("DFRPG:A12")
+	require.True(t, ok)
+	require.Equal(t, "DFRPG:A", key)
+	require.Equal(t, 12, page)
+
+	for _, ref := range []string{"", "B", "123", "md:Help/Interface/Spell"} {
+		_, _, ok = SplitPageRef(ref)
+		require.False(t, ok, ref)
+	}
+}
+
+func TestRebasePageRefs(t *testing.T) {
+	for _, one := range []struct {
+		refs     string
+		key      string
+		offset   int
+		expected string
+		changed  bool
+	}{
+		{"B123", "B", 2, "B125", true},
+		{"B123, MA45; B7", "B", -3, "B120, MA45; B4", true},
+		{"MA45,B10", "MA", 5, "MA50,B10", true},
+		{"BX10, B2", "B", -2, "BX10, B2", false},
+		{"B2, B10", "B", -5, "B2, B5", true},
+		{"https://example.com/1, md:Help", "B", 1, "https://example.com/1, md:Help", false},
+		{"https://example.com/page5, B5", "https://example.com/page", 1, "https://example.com/page5, B5", false},
+		{"MD:Help/Page2", "MD:Help/Page", 1, "MD:Help/Page2", false},
+		{"B123", "B", 0, "B123", false},
+		{"", "B", 1, "", false},
+	} {
+		result, changed := RebasePageRefs(one.refs, one.key, one.offset)
+		require.Equal(t, one.expected, result, one.refs)
+		require.Equal(t, one.changed, changed, one.refs)
+	}
+
+	require.True(t, IsLinkPageRef("HTTPS://example.com/page5"))
+	require.True(t, IsLinkPageRef("md:Help/Interface/Spell"))
+	require.False(t, IsLinkPageRef("B123"))
+
+	var editor PageRefEditor = NewSpell(nil, nil, false)
+	editor.SetPageReference("M12")
+	require.Equal(t, "M12", editor.PageReference())
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// PluralForm returns whichever of singular or plural is correct for count in the current i18n language. Only two forms
+// are available, so languages with additional plural categories use the plural form for all but their "one" category.
+func PluralForm(count int, singular, plural string) string {
+	if usesSingularForm(i18n.Language, count) {
+		return singular
+	}
+	return plural
+}
+
+// CountWithNoun returns the count followed by the correct form of the noun, e.g. "3 Spells".
+func CountWithNoun(count int, singular, plural string) string {
+	return fmt.Sprintf(i18n.Text("%d %s"), count, PluralForm(count, singular, plural))
+}
+
+// usesSingularForm returns true if the language places count in its "one" plural category, per the CLDR plural rules.
+func usesSingularForm(language string, count int) bool {
+	if count < 0 {
+		count = -count
+	}
+	locale := strings.ReplaceAll(strings.ToLower(language), "-", "_")
+	lang := locale
+	if i := strings.IndexAny(lang, "_.@"); i != -1 {
+		lang = lang[:i]
+	}
+	switch lang {
+	case "ja", "zh", "ko", "th", "vi", "id", "ms", "lo", "my":
+		return false
+	case "fr", "hi", "fa", "bn", "am", "zu":
+		return count == 0 || count == 1
+	case "pt":
+		if strings.HasPrefix(locale, "pt_pt") {
+			return count == 1
+		}
+		return count == 0 || count == 1
+	case "ru", "uk", "be", "hr", "sr", "bs":
+		return count%10 == 1 && count%100 != 11
+	default:
+		return count == 1
+	}
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsesSingularForm(t *testing.T) {
+	require.True(t, usesSingularForm("en_US.UTF-8", 1))
+	require.False(t, usesSingularForm("en_US.UTF-8", 0))
+	require.False(t, usesSingularForm("en_US.UTF-8", 3))
+	require.True(t, usesSingularForm("en", -1))
+
+	require.True(t, usesSingularForm("fr_FR.UTF-8", 0))
+	require.True(t, usesSingularForm("fr_FR.UTF-8", 1))
+	require.False(t, usesSingularForm("fr_FR.UTF-8", 2))
+
+	require.True(t, usesSingularForm("pt_BR", 0))
+	require.False(t, usesSingularForm("pt-PT", 0))
+	require.True(t, usesSingularForm("pt-PT", 1))
+
+	require.True(t, usesSingularForm("ru_RU.UTF-8", 21))
+	require.False(t, usesSingularForm("ru_RU.UTF-8", 11))
+	require.False(t, usesSingularForm("ru_RU.UTF-8", 3))
+
+	require.False(t, usesSingularForm("ja_JP.UTF-8", 1))
+}
+
+func TestPluralForm(t *testing.T) {
+	require.Equal(t, "Spell", PluralForm(1, "Spell", "Spells"))
+	require.Equal(t, "Spells", PluralForm(3, "Spell", "Spells"))
+	require.Equal(t, "3 Spells", CountWithNoun(3, "Spell", "Spells"))
+}
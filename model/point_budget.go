@@ -0,0 +1,41 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// PointBudgetCategory holds the points spent on one category of a character.
+type PointBudgetCategory struct {
+	Name   string
+	Points fxp.Int
+}
+
+// PointBudgetCategories returns the points spent on attributes, advantages, disadvantages, skills and spells. Racial
+// points are counted as advantages and quirks as disadvantages, so the categories sum to the total points spent.
+func (e *Entity) PointBudgetCategories() []*PointBudgetCategory {
+	pb := e.PointsBreakdown()
+	return []*PointBudgetCategory{
+		{Name: i18n.Text("Attributes"), Points: pb.Attributes},
+		{Name: i18n.Text("Advantages"), Points: pb.Race + pb.Advantages},
+		{Name: i18n.Text("Disadvantages"), Points: pb.Disadvantages + pb.Quirks},
+		{Name: i18n.Text("Skills"), Points: pb.Skills},
+		{Name: i18n.Text("Spells"), Points: pb.Spells},
+	}
+}
+
+// OverPointBudget returns true if a point budget has been set and more points than it allows have been spent.
+func (e *Entity) OverPointBudget() bool {
+	return e.PointBudget > 0 && e.PointsBreakdown().Total() > e.PointBudget
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointBudget(t *testing.T) {
+	entity := NewEntity(PC)
+	skill := NewSkill(entity, nil, false)
+	skill.Name = "Stealth"
+	skill.Points = fxp.From(8)
+	entity.Skills = append(entity.Skills, skill)
+	entity.Recalculate()
+
+	var sum fxp.Int
+	for _, one := range entity.PointBudgetCategories() {
+		sum += one.Points
+	}
+	require.Equal(t, entity.PointsBreakdown().Total(), sum)
+
+	require.False(t, entity.OverPointBudget(), "no budget set")
+	entity.PointBudget = sum
+	require.False(t, entity.OverPointBudget())
+	entity.PointBudget = sum - fxp.One
+	require.True(t, entity.OverPointBudget())
+
+	var buffer bytes.Buffer
+	require.NoError(t, jio.Save(context.Background(), &buffer, entity))
+	var loaded Entity
+	require.NoError(t, jio.Load(context.Background(), &buffer, &loaded))
+	require.Equal(t, entity.PointBudget, loaded.PointBudget)
+}
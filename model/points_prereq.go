@@ -0,0 +1,182 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/txt"
+	"github.com/richardwilkes/toolbox/xio"
+	"golang.org/x/exp/slices"
+)
+
+var _ Prereq = &PointsPrereq{}
+
+// PointsPrereq holds a prerequisite for the number of points spent.
+type PointsPrereq struct {
+	Parent            *PrereqList     `json:"-"`
+	Type              PrereqType      `json:"type"`
+	Has               bool            `json:"has"`
+	Category          PointsCategory  `json:"category,omitempty"`
+	Tag               string          `json:"tag,omitempty"`
+	QualifierCriteria NumericCriteria `json:"qualifier,omitempty"`
+}
+
+// NewPointsPrereq creates a new PointsPrereq.
+func NewPointsPrereq() *PointsPrereq {
+	return &PointsPrereq{
+		Type: PointsPrereqType,
+		QualifierCriteria: NumericCriteria{
+			NumericCriteriaData: NumericCriteriaData{
+				Compare:   AtLeastNumber,
+				Qualifier: fxp.From(20),
+			},
+		},
+		Has: true,
+	}
+}
+
+// PrereqType implements Prereq.
+func (p *PointsPrereq) PrereqType() PrereqType {
+	return p.Type
+}
+
+// ParentList implements Prereq.
+func (p *PointsPrereq) ParentList() *PrereqList {
+	return p.Parent
+}
+
+// Clone implements Prereq.
+func (p *PointsPrereq) Clone(parent *PrereqList) Prereq {
+	clone := *p
+	clone.Parent = parent
+	return &clone
+}
+
+// FillWithNameableKeys implements Prereq.
+func (p *PointsPrereq) FillWithNameableKeys(m map[string]string) {
+	Extract(p.Tag, m)
+}
+
+// ApplyNameableKeys implements Prereq.
+func (p *PointsPrereq) ApplyNameableKeys(m map[string]string) {
+	p.Tag = Apply(p.Tag, m)
+}
+
+// Satisfied implements Prereq.
+func (p *PointsPrereq) Satisfied(entity *Entity, exclude any, tooltip *xio.ByteBuffer, prefix string, _ *bool) bool {
+	satisfied := p.QualifierCriteria.Matches(p.pointsSpent(entity, exclude))
+	if !p.Has {
+		satisfied = !satisfied
+	}
+	if !satisfied && tooltip != nil {
+		tooltip.WriteString(prefix)
+		tooltip.WriteString(HasText(p.Has))
+		tooltip.WriteString(i18n.Text(" points spent "))
+		tooltip.WriteString(p.categoryText())
+		tooltip.WriteString(i18n.Text(" which "))
+		tooltip.WriteString(p.QualifierCriteria.String())
+	}
+	return satisfied
+}
+
+func (p *PointsPrereq) currentValue(entity *Entity, exclude any) string {
+	return p.pointsSpent(entity, exclude).String()
+}
+
+// pointsSpent returns the points spent in the category, counted the same way as Entity.PointsBreakdown(), but leaving
+// out the points spent on exclude.
+func (p *PointsPrereq) pointsSpent(entity *Entity, exclude any) fxp.Int {
+	category := p.Category.EnsureValid()
+	var filter func(tags []string) bool
+	if category == TagPointsCategory {
+		filter = p.hasTag
+	}
+	pb := entity.pointsBreakdown(exclude, filter)
+	switch category {
+	case AncestryPointsCategory:
+		return pb.Race
+	case AttributesPointsCategory:
+		return pb.Attributes
+	case AdvantagesPointsCategory:
+		return pb.Advantages
+	case DisadvantagesPointsCategory:
+		return pb.Disadvantages
+	case QuirksPointsCategory:
+		return pb.Quirks
+	case SkillsPointsCategory:
+		return pb.Skills
+	case SpellsPointsCategory:
+		return pb.Spells
+	default:
+		return pb.Total()
+	}
+}
+
+func (p *PointsPrereq) hasTag(tags []string) bool {
+	return txt.CaselessSliceContains(tags, p.Tag)
+}
+
+func (p *PointsPrereq) categoryText() string {
+	text := p.Category.EnsureValid().String()
+	if p.Category == TagPointsCategory {
+		text += " \"" + p.Tag + "\""
+	}
+	return text
+}
+
+// Description implements Prereq.
+func (p *PointsPrereq) Description(_ *Entity) string {
+	return compactPrereq(p.Has, i18n.Text("Points ")+p.categoryText(), p.QualifierCriteria.CompactString())
+}
+
+// Equal implements Prereq.
+func (p *PointsPrereq) Equal(other Prereq) bool {
+	o, ok := other.(*PointsPrereq)
+	return ok && p.Type == o.Type && p.Has == o.Has && p.Category.EnsureValid() == o.Category.EnsureValid() &&
+		p.Tag == o.Tag && p.QualifierCriteria == o.QualifierCriteria
+}
+
+// PointsTagsFor returns the sorted set of tags in use by the traits, skills and spells of the entity, which may be nil.
+func PointsTagsFor(entity *Entity) []string {
+	if entity == nil {
+		return nil
+	}
+	m := make(map[string]string)
+	add := func(tags []string) {
+		for _, tag := range tags {
+			if key := strings.ToLower(tag); m[key] == "" {
+				m[key] = tag
+			}
+		}
+	}
+	Traverse(func(t *Trait) bool {
+		add(t.Tags)
+		return false
+	}, false, false, entity.Traits...)
+	Traverse(func(s *Skill) bool {
+		add(s.Tags)
+		return false
+	}, false, false, entity.Skills...)
+	Traverse(func(s *Spell) bool {
+		add(s.Tags)
+		return false
+	}, false, false, entity.Spells...)
+	list := make([]string, 0, len(m))
+	for _, tag := range m {
+		list = append(list, tag)
+	}
+	slices.SortFunc(list, func(a, b string) bool { return txt.NaturalLess(a, b, true) })
+	return list
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointsPrereqTag(t *testing.T) {
+	entity := NewEntity(PC)
+	var magery *Trait
+	for _, pts := range []int{15, 10} {
+		magery = NewTrait(entity, nil, false)
+		magery.Name = "Magery"
+		magery.BasePoints = fxp.From(pts)
+		magery.Tags = []string{"Magical"}
+		entity.Traits = append(entity.Traits, magery)
+	}
+	other := NewTrait(entity, nil, false)
+	other.BasePoints = fxp.From(50)
+	entity.Traits = append(entity.Traits, other)
+
+	pr := NewPointsPrereq()
+	pr.Category = TagPointsCategory
+	pr.Tag = "magical"
+	require.True(t, pr.Satisfied(entity, nil, nil, "", nil))
+	require.False(t, pr.Satisfied(entity, magery, nil, "", nil))
+	require.Equal(t, "Points on items tagged \"magical\" ≥ 20", pr.Description(entity))
+	require.Equal(t, []string{"Magical"}, PointsTagsFor(entity))
+
+	pr.Category = AdvantagesPointsCategory
+	pr.QualifierCriteria.Qualifier = fxp.From(75)
+	require.True(t, pr.Satisfied(entity, nil, nil, "", nil))
+	require.False(t, pr.Satisfied(entity, other, nil, "", nil))
+
+	race := NewTrait(entity, nil, true)
+	race.ContainerType = RaceContainerType
+	race.Children = []*Trait{other}
+	other.parent = race
+	entity.Traits = append(entity.Traits[:2], race)
+	pr.Category = AncestryPointsCategory
+	pr.QualifierCriteria.Qualifier = fxp.From(50)
+	require.True(t, pr.Satisfied(entity, nil, nil, "", nil))
+	require.False(t, pr.Satisfied(entity, other, nil, "", nil))
+}
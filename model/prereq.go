@@ -12,6 +12,8 @@
 package model
 
 import (
+	"strings"
+
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/xio"
 )
@@ -27,6 +29,10 @@ type Prereq interface {
 	// Satisfied returns true if this Prereq is satisfied by the specified Entity. 'buffer' will be used, if not nil, to
 	// write a description of what was unsatisfied. 'prefix' will be appended to each line of the description.
 	Satisfied(entity *Entity, exclude any, buffer *xio.ByteBuffer, prefix string, hasEquipmentPenalty *bool) bool
+	// Description returns a compact, human-readable form of this Prereq, such as "ST ≥ 12". 'entity' may be nil.
+	Description(entity *Entity) string
+	// Equal returns true if the other Prereq is of the same type and has the same criteria. Parent lists are ignored.
+	Equal(other Prereq) bool
 }
 
 // HasText returns the appropriate text for has.
@@ -36,3 +42,16 @@ func HasText(has bool) string {
 	}
 	return i18n.Text("Does not have")
 }
+
+func compactPrereq(has bool, label, criteria string) string {
+	var buffer strings.Builder
+	if !has {
+		buffer.WriteString(i18n.Text("NOT "))
+	}
+	buffer.WriteString(label)
+	if criteria != "" {
+		buffer.WriteByte(' ')
+		buffer.WriteString(criteria)
+	}
+	return buffer.String()
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+// CanApplyDeMorgan returns true if ApplyDeMorgan() can transform this list without changing its outcome. This requires
+// the list to be non-empty and every entry to be negatable: empty lists are always satisfied regardless of their
+// negation, and lists restricted to certain tech levels are satisfied outside of them regardless of their negation.
+func (p *PrereqList) CanApplyDeMorgan() bool {
+	if len(p.Prereqs) == 0 {
+		return false
+	}
+	for _, one := range p.Prereqs {
+		if list, ok := one.(*PrereqList); ok && (len(list.Prereqs) == 0 || list.WhenTL.Compare.EnsureValid() != AnyNumber) {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplyDeMorgan switches this list between requiring all and at least one of its entries using De Morgan's laws. Each
+// entry is negated, along with the list itself, so the outcome is unchanged; for example, "all of: A, not B" becomes
+// "not at least one of: not A, B". Returns false without making changes if CanApplyDeMorgan() returns false.
+func (p *PrereqList) ApplyDeMorgan() bool {
+	if !p.CanApplyDeMorgan() {
+		return false
+	}
+	p.All = !p.All
+	p.Negate = !p.Negate
+	for _, one := range p.Prereqs {
+		negatePrereq(one)
+	}
+	return true
+}
+
+func negatePrereq(pr Prereq) {
+	switch one := pr.(type) {
+	case *PrereqList:
+		one.Negate = !one.Negate
+	case *AttributePrereq:
+		one.Has = !one.Has
+	case *CampaignSettingPrereq:
+		one.Has = !one.Has
+	case *ContainedQuantityPrereq:
+		one.Has = !one.Has
+	case *ContainedWeightPrereq:
+		one.Has = !one.Has
+	case *EquippedEquipmentPrereq:
+		one.Has = !one.Has
+	case *PointsPrereq:
+		one.Has = !one.Has
+	case *SkillPrereq:
+		one.Has = !one.Has
+	case *SpellPrereq:
+		one.Has = !one.Has
+	case *TraitPrereq:
+		one.Has = !one.Has
+	}
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrereqListApplyDeMorgan(t *testing.T) {
+	attr := func(parent *PrereqList, which string, has bool) *AttributePrereq {
+		pr := NewAttributePrereq(nil)
+		pr.Parent = parent
+		pr.Which = which
+		pr.Has = has
+		pr.QualifierCriteria.Compare = AtLeastNumber
+		pr.QualifierCriteria.Qualifier = fxp.From(12)
+		return pr
+	}
+	sub := func(parent *PrereqList, all, negate bool, children ...func(*PrereqList) Prereq) *PrereqList {
+		list := NewPrereqList()
+		list.Parent = parent
+		list.All = all
+		list.Negate = negate
+		for _, child := range children {
+			list.Prereqs = append(list.Prereqs, child(list))
+		}
+		return list
+	}
+	a := func(parent *PrereqList) Prereq { return attr(parent, StrengthID, true) }
+	notB := func(parent *PrereqList) Prereq { return attr(parent, DexterityID, false) }
+	c := func(parent *PrereqList) Prereq { return attr(parent, "iq", true) }
+	orBC := func(parent *PrereqList) Prereq { return sub(parent, false, false, notB, c) }
+	notAndAC := func(parent *PrereqList) Prereq { return sub(parent, true, true, a, c) }
+
+	var entities []*Entity
+	for _, st := range []int{10, 14} {
+		for _, dx := range []int{10, 14} {
+			for _, iq := range []int{10, 14} {
+				sandbox := NewPrereqSandbox()
+				sandbox.Attributes[StrengthID] = fxp.From(st)
+				sandbox.Attributes[DexterityID] = fxp.From(dx)
+				sandbox.Attributes["iq"] = fxp.From(iq)
+				entities = append(entities, sandbox.Entity())
+			}
+		}
+	}
+
+	for _, list := range []*PrereqList{
+		sub(nil, true, false, a, notB),
+		sub(nil, false, false, a, notB, c),
+		sub(nil, true, true, a, orBC),
+		sub(nil, false, false, c, notAndAC),
+	} {
+		before := list.String()
+		var expected []bool
+		for _, entity := range entities {
+			expected = append(expected, list.Satisfied(entity, nil, nil, "", nil))
+		}
+		require.True(t, list.CanApplyDeMorgan(), before)
+		require.True(t, list.ApplyDeMorgan(), before)
+		require.NotEqual(t, before, list.String())
+		for i, entity := range entities {
+			require.Equal(t, expected[i], list.Satisfied(entity, nil, nil, "", nil), "%s → %s", before, list.String())
+		}
+		require.True(t, list.ApplyDeMorgan(), before)
+		require.Equal(t, before, list.String())
+	}
+
+	require.False(t, sub(nil, true, false).ApplyDeMorgan())
+	withEmpty := sub(nil, true, false, a, func(parent *PrereqList) Prereq { return sub(parent, true, false) })
+	require.False(t, withEmpty.CanApplyDeMorgan())
+	withTL := sub(nil, true, false, a, orBC)
+	withTL.Prereqs[1].(*PrereqList).WhenTL.Compare = AtLeastNumber
+	require.False(t, withTL.CanApplyDeMorgan())
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// ToDOT writes this prereq list as a Graphviz digraph. Lists become AND/OR nodes and the remaining prerequisites become
+// leaf nodes labeled with their description. 'entity' may be nil.
+func (p *PrereqList) ToDOT(w io.Writer, entity *Entity) error {
+	var buffer strings.Builder
+	buffer.WriteString("digraph prereqs {\n")
+	id := 0
+	writePrereqDOTNode(&buffer, p, entity, &id)
+	buffer.WriteString("}\n")
+	if _, err := io.WriteString(w, buffer.String()); err != nil {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+func writePrereqDOTNode(buffer *strings.Builder, prereq Prereq, entity *Entity, id *int) string {
+	name := fmt.Sprintf("n%d", *id)
+	*id++
+	list, ok := prereq.(*PrereqList)
+	if !ok {
+		fmt.Fprintf(buffer, "\t%s [shape=box, label=%s];\n", name, quoteDOT(prereq.Description(entity)))
+		return name
+	}
+	fmt.Fprintf(buffer, "\t%s [shape=ellipse, label=%s];\n", name, quoteDOT(list.dotLabel()))
+	for _, one := range list.Prereqs {
+		child := writePrereqDOTNode(buffer, one, entity, id)
+		fmt.Fprintf(buffer, "\t%s -> %s;\n", name, child)
+	}
+	return name
+}
+
+func (p *PrereqList) dotLabel() string {
+	label := i18n.Text("OR")
+	if p.All {
+		label = i18n.Text("AND")
+	}
+	if p.Negate {
+		label = i18n.Text("NOT ") + label
+	}
+	if p.WhenTL.Compare.EnsureValid() != AnyNumber {
+		label = fmt.Sprintf(i18n.Text("When TL %s: %s"), p.WhenTL.CompactString(), label)
+	}
+	return label
+}
+
+func quoteDOT(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(text) + `"`
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrereqListToDOT(t *testing.T) {
+	list := NewPrereqList()
+	var buffer strings.Builder
+	require.NoError(t, list.ToDOT(&buffer, nil))
+	require.Equal(t, "digraph prereqs {\n\tn0 [shape=ellipse, label=\"AND\"];\n}\n", buffer.String())
+
+	st := NewAttributePrereq(nil)
+	st.Parent = list
+	st.QualifierCriteria.Compare = AtLeastNumber
+	st.QualifierCriteria.Qualifier = fxp.From(12)
+	sub := NewPrereqList()
+	sub.Parent = list
+	sub.All = false
+	sub.Negate = true
+	trait := NewTraitPrereq()
+	trait.Parent = sub
+	trait.NameCriteria.Qualifier = `The "Best"`
+	sub.Prereqs = append(sub.Prereqs, trait)
+	list.Prereqs = append(list.Prereqs, st, sub)
+
+	buffer.Reset()
+	require.NoError(t, list.ToDOT(&buffer, nil))
+	require.Equal(t, `digraph prereqs {
+	n0 [shape=ellipse, label="AND"];
+	n1 [shape=box, label="ST ≥ 12"];
+	n0 -> n1;
+	n2 [shape=ellipse, label="NOT OR"];
+	n3 [shape=box, label="Trait: The \"Best\""];
+	n2 -> n3;
+	n0 -> n2;
+}
+`, buffer.String())
+}
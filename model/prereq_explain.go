@@ -0,0 +1,128 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// prereqCurrentValuer may be implemented by prereqs that can report the entity's current value for what they check.
+type prereqCurrentValuer interface {
+	currentValue(entity *Entity, exclude any) string
+}
+
+type prereqFailure struct {
+	depth int
+	text  string
+}
+
+// ExplainPrereqFailure returns a human-readable explanation of why the entity does not satisfy the prereq, one reason
+// per line, or an empty string if it is satisfied. Only the branches of a PrereqList that actually caused the failure
+// are included.
+func ExplainPrereqFailure(entity *Entity, prereq Prereq) string {
+	_, failures := explainPrereq(entity, nil, prereq, 0)
+	return formatPrereqFailures(failures, "")
+}
+
+// prereqFailureTooltip returns the explanation formatted for use as the unsatisfied reason of an item.
+func prereqFailureTooltip(entity *Entity, exclude any, prereq Prereq) string {
+	_, failures := explainPrereq(entity, exclude, prereq, 0)
+	return "\n" + formatPrereqFailures(failures, "● ")
+}
+
+func formatPrereqFailures(failures []prereqFailure, bullet string) string {
+	var buffer strings.Builder
+	for i, one := range failures {
+		if i != 0 {
+			buffer.WriteByte('\n')
+		}
+		buffer.WriteString(strings.Repeat("  ", one.depth))
+		buffer.WriteString(bullet)
+		buffer.WriteString(one.text)
+	}
+	return buffer.String()
+}
+
+// explainPrereq evaluates the prereq and returns whether it is satisfied, along with the reasons it isn't. Each prereq
+// is evaluated only once: the result for a PrereqList is derived from the results of its entries, in the same way
+// PrereqList.Satisfied does, rather than by evaluating the list and then each of its entries again.
+func explainPrereq(entity *Entity, exclude any, prereq Prereq, depth int) (satisfied bool, failures []prereqFailure) {
+	if prereq == nil {
+		return true, nil
+	}
+	list, ok := prereq.(*PrereqList)
+	if !ok {
+		var penalty bool
+		if prereq.Satisfied(entity, exclude, nil, "", &penalty) {
+			return true, nil
+		}
+		text := fmt.Sprintf(i18n.Text("requires %s"), prereq.Description(entity))
+		if valuer, hasValue := prereq.(prereqCurrentValuer); hasValue {
+			text += fmt.Sprintf(i18n.Text(" but you have %s"), valuer.currentValue(entity, exclude))
+		}
+		return false, []prereqFailure{{depth: depth, text: text}}
+	}
+	if list.WhenTL.Compare != AnyNumber {
+		tl, _, _ := ExtractTechLevel(entity.Profile.TechLevel)
+		if tl < 0 {
+			tl = 0
+		}
+		if !list.WhenTL.Matches(tl) {
+			return true, nil
+		}
+	}
+	anyOf := !list.All && len(list.Prereqs) > 1
+	childDepth := depth
+	if anyOf {
+		childDepth++
+	}
+	count := 0
+	var childFailures []prereqFailure
+	var satisfiedChildren []Prereq
+	for _, one := range list.Prereqs {
+		childSatisfied, more := explainPrereq(entity, exclude, one, childDepth)
+		if childSatisfied {
+			count++
+			satisfiedChildren = append(satisfiedChildren, one)
+		} else {
+			childFailures = append(childFailures, more...)
+		}
+	}
+	satisfied = count == len(list.Prereqs) || (!list.All && count > 0)
+	if list.Negate && len(list.Prereqs) != 0 {
+		if satisfied {
+			// The list failed because its entries were satisfied, so describe those instead
+			var title string
+			if list.All {
+				title = i18n.Text("must not meet all of:")
+			} else {
+				title = i18n.Text("must not meet any of:")
+			}
+			failures = append(failures, prereqFailure{depth: depth, text: title})
+			for _, one := range satisfiedChildren {
+				failures = append(failures, prereqFailure{depth: depth + 1, text: one.Description(entity)})
+			}
+			return false, failures
+		}
+		return true, nil
+	}
+	if satisfied {
+		return true, nil
+	}
+	if anyOf {
+		failures = append(failures, prereqFailure{depth: depth, text: i18n.Text("requires at least one of:")})
+	}
+	return false, append(failures, childFailures...)
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainPrereqFailure(t *testing.T) {
+	entity := NewEntity(PC)
+	entity.Recalculate()
+	list := NewPrereqList()
+	st := NewAttributePrereq(entity)
+	st.Parent = list
+	st.Which = StrengthID
+	st.QualifierCriteria.Compare = AtLeastNumber
+	st.QualifierCriteria.Qualifier = fxp.From(12)
+	dx := NewAttributePrereq(entity)
+	dx.Parent = list
+	dx.Which = DexterityID
+	dx.QualifierCriteria.Compare = AtLeastNumber
+	dx.QualifierCriteria.Qualifier = fxp.From(8)
+	list.Prereqs = append(list.Prereqs, st, dx)
+	require.Equal(t, "requires "+st.Description(entity)+" but you have 10", ExplainPrereqFailure(entity, list))
+
+	list.All = false
+	require.Equal(t, "", ExplainPrereqFailure(entity, list))
+
+	dx.QualifierCriteria.Qualifier = fxp.From(14)
+	require.Equal(t, "requires at least one of:\n  requires "+st.Description(entity)+
+		" but you have 10\n  requires "+dx.Description(entity)+" but you have 10", ExplainPrereqFailure(entity, list))
+
+	dx.QualifierCriteria.Qualifier = fxp.From(8)
+	list.Negate = true
+	require.Equal(t, "must not meet any of:\n  "+dx.Description(entity), ExplainPrereqFailure(entity, list))
+
+	outer := NewPrereqList()
+	list.Negate = false
+	list.Parent = outer
+	iq := NewAttributePrereq(entity)
+	iq.Parent = outer
+	iq.Which = "iq"
+	iq.QualifierCriteria.Compare = AtLeastNumber
+	iq.QualifierCriteria.Qualifier = fxp.From(15)
+	outer.Prereqs = append(outer.Prereqs, list, iq)
+	var penalty bool
+	require.False(t, outer.Satisfied(entity, nil, nil, "", &penalty))
+	require.Equal(t, "requires "+iq.Description(entity)+" but you have 10", ExplainPrereqFailure(entity, outer))
+	iq.QualifierCriteria.Qualifier = fxp.From(10)
+	require.True(t, outer.Satisfied(entity, nil, nil, "", &penalty))
+	require.Equal(t, "", ExplainPrereqFailure(entity, outer))
+}
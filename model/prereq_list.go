@@ -12,8 +12,12 @@
 package model
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/xio"
 )
@@ -25,6 +29,7 @@ type PrereqList struct {
 	Parent  *PrereqList     `json:"-"`
 	Type    PrereqType      `json:"type"`
 	All     bool            `json:"all"`
+	Negate  bool            `json:"negate,omitempty"`
 	WhenTL  NumericCriteria `json:"when_tl,omitempty"`
 	Prereqs Prereqs         `json:"prereqs,omitempty"`
 }
@@ -105,7 +110,7 @@ func (p *PrereqList) Satisfied(entity *Entity, exclude any, buffer *xio.ByteBuff
 		if tl < 0 {
 			tl = 0
 		}
-		if !p.WhenTL.Compare.Matches(p.WhenTL.Qualifier, tl) {
+		if !p.WhenTL.Matches(tl) {
 			return true
 		}
 	}
@@ -126,15 +131,32 @@ func (p *PrereqList) Satisfied(entity *Entity, exclude any, buffer *xio.ByteBuff
 		local.WriteString(indented)
 	}
 	satisfied := count == len(p.Prereqs) || (!p.All && count > 0)
+	if p.Negate && len(p.Prereqs) != 0 {
+		satisfied = !satisfied
+		if !satisfied && buffer != nil {
+			// The entries themselves were satisfied, so nothing was written for them. Describe them instead.
+			local = &xio.ByteBuffer{}
+			for _, one := range p.Prereqs {
+				local.WriteString(strings.ReplaceAll(prefix, "\n", "\n  "))
+				local.WriteString(one.Description(entity))
+			}
+		}
+		eqpPenalty = false
+	}
 	if !satisfied {
 		if eqpPenalty {
 			*hasEquipmentPenalty = eqpPenalty
 		}
 		if buffer != nil && local != nil {
 			buffer.WriteString(prefix)
-			if p.All {
+			switch {
+			case p.Negate && p.All:
+				buffer.WriteString(i18n.Text("Requires not all of:"))
+			case p.Negate:
+				buffer.WriteString(i18n.Text("Requires none of:"))
+			case p.All:
 				buffer.WriteString(i18n.Text("Requires all of:"))
-			} else {
+			default:
 				buffer.WriteString(i18n.Text("Requires at least one of:"))
 			}
 			buffer.WriteString(local.String())
@@ -142,3 +164,173 @@ func (p *PrereqList) Satisfied(entity *Entity, exclude any, buffer *xio.ByteBuff
 	}
 	return satisfied
 }
+
+// Description implements Prereq. The result is parenthesized, with the entries joined by AND or OR, as appropriate.
+// Returns an empty string if the list is empty.
+func (p *PrereqList) Description(entity *Entity) string {
+	parts := make([]string, 0, len(p.Prereqs))
+	for _, one := range p.Prereqs {
+		if desc := one.Description(entity); desc != "" {
+			parts = append(parts, desc)
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	separator := i18n.Text(" OR ")
+	if p.All {
+		separator = i18n.Text(" AND ")
+	}
+	desc := "(" + strings.Join(parts, separator) + ")"
+	if p.Negate {
+		desc = i18n.Text("NOT ") + desc
+	}
+	if p.WhenTL.Compare.EnsureValid() != AnyNumber {
+		desc = fmt.Sprintf(i18n.Text("When TL %s: %s"), p.WhenTL.CompactString(), desc)
+	}
+	return desc
+}
+
+// String implements fmt.Stringer.
+func (p *PrereqList) String() string {
+	return p.Description(nil)
+}
+
+// Equal implements Prereq. Nested lists are compared recursively and the order of entries is significant.
+func (p *PrereqList) Equal(other Prereq) bool {
+	o, ok := other.(*PrereqList)
+	if !ok || p.Type != o.Type || p.All != o.All || p.Negate != o.Negate || p.WhenTL != o.WhenTL ||
+		len(p.Prereqs) != len(o.Prereqs) {
+		return false
+	}
+	for i, one := range p.Prereqs {
+		if !one.Equal(o.Prereqs[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// CountDuplicates returns the number of entries that RemoveDuplicates() would remove.
+func (p *PrereqList) CountDuplicates() int {
+	return p.CloneAsPrereqList(nil).RemoveDuplicates()
+}
+
+// RemoveDuplicates removes any entries within this list and its nested lists that are equal to an earlier entry in the
+// same list. Nested lists are processed first, so that lists which only differ by their own duplicates are also
+// detected. Returns the number of entries removed.
+func (p *PrereqList) RemoveDuplicates() int {
+	count := 0
+	for _, one := range p.Prereqs {
+		if list, ok := one.(*PrereqList); ok {
+			count += list.RemoveDuplicates()
+		}
+	}
+	kept := make(Prereqs, 0, len(p.Prereqs))
+	for i, one := range p.Prereqs {
+		if p.isDuplicate(i) {
+			count++
+		} else {
+			kept = append(kept, one)
+		}
+	}
+	p.Prereqs = kept
+	return count
+}
+
+func (p *PrereqList) isDuplicate(index int) bool {
+	for _, one := range p.Prereqs[:index] {
+		if one.Equal(p.Prereqs[index]) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanFlatten returns true if calling Flatten() would simplify this prereq list.
+func (p *PrereqList) CanFlatten() bool {
+	return p.CloneAsPrereqList(p.Parent).Flatten()
+}
+
+// Flatten simplifies the structure of this prereq list without changing its outcome. Empty lists, which are always
+// satisfied, are removed from lists requiring all of their entries, and lists which have a single entry or use the same
+// logic as the list they are in are replaced by their contents. Returns true if anything changed.
+func (p *PrereqList) Flatten() bool {
+	changed := false
+	for _, one := range p.Prereqs {
+		if list, ok := one.(*PrereqList); ok && list.Flatten() {
+			changed = true
+		}
+	}
+	if p.All {
+		kept := make(Prereqs, 0, len(p.Prereqs))
+		for _, one := range p.Prereqs {
+			if list, ok := one.(*PrereqList); !ok || len(list.Prereqs) != 0 {
+				kept = append(kept, one)
+			}
+		}
+		// A negated list that is left empty would go from unsatisfied to satisfied.
+		if len(kept) != len(p.Prereqs) && (len(kept) != 0 || !p.Negate) {
+			p.Prereqs = kept
+			changed = true
+		}
+	}
+	result := make(Prereqs, 0, len(p.Prereqs))
+	for _, one := range p.Prereqs {
+		if list, ok := one.(*PrereqList); ok && list.isTransparent() && (len(list.Prereqs) == 1 || list.All == p.All) {
+			for _, child := range list.Prereqs {
+				result = append(result, child.Clone(p))
+			}
+			changed = true
+		} else {
+			result = append(result, one)
+		}
+	}
+	p.Prereqs = result
+	if len(p.Prereqs) == 1 && p.isTransparent() {
+		if list, ok := p.Prereqs[0].(*PrereqList); ok {
+			p.All = list.All
+			p.Negate = list.Negate
+			p.WhenTL = list.WhenTL
+			p.Prereqs = make(Prereqs, len(list.Prereqs))
+			for i, child := range list.Prereqs {
+				p.Prereqs[i] = child.Clone(p)
+			}
+			changed = true
+		}
+	}
+	return changed
+}
+
+// isTransparent returns true if this prereq list is non-empty and neither negates its result nor applies only to
+// certain tech levels, so that its outcome is determined solely by its entries.
+func (p *PrereqList) isTransparent() bool {
+	return len(p.Prereqs) != 0 && !p.Negate && p.WhenTL.Compare.EnsureValid() == AnyNumber
+}
+
+// ToText serializes this prereq list into JSON text, suitable for placing on the clipboard.
+func (p *PrereqList) ToText() (string, error) {
+	var buffer strings.Builder
+	if err := jio.Save(context.Background(), &buffer, p); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// PrereqListFromText deserializes a prereq list from JSON text previously created by ToText(). The returned list has
+// no parent and all of its descendants have their parent pointers set correctly.
+func PrereqListFromText(text string) (*PrereqList, error) {
+	var list PrereqList
+	if err := jio.Load(context.Background(), strings.NewReader(text), &list); err != nil {
+		return nil, err
+	}
+	if list.Type != ListPrereqType {
+		return nil, errs.New(i18n.Text("text does not contain a prerequisite list"))
+	}
+	return list.CloneAsPrereqList(nil), nil
+}
+
+// Merge appends a copy of the other list to this one as a new sub-list.
+func (p *PrereqList) Merge(other *PrereqList) {
+	p.Prereqs = append(p.Prereqs, other.CloneAsPrereqList(p))
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/xio"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrereqListDescription(t *testing.T) {
+	list := NewPrereqList()
+	require.Equal(t, "", list.Description(nil))
+
+	st := NewAttributePrereq(nil)
+	st.Parent = list
+	st.QualifierCriteria.Compare = AtLeastNumber
+	st.QualifierCriteria.Qualifier = fxp.From(12)
+	list.Prereqs = append(list.Prereqs, st)
+
+	sub := NewPrereqList()
+	sub.Parent = list
+	sub.All = false
+	dx := NewAttributePrereq(nil)
+	dx.Parent = sub
+	dx.Which = DexterityID
+	dx.QualifierCriteria.Compare = AtLeastNumber
+	dx.QualifierCriteria.Qualifier = fxp.From(11)
+	skill := NewSkillPrereq()
+	skill.Parent = sub
+	skill.NameCriteria.Qualifier = "Climbing"
+	skill.LevelCriteria.Qualifier = fxp.From(12)
+	sub.Prereqs = append(sub.Prereqs, dx, skill)
+	list.Prereqs = append(list.Prereqs, sub)
+	require.Equal(t, "(ST ≥ 12 AND (DX ≥ 11 OR Skill: Climbing ≥ 12))", list.String())
+
+	trait := NewTraitPrereq()
+	trait.Has = false
+	trait.NameCriteria.Qualifier = "Unfit"
+	spell := NewSpellPrereq()
+	spell.SubType = CollegeSpellComparisonType
+	spell.QualifierCriteria.Qualifier = "Fire"
+	spell.QuantityCriteria.Qualifier = fxp.Five
+	sub.Prereqs = Prereqs{trait, spell}
+	require.Equal(t, "(ST ≥ 12 AND (NOT Trait: Unfit OR Spell college: Fire ≥ 5))", list.String())
+}
+
+func TestPrereqListRemoveDuplicates(t *testing.T) {
+	list := NewPrereqList()
+	skill := NewSkillPrereq()
+	skill.Parent = list
+	skill.NameCriteria.Qualifier = "Climbing"
+	sub := NewPrereqList()
+	sub.Parent = list
+	sub.Prereqs = Prereqs{skill.Clone(sub), skill.Clone(sub)}
+	list.Prereqs = Prereqs{skill, skill.Clone(list), sub, sub.Clone(list)}
+	require.True(t, skill.Equal(sub.Prereqs[0]))
+	require.True(t, sub.Equal(list.Prereqs[3]))
+	other := NewSkillPrereq()
+	other.NameCriteria.Qualifier = "Climbing"
+	other.LevelCriteria.Qualifier = fxp.One
+	require.False(t, skill.Equal(other))
+	require.False(t, skill.Equal(NewTraitPrereq()))
+
+	require.Equal(t, 4, list.CountDuplicates())
+	require.Equal(t, 4, list.RemoveDuplicates())
+	require.Len(t, list.Prereqs, 2)
+	require.Len(t, sub.Prereqs, 1)
+	require.Equal(t, 0, list.CountDuplicates())
+}
+
+func TestPrereqListNegate(t *testing.T) {
+	entity := NewEntity(PC)
+	list := NewPrereqList()
+	list.Negate = true
+	require.True(t, list.Satisfied(entity, nil, nil, "", new(bool)), "empty negated list")
+
+	st := NewAttributePrereq(entity)
+	st.Parent = list
+	st.QualifierCriteria.Compare = AtLeastNumber
+	st.QualifierCriteria.Qualifier = fxp.From(12)
+	list.Prereqs = append(list.Prereqs, st)
+	require.True(t, list.Satisfied(entity, nil, nil, "", new(bool)))
+	require.Equal(t, "NOT (ST ≥ 12)", list.Description(entity))
+
+	st.QualifierCriteria.Qualifier = fxp.From(8)
+	var buffer xio.ByteBuffer
+	require.False(t, list.Satisfied(entity, nil, &buffer, "\n", new(bool)))
+	require.Equal(t, "\nRequires not all of:\n  ST ≥ 8", buffer.String())
+}
+
+func TestPrereqListTextRoundTrip(t *testing.T) {
+	list := NewPrereqList()
+	list.All = false
+	sub := NewPrereqList()
+	sub.Parent = list
+	trait := NewTraitPrereq()
+	trait.Parent = sub
+	trait.NameCriteria.Qualifier = "Magery"
+	sub.Prereqs = append(sub.Prereqs, trait)
+	list.Prereqs = append(list.Prereqs, sub)
+
+	text, err := list.ToText()
+	require.NoError(t, err)
+	pasted, err := PrereqListFromText(text)
+	require.NoError(t, err)
+	require.True(t, list.Equal(pasted))
+	require.Nil(t, pasted.Parent)
+	pastedSub, ok := pasted.Prereqs[0].(*PrereqList)
+	require.True(t, ok)
+	require.Same(t, pasted, pastedSub.Parent)
+	require.Same(t, pastedSub, pastedSub.Prereqs[0].ParentList())
+
+	_, err = PrereqListFromText(`{"type":"trait_prereq"}`)
+	require.Error(t, err)
+	_, err = PrereqListFromText("not json")
+	require.Error(t, err)
+
+	target := NewPrereqList()
+	target.Merge(pasted)
+	require.Len(t, target.Prereqs, 1)
+	require.Same(t, target, target.Prereqs[0].ParentList())
+	require.True(t, pasted.Equal(target.Prereqs[0]))
+}
+
+func TestPrereqListFlatten(t *testing.T) {
+	attr := func(parent *PrereqList, which string) *AttributePrereq {
+		pr := NewAttributePrereq(nil)
+		pr.Parent = parent
+		pr.Which = which
+		pr.QualifierCriteria.Compare = AtLeastNumber
+		pr.QualifierCriteria.Qualifier = fxp.From(12)
+		return pr
+	}
+	sub := func(parent *PrereqList, all bool, children ...func(*PrereqList) Prereq) *PrereqList {
+		list := NewPrereqList()
+		list.Parent = parent
+		list.All = all
+		for _, child := range children {
+			list.Prereqs = append(list.Prereqs, child(list))
+		}
+		return list
+	}
+	a := func(parent *PrereqList) Prereq { return attr(parent, StrengthID) }
+	b := func(parent *PrereqList) Prereq { return attr(parent, DexterityID) }
+	c := func(parent *PrereqList) Prereq { return attr(parent, "iq") }
+	empty := func(parent *PrereqList) Prereq { return sub(parent, true) }
+	single := func(parent *PrereqList) Prereq { return sub(parent, false, b) }
+	orBC := func(parent *PrereqList) Prereq { return sub(parent, false, b, c) }
+	andBC := func(parent *PrereqList) Prereq { return sub(parent, true, b, c) }
+
+	for _, one := range []struct {
+		list     *PrereqList
+		expected string
+	}{
+		{sub(nil, true, a, empty), "(ST ≥ 12)"},
+		{sub(nil, true, a, single), "(ST ≥ 12 AND DX ≥ 12)"},
+		{sub(nil, true, a, andBC), "(ST ≥ 12 AND DX ≥ 12 AND IQ ≥ 12)"},
+		{sub(nil, true, func(parent *PrereqList) Prereq { return sub(parent, false, a, b) }), "(ST ≥ 12 OR DX ≥ 12)"},
+		{sub(nil, true, func(parent *PrereqList) Prereq {
+			return sub(parent, true, func(parent2 *PrereqList) Prereq { return sub(parent2, false, a, orBC) })
+		}), "(ST ≥ 12 OR DX ≥ 12 OR IQ ≥ 12)"},
+	} {
+		require.True(t, one.list.CanFlatten(), one.expected)
+		require.True(t, one.list.Flatten(), one.expected)
+		require.Equal(t, one.expected, one.list.String())
+		require.False(t, one.list.CanFlatten(), one.expected)
+		checkPrereqParents(t, one.list)
+	}
+
+	for _, list := range []*PrereqList{
+		sub(nil, true, a, orBC),
+		sub(nil, false, a, empty),
+		sub(nil, true, a, func(parent *PrereqList) Prereq {
+			list := sub(parent, true, b, c)
+			list.Negate = true
+			return list
+		}),
+	} {
+		before := list.String()
+		require.False(t, list.CanFlatten(), before)
+		require.False(t, list.Flatten(), before)
+		require.Equal(t, before, list.String())
+	}
+
+	negated := sub(nil, true, empty)
+	negated.Negate = true
+	require.False(t, negated.Flatten(), "emptying a negated list would change its outcome")
+}
+
+func checkPrereqParents(t *testing.T, list *PrereqList) {
+	for _, one := range list.Prereqs {
+		require.Same(t, list, one.ParentList())
+		if child, ok := one.(*PrereqList); ok {
+			checkPrereqParents(t, child)
+		}
+	}
+}
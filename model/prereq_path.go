@@ -0,0 +1,70 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// PrereqPath returns the JSON Pointer-style path of the prerequisite within its tree, such as "/0/2/1", where each
+// segment is an index into the prerequisites of the enclosing list. The root list's path is empty. Paths are derived from
+// the current position of the prerequisite, so they change when the tree is rearranged.
+func PrereqPath(pr Prereq) string {
+	var segments []string
+	for parent := pr.ParentList(); parent != nil; parent = parent.ParentList() {
+		index := -1
+		for i, one := range parent.Prereqs {
+			if one == pr {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			return ""
+		}
+		segments = append(segments, strconv.Itoa(index))
+		pr = parent
+	}
+	var buffer strings.Builder
+	for i := len(segments) - 1; i >= 0; i-- {
+		buffer.WriteByte('/')
+		buffer.WriteString(segments[i])
+	}
+	return buffer.String()
+}
+
+// AtPath returns the prerequisite at the JSON Pointer-style path within this list. An empty path refers to the list
+// itself.
+func (p *PrereqList) AtPath(path string) (Prereq, error) {
+	if path == "" {
+		return p, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, errs.Newf("invalid prerequisite path: %s", path)
+	}
+	var current Prereq = p
+	for _, segment := range strings.Split(path[1:], "/") {
+		list, ok := current.(*PrereqList)
+		if !ok {
+			return nil, errs.Newf("prerequisite path descends into a non-list: %s", path)
+		}
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 || index >= len(list.Prereqs) || segment != strconv.Itoa(index) {
+			return nil, errs.Newf("invalid index '%s' in prerequisite path: %s", segment, path)
+		}
+		current = list.Prereqs[index]
+	}
+	return current, nil
+}
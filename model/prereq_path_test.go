@@ -0,0 +1,51 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrereqPath(t *testing.T) {
+	root := NewPrereqList()
+	st := NewAttributePrereq(nil)
+	st.Parent = root
+	sub := NewPrereqList()
+	sub.Parent = root
+	skill := NewSkillPrereq()
+	skill.Parent = sub
+	trait := NewTraitPrereq()
+	trait.Parent = sub
+	sub.Prereqs = Prereqs{skill, trait}
+	root.Prereqs = Prereqs{st, sub}
+
+	require.Equal(t, "", PrereqPath(root))
+	require.Equal(t, "/0", PrereqPath(st))
+	require.Equal(t, "/1", PrereqPath(sub))
+	require.Equal(t, "/1/1", PrereqPath(trait))
+
+	for _, pr := range []Prereq{root, st, sub, skill, trait} {
+		found, err := root.AtPath(PrereqPath(pr))
+		require.NoError(t, err)
+		require.Same(t, pr, found)
+	}
+
+	for _, path := range []string{"0", "/2", "/-1", "/01", "/0/0", "/1/x", "/1/"} {
+		_, err := root.AtPath(path)
+		require.Error(t, err, path)
+	}
+
+	root.Prereqs = Prereqs{sub, st}
+	require.Equal(t, "/0/1", PrereqPath(trait), "paths should follow structural changes")
+}
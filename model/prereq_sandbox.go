@@ -0,0 +1,100 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/xio"
+)
+
+// PrereqSandbox describes a hypothetical character that prerequisites can be tested against.
+type PrereqSandbox struct {
+	// Attributes holds the values to use for attributes, keyed by attribute ID. Attributes not present use their
+	// defaults.
+	Attributes map[string]fxp.Int
+	Traits     []*PrereqSandboxItem
+	Skills     []*PrereqSandboxItem
+	Spells     []*PrereqSandboxItem
+}
+
+// PrereqSandboxItem describes a trait, skill or spell the hypothetical character has.
+type PrereqSandboxItem struct {
+	Name string
+	// Specialization is only used by skills.
+	Specialization string
+	// Colleges is a comma-separated list and is only used by spells.
+	Colleges string
+	// Level is the number of levels for a trait and the level for a skill. Unused by spells.
+	Level fxp.Int
+}
+
+// NewPrereqSandbox creates a new, empty PrereqSandbox.
+func NewPrereqSandbox() *PrereqSandbox {
+	return &PrereqSandbox{Attributes: make(map[string]fxp.Int)}
+}
+
+// Entity creates a throwaway entity with the sandbox's attributes, traits, skills and spells.
+func (s *PrereqSandbox) Entity() *Entity {
+	entity := NewEntity(PC)
+	for id, value := range s.Attributes {
+		if attr, ok := entity.Attributes.Set[id]; ok {
+			attr.SetMaximum(value)
+		}
+	}
+	for _, one := range s.Traits {
+		t := NewTrait(entity, nil, false)
+		t.Name = one.Name
+		if one.Level > 0 {
+			t.CanLevel = true
+			t.Levels = one.Level
+		}
+		entity.Traits = append(entity.Traits, t)
+	}
+	for _, one := range s.Skills {
+		sk := NewSkill(entity, nil, false)
+		sk.Name = one.Name
+		sk.Specialization = one.Specialization
+		entity.Skills = append(entity.Skills, sk)
+	}
+	for _, one := range s.Spells {
+		sp := NewSpell(entity, nil, false)
+		sp.Name = one.Name
+		sp.College = nil
+		for _, college := range strings.Split(one.Colleges, ",") {
+			if college = strings.TrimSpace(college); college != "" {
+				sp.College = append(sp.College, college)
+			}
+		}
+		entity.Spells = append(entity.Spells, sp)
+	}
+	entity.Recalculate()
+	// Skill levels are normally derived from attributes and points, so impose the requested ones afterward.
+	for i, one := range s.Skills {
+		entity.Skills[i].LevelData.Level = one.Level
+	}
+	return entity
+}
+
+// EvaluatePrereqs returns true if the prerequisites are satisfied by the entity, along with an explanation of any that
+// aren't. When used with an entity obtained from a PrereqSandbox, don't recalculate it first, as that would discard the
+// imposed skill levels.
+func EvaluatePrereqs(entity *Entity, list *PrereqList) (satisfied bool, explanation string) {
+	if list == nil {
+		return true, ""
+	}
+	var tooltip xio.ByteBuffer
+	var eqpPenalty bool
+	satisfied = list.Satisfied(entity, nil, &tooltip, "\n● ", &eqpPenalty)
+	return satisfied, tooltip.String()
+}
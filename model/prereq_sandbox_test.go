@@ -0,0 +1,62 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrereqSandbox(t *testing.T) {
+	sandbox := NewPrereqSandbox()
+	list := NewPrereqList()
+
+	attrPrereq := NewAttributePrereq(nil)
+	attrPrereq.Which = "iq"
+	attrPrereq.QualifierCriteria.Compare = AtLeastNumber
+	attrPrereq.QualifierCriteria.Qualifier = fxp.From(12)
+	list.Prereqs = append(list.Prereqs, attrPrereq)
+
+	skillPrereq := NewSkillPrereq()
+	skillPrereq.NameCriteria.Qualifier = "Thaumatology"
+	skillPrereq.LevelCriteria.Compare = AtLeastNumber
+	skillPrereq.LevelCriteria.Qualifier = fxp.From(15)
+	list.Prereqs = append(list.Prereqs, skillPrereq)
+
+	spellPrereq := NewSpellPrereq()
+	spellPrereq.SubType = CollegeSpellComparisonType
+	spellPrereq.QualifierCriteria.Qualifier = "Fire"
+	list.Prereqs = append(list.Prereqs, spellPrereq)
+
+	satisfied, explanation := EvaluatePrereqs(sandbox.Entity(), list)
+	require.False(t, satisfied)
+	require.NotEmpty(t, explanation)
+
+	sandbox.Attributes["iq"] = fxp.From(12)
+	sandbox.Skills = append(sandbox.Skills, &PrereqSandboxItem{Name: "Thaumatology", Level: fxp.From(15)})
+	sandbox.Spells = append(sandbox.Spells, &PrereqSandboxItem{Name: "Ignite Fire", Colleges: "Fire, Light"})
+	entity := sandbox.Entity()
+	require.Equal(t, fxp.From(12), entity.Attributes.Current("iq"))
+	require.Equal(t, CollegeList{"Fire", "Light"}, entity.Spells[0].College)
+	satisfied, explanation = EvaluatePrereqs(entity, list)
+	require.True(t, satisfied, explanation)
+	require.Empty(t, explanation)
+
+	sandbox.Skills[0].Level = fxp.From(14)
+	satisfied, _ = EvaluatePrereqs(sandbox.Entity(), list)
+	require.False(t, satisfied)
+
+	satisfied, _ = EvaluatePrereqs(sandbox.Entity(), nil)
+	require.True(t, satisfied)
+}
@@ -30,7 +30,9 @@ const (
 	EquippedEquipmentPrereqType
 	SkillPrereqType
 	SpellPrereqType
-	LastPrereqType = SpellPrereqType
+	PointsPrereqType
+	CampaignSettingPrereqType
+	LastPrereqType = CampaignSettingPrereqType
 )
 
 // AllPrereqType holds all possible values.
@@ -43,6 +45,8 @@ var AllPrereqType = []PrereqType{
 	EquippedEquipmentPrereqType,
 	SkillPrereqType,
 	SpellPrereqType,
+	PointsPrereqType,
+	CampaignSettingPrereqType,
 }
 
 // PrereqType holds the type of a Prereq.
@@ -75,6 +79,10 @@ func (enum PrereqType) Key() string {
 		return "skill_prereq"
 	case SpellPrereqType:
 		return "spell_prereq"
+	case PointsPrereqType:
+		return "points_prereq"
+	case CampaignSettingPrereqType:
+		return "campaign_setting_prereq"
 	default:
 		return PrereqType(0).Key()
 	}
@@ -98,6 +106,10 @@ func (enum PrereqType) oldKeys() []string {
 		return nil
 	case SpellPrereqType:
 		return nil
+	case PointsPrereqType:
+		return nil
+	case CampaignSettingPrereqType:
+		return nil
 	default:
 		return PrereqType(0).oldKeys()
 	}
@@ -122,6 +134,10 @@ func (enum PrereqType) String() string {
 		return i18n.Text("a skill")
 	case SpellPrereqType:
 		return i18n.Text("spell(s)")
+	case PointsPrereqType:
+		return i18n.Text("points spent")
+	case CampaignSettingPrereqType:
+		return i18n.Text("the campaign setting")
 	default:
 		return PrereqType(0).String()
 	}
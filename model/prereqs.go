@@ -47,11 +47,16 @@ func (p *Prereqs) UnmarshalJSON(data []byte) error {
 		case ContainedWeightPrereqType:
 			pr = &ContainedWeightPrereq{}
 		case EquippedEquipmentPrereqType:
-			pr = &EquippedEquipmentPrereq{}
+			// Older data has no has, tags or quantity fields, so start with the defaults
+			pr = NewEquippedEquipmentPrereq()
 		case SkillPrereqType:
 			pr = &SkillPrereq{}
 		case SpellPrereqType:
 			pr = &SpellPrereq{}
+		case PointsPrereqType:
+			pr = &PointsPrereq{}
+		case CampaignSettingPrereqType:
+			pr = &CampaignSettingPrereq{}
 		default:
 			return errs.Newf(i18n.Text("Unknown prerequisite type: %s"), typeData.Type)
 		}
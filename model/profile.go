@@ -98,3 +98,38 @@ func (p *Profile) AutoFill(entity *Entity) {
 	p.Name = a.RandomName(AvailableNameGenerators(globalSettings.Libraries()), p.Gender)
 	p.Birthday = generalSettings.CalendarRef(globalSettings.Libraries()).RandomBirthday(p.Birthday)
 }
+
+// RandomizeProfileOptions holds the options for RandomizeProfile. Fields that are locked are left untouched.
+type RandomizeProfileOptions struct {
+	LockName       bool
+	LockGender     bool
+	LockHeight     bool
+	LockWeight     bool
+	LockAppearance bool
+}
+
+// RandomizeProfile fills in the name, gender, height, weight and appearance (hair, eyes, skin and handedness) of the
+// entity's profile using the generators provided by its current ancestry. The gender is chosen first, so that the
+// other fields are generated for it.
+func RandomizeProfile(entity *Entity, opts RandomizeProfileOptions) {
+	p := entity.Profile
+	a := entity.Ancestry()
+	if !opts.LockGender {
+		p.Gender = a.RandomGender(p.Gender)
+	}
+	if !opts.LockHeight {
+		p.Height = a.RandomHeight(entity, p.Gender, p.Height)
+	}
+	if !opts.LockWeight {
+		p.Weight = a.RandomWeight(entity, p.Gender, p.Weight)
+	}
+	if !opts.LockAppearance {
+		p.Hair = a.RandomHair(p.Gender, p.Hair)
+		p.Eyes = a.RandomEyes(p.Gender, p.Eyes)
+		p.Skin = a.RandomSkin(p.Gender, p.Skin)
+		p.Handedness = a.RandomHandedness(p.Gender, p.Handedness)
+	}
+	if !opts.LockName {
+		p.Name = a.RandomName(AvailableNameGenerators(GlobalSettings().Libraries()), p.Gender)
+	}
+}
@@ -44,7 +44,7 @@ func NewQuickExports() *QuickExports {
 
 // MarshalJSON implements json.Marshaler.
 func (q *QuickExports) MarshalJSON() ([]byte, error) {
-	sort.Slice(q.Exports, func(i, j int) bool { return q.Exports[i].LastUsed.After(q.Exports[j].LastUsed) })
+	sort.SliceStable(q.Exports, func(i, j int) bool { return q.Exports[i].LastUsed.After(q.Exports[j].LastUsed) })
 	if q.Max < 0 {
 		q.Max = 0
 	}
@@ -0,0 +1,63 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecentFilesPinning(t *testing.T) {
+	if _, exists := fileTypeRegistry[SheetExt]; !exists {
+		fileTypeRegistry[SheetExt] = FileInfo{Name: "Sheet", Extensions: []string{SheetExt}}
+		t.Cleanup(func() { delete(fileTypeRegistry, SheetExt) })
+	}
+	dir := t.TempDir()
+	files := make([]string, maxRecentFiles+2)
+	for i := range files {
+		files[i] = filepath.Join(dir, fmt.Sprintf("sheet%d%s", i, SheetExt))
+		require.NoError(t, os.WriteFile(files[i], []byte("{}"), 0o600))
+	}
+	var s Settings
+	s.AddRecentFile(files[0])
+	s.AddRecentFile(files[1])
+	s.AddRecentFile(files[0])
+	require.Equal(t, []string{files[0], files[1]}, s.ListRecentFiles())
+
+	s.SetFilePinned(files[1], true)
+	require.True(t, s.IsFilePinned(files[1]))
+	require.Equal(t, []string{files[1]}, s.ListPinnedFiles())
+	require.Equal(t, []string{files[0]}, s.ListRecentFiles())
+
+	for _, one := range files[2:] {
+		s.AddRecentFile(one)
+	}
+	require.Len(t, s.ListRecentFiles(), maxRecentFiles)
+	require.NotContains(t, s.ListRecentFiles(), files[0])
+	require.Equal(t, []string{files[1]}, s.ListPinnedFiles())
+
+	s.SetFilePinned(files[1], false)
+	require.False(t, s.IsFilePinned(files[1]))
+	require.Empty(t, s.ListPinnedFiles())
+	require.Equal(t, files[1], s.ListRecentFiles()[0])
+
+	require.NoError(t, os.Remove(files[2]))
+	require.NotContains(t, s.ListRecentFiles(), files[2])
+
+	s.SetFilePinned(files[3], true)
+	require.NoError(t, os.Remove(files[3]))
+	require.Equal(t, []string{files[3]}, s.ListPinnedFiles())
+}
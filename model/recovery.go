@@ -0,0 +1,181 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/cmdline"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/log/jot"
+	"github.com/richardwilkes/toolbox/xio/fs/paths"
+)
+
+const recoveryIndexName = "index.json"
+
+// RecoveryEntry describes a document whose unsaved changes were autosaved into the recovery directory. For settings
+// editors, Settings holds the kind of settings and OriginalPath holds the path of the sheet they belong to, or is empty
+// for the defaults.
+type RecoveryEntry struct {
+	ID           string    `json:"id"`
+	OriginalPath string    `json:"original_path"`
+	Settings     string    `json:"settings,omitempty"`
+	NeedsSaveAs  bool      `json:"needs_save_as,omitempty"`
+	Saved        time.Time `json:"saved"`
+	CRC          uint64    `json:"crc"`
+}
+
+// RecoveryIndex tracks the documents that have been autosaved into a recovery directory.
+type RecoveryIndex struct {
+	Entries []*RecoveryEntry `json:"entries,omitempty"`
+	dir     string
+}
+
+// RecoveryDir returns the standard directory used to hold autosaved documents.
+func RecoveryDir() string {
+	return filepath.Join(paths.AppDataDir(), cmdline.AppCmdName+"_recovery")
+}
+
+// NewRecoveryIndex loads the recovery index from the given directory. A missing or unreadable index results in an
+// empty one.
+func NewRecoveryIndex(dir string) *RecoveryIndex {
+	var r RecoveryIndex
+	if err := jio.LoadFromFile(context.Background(), filepath.Join(dir, recoveryIndexName), &r); err != nil {
+		r.Entries = nil
+	}
+	r.dir = dir
+	return &r
+}
+
+// FilePath returns the path of the autosaved copy for the entry.
+func (r *RecoveryIndex) FilePath(entry *RecoveryEntry) string {
+	if entry.Settings != "" {
+		return filepath.Join(r.dir, entry.ID+"."+entry.Settings)
+	}
+	return filepath.Join(r.dir, entry.ID+path.Ext(entry.OriginalPath))
+}
+
+// Entry returns the entry with the given ID, or nil.
+func (r *RecoveryIndex) Entry(id string) *RecoveryEntry {
+	for _, one := range r.Entries {
+		if one.ID == id {
+			return one
+		}
+	}
+	return nil
+}
+
+// Put autosaves a document by calling saver with the path to write to, then records it in the index. If id is empty, a
+// new one is generated. Returns the ID used. Nothing is written if an entry with the same ID already holds data with
+// the same crc.
+func (r *RecoveryIndex) Put(id, originalPath string, needsSaveAs bool, crc uint64, saver func(filePath string) error) (string, error) {
+	return r.put(id, originalPath, "", needsSaveAs, crc, saver)
+}
+
+// PutSettings autosaves the unapplied changes of a settings editor, in the same manner as Put. ownerPath is the path of
+// the sheet the settings belong to, or empty for the defaults.
+func (r *RecoveryIndex) PutSettings(id, settings, ownerPath string, crc uint64,
+	saver func(filePath string) error) (string, error) {
+	return r.put(id, ownerPath, settings, false, crc, saver)
+}
+
+func (r *RecoveryIndex) put(id, originalPath, settings string, needsSaveAs bool, crc uint64,
+	saver func(filePath string) error) (string, error) {
+	entry := r.Entry(id)
+	if entry != nil && entry.CRC == crc && entry.OriginalPath == originalPath && entry.Settings == settings {
+		return id, nil
+	}
+	if entry == nil {
+		if id == "" {
+			id = uuid.New().String()
+		}
+		entry = &RecoveryEntry{ID: id}
+		r.Entries = append(r.Entries, entry)
+	} else if entry.OriginalPath != originalPath || entry.Settings != settings {
+		r.removeFile(entry)
+	}
+	entry.OriginalPath = originalPath
+	entry.Settings = settings
+	entry.NeedsSaveAs = needsSaveAs
+	entry.Saved = time.Now()
+	entry.CRC = crc
+	if err := os.MkdirAll(r.dir, 0o750); err != nil {
+		return id, errs.NewWithCause(r.dir, err)
+	}
+	if err := saver(r.FilePath(entry)); err != nil {
+		r.Remove(id)
+		return id, err
+	}
+	return id, r.save()
+}
+
+// Retain removes all entries, along with their autosaved copies, whose IDs aren't in the keep set.
+func (r *RecoveryIndex) Retain(keep map[string]bool) error {
+	entries := make([]*RecoveryEntry, 0, len(r.Entries))
+	for _, entry := range r.Entries {
+		if keep[entry.ID] {
+			entries = append(entries, entry)
+		} else {
+			r.removeFile(entry)
+		}
+	}
+	if len(entries) == len(r.Entries) {
+		return nil
+	}
+	r.Entries = entries
+	return r.save()
+}
+
+// Remove the entry with the given ID, along with its autosaved copy.
+func (r *RecoveryIndex) Remove(id string) {
+	if err := r.Retain(r.idsExcept(id)); err != nil {
+		jot.Error(err)
+	}
+}
+
+// Clear removes all entries, along with their autosaved copies.
+func (r *RecoveryIndex) Clear() error {
+	return r.Retain(nil)
+}
+
+func (r *RecoveryIndex) idsExcept(id string) map[string]bool {
+	m := make(map[string]bool, len(r.Entries))
+	for _, one := range r.Entries {
+		if one.ID != id {
+			m[one.ID] = true
+		}
+	}
+	return m
+}
+
+func (r *RecoveryIndex) removeFile(entry *RecoveryEntry) {
+	if err := os.Remove(r.FilePath(entry)); err != nil && !os.IsNotExist(err) {
+		jot.Error(err)
+	}
+}
+
+func (r *RecoveryIndex) save() error {
+	p := filepath.Join(r.dir, recoveryIndexName)
+	if len(r.Entries) == 0 {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return errs.NewWithCause(p, err)
+		}
+		return nil
+	}
+	return jio.SaveToFile(context.Background(), p, r)
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoveryIndex(t *testing.T) {
+	dir := t.TempDir()
+	writes := 0
+	saver := func(content string) func(filePath string) error {
+		return func(filePath string) error {
+			writes++
+			return os.WriteFile(filePath, []byte(content), 0o600)
+		}
+	}
+	r := NewRecoveryIndex(dir)
+	require.Empty(t, r.Entries)
+
+	id, err := r.Put("", "/docs/hero.gcs", false, 1, saver("one"))
+	require.NoError(t, err)
+	require.NotEmpty(t, id)
+	_, err = r.Put(id, "/docs/hero.gcs", false, 1, saver("one"))
+	require.NoError(t, err)
+	require.Equal(t, 1, writes)
+	_, err = r.Put(id, "/docs/hero.gcs", false, 2, saver("two"))
+	require.NoError(t, err)
+	require.Equal(t, 2, writes)
+
+	var other string
+	other, err = r.Put("", "Untitled.gcl", true, 3, saver("three"))
+	require.NoError(t, err)
+
+	reloaded := NewRecoveryIndex(dir)
+	require.Len(t, reloaded.Entries, 2)
+	entry := reloaded.Entry(id)
+	require.NotNil(t, entry)
+	require.Equal(t, "/docs/hero.gcs", entry.OriginalPath)
+	require.Equal(t, ".gcs", filepath.Ext(reloaded.FilePath(entry)))
+	data, err := os.ReadFile(reloaded.FilePath(entry))
+	require.NoError(t, err)
+	require.Equal(t, "two", string(data))
+	require.True(t, reloaded.Entry(other).NeedsSaveAs)
+
+	require.NoError(t, reloaded.Retain(map[string]bool{other: true}))
+	require.Nil(t, reloaded.Entry(id))
+	require.NoFileExists(t, filepath.Join(dir, id+".gcs"))
+	require.Len(t, NewRecoveryIndex(dir).Entries, 1)
+
+	var settings string
+	settings, err = reloaded.PutSettings("", "attributes", "/docs/hero.gcs", 4, saver("four"))
+	require.NoError(t, err)
+	_, err = reloaded.PutSettings(settings, "attributes", "/docs/hero.gcs", 4, saver("four"))
+	require.NoError(t, err)
+	require.Equal(t, 4, writes)
+	entry = NewRecoveryIndex(dir).Entry(settings)
+	require.NotNil(t, entry)
+	require.Equal(t, "attributes", entry.Settings)
+	require.Equal(t, "/docs/hero.gcs", entry.OriginalPath)
+	require.FileExists(t, filepath.Join(dir, settings+".attributes"))
+
+	require.NoError(t, reloaded.Clear())
+	require.Empty(t, NewRecoveryIndex(dir).Entries)
+	require.NoFileExists(t, filepath.Join(dir, recoveryIndexName))
+}
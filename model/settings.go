@@ -26,6 +26,7 @@ import (
 	"github.com/richardwilkes/toolbox/xio/fs"
 	"github.com/richardwilkes/toolbox/xio/fs/paths"
 	"github.com/richardwilkes/unison"
+	"golang.org/x/exp/slices"
 )
 
 const maxRecentFiles = 20
@@ -52,6 +53,7 @@ type Settings struct {
 	LibrarySet         Libraries             `json:"libraries,omitempty"`
 	LibraryExplorer    NavigatorSettings     `json:"library_explorer"`
 	RecentFiles        []string              `json:"recent_files,omitempty"`
+	PinnedFiles        []string              `json:"pinned_files,omitempty"`
 	LastDirs           map[string]string     `json:"last_dirs,omitempty"`
 	PageRefs           PageRefs              `json:"page_refs,omitempty"`
 	KeyBindings        KeyBindings           `json:"key_bindings,omitempty"`
@@ -60,7 +62,9 @@ type Settings struct {
 	Fonts              Fonts                 `json:"fonts"`
 	QuickExports       *QuickExports         `json:"quick_exports,omitempty"`
 	Sheet              *SheetSettings        `json:"sheet_settings,omitempty"`
+	BodyTemplates      BodyTemplates         `json:"body_templates,omitempty"`
 	ColorMode          unison.ColorMode      `json:"color_mode"`
+	LastPrereqTypeUsed PrereqType            `json:"last_prereq_type_used,omitempty"`
 }
 
 // DefaultSettings returns new default settings.
@@ -73,6 +77,7 @@ func DefaultSettings() *Settings {
 		LastDirs:           make(map[string]string),
 		QuickExports:       NewQuickExports(),
 		Sheet:              FactorySheetSettings(),
+		LastPrereqTypeUsed: TraitPrereqType,
 	}
 }
 
@@ -122,6 +127,10 @@ func (s *Settings) EnsureValidity() {
 	} else {
 		s.Sheet.EnsureValidity()
 	}
+	s.BodyTemplates.EnsureValidity()
+	if s.LastPrereqTypeUsed = s.LastPrereqTypeUsed.EnsureValid(); s.LastPrereqTypeUsed == ListPrereqType {
+		s.LastPrereqTypeUsed = TraitPrereqType
+	}
 }
 
 // LastDir returns the last directory used for the given key.
@@ -145,22 +154,55 @@ func (s *Settings) SetLastDir(key, dir string) {
 	}
 }
 
-// ListRecentFiles returns the current list of recently opened files. Files that are no longer readable for any reason
-// are omitted.
+// ListRecentFiles returns the current list of recently opened files, most recent first, excluding any that are pinned.
+// Files that are no longer readable for any reason are omitted.
 func (s *Settings) ListRecentFiles() []string {
+	s.RecentFiles = readableFiles(s.RecentFiles)
 	list := make([]string, 0, len(s.RecentFiles))
 	for _, one := range s.RecentFiles {
-		if fs.FileIsReadable(one) {
+		if !s.IsFilePinned(one) {
 			list = append(list, one)
 		}
 	}
-	if len(list) != len(s.RecentFiles) {
-		s.RecentFiles = make([]string, len(list))
-		copy(s.RecentFiles, list)
+	return list
+}
+
+// ListPinnedFiles returns the current list of pinned files, in the order they were pinned. Unlike the recent files,
+// pinned files that can't be read right now, such as those on a drive that is offline, are kept until unpinned.
+func (s *Settings) ListPinnedFiles() []string {
+	list := make([]string, len(s.PinnedFiles))
+	copy(list, s.PinnedFiles)
+	return list
+}
+
+func readableFiles(files []string) []string {
+	list := make([]string, 0, len(files))
+	for _, one := range files {
+		if fs.FileIsReadable(one) {
+			list = append(list, one)
+		}
 	}
 	return list
 }
 
+// IsFilePinned returns true if the file path has been pinned.
+func (s *Settings) IsFilePinned(filePath string) bool {
+	return slices.Contains(s.PinnedFiles, filePath)
+}
+
+// SetFilePinned pins or unpins a file path. Pinned files are listed separately from the recent files and never age out.
+// Unpinning a file returns it to the front of the recent files.
+func (s *Settings) SetFilePinned(filePath string, pinned bool) {
+	if i := slices.Index(s.PinnedFiles, filePath); i != -1 {
+		if !pinned {
+			s.PinnedFiles = slices.Delete(s.PinnedFiles, i, i+1)
+			s.AddRecentFile(filePath)
+		}
+	} else if pinned {
+		s.PinnedFiles = append(s.PinnedFiles, filePath)
+	}
+}
+
 // AddRecentFile adds a file path to the list of recently opened files.
 func (s *Settings) AddRecentFile(filePath string) {
 	ext := strings.ToLower(path.Ext(filePath))
@@ -182,15 +224,26 @@ func (s *Settings) AddRecentFile(filePath string) {
 				s.RecentFiles = append(s.RecentFiles, "")
 				copy(s.RecentFiles[1:], s.RecentFiles)
 				s.RecentFiles[0] = full
-				if len(s.RecentFiles) > maxRecentFiles {
-					s.RecentFiles = s.RecentFiles[:maxRecentFiles]
-				}
+				s.trimRecentFiles()
 			}
 			return
 		}
 	}
 }
 
+// trimRecentFiles drops the oldest recent files beyond the maximum. Pinned files don't count towards the limit.
+func (s *Settings) trimRecentFiles() {
+	count := 0
+	for i, one := range s.RecentFiles {
+		if !s.IsFilePinned(one) {
+			if count++; count > maxRecentFiles {
+				s.RecentFiles = s.RecentFiles[:i]
+				return
+			}
+		}
+	}
+}
+
 // GeneralSettings implements gurps.SettingsProvider.
 func (s *Settings) GeneralSettings() *GeneralSheetSettings {
 	return s.General
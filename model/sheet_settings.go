@@ -46,8 +46,11 @@ type SheetSettingsData struct {
 	ShowTraitModifierAdj          bool              `json:"show_trait_modifier_adj,alt=show_advantage_modifier_adj,omitempty"`
 	ShowEquipmentModifierAdj      bool              `json:"show_equipment_modifier_adj,omitempty"`
 	ShowSpellAdj                  bool              `json:"show_spell_adj,omitempty"`
+	ShowLevelDerivation           bool              `json:"show_level_derivation,omitempty"`
 	UseTitleInFooter              bool              `json:"use_title_in_footer,omitempty"`
 	ExcludeUnspentPointsFromTotal bool              `json:"exclude_unspent_points_from_total"`
+	AutoSortSkills                bool              `json:"auto_sort_skills,omitempty"`
+	AutoSortSpells                bool              `json:"auto_sort_spells,omitempty"`
 }
 
 // SheetSettings holds sheet settings.
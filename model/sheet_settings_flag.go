@@ -0,0 +1,102 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import "github.com/richardwilkes/toolbox/i18n"
+
+// SheetSettingsFlag describes one of the boolean flags available in the SheetSettings.
+type SheetSettingsFlag struct {
+	Key   string
+	Title string
+	value func(s *SheetSettings) bool
+}
+
+// SheetSettingsFlags returns the boolean flags available in the SheetSettings.
+func SheetSettingsFlags() []*SheetSettingsFlag {
+	return []*SheetSettingsFlag{
+		{
+			Key:   "use_multiplicative_modifiers",
+			Title: i18n.Text("Use Multiplicative Modifiers"),
+			value: func(s *SheetSettings) bool { return s.UseMultiplicativeModifiers },
+		},
+		{
+			Key:   "use_modifying_dice_plus_adds",
+			Title: i18n.Text("Use Modifying Dice + Adds"),
+			value: func(s *SheetSettings) bool { return s.UseModifyingDicePlusAdds },
+		},
+		{
+			Key:   "use_half_stat_defaults",
+			Title: i18n.Text("Use Half-Stat Defaults"),
+			value: func(s *SheetSettings) bool { return s.UseHalfStatDefaults },
+		},
+		{
+			Key:   "show_trait_modifier_adj",
+			Title: i18n.Text("Show trait modifier cost adjustments"),
+			value: func(s *SheetSettings) bool { return s.ShowTraitModifierAdj },
+		},
+		{
+			Key:   "show_equipment_modifier_adj",
+			Title: i18n.Text("Show equipment modifier cost & weight adjustments"),
+			value: func(s *SheetSettings) bool { return s.ShowEquipmentModifierAdj },
+		},
+		{
+			Key:   "show_spell_adj",
+			Title: i18n.Text("Show spell ritual, cost & time adjustments"),
+			value: func(s *SheetSettings) bool { return s.ShowSpellAdj },
+		},
+		{
+			Key:   "show_level_derivation",
+			Title: i18n.Text("Show how spell levels are derived"),
+			value: func(s *SheetSettings) bool { return s.ShowLevelDerivation },
+		},
+		{
+			Key:   "use_title_in_footer",
+			Title: i18n.Text("Show the title instead of the name in the footer"),
+			value: func(s *SheetSettings) bool { return s.UseTitleInFooter },
+		},
+		{
+			Key:   "exclude_unspent_points_from_total",
+			Title: i18n.Text("Exclude unspent points from total"),
+			value: func(s *SheetSettings) bool { return s.ExcludeUnspentPointsFromTotal },
+		},
+		{
+			Key:   "auto_sort_skills",
+			Title: i18n.Text("Keep skills sorted by name"),
+			value: func(s *SheetSettings) bool { return s.AutoSortSkills },
+		},
+		{
+			Key:   "auto_sort_spells",
+			Title: i18n.Text("Keep spells sorted by name"),
+			value: func(s *SheetSettings) bool { return s.AutoSortSpells },
+		},
+	}
+}
+
+// SheetSettingsFlagFor returns the SheetSettingsFlag with the given key, or nil.
+func SheetSettingsFlagFor(key string) *SheetSettingsFlag {
+	for _, one := range SheetSettingsFlags() {
+		if one.Key == key {
+			return one
+		}
+	}
+	return nil
+}
+
+// Enabled returns true if the flag is set in the SheetSettings.
+func (f *SheetSettingsFlag) Enabled(s *SheetSettings) bool {
+	return s != nil && f.value(s)
+}
+
+// String implements fmt.Stringer.
+func (f *SheetSettingsFlag) String() string {
+	return f.Title
+}
@@ -353,6 +353,21 @@ func (s *Skill) TagList() []string {
 	return s.Tags
 }
 
+// SetTagList sets the list of tags.
+func (s *Skill) SetTagList(tags []string) {
+	s.Tags = tags
+}
+
+// PageReference returns the page reference.
+func (s *Skill) PageReference() string {
+	return s.PageRef
+}
+
+// SetPageReference sets the page reference.
+func (s *Skill) SetPageReference(ref string) {
+	s.PageRef = ref
+}
+
 // Description implements WeaponOwner.
 func (s *Skill) Description() string {
 	return s.String()
@@ -674,7 +689,7 @@ func (s *Skill) bestDefault(excluded *SkillDefault) *SkillDefault {
 	best := fxp.Min
 	for _, def := range s.resolveToSpecificDefaults() {
 		// For skill-based defaults, prune out any that already use a default that we are involved with
-		if def.Equivalent(excluded) || s.inDefaultChain(def, make(map[*Skill]bool)) {
+		if def.Disabled || def.Equivalent(excluded) || s.inDefaultChain(def, make(map[*Skill]bool)) {
 			continue
 		}
 		if level := s.calcSkillDefaultLevel(def, excludes); best < level {
@@ -30,6 +30,7 @@ type SkillDefault struct {
 	Name           string  `json:"name,omitempty"`
 	Specialization string  `json:"specialization,omitempty"`
 	Modifier       fxp.Int `json:"modifier,omitempty"`
+	Disabled       bool    `json:"disabled,omitempty"`
 	Level          fxp.Int `json:"level,omitempty"`
 	AdjLevel       fxp.Int `json:"adjusted_level,omitempty"`
 	Points         fxp.Int `json:"points,omitempty"`
@@ -114,8 +115,17 @@ func (s *SkillDefault) SkillBased() bool {
 	return skillBasedDefaultTypes[strings.ToLower(strings.TrimSpace(s.DefaultType))]
 }
 
-// SkillLevel returns the base skill level for this SkillDefault.
+// ReferencesMissingSkill returns true if this is a skill-based default and the entity has no skill matching its name
+// and specialization. Always returns false if the entity is nil.
+func (s *SkillDefault) ReferencesMissingSkill(entity *Entity) bool {
+	return entity != nil && s.SkillBased() && entity.BaseSkill(s, false) == nil
+}
+
+// SkillLevel returns the base skill level for this SkillDefault. A disabled default always returns fxp.Min.
 func (s *SkillDefault) SkillLevel(entity *Entity, requirePoints bool, excludes map[string]bool, ruleOf20 bool) fxp.Int {
+	if s.Disabled {
+		return fxp.Min
+	}
 	switch s.Type() {
 	case ParryID:
 		best := s.best(entity, requirePoints, excludes)
@@ -149,8 +159,11 @@ func (s *SkillDefault) best(entity *Entity, requirePoints bool, excludes map[str
 	return best
 }
 
-// SkillLevelFast returns the base skill level for this SkillDefault.
+// SkillLevelFast returns the base skill level for this SkillDefault. A disabled default always returns fxp.Min.
 func (s *SkillDefault) SkillLevelFast(entity *Entity, requirePoints bool, excludes map[string]bool, ruleOf20 bool) fxp.Int {
+	if s.Disabled {
+		return fxp.Min
+	}
 	switch s.Type() {
 	case DodgeID:
 		level := entity.Dodge(entity.EncumbranceLevel(false))
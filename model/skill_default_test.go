@@ -0,0 +1,35 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkillDefaultReferencesMissingSkill(t *testing.T) {
+	entity := NewEntity(PC)
+	skill := NewSkill(entity, nil, false)
+	skill.Name = "Guns"
+	skill.Specialization = "Pistol"
+	entity.SetSkillList([]*Skill{skill})
+
+	require.False(t, (&SkillDefault{DefaultType: SkillID, Name: "guns", Specialization: "pistol"}).
+		ReferencesMissingSkill(entity))
+	require.False(t, (&SkillDefault{DefaultType: SkillID, Name: "Guns"}).ReferencesMissingSkill(entity))
+	require.True(t, (&SkillDefault{DefaultType: SkillID, Name: "Guns", Specialization: "Rifle"}).
+		ReferencesMissingSkill(entity))
+	require.True(t, (&SkillDefault{DefaultType: ParryID, Name: "Broadsword"}).ReferencesMissingSkill(entity))
+	require.False(t, (&SkillDefault{DefaultType: DexterityID}).ReferencesMissingSkill(entity))
+	require.False(t, (&SkillDefault{DefaultType: SkillID, Name: "Broadsword"}).ReferencesMissingSkill(nil))
+}
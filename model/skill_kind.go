@@ -0,0 +1,26 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import "strings"
+
+// Matches returns true if the skill is of this kind.
+func (enum SkillKind) Matches(s *Skill) bool {
+	switch enum.EnsureValid() {
+	case NonTechniqueSkillKind:
+		return !strings.HasPrefix(s.Type, TechniqueID)
+	case TechniqueSkillKind:
+		return strings.HasPrefix(s.Type, TechniqueID)
+	default:
+		return true
+	}
+}
@@ -26,6 +26,7 @@ type SkillPrereq struct {
 	NameCriteria           StringCriteria  `json:"name,omitempty"`
 	LevelCriteria          NumericCriteria `json:"level,omitempty"`
 	SpecializationCriteria StringCriteria  `json:"specialization,omitempty"`
+	Kind                   SkillKind       `json:"kind,omitempty"`
 }
 
 // NewSkillPrereq creates a new SkillPrereq.
@@ -88,7 +89,8 @@ func (s *SkillPrereq) Satisfied(entity *Entity, exclude any, tooltip *xio.ByteBu
 		techLevel = sk.TechLevel
 	}
 	Traverse(func(sk *Skill) bool {
-		if exclude == sk || !s.NameCriteria.Matches(sk.Name) || !s.SpecializationCriteria.Matches(sk.Specialization) {
+		if exclude == sk || !s.Kind.Matches(sk) || !s.NameCriteria.Matches(sk.Name) ||
+			!s.SpecializationCriteria.Matches(sk.Specialization) {
 			return false
 		}
 		satisfied = s.LevelCriteria.Matches(sk.LevelData.Level)
@@ -103,7 +105,9 @@ func (s *SkillPrereq) Satisfied(entity *Entity, exclude any, tooltip *xio.ByteBu
 	if !satisfied && tooltip != nil {
 		tooltip.WriteString(prefix)
 		tooltip.WriteString(HasText(s.Has))
-		tooltip.WriteString(i18n.Text(" a skill whose name "))
+		tooltip.WriteByte(' ')
+		tooltip.WriteString(s.Kind.EnsureValid().String())
+		tooltip.WriteString(i18n.Text(" whose name "))
 		tooltip.WriteString(s.NameCriteria.String())
 		if s.SpecializationCriteria.Compare != AnyString {
 			tooltip.WriteString(i18n.Text(", specialization "))
@@ -124,3 +128,29 @@ func (s *SkillPrereq) Satisfied(entity *Entity, exclude any, tooltip *xio.ByteBu
 	}
 	return satisfied
 }
+
+// Description implements Prereq.
+func (s *SkillPrereq) Description(_ *Entity) string {
+	var label string
+	switch s.Kind.EnsureValid() {
+	case NonTechniqueSkillKind:
+		label = i18n.Text("Skill (not technique): ")
+	case TechniqueSkillKind:
+		label = i18n.Text("Technique: ")
+	default:
+		label = i18n.Text("Skill: ")
+	}
+	label += s.NameCriteria.CompactString()
+	if s.SpecializationCriteria.Compare.EnsureValid() != AnyString {
+		label += " (" + s.SpecializationCriteria.CompactString() + ")"
+	}
+	return compactPrereq(s.Has, label, s.LevelCriteria.CompactString())
+}
+
+// Equal implements Prereq.
+func (s *SkillPrereq) Equal(other Prereq) bool {
+	o, ok := other.(*SkillPrereq)
+	return ok && s.Type == o.Type && s.Has == o.Has && s.NameCriteria == o.NameCriteria &&
+		s.LevelCriteria == o.LevelCriteria && s.SpecializationCriteria == o.SpecializationCriteria &&
+		s.Kind.EnsureValid() == o.Kind.EnsureValid()
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"github.com/richardwilkes/toolbox/txt"
+	"golang.org/x/exp/slices"
+)
+
+// SortNodesByName sorts the nodes, along with the children of any containers, using a natural ordering of their names.
+// Nodes with the same name retain their relative order. Headings stay where they are and mark the boundaries of
+// sections, with nodes only sorted within their own section. Returns true if the order of anything changed.
+func SortNodesByName[T NodeTypes](nodes []T) bool {
+	changed := false
+	less := func(a, b T) bool { return txt.NaturalLess(AsNode(a).String(), AsNode(b).String(), true) }
+	start := 0
+	for i := 0; i <= len(nodes); i++ {
+		if i == len(nodes) || isSectionHeading(nodes[i]) {
+			if section := nodes[start:i]; !slices.IsSortedFunc(section, less) {
+				slices.SortStableFunc(section, less)
+				changed = true
+			}
+			start = i + 1
+		}
+	}
+	for _, one := range nodes {
+		if node := AsNode(one); node.Container() && SortNodesByName(node.NodeChildren()) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+func isSectionHeading[T NodeTypes](node T) bool {
+	heading, ok := any(node).(interface{ Heading() bool })
+	return ok && heading.Heading()
+}
+
+// ApplyAutoSort sorts the entity's skills and spells by name, if its sheet settings ask for that. Returns true if the
+// order of anything changed.
+func (e *Entity) ApplyAutoSort() bool {
+	changed := false
+	if e.SheetSettings.AutoSortSkills && SortNodesByName(e.Skills) {
+		changed = true
+	}
+	if e.SheetSettings.AutoSortSpells && SortNodesByName(e.Spells) {
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortNodesByName(t *testing.T) {
+	e := NewEntity(PC)
+	newSkill := func(parent *Skill, name, specialization string) *Skill {
+		s := NewSkill(e, parent, false)
+		s.Name = name
+		s.Specialization = specialization
+		return s
+	}
+	container := NewSkill(e, nil, true)
+	container.Name = "Combat"
+	container.Children = []*Skill{
+		newSkill(container, "Sword", ""),
+		newSkill(container, "Axe/Mace", ""),
+	}
+	e.Skills = []*Skill{
+		newSkill(nil, "Guns", "Rifle"),
+		container,
+		newSkill(nil, "Area Knowledge 10", ""),
+		newSkill(nil, "Area Knowledge 2", ""),
+		newSkill(nil, "Guns", "Pistol"),
+	}
+	names := func(list []*Skill) []string {
+		result := make([]string, 0, len(list))
+		for _, one := range list {
+			result = append(result, one.String())
+		}
+		return result
+	}
+
+	require.False(t, e.ApplyAutoSort(), "auto-sort is off by default")
+	require.Equal(t, "Guns (Rifle)", e.Skills[0].String())
+
+	e.SheetSettings.AutoSortSkills = true
+	require.True(t, e.ApplyAutoSort())
+	require.Equal(t, []string{"Area Knowledge 2", "Area Knowledge 10", "Combat", "Guns (Pistol)", "Guns (Rifle)"},
+		names(e.Skills))
+	require.Equal(t, []string{"Axe/Mace", "Sword"}, names(container.Children))
+	require.False(t, e.ApplyAutoSort())
+
+	spells := []*Spell{NewSpell(e, nil, false), NewSpell(e, nil, false)}
+	spells[0].Name = "Fireball"
+	spells[1].Name = "Apportation"
+	e.Spells = spells
+	require.False(t, e.ApplyAutoSort())
+	e.SheetSettings.AutoSortSpells = true
+	require.True(t, e.ApplyAutoSort())
+	require.Equal(t, "Apportation", e.Spells[0].Name)
+
+	newSpell := func(name string) *Spell {
+		s := NewSpell(e, nil, false)
+		s.Name = name
+		return s
+	}
+	heading := NewSpellHeading(e, nil, false)
+	heading.Name = "Fire"
+	e.Spells = []*Spell{newSpell("Light"), newSpell("Darkness"), heading, newSpell("Ignite Fire"), newSpell("Create Fire")}
+	require.True(t, e.ApplyAutoSort())
+	spellNames := make([]string, 0, len(e.Spells))
+	for _, one := range e.Spells {
+		spellNames = append(spellNames, one.Name)
+	}
+	require.Equal(t, []string{"Darkness", "Light", "Fire", "Create Fire", "Ignite Fire"}, spellNames,
+		"headings should stay in place and only the spells within each section should be sorted")
+}
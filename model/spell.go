@@ -34,6 +34,9 @@ var (
 	_ TemplatePickerProvider          = &Spell{}
 )
 
+// costOverrideMarker is appended to the casting cost when it has been overridden.
+const costOverrideMarker = "*"
+
 // Columns that can be used with the spell method .CellData()
 const (
 	SpellDescriptionColumn = iota
@@ -51,6 +54,8 @@ const (
 	SpellRelativeLevelColumn
 	SpellPointsColumn
 	SpellDescriptionForPageColumn
+	SpellPreparedColumn
+	SpellMaintainRateColumn
 )
 
 const spellListTypeKey = "spell_list"
@@ -61,6 +66,7 @@ type Spell struct {
 	Entity            *Entity
 	LevelData         Level
 	UnsatisfiedReason string
+	libraryModified   bool
 }
 
 type spellListData struct {
@@ -108,6 +114,11 @@ func NewRitualMagicSpell(entity *Entity, parent *Spell, _ bool) *Spell {
 	return s
 }
 
+// NewSpellHeading creates a new heading for organizing a spell list.
+func NewSpellHeading(entity *Entity, parent *Spell, _ bool) *Spell {
+	return newSpell(entity, parent, SpellHeadingID, false)
+}
+
 func newSpell(entity *Entity, parent *Spell, typeKey string, container bool) *Spell {
 	s := Spell{
 		SpellData: SpellData{
@@ -116,9 +127,12 @@ func newSpell(entity *Entity, parent *Spell, typeKey string, container bool) *Sp
 		Entity: entity,
 	}
 	s.parent = parent
-	if container {
+	switch {
+	case container:
 		s.TemplatePicker = &TemplatePicker{}
-	} else {
+	case typeKey == SpellHeadingID:
+		s.Difficulty.omit = true
+	default:
 		s.Difficulty.Attribute = AttributeIDFor(entity, "iq")
 		s.Difficulty.Difficulty = Hard
 		s.PowerSource = i18n.Text("Arcane")
@@ -135,9 +149,12 @@ func newSpell(entity *Entity, parent *Spell, typeKey string, container bool) *Sp
 // Clone implements Node.
 func (s *Spell) Clone(entity *Entity, parent *Spell, preserveID bool) *Spell {
 	var other *Spell
-	if s.Type == RitualMagicSpellID {
+	switch {
+	case s.Type == RitualMagicSpellID:
 		other = NewRitualMagicSpell(entity, parent, false)
-	} else {
+	case s.Heading():
+		other = NewSpellHeading(entity, parent, false)
+	default:
 		other = NewSpell(entity, parent, s.Container())
 		other.IsOpen = s.IsOpen
 	}
@@ -145,6 +162,7 @@ func (s *Spell) Clone(entity *Entity, parent *Spell, preserveID bool) *Spell {
 		other.ID = s.ID
 	}
 	other.SpellEditData.CopyFrom(s)
+	other.Source = s.Source
 	if s.HasChildren() {
 		other.Children = make([]*Spell, 0, len(s.Children))
 		for _, child := range s.Children {
@@ -205,6 +223,15 @@ func (s *Spell) TemplatePickerData() *TemplatePicker {
 
 // CellData returns the cell data information for the given column.
 func (s *Spell) CellData(columnID int, data *CellData) {
+	if s.Heading() {
+		if columnID == SpellDescriptionColumn || columnID == SpellDescriptionForPageColumn {
+			data.Type = TextCellType
+			data.Primary = s.Name
+			data.Tooltip = s.LocalNotes
+			data.Heading = true
+		}
+		return
+	}
 	switch columnID {
 	case SpellDescriptionColumn:
 		data.Type = TextCellType
@@ -213,6 +240,7 @@ func (s *Spell) CellData(columnID int, data *CellData) {
 		data.UnsatisfiedReason = s.UnsatisfiedReason
 		data.Tooltip = s.SecondaryText(func(option DisplayOption) bool { return option.Tooltip() })
 		data.TemplateInfo = s.TemplatePicker.Description()
+		data.LibraryInfo = libraryInfo(s)
 	case SpellResistColumn:
 		if !s.Container() {
 			data.Type = TextCellType
@@ -231,12 +259,28 @@ func (s *Spell) CellData(columnID int, data *CellData) {
 	case SpellCastCostColumn:
 		if !s.Container() {
 			data.Type = TextCellType
-			data.Primary = s.CastingCost
+			data.Primary = s.EffectiveCastingCost()
+			data.SortKey = leadingNumberSortKey(data.Primary)
+			if s.HasCostOverride() {
+				data.Primary += costOverrideMarker
+				data.Tooltip = fmt.Sprintf(i18n.Text("Overrides the casting cost of: %s"), s.CastingCost)
+			}
 		}
 	case SpellMaintainCostColumn:
 		if !s.Container() {
 			data.Type = TextCellType
 			data.Primary = s.MaintenanceCost
+			data.SortKey = leadingNumberSortKey(data.Primary)
+		}
+	case SpellMaintainRateColumn:
+		if !s.Container() {
+			data.Type = TextCellType
+			if rate, ok := s.MaintenanceRate(); ok {
+				data.Primary = rate.PerTurn.String()
+				data.Tooltip = rate.String()
+			} else {
+				data.Primary = s.MaintenanceCost
+			}
 		}
 	case SpellCastTimeColumn:
 		if !s.Container() {
@@ -247,6 +291,7 @@ func (s *Spell) CellData(columnID int, data *CellData) {
 		if !s.Container() {
 			data.Type = TextCellType
 			data.Primary = s.Duration
+			data.SortKey = s.ParsedDuration().SortKey()
 		}
 	case SpellDifficultyColumn:
 		if !s.Container() {
@@ -265,6 +310,7 @@ func (s *Spell) CellData(columnID int, data *CellData) {
 			data.Type = TextCellType
 			level := s.CalculateLevel()
 			data.Primary = level.LevelAsString(s.Container())
+			data.SortKey = level.LevelSortKey()
 			if level.Tooltip != "" {
 				data.Tooltip = includesModifiersFrom() + ":" + level.Tooltip
 			}
@@ -274,6 +320,7 @@ func (s *Spell) CellData(columnID int, data *CellData) {
 		if !s.Container() {
 			data.Type = TextCellType
 			rsl := s.AdjustedRelativeLevel()
+			data.SortKey = rsl.String()
 			if rsl == fxp.Min {
 				data.Primary = "-"
 			} else {
@@ -285,6 +332,11 @@ func (s *Spell) CellData(columnID int, data *CellData) {
 			if tooltip := s.CalculateLevel().Tooltip; tooltip != "" {
 				data.Tooltip = includesModifiersFrom() + ":" + tooltip
 			}
+			if s.Entity != nil && s.Entity.SheetSettings.ShowLevelDerivation {
+				if breakdown := s.LevelBreakdown(); breakdown != nil {
+					data.InfoPop = breakdown.String()
+				}
+			}
 		}
 	case SpellPointsColumn:
 		data.Type = TextCellType
@@ -294,13 +346,19 @@ func (s *Spell) CellData(columnID int, data *CellData) {
 		if tooltip.Len() != 0 {
 			data.Tooltip = includesModifiersFrom() + ":" + tooltip.String()
 		}
+	case SpellPreparedColumn:
+		if !s.Container() {
+			data.Type = ToggleCellType
+			data.Checked = s.Prepared
+			data.Alignment = unison.MiddleAlignment
+		}
 	case SpellDescriptionForPageColumn:
 		s.CellData(SpellDescriptionColumn, data)
 		if !s.Container() {
 			var buffer strings.Builder
 			addPartToBuffer(&buffer, i18n.Text("Resistance"), s.Resist)
 			addPartToBuffer(&buffer, i18n.Text("Class"), s.Class)
-			addPartToBuffer(&buffer, i18n.Text("Cost"), s.CastingCost)
+			addPartToBuffer(&buffer, i18n.Text("Cost"), s.EffectiveCastingCost())
 			addPartToBuffer(&buffer, i18n.Text("Maintain"), s.MaintenanceCost)
 			addPartToBuffer(&buffer, i18n.Text("Time"), s.CastingTime)
 			addPartToBuffer(&buffer, i18n.Text("Duration"), s.Duration)
@@ -368,18 +426,44 @@ func (s *Spell) AdjustedRelativeLevel() fxp.Int {
 // UpdateLevel updates the level of the spell, returning true if it has changed.
 func (s *Spell) UpdateLevel() bool {
 	saved := s.LevelData
-	if strings.HasPrefix(s.Type, SpellID) {
+	switch {
+	case s.Heading():
+		s.LevelData = Level{}
+	case strings.HasPrefix(s.Type, SpellID):
 		s.LevelData = CalculateSpellLevel(s.Entity, s.Name, s.PowerSource, s.College, s.Tags, s.Difficulty,
 			s.AdjustedPoints(nil))
-	} else {
+	default:
 		s.LevelData = CalculateRitualMagicSpellLevel(s.Entity, s.Name, s.PowerSource, s.RitualSkillName,
 			s.RitualPrereqCount, s.College, s.Tags, s.Difficulty, s.AdjustedPoints(nil))
 	}
 	return saved != s.LevelData
 }
 
+// LevelBreakdown returns the steps used to derive the spell's level, or nil if the spell currently has no level.
+func (s *Spell) LevelBreakdown() *LevelBreakdown {
+	if s.Container() || s.Heading() || s.Entity == nil {
+		return nil
+	}
+	breakdown := &LevelBreakdown{}
+	var level Level
+	if strings.HasPrefix(s.Type, SpellID) {
+		level = calculateSpellLevel(s.Entity, s.Name, s.PowerSource, s.College, s.Tags, s.Difficulty,
+			s.AdjustedPoints(nil), breakdown)
+	} else {
+		level = calculateRitualMagicSpellLevel(s.Entity, s.Name, s.PowerSource, s.RitualSkillName,
+			s.RitualPrereqCount, s.College, s.Tags, s.Difficulty, s.AdjustedPoints(nil), breakdown)
+	}
+	if level.Level <= 0 || len(breakdown.Steps) == 0 {
+		return nil
+	}
+	return breakdown
+}
+
 // CalculateLevel returns the computed level without updating it.
 func (s *Spell) CalculateLevel() Level {
+	if s.Heading() {
+		return Level{}
+	}
 	if strings.HasPrefix(s.Type, SpellID) {
 		return CalculateSpellLevel(s.Entity, s.Name, s.PowerSource, s.College, s.Tags, s.Difficulty,
 			s.AdjustedPoints(nil))
@@ -390,7 +474,7 @@ func (s *Spell) CalculateLevel() Level {
 
 // IncrementSkillLevel adds enough points to increment the skill level to the next level.
 func (s *Spell) IncrementSkillLevel() {
-	if !s.Container() {
+	if !s.Container() && !s.Heading() {
 		basePoints := s.Points.Trunc() + fxp.One
 		maxPoints := basePoints
 		if s.Difficulty.Difficulty == Wildcard {
@@ -410,7 +494,7 @@ func (s *Spell) IncrementSkillLevel() {
 
 // DecrementSkillLevel removes enough points to decrement the skill level to the previous level.
 func (s *Spell) DecrementSkillLevel() {
-	if !s.Container() && s.Points > 0 {
+	if !s.Container() && !s.Heading() && s.Points > 0 {
 		basePoints := s.Points.Trunc()
 		minPoints := basePoints
 		if s.Difficulty.Difficulty == Wildcard {
@@ -441,6 +525,10 @@ func (s *Spell) DecrementSkillLevel() {
 
 // CalculateSpellLevel returns the calculated spell level.
 func CalculateSpellLevel(entity *Entity, name, powerSource string, colleges, tags []string, difficulty AttributeDifficulty, pts fxp.Int) Level {
+	return calculateSpellLevel(entity, name, powerSource, colleges, tags, difficulty, pts, nil)
+}
+
+func calculateSpellLevel(entity *Entity, name, powerSource string, colleges, tags []string, difficulty AttributeDifficulty, pts fxp.Int, breakdown *LevelBreakdown) Level {
 	var tooltip xio.ByteBuffer
 	relativeLevel := difficulty.Difficulty.BaseRelativeLevel()
 	level := fxp.Min
@@ -462,8 +550,18 @@ func CalculateSpellLevel(entity *Entity, name, powerSource string, colleges, tag
 			relativeLevel += fxp.One + pts.Div(fxp.Four).Trunc()
 		}
 		if level != fxp.Min {
+			breakdown.add(ResolveAttributeName(entity, difficulty.Attribute), level)
+			base := difficulty.Difficulty.BaseRelativeLevel()
+			breakdown.add(fmt.Sprintf(i18n.Text("Difficulty (%s)"), difficulty.Difficulty.String()), base)
+			if relativeLevel != base {
+				breakdown.add(i18n.Text("Points"), relativeLevel-base)
+			}
+			before := relativeLevel
 			relativeLevel += entity.SpellBonusFor(name, powerSource, colleges, tags, &tooltip)
 			relativeLevel = relativeLevel.Trunc()
+			if relativeLevel != before {
+				breakdown.add(i18n.Text("Bonuses"), relativeLevel-before)
+			}
 			level += relativeLevel
 		}
 	}
@@ -476,6 +574,11 @@ func CalculateSpellLevel(entity *Entity, name, powerSource string, colleges, tag
 
 // CalculateRitualMagicSpellLevel returns the calculated spell level.
 func CalculateRitualMagicSpellLevel(entity *Entity, name, powerSource, ritualSkillName string, ritualPrereqCount int, colleges, tags []string, difficulty AttributeDifficulty, points fxp.Int) Level {
+	return calculateRitualMagicSpellLevel(entity, name, powerSource, ritualSkillName, ritualPrereqCount, colleges, tags,
+		difficulty, points, nil)
+}
+
+func calculateRitualMagicSpellLevel(entity *Entity, name, powerSource, ritualSkillName string, ritualPrereqCount int, colleges, tags []string, difficulty AttributeDifficulty, points fxp.Int, breakdown *LevelBreakdown) Level {
 	var skillLevel Level
 	if len(colleges) == 0 {
 		skillLevel = determineRitualMagicSkillLevelForCollege(entity, name, "", ritualSkillName, ritualPrereqCount,
@@ -490,9 +593,15 @@ func CalculateRitualMagicSpellLevel(entity *Entity, name, powerSource, ritualSki
 		}
 	}
 	if entity != nil {
+		if skillLevel.Level != fxp.Min {
+			breakdown.add(fmt.Sprintf(i18n.Text("Based on %s"), ritualSkillName), skillLevel.Level)
+		}
 		tooltip := &xio.ByteBuffer{}
 		tooltip.WriteString(skillLevel.Tooltip)
 		levels := entity.SpellBonusFor(name, powerSource, colleges, tags, tooltip).Trunc()
+		if levels != 0 && skillLevel.Level != fxp.Min {
+			breakdown.add(i18n.Text("Bonuses"), levels)
+		}
 		skillLevel.Level += levels
 		skillLevel.RelativeLevel += levels
 		skillLevel.Tooltip = tooltip.String()
@@ -586,6 +695,19 @@ func (s *Spell) Notes() string {
 	return s.LocalNotes
 }
 
+// HasCostOverride returns true if the casting cost has been overridden.
+func (s *Spell) HasCostOverride() bool {
+	return !s.Container() && strings.TrimSpace(s.CostOverride) != ""
+}
+
+// EffectiveCastingCost returns the casting cost override, if one has been set, or the casting cost otherwise.
+func (s *Spell) EffectiveCastingCost() string {
+	if s.HasCostOverride() {
+		return s.CostOverride
+	}
+	return s.CastingCost
+}
+
 // Rituals returns the rituals required to cast the spell.
 func (s *Spell) Rituals() string {
 	if s.Container() || !(s.Entity != nil && s.Entity.Type == PC && s.Entity.SheetSettings.ShowSpellAdj) {
@@ -628,6 +750,21 @@ func (s *Spell) TagList() []string {
 	return s.Tags
 }
 
+// SetTagList sets the list of tags.
+func (s *Spell) SetTagList(tags []string) {
+	s.Tags = tags
+}
+
+// PageReference returns the page reference.
+func (s *Spell) PageReference() string {
+	return s.PageRef
+}
+
+// SetPageReference sets the page reference.
+func (s *Spell) SetPageReference(ref string) {
+	s.PageRef = ref
+}
+
 // Description implements WeaponOwner.
 func (s *Spell) Description() string {
 	return s.String()
@@ -692,12 +829,18 @@ func (s *Spell) RawPoints() fxp.Int {
 
 // SetRawPoints sets the unadjusted points and updates the level. Returns true if the level changed.
 func (s *Spell) SetRawPoints(points fxp.Int) bool {
+	if s.Heading() {
+		return false
+	}
 	s.Points = points
 	return s.UpdateLevel()
 }
 
 // AdjustedPoints returns the points, adjusted for any bonuses.
 func (s *Spell) AdjustedPoints(tooltip *xio.ByteBuffer) fxp.Int {
+	if s.Heading() {
+		return 0
+	}
 	if s.Container() {
 		var total fxp.Int
 		for _, one := range s.Children {
@@ -750,6 +893,7 @@ func (s *Spell) FillWithNameableKeys(m map[string]string) {
 	Extract(s.Class, m)
 	Extract(s.Resist, m)
 	Extract(s.CastingCost, m)
+	Extract(s.CostOverride, m)
 	Extract(s.MaintenanceCost, m)
 	Extract(s.CastingTime, m)
 	Extract(s.Duration, m)
@@ -773,6 +917,7 @@ func (s *Spell) ApplyNameableKeys(m map[string]string) {
 	s.Class = Apply(s.Class, m)
 	s.Resist = Apply(s.Resist, m)
 	s.CastingCost = Apply(s.CastingCost, m)
+	s.CostOverride = Apply(s.CostOverride, m)
 	s.MaintenanceCost = Apply(s.MaintenanceCost, m)
 	s.CastingTime = Apply(s.CastingTime, m)
 	s.Duration = Apply(s.Duration, m)
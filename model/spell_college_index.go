@@ -0,0 +1,92 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/txt"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// SpellCollegeGroup holds the spells that belong to a college. Spells without a college are grouped under an empty
+// College.
+type SpellCollegeGroup struct {
+	College string
+	Spells  []*Spell
+}
+
+// SpellsByCollege groups the spells found within the list, including those inside containers, by college. A spell in
+// multiple colleges appears in each of them, while headings are ignored. Colleges are sorted by name, with the group for
+// spells lacking a college last, and the spells within each group are sorted by name.
+func SpellsByCollege(spells []*Spell) []*SpellCollegeGroup {
+	m := make(map[string]*SpellCollegeGroup)
+	seen := make(map[string]bool)
+	add := func(college string, spell *Spell) {
+		key := strings.ToLower(college)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		group, exists := m[key]
+		if !exists {
+			group = &SpellCollegeGroup{College: college}
+			m[key] = group
+		}
+		group.Spells = append(group.Spells, spell)
+	}
+	Traverse(func(spell *Spell) bool {
+		if spell.Heading() {
+			return false
+		}
+		maps.Clear(seen)
+		for _, college := range spell.College {
+			if college = strings.TrimSpace(college); college != "" {
+				add(college, spell)
+			}
+		}
+		if len(seen) == 0 {
+			add("", spell)
+		}
+		return false
+	}, false, true, spells...)
+	groups := make([]*SpellCollegeGroup, 0, len(m))
+	for _, group := range m {
+		slices.SortFunc(group.Spells, func(a, b *Spell) bool { return txt.NaturalLess(a.String(), b.String(), true) })
+		groups = append(groups, group)
+	}
+	slices.SortFunc(groups, func(a, b *SpellCollegeGroup) bool {
+		if a.College == "" || b.College == "" {
+			return b.College == "" && a.College != ""
+		}
+		return txt.NaturalLess(a.College, b.College, true)
+	})
+	return groups
+}
+
+// SpellCollegeSummary returns a compact, single-line summary of the number of spells in each college, such as
+// "Air (2), Fire (1)". Returns an empty string if there are no spells.
+func SpellCollegeSummary(spells []*Spell) string {
+	groups := SpellsByCollege(spells)
+	parts := make([]string, 0, len(groups))
+	for _, group := range groups {
+		college := group.College
+		if college == "" {
+			college = i18n.Text("No College")
+		}
+		parts = append(parts, fmt.Sprintf(i18n.Text("%s (%d)"), college, len(group.Spells)))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpellsByCollege(t *testing.T) {
+	newCollegeSpell := func(parent *Spell, name string, colleges ...string) *Spell {
+		s := NewSpell(nil, parent, false)
+		s.Name = name
+		s.College = colleges
+		return s
+	}
+	container := NewSpell(nil, nil, true)
+	container.Name = "Favorites"
+	container.College = []string{"Ignored"}
+	fireball := newCollegeSpell(container, "Fireball", "Fire")
+	container.Children = []*Spell{fireball}
+	spells := []*Spell{
+		container,
+		newCollegeSpell(nil, "Ignite Fire", "Fire"),
+		newCollegeSpell(nil, "Steam Jet", "Water", "fire"),
+		newCollegeSpell(nil, "Mystery"),
+		newCollegeSpell(nil, "Create Water", "Water", " water"),
+	}
+	groups := SpellsByCollege(spells)
+	require.Len(t, groups, 3)
+	require.Equal(t, "Fire", groups[0].College)
+	require.Len(t, groups[0].Spells, 3)
+	require.Same(t, fireball, groups[0].Spells[0])
+	require.Equal(t, "Steam Jet", groups[0].Spells[2].Name)
+	require.Equal(t, "Water", groups[1].College)
+	require.Equal(t, "Create Water", groups[1].Spells[0].Name)
+	require.Empty(t, groups[2].College)
+	require.Equal(t, "Mystery", groups[2].Spells[0].Name)
+
+	require.Equal(t, "Fire (3), Water (2), No College (1)", SpellCollegeSummary(spells))
+	require.Empty(t, SpellCollegeSummary(nil))
+}
@@ -19,17 +19,27 @@ import (
 type SpellData struct {
 	ContainerBase[*Spell]
 	SpellEditData
+	Source *LibrarySource `json:"source,omitempty"`
 }
 
 // Kind returns the kind of data.
 func (d *SpellData) Kind() string {
+	if d.Heading() {
+		return i18n.Text("Spell Heading")
+	}
 	return d.kind(i18n.Text("Spell"))
 }
 
+// Heading returns true if this is a heading, which only serves to visually organize the list and takes no part in any
+// calculations.
+func (d *SpellData) Heading() bool {
+	return d.Type == SpellHeadingID
+}
+
 // ClearUnusedFieldsForType zeroes out the fields that are not applicable to this type (container vs not-container).
 func (d *SpellData) ClearUnusedFieldsForType() {
 	d.clearUnusedFields()
-	if d.Container() {
+	if d.Container() || d.Heading() {
 		d.TechLevel = nil
 		d.Difficulty = AttributeDifficulty{omit: true}
 		d.College = nil
@@ -37,6 +47,7 @@ func (d *SpellData) ClearUnusedFieldsForType() {
 		d.Class = ""
 		d.Resist = ""
 		d.CastingCost = ""
+		d.CostOverride = ""
 		d.MaintenanceCost = ""
 		d.CastingTime = ""
 		d.Duration = ""
@@ -45,7 +56,12 @@ func (d *SpellData) ClearUnusedFieldsForType() {
 		d.Points = 0
 		d.Prereq = nil
 		d.Weapons = nil
-		if d.TemplatePicker == nil {
+		d.Prepared = false
+		switch {
+		case d.Heading():
+			d.TemplatePicker = nil
+			d.Study = nil
+		case d.TemplatePicker == nil:
 			d.TemplatePicker = &TemplatePicker{}
 		}
 	} else {
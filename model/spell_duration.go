@@ -0,0 +1,81 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+)
+
+// Possible SpellDurationKind values.
+const (
+	UnrecognizedSpellDuration SpellDurationKind = iota
+	InstantSpellDuration
+	TimedSpellDuration
+	PermanentSpellDuration
+)
+
+// SpellDurationKind identifies the type of a SpellDuration.
+type SpellDurationKind byte
+
+// SpellDuration holds a spell's duration in structured form, along with the text it was parsed from.
+type SpellDuration struct {
+	Kind SpellDurationKind
+	// Seconds holds the length of the duration. Only set for TimedSpellDuration.
+	Seconds fxp.Int
+	Text    string
+}
+
+// ParseSpellDuration interprets a duration such as "1 minute", "10 sec", "Instant" or "Permanent". Text that can't be
+// interpreted, such as "Varies", results in an UnrecognizedSpellDuration that only holds the text.
+func ParseSpellDuration(text string) SpellDuration {
+	d := SpellDuration{Text: text}
+	trimmed := strings.ToLower(strings.TrimSpace(text))
+	var word string
+	if fields := strings.Fields(trimmed); len(fields) != 0 {
+		word = strings.TrimRight(fields[0], ".*,;")
+	}
+	switch word {
+	case "":
+	case "instant", "instantaneous":
+		d.Kind = InstantSpellDuration
+	case "permanent", "perm":
+		d.Kind = PermanentSpellDuration
+	default:
+		if seconds, ok := parseSpellPeriod(trimmed); ok && seconds > 0 {
+			d.Kind = TimedSpellDuration
+			d.Seconds = seconds
+		}
+	}
+	return d
+}
+
+// SortKey returns a value that orders durations from shortest to longest when compared numerically: instant, then
+// timed durations, then permanent. Unrecognized durations return their text, which sorts after all of the others.
+func (d SpellDuration) SortKey() string {
+	switch d.Kind {
+	case InstantSpellDuration:
+		return "0"
+	case TimedSpellDuration:
+		return d.Seconds.String()
+	case PermanentSpellDuration:
+		return fxp.Max.String()
+	default:
+		return d.Text
+	}
+}
+
+// ParsedDuration returns the spell's duration in structured form.
+func (s *Spell) ParsedDuration() SpellDuration {
+	return ParseSpellDuration(s.Duration)
+}
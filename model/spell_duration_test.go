@@ -0,0 +1,54 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpellDuration(t *testing.T) {
+	for text, expected := range map[string]SpellDuration{
+		"1 minute":   {Kind: TimedSpellDuration, Seconds: fxp.From(60)},
+		"10 seconds": {Kind: TimedSpellDuration, Seconds: fxp.Ten},
+		"10 sec.":    {Kind: TimedSpellDuration, Seconds: fxp.Ten},
+		"1 hr":       {Kind: TimedSpellDuration, Seconds: fxp.From(3600)},
+		"1 day":      {Kind: TimedSpellDuration, Seconds: fxp.From(86400)},
+		"Instant":    {Kind: InstantSpellDuration},
+		"Permanent":  {Kind: PermanentSpellDuration},
+		"Perm.":      {Kind: PermanentSpellDuration},
+		"Varies":     {Kind: UnrecognizedSpellDuration},
+		"":           {Kind: UnrecognizedSpellDuration},
+	} {
+		expected.Text = text
+		require.Equal(t, expected, ParseSpellDuration(text), text)
+	}
+
+	s := NewSpell(nil, nil, false)
+	s.Duration = "1 min"
+	require.Equal(t, TimedSpellDuration, s.ParsedDuration().Kind)
+	require.Equal(t, "1 min", s.ParsedDuration().Text)
+}
+
+func TestSpellDurationSortKey(t *testing.T) {
+	order := []string{"Instant", "10 sec", "1 min", "1 hr", "Permanent"}
+	for i := 1; i < len(order); i++ {
+		prev, err := fxp.FromString(ParseSpellDuration(order[i-1]).SortKey())
+		require.NoError(t, err)
+		next, err := fxp.FromString(ParseSpellDuration(order[i]).SortKey())
+		require.NoError(t, err)
+		require.Less(t, prev, next, "%s should sort before %s", order[i-1], order[i])
+	}
+	require.Equal(t, "Varies", ParseSpellDuration("Varies").SortKey())
+}
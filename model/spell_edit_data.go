@@ -32,6 +32,7 @@ type SpellEditData struct {
 	Class             string              `json:"spell_class,omitempty"`      // Non-container only
 	Resist            string              `json:"resist,omitempty"`           // Non-container only
 	CastingCost       string              `json:"casting_cost,omitempty"`     // Non-container only
+	CostOverride      string              `json:"cost_override,omitempty"`    // Non-container only
 	MaintenanceCost   string              `json:"maintenance_cost,omitempty"` // Non-container only
 	CastingTime       string              `json:"casting_time,omitempty"`     // Non-container only
 	Duration          string              `json:"duration,omitempty"`         // Non-container only
@@ -41,6 +42,7 @@ type SpellEditData struct {
 	Prereq            *PrereqList         `json:"prereqs,omitempty"`          // Non-container only
 	Weapons           []*Weapon           `json:"weapons,omitempty"`          // Non-container only
 	Study             []*Study            `json:"study,omitempty"`            // Non-container only
+	Prepared          bool                `json:"prepared,omitempty"`         // Non-container only
 	TemplatePicker    *TemplatePicker     `json:"template_picker,omitempty"`  // Container only
 }
 
@@ -78,3 +80,24 @@ func (d *SpellEditData) copyFrom(entity *Entity, other *SpellEditData, isContain
 	}
 	d.TemplatePicker = d.TemplatePicker.Clone()
 }
+
+// FillFromLibrarySpell copies the descriptive data from a library spell into the edit data, leaving the name, points,
+// notes and anything specific to this copy of the spell alone.
+func (d *SpellEditData) FillFromLibrarySpell(spell *Spell) {
+	d.PageRef = spell.PageRef
+	d.Tags = txt.CloneStringSlice(spell.Tags)
+	d.Difficulty = spell.Difficulty
+	d.College = txt.CloneStringSlice(spell.College)
+	d.PowerSource = spell.PowerSource
+	d.Class = spell.Class
+	d.Resist = spell.Resist
+	d.CastingCost = spell.CastingCost
+	d.MaintenanceCost = spell.MaintenanceCost
+	d.CastingTime = spell.CastingTime
+	d.Duration = spell.Duration
+	d.RitualSkillName = spell.RitualSkillName
+	d.RitualPrereqCount = spell.RitualPrereqCount
+	if spell.Prereq != nil {
+		d.Prereq = spell.Prereq.CloneResolvingEmpty(false, false)
+	}
+}
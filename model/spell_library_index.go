@@ -0,0 +1,82 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/log/jot"
+	"github.com/richardwilkes/toolbox/txt"
+)
+
+var spellLibraryIndex = newBackgroundLibraryIndex(&SpellLibraryIndex{spells: make(map[string]*Spell)},
+	NewSpellLibraryIndex)
+
+// SpellLibraryIndex holds the spells found in a set of libraries, keyed by name.
+type SpellLibraryIndex struct {
+	names  []string
+	spells map[string]*Spell
+}
+
+// GlobalSpellLibraryIndex returns the index of the spells found in the configured libraries. The index is built in the
+// background, starting with the first call, and is empty until that build completes.
+func GlobalSpellLibraryIndex() *SpellLibraryIndex {
+	return spellLibraryIndex.get()
+}
+
+// NewSpellLibraryIndex creates a new index of the spells found in the libraries. When the same name is found more than
+// once, the first one encountered is used.
+func NewSpellLibraryIndex(libraries Libraries) *SpellLibraryIndex {
+	index := &SpellLibraryIndex{spells: make(map[string]*Spell)}
+	for _, lib := range libraries.List() {
+		index.addFrom(os.DirFS(lib.Path()), ".")
+	}
+	index.finish()
+	return index
+}
+
+func (x *SpellLibraryIndex) addFrom(fileSystem fs.FS, dirPath string) {
+	walkLibraryFiles(fileSystem, dirPath, SpellsExt, func(filePath string) {
+		spells, err := NewSpellsFromFile(fileSystem, filePath)
+		if err != nil {
+			jot.Warn(err)
+			return
+		}
+		Traverse(func(spell *Spell) bool {
+			key := strings.ToLower(strings.TrimSpace(spell.Name))
+			if _, exists := x.spells[key]; key != "" && !exists {
+				x.spells[key] = spell
+			}
+			return false
+		}, false, true, spells...)
+	})
+}
+
+func (x *SpellLibraryIndex) finish() {
+	x.names = make([]string, 0, len(x.spells))
+	for _, spell := range x.spells {
+		x.names = append(x.names, strings.TrimSpace(spell.Name))
+	}
+	txt.SortStringsNaturalAscending(x.names)
+}
+
+// Names returns the names of all indexed spells, sorted.
+func (x *SpellLibraryIndex) Names() []string {
+	return x.names
+}
+
+// Lookup returns the indexed spell with the given name, ignoring case, or nil if there isn't one.
+func (x *SpellLibraryIndex) Lookup(name string) *Spell {
+	return x.spells[strings.ToLower(strings.TrimSpace(name))]
+}
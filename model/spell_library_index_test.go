@@ -0,0 +1,73 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpellLibraryIndex(t *testing.T) {
+	dir := t.TempDir()
+	container := NewSpell(nil, nil, true)
+	container.Name = "Fire"
+	fireball := NewSpell(nil, container, false)
+	fireball.Name = "Fireball"
+	fireball.College = CollegeList{"Fire"}
+	fireball.CastingCost = "1 to 3"
+	container.Children = []*Spell{fireball}
+	ignite := NewSpell(nil, nil, false)
+	ignite.Name = "Ignite Fire"
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o750))
+	require.NoError(t, SaveSpells([]*Spell{container, ignite}, filepath.Join(dir, "sub", "Magic"+SpellsExt)))
+	duplicate := NewSpell(nil, nil, false)
+	duplicate.Name = "fireball"
+	require.NoError(t, SaveSpells([]*Spell{duplicate}, filepath.Join(dir, "zzz"+SpellsExt)))
+
+	index := &SpellLibraryIndex{spells: make(map[string]*Spell)}
+	index.addFrom(os.DirFS(dir), ".")
+	index.finish()
+	require.Equal(t, []string{"Fireball", "Ignite Fire"}, index.Names())
+	spell := index.Lookup(" FIREBALL ")
+	require.NotNil(t, spell)
+	require.Equal(t, "1 to 3", spell.CastingCost)
+	require.Nil(t, index.Lookup("Fire"))
+
+	var data SpellEditData
+	data.Name = "My Fireball"
+	data.Points = 4
+	data.FillFromLibrarySpell(spell)
+	require.Equal(t, "My Fireball", data.Name)
+	require.Equal(t, CollegeList{"Fire"}, data.College)
+	require.Equal(t, "1 to 3", data.CastingCost)
+	require.EqualValues(t, 4, data.Points)
+}
+
+func TestBackgroundLibraryIndex(t *testing.T) {
+	builds := make(chan int, 4)
+	count := 0
+	x := newBackgroundLibraryIndex(0, func(_ Libraries) int {
+		count++
+		builds <- count
+		return count
+	})
+	require.Equal(t, 0, x.get(), "the empty index should be returned until a build completes")
+	require.Equal(t, 1, <-builds)
+	require.Eventually(t, func() bool { return x.get() == 1 }, time.Second, time.Millisecond)
+	InvalidateLibraryIndexes()
+	require.Equal(t, 2, <-builds)
+	require.Eventually(t, func() bool { return x.get() == 2 }, time.Second, time.Millisecond)
+}
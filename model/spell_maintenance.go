@@ -0,0 +1,129 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// spellPeriodSeconds maps the time units used in spell durations and maintenance costs to their length in seconds. A
+// turn is one second.
+var spellPeriodSeconds = map[string]int{
+	"s":       1,
+	"sec":     1,
+	"secs":    1,
+	"second":  1,
+	"seconds": 1,
+	"turn":    1,
+	"turns":   1,
+	"m":       60,
+	"min":     60,
+	"mins":    60,
+	"minute":  60,
+	"minutes": 60,
+	"h":       3600,
+	"hr":      3600,
+	"hrs":     3600,
+	"hour":    3600,
+	"hours":   3600,
+	"d":       86400,
+	"day":     86400,
+	"days":    86400,
+	"week":    604800,
+	"weeks":   604800,
+}
+
+// MaintenanceRate holds a spell's maintenance cost converted to common time periods.
+type MaintenanceRate struct {
+	PerTurn   fxp.Int
+	PerMinute fxp.Int
+}
+
+func (r MaintenanceRate) String() string {
+	return fmt.Sprintf(i18n.Text("%s per turn, %s per minute"), r.PerTurn.String(), r.PerMinute.String())
+}
+
+// ParseMaintenanceRate interprets a maintenance cost such as "2", "2 per minute" or "1/10 sec". When the cost doesn't
+// name its own period, the duration is used instead, since a spell is maintained once per duration. Returns false if
+// the cost, such as "N/A" or a formula, can't be interpreted.
+func ParseMaintenanceRate(cost, duration string) (MaintenanceRate, bool) {
+	value, remainder := fxp.Extract(strings.TrimSpace(cost))
+	if value <= 0 {
+		return MaintenanceRate{}, false
+	}
+	remainder = strings.ToLower(strings.TrimSpace(remainder))
+	switch {
+	case strings.HasPrefix(remainder, "per "):
+		remainder = remainder[4:]
+	case strings.HasPrefix(remainder, "/"):
+		remainder = remainder[1:]
+	case remainder == "":
+		remainder = duration
+	default:
+		return MaintenanceRate{}, false
+	}
+	seconds, ok := parseSpellPeriod(remainder)
+	if !ok {
+		return MaintenanceRate{}, false
+	}
+	return MaintenanceRate{
+		PerTurn:   value.Div(seconds),
+		PerMinute: value.Mul(fxp.From(60)).Div(seconds),
+	}, true
+}
+
+// parseSpellPeriod returns the length in seconds of a period such as "10 sec", "minute" or "1 hr".
+func parseSpellPeriod(period string) (fxp.Int, bool) {
+	count, remainder := fxp.Extract(strings.TrimSpace(period))
+	if count == 0 {
+		if strings.TrimSpace(remainder) == "" {
+			return 0, false
+		}
+		count = fxp.One
+	}
+	if count < 0 {
+		return 0, false
+	}
+	seconds, ok := spellPeriodSeconds[strings.TrimSuffix(strings.ToLower(strings.TrimSpace(remainder)), ".")]
+	if !ok {
+		return 0, false
+	}
+	return count.Mul(fxp.From(seconds)), true
+}
+
+// MaintenanceRate returns the spell's maintenance cost converted to per turn and per minute costs. Returns false if the
+// maintenance cost can't be interpreted.
+func (s *Spell) MaintenanceRate() (MaintenanceRate, bool) {
+	if s.Container() {
+		return MaintenanceRate{}, false
+	}
+	return ParseMaintenanceRate(s.MaintenanceCost, s.Duration)
+}
+
+// MaintenanceRateText returns a description of the spell's maintenance cost per turn and per minute, falling back to
+// the maintenance cost as written if it can't be interpreted.
+func (s *Spell) MaintenanceRateText() string {
+	return MaintenanceRateText(s.MaintenanceCost, s.Duration)
+}
+
+// MaintenanceRateText returns a description of the maintenance cost per turn and per minute, falling back to the cost
+// as written if it can't be interpreted.
+func MaintenanceRateText(cost, duration string) string {
+	if rate, ok := ParseMaintenanceRate(cost, duration); ok {
+		return rate.String()
+	}
+	return cost
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMaintenanceRate(t *testing.T) {
+	rate, ok := ParseMaintenanceRate("2 per minute", "")
+	require.True(t, ok)
+	require.Equal(t, fxp.Two, rate.PerMinute)
+	require.Equal(t, fxp.FromStringForced("0.0333"), rate.PerTurn)
+
+	rate, ok = ParseMaintenanceRate("1/10 sec", "Instant")
+	require.True(t, ok)
+	require.Equal(t, fxp.Tenth, rate.PerTurn)
+	require.Equal(t, fxp.From(6), rate.PerMinute)
+
+	rate, ok = ParseMaintenanceRate("3", "1 hr.")
+	require.True(t, ok)
+	require.Equal(t, fxp.FromStringForced("0.05"), rate.PerMinute)
+
+	rate, ok = ParseMaintenanceRate("1", "10 sec")
+	require.True(t, ok)
+	require.Equal(t, fxp.Tenth, rate.PerTurn)
+
+	for _, cost := range []string{"", "N/A", "Same as cast", "2", "1/2 cast cost"} {
+		_, ok = ParseMaintenanceRate(cost, "Permanent")
+		require.False(t, ok, cost)
+	}
+
+	s := NewSpell(nil, nil, false)
+	s.MaintenanceCost = "N/A"
+	require.Equal(t, "N/A", s.MaintenanceRateText())
+	s.MaintenanceCost = "2"
+	s.Duration = "1 min"
+	require.Equal(t, "0.0333 per turn, 2 per minute", s.MaintenanceRateText())
+}
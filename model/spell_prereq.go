@@ -13,6 +13,7 @@ package model
 
 import (
 	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/xio"
 )
 
@@ -160,3 +161,33 @@ func (s *SpellPrereq) Satisfied(entity *Entity, exclude any, tooltip *xio.ByteBu
 	}
 	return satisfied
 }
+
+// Description implements Prereq.
+func (s *SpellPrereq) Description(_ *Entity) string {
+	var label string
+	switch s.SubType {
+	case NameSpellComparisonType:
+		label = i18n.Text("Spell: ") + s.QualifierCriteria.CompactString()
+	case TagSpellComparisonType:
+		label = i18n.Text("Spell tag: ") + s.QualifierCriteria.CompactString()
+	case CollegeSpellComparisonType:
+		label = i18n.Text("Spell college: ") + s.QualifierCriteria.CompactString()
+	case CollegeCountSpellComparisonType:
+		label = i18n.Text("Spell colleges")
+	default:
+		label = i18n.Text("Spells")
+	}
+	var quantity string
+	if s.QuantityCriteria.Compare != AtLeastNumber || s.QuantityCriteria.Qualifier != fxp.One ||
+		s.SubType == CollegeCountSpellComparisonType || s.SubType == AnySpellComparisonType {
+		quantity = s.QuantityCriteria.CompactString()
+	}
+	return compactPrereq(s.Has, label, quantity)
+}
+
+// Equal implements Prereq.
+func (s *SpellPrereq) Equal(other Prereq) bool {
+	o, ok := other.(*SpellPrereq)
+	return ok && s.Type == o.Type && s.SubType == o.SubType && s.Has == o.Has &&
+		s.QualifierCriteria == o.QualifierCriteria && s.QuantityCriteria == o.QuantityCriteria
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func spellSortValue(t *testing.T, s *Spell, column int) fxp.Int {
+	t.Helper()
+	var data CellData
+	s.CellData(column, &data)
+	value, err := fxp.FromString(data.SortKey)
+	require.NoError(t, err, "sort key %q for %q", data.SortKey, data.Primary)
+	return value
+}
+
+func TestSpellNumericSortKeys(t *testing.T) {
+	e := NewEntity(PC)
+	low := NewSpell(e, nil, false)
+	low.Name = "Low"
+	low.Points = fxp.One
+	high := NewSpell(e, nil, false)
+	high.Name = "High"
+	high.Points = fxp.From(12)
+	none := NewSpell(e, nil, false)
+	none.Name = "None"
+	none.Points = 0
+	e.Spells = append(e.Spells, low, high, none)
+	e.Recalculate()
+
+	// Relative levels such as "IQ-3" and "IQ+1" don't sort correctly as text.
+	require.Less(t, spellSortValue(t, low, SpellRelativeLevelColumn), spellSortValue(t, high, SpellRelativeLevelColumn))
+	require.Less(t, spellSortValue(t, none, SpellRelativeLevelColumn), spellSortValue(t, low, SpellRelativeLevelColumn))
+	require.Less(t, spellSortValue(t, low, SpellLevelColumn), spellSortValue(t, high, SpellLevelColumn))
+	require.Less(t, spellSortValue(t, none, SpellLevelColumn), spellSortValue(t, low, SpellLevelColumn))
+
+	low.CastingCost = "3"
+	high.CastingCost = "10 per hex"
+	require.Less(t, spellSortValue(t, low, SpellCastCostColumn), spellSortValue(t, high, SpellCastCostColumn))
+
+	var data CellData
+	none.CastingCost = "Varies"
+	none.CellData(SpellCastCostColumn, &data)
+	require.Empty(t, data.SortKey)
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpellPrepared(t *testing.T) {
+	spell := NewSpell(nil, nil, false)
+	data, err := json.Marshal(spell)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), `"prepared"`)
+
+	var loaded Spell
+	require.NoError(t, json.Unmarshal(data, &loaded))
+	require.False(t, loaded.Prepared)
+
+	spell.Prepared = true
+	data, err = json.Marshal(spell)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"prepared":true`)
+	require.NoError(t, json.Unmarshal(data, &loaded))
+	require.True(t, loaded.Prepared)
+
+	var cellData CellData
+	loaded.CellData(SpellPreparedColumn, &cellData)
+	require.Equal(t, ToggleCellType, cellData.Type)
+	require.True(t, cellData.Checked)
+
+	container := NewSpell(nil, nil, true)
+	container.Prepared = true
+	container.ClearUnusedFieldsForType()
+	require.False(t, container.Prepared)
+}
+
+func TestSpellCostOverride(t *testing.T) {
+	spell := NewSpell(nil, nil, false)
+	spell.CastingCost = "1/2 per yard"
+	require.False(t, spell.HasCostOverride())
+	require.Equal(t, "1/2 per yard", spell.EffectiveCastingCost())
+
+	spell.CostOverride = "3"
+	require.True(t, spell.HasCostOverride())
+	require.Equal(t, "3", spell.EffectiveCastingCost())
+	require.Equal(t, "1/2 per yard", spell.CastingCost)
+
+	var cellData CellData
+	spell.CellData(SpellCastCostColumn, &cellData)
+	require.Equal(t, "3"+costOverrideMarker, cellData.Primary)
+	require.Contains(t, cellData.Tooltip, "1/2 per yard")
+}
+
+func TestSpellLevelBreakdown(t *testing.T) {
+	entity := NewEntity(PC)
+	spell := NewSpell(entity, nil, false)
+	spell.Points = fxp.Four
+	entity.Spells = append(entity.Spells, spell)
+	entity.Recalculate()
+
+	breakdown := spell.LevelBreakdown()
+	require.NotNil(t, breakdown)
+	require.Len(t, breakdown.Steps, 3)
+	require.Equal(t, fxp.Ten, breakdown.Steps[0].Amount)
+	require.Equal(t, -fxp.Two, breakdown.Steps[1].Amount)
+	require.Equal(t, fxp.Two, breakdown.Steps[2].Amount)
+	require.Equal(t, spell.CalculateLevel().Level, breakdown.Level())
+
+	var cellData CellData
+	spell.CellData(SpellRelativeLevelColumn, &cellData)
+	require.Empty(t, cellData.InfoPop)
+	entity.SheetSettings.ShowLevelDerivation = true
+	spell.CellData(SpellRelativeLevelColumn, &cellData)
+	require.Equal(t, breakdown.String(), cellData.InfoPop)
+
+	spell.Points = 0
+	require.Nil(t, spell.LevelBreakdown())
+}
+
+func TestSpellHeading(t *testing.T) {
+	e := NewEntity(PC)
+	heading := NewSpellHeading(e, nil, false)
+	heading.Name = "Fire Spells"
+	require.True(t, heading.Heading())
+	require.False(t, heading.Container())
+	require.Equal(t, "Spell Heading", heading.Kind())
+	require.False(t, heading.SetRawPoints(fxp.Four))
+	require.Zero(t, heading.Points)
+
+	fireball := NewSpell(e, nil, false)
+	fireball.Name = "Fireball"
+	fireball.College = []string{"Fire"}
+	e.Spells = []*Spell{heading, fireball}
+	e.Recalculate()
+	require.Equal(t, fxp.One, e.PointsBreakdown().Spells)
+	require.Zero(t, heading.AdjustedPoints(nil))
+	require.Zero(t, heading.LevelData.Level)
+	require.Nil(t, heading.LevelBreakdown())
+	require.Equal(t, "Fire (1)", SpellCollegeSummary(e.Spells))
+
+	var cellData CellData
+	heading.CellData(SpellDescriptionForPageColumn, &cellData)
+	require.True(t, cellData.Heading)
+	require.Equal(t, "Fire Spells", cellData.Primary)
+	cellData = CellData{}
+	heading.CellData(SpellPointsColumn, &cellData)
+	require.Empty(t, cellData.Primary)
+
+	data, err := json.Marshal(heading)
+	require.NoError(t, err)
+	var loaded Spell
+	require.NoError(t, json.Unmarshal(data, &loaded))
+	require.True(t, loaded.Heading())
+	require.Equal(t, "Fire Spells", loaded.Name)
+	require.Empty(t, loaded.CastingCost)
+
+	clone := heading.Clone(e, nil, false)
+	require.True(t, clone.Heading())
+	require.Equal(t, "Fire Spells", clone.Name)
+}
@@ -19,15 +19,17 @@ import (
 
 // Possible StringCompareType values.
 const (
-	AnyString              = StringCompareType("")
-	IsString               = StringCompareType("is")
-	IsNotString            = StringCompareType("is_not")
-	ContainsString         = StringCompareType("contains")
-	DoesNotContainString   = StringCompareType("does_not_contain")
-	StartsWithString       = StringCompareType("starts_with")
-	DoesNotStartWithString = StringCompareType("does_not_start_with")
-	EndsWithString         = StringCompareType("ends_with")
-	DoesNotEndWithString   = StringCompareType("does_not_end_with")
+	AnyString               = StringCompareType("")
+	IsString                = StringCompareType("is")
+	IsNotString             = StringCompareType("is_not")
+	ContainsString          = StringCompareType("contains")
+	DoesNotContainString    = StringCompareType("does_not_contain")
+	StartsWithString        = StringCompareType("starts_with")
+	DoesNotStartWithString  = StringCompareType("does_not_start_with")
+	EndsWithString          = StringCompareType("ends_with")
+	DoesNotEndWithString    = StringCompareType("does_not_end_with")
+	MatchesRegexString      = StringCompareType("matches_regex")
+	DoesNotMatchRegexString = StringCompareType("does_not_match_regex")
 )
 
 // AllStringCompareTypes is the complete set of StringCompareType values.
@@ -41,6 +43,8 @@ var AllStringCompareTypes = []StringCompareType{
 	DoesNotStartWithString,
 	EndsWithString,
 	DoesNotEndWithString,
+	MatchesRegexString,
+	DoesNotMatchRegexString,
 }
 
 // StringCompareType holds the type for a string comparison.
@@ -77,6 +81,10 @@ func (s StringCompareType) String() string {
 		return i18n.Text("ends with")
 	case DoesNotEndWithString:
 		return i18n.Text("does not end with")
+	case MatchesRegexString:
+		return i18n.Text("matches pattern")
+	case DoesNotMatchRegexString:
+		return i18n.Text("does not match pattern")
 	default:
 		return AnyString.String()
 	}
@@ -93,6 +101,8 @@ func (s StringCompareType) AltString() string {
 		return i18n.Text("do not start with")
 	case DoesNotEndWithString:
 		return i18n.Text("do not end with")
+	case DoesNotMatchRegexString:
+		return i18n.Text("do not match pattern")
 	default:
 		return s.String()
 	}
@@ -107,7 +117,8 @@ func (s StringCompareType) Describe(qualifier string) string {
 	return v.String() + ` "` + qualifier + `"`
 }
 
-// Matches performs a comparison and returns true if the data matches.
+// Matches performs a comparison and returns true if the data matches. For MatchesRegexString and
+// DoesNotMatchRegexString, the qualifier is a case-insensitive regular expression and an invalid one never matches.
 func (s StringCompareType) Matches(qualifier, data string) bool {
 	switch s {
 	case AnyString:
@@ -128,6 +139,11 @@ func (s StringCompareType) Matches(qualifier, data string) bool {
 		return strings.HasSuffix(strings.ToLower(data), strings.ToLower(qualifier))
 	case DoesNotEndWithString:
 		return !strings.HasSuffix(strings.ToLower(data), strings.ToLower(qualifier))
+	case MatchesRegexString:
+		return matchesStringPattern(qualifier, data)
+	case DoesNotMatchRegexString:
+		_, err := CompileStringPattern(qualifier)
+		return err == nil && !matchesStringPattern(qualifier, data)
 	default:
 		return AnyString.Matches(qualifier, data)
 	}
@@ -147,7 +163,7 @@ func ExtractStringCompareTypeIndex(str string) int {
 func PrefixedStringCompareTypeChoices(prefix, notPrefix string) []string {
 	choices := make([]string, len(AllStringCompareTypes))
 	for i, choice := range AllStringCompareTypes {
-		if prefix == notPrefix || choice == AnyString || choice == IsString || choice == ContainsString || choice == StartsWithString || choice == EndsWithString {
+		if prefix == notPrefix || choice == AnyString || choice == IsString || choice == ContainsString || choice == StartsWithString || choice == EndsWithString || choice == MatchesRegexString {
 			choices[i] = prefix + " " + choice.String()
 		} else {
 			choices[i] = notPrefix + " " + choice.AltString()
@@ -13,6 +13,7 @@ package model
 
 import (
 	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/i18n"
 )
 
 // StringCriteria holds the criteria for matching a string.
@@ -55,15 +56,36 @@ func (s StringCriteria) MatchesList(value ...string) bool {
 		}
 	}
 	switch s.Compare {
-	case AnyString, IsString, ContainsString, StartsWithString, EndsWithString:
+	case AnyString, IsString, ContainsString, StartsWithString, EndsWithString, MatchesRegexString:
 		return matches > 0
-	case IsNotString, DoesNotContainString, DoesNotStartWithString, DoesNotEndWithString:
+	case IsNotString, DoesNotContainString, DoesNotStartWithString, DoesNotEndWithString, DoesNotMatchRegexString:
 		return matches == len(value)
 	default:
 		return matches > 0
 	}
 }
 
+// Validate returns an error if the qualifier can't be used with the comparison, such as an invalid regular expression.
+func (s StringCriteria) Validate() error {
+	if s.Compare == MatchesRegexString || s.Compare == DoesNotMatchRegexString {
+		_, err := CompileStringPattern(s.Qualifier)
+		return err
+	}
+	return nil
+}
+
 func (s StringCriteria) String() string {
 	return s.Compare.Describe(s.Qualifier)
 }
+
+// CompactString returns a compact description. An exact match is described by just its qualifier.
+func (s StringCriteria) CompactString() string {
+	switch v := s.Compare.EnsureValid(); v {
+	case AnyString:
+		return i18n.Text("any")
+	case IsString:
+		return s.Qualifier
+	default:
+		return v.Describe(s.Qualifier)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"regexp"
+	"sync"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// Limits placed on regular expressions used by MatchesRegexString. Go's regular expressions run in time linear to the
+// size of their input, so bounding the size of the pattern is sufficient to keep matching cheap.
+const (
+	MaxStringPatternLength = 256
+	maxStringPatternCache  = 256
+)
+
+type compiledStringPattern struct {
+	re  *regexp.Regexp
+	err error
+}
+
+var (
+	stringPatternLock  sync.Mutex
+	stringPatternCache = make(map[string]compiledStringPattern)
+)
+
+// CompileStringPattern returns the compiled, case-insensitive form of the regular expression. Compiled patterns,
+// including those that failed to compile, are cached.
+func CompileStringPattern(pattern string) (*regexp.Regexp, error) {
+	stringPatternLock.Lock()
+	defer stringPatternLock.Unlock()
+	if compiled, ok := stringPatternCache[pattern]; ok {
+		return compiled.re, compiled.err
+	}
+	var compiled compiledStringPattern
+	if len(pattern) > MaxStringPatternLength {
+		compiled.err = errs.Newf(i18n.Text("pattern is longer than %d characters"), MaxStringPatternLength)
+	} else {
+		var err error
+		if compiled.re, err = regexp.Compile("(?i)" + pattern); err != nil {
+			compiled.err = errs.Newf(i18n.Text("invalid pattern: %s"), err.Error())
+		}
+	}
+	if len(stringPatternCache) >= maxStringPatternCache {
+		stringPatternCache = make(map[string]compiledStringPattern)
+	}
+	stringPatternCache[pattern] = compiled
+	return compiled.re, compiled.err
+}
+
+func matchesStringPattern(pattern, data string) bool {
+	re, err := CompileStringPattern(pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(data)
+}
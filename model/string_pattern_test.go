@@ -0,0 +1,79 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/richardwilkes/json"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringCriteriaRegex(t *testing.T) {
+	var criteria StringCriteria
+	criteria.Compare = MatchesRegexString
+	criteria.Qualifier = `^combat reflexes|^enhanced (dodge|parry)`
+	require.NoError(t, criteria.Validate())
+	require.True(t, criteria.Matches("Combat Reflexes"))
+	require.True(t, criteria.Matches("Enhanced Parry (Broadsword)"))
+	require.False(t, criteria.Matches("Enhanced Block"))
+	require.True(t, criteria.MatchesList("Mental", "Enhanced Dodge"))
+	require.False(t, criteria.MatchesList("Mental", "Physical"))
+
+	re1, err := CompileStringPattern(criteria.Qualifier)
+	require.NoError(t, err)
+	re2, err := CompileStringPattern(criteria.Qualifier)
+	require.NoError(t, err)
+	require.Same(t, re1, re2)
+
+	criteria.Qualifier = `(unclosed`
+	require.Error(t, criteria.Validate())
+	require.False(t, criteria.Matches("(unclosed"))
+
+	criteria.Qualifier = strings.Repeat("a", MaxStringPatternLength+1)
+	require.Error(t, criteria.Validate())
+
+	long := strings.Repeat("a", 16384) + "!"
+	criteria.Qualifier = `(a+)+!$`
+	require.NoError(t, criteria.Validate())
+	require.True(t, criteria.Matches(long))
+	require.True(t, criteria.Matches("aaa!"))
+	require.False(t, criteria.Matches(long+"a"))
+
+	criteria.Compare = DoesNotMatchRegexString
+	criteria.Qualifier = `needle`
+	require.False(t, criteria.Matches(strings.Repeat("x", 16384)+"needle"))
+
+	criteria.Compare = ContainsString
+	criteria.Qualifier = `(unclosed`
+	require.NoError(t, criteria.Validate())
+}
+
+func TestStringCriteriaNegatedRegex(t *testing.T) {
+	var criteria StringCriteria
+	require.NoError(t, json.Unmarshal([]byte(`{"compare":"does_not_match_regex","qualifier":"^enhanced"}`), &criteria))
+	require.Equal(t, DoesNotMatchRegexString, criteria.Compare)
+	require.NoError(t, criteria.Validate())
+	require.True(t, criteria.Matches("Combat Reflexes"))
+	require.False(t, criteria.Matches("Enhanced Dodge"))
+	require.True(t, criteria.MatchesList("Mental", "Physical"))
+	require.False(t, criteria.MatchesList("Mental", "Enhanced Dodge"))
+
+	criteria.Qualifier = `(unclosed`
+	require.Error(t, criteria.Validate())
+	require.False(t, criteria.Matches("anything"))
+
+	require.NoError(t, json.Unmarshal([]byte(`{"compare":"does_not_start_with","qualifier":"x"}`), &criteria))
+	require.Equal(t, DoesNotStartWithString, criteria.Compare)
+	require.Equal(t, 6, ExtractStringCompareTypeIndex(string(DoesNotStartWithString)))
+}
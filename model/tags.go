@@ -0,0 +1,49 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import "strings"
+
+// TagEditor defines the methods required for data whose tags can be edited.
+type TagEditor interface {
+	TagList() []string
+	SetTagList(tags []string)
+}
+
+// AddTag returns a new list of tags with the tag appended and any duplicates removed.
+func AddTag(tags []string, tag string) []string {
+	list := make([]string, 0, len(tags)+1)
+	set := make(map[string]bool, len(tags)+1)
+	add := func(one string) {
+		if one != "" && !set[one] {
+			set[one] = true
+			list = append(list, one)
+		}
+	}
+	for _, one := range tags {
+		add(one)
+	}
+	add(strings.TrimSpace(tag))
+	return list
+}
+
+// RemoveTag returns a new list of tags with all occurrences of the tag removed.
+func RemoveTag(tags []string, tag string) []string {
+	tag = strings.TrimSpace(tag)
+	list := make([]string, 0, len(tags))
+	for _, one := range tags {
+		if one != tag {
+			list = append(list, one)
+		}
+	}
+	return list
+}
@@ -0,0 +1,33 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddTag(t *testing.T) {
+	tags := []string{"Mental", "Advantage", "Mental"}
+	require.Equal(t, []string{"Mental", "Advantage", "Social"}, AddTag(tags, " Social "))
+	require.Equal(t, []string{"Mental", "Advantage"}, AddTag(tags, "Advantage"))
+	require.Equal(t, []string{"Mental", "Advantage", "Mental"}, tags)
+	require.Equal(t, []string{"Social"}, AddTag(nil, "Social"))
+	require.Empty(t, AddTag(nil, " "))
+}
+
+func TestRemoveTag(t *testing.T) {
+	tags := []string{"Mental", "Advantage", "Mental"}
+	require.Equal(t, []string{"Advantage"}, RemoveTag(tags, "Mental"))
+	require.Equal(t, tags, RemoveTag(tags, "Social"))
+}
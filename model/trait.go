@@ -21,6 +21,7 @@ import (
 	"github.com/richardwilkes/json"
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/txt"
 	"github.com/richardwilkes/unison"
 	"golang.org/x/exp/slices"
 )
@@ -37,11 +38,14 @@ const (
 	TraitPointsColumn
 	TraitTagsColumn
 	TraitReferenceColumn
+	TraitPrereqsColumn
 )
 
 const (
 	traitListTypeKey = "trait_list"
 	traitTypeKey     = "trait"
+	// prereqSummaryMaxLength is the maximum number of characters shown in the prerequisites column before truncation.
+	prereqSummaryMaxLength = 40
 )
 
 // Trait holds an advantage, disadvantage, quirk, or perk.
@@ -49,6 +53,7 @@ type Trait struct {
 	TraitData
 	Entity            *Entity
 	UnsatisfiedReason string
+	libraryModified   bool
 }
 
 type traitListData struct {
@@ -108,6 +113,7 @@ func (a *Trait) Clone(entity *Entity, parent *Trait, preserveID bool) *Trait {
 	}
 	other.IsOpen = a.IsOpen
 	other.TraitEditData.CopyFrom(a)
+	other.Source = a.Source
 	if a.HasChildren() {
 		other.Children = make([]*Trait, 0, len(a.Children))
 		for _, child := range a.Children {
@@ -219,6 +225,7 @@ func (a *Trait) CellData(columnID int, data *CellData) {
 		data.UnsatisfiedReason = a.UnsatisfiedReason
 		data.Tooltip = a.SecondaryText(func(option DisplayOption) bool { return option.Tooltip() })
 		data.TemplateInfo = a.TemplatePicker.Description()
+		data.LibraryInfo = libraryInfo(a)
 	case TraitPointsColumn:
 		data.Type = TextCellType
 		data.Primary = a.AdjustedPoints().String()
@@ -230,7 +237,23 @@ func (a *Trait) CellData(columnID int, data *CellData) {
 		data.Type = PageRefCellType
 		data.Primary = a.PageRef
 		data.Secondary = a.Name
+	case TraitPrereqsColumn:
+		data.Type = TextCellType
+		summary := a.PrereqSummary()
+		data.Primary = txt.Truncate(summary, prereqSummaryMaxLength, true)
+		if data.Primary != summary {
+			data.Tooltip = summary
+		}
+	}
+}
+
+// PrereqSummary returns a compact, single-line description of the top-level prerequisites, or an empty string if there
+// are none.
+func (a *Trait) PrereqSummary() string {
+	if a.Prereq == nil {
+		return ""
 	}
+	return a.Prereq.Description(a.Entity)
 }
 
 // Depth returns the number of parents this node has.
@@ -363,6 +386,21 @@ func (a *Trait) TagList() []string {
 	return a.Tags
 }
 
+// SetTagList sets the list of tags.
+func (a *Trait) SetTagList(tags []string) {
+	a.Tags = tags
+}
+
+// PageReference returns the page reference.
+func (a *Trait) PageReference() string {
+	return a.PageRef
+}
+
+// SetPageReference sets the page reference.
+func (a *Trait) SetPageReference(ref string) {
+	a.PageRef = ref
+}
+
 // FillWithNameableKeys adds any nameable keys found to the provided map.
 func (a *Trait) FillWithNameableKeys(m map[string]string) {
 	Extract(a.Name, m)
@@ -19,6 +19,7 @@ import (
 type TraitData struct {
 	ContainerBase[*Trait]
 	TraitEditData
+	Source *LibrarySource `json:"source,omitempty"`
 }
 
 // Kind returns the kind of data.
@@ -153,6 +153,21 @@ func (m *TraitModifier) TagList() []string {
 	return m.Tags
 }
 
+// SetTagList sets the list of tags.
+func (m *TraitModifier) SetTagList(tags []string) {
+	m.Tags = tags
+}
+
+// PageReference returns the page reference.
+func (m *TraitModifier) PageReference() string {
+	return m.PageRef
+}
+
+// SetPageReference sets the page reference.
+func (m *TraitModifier) SetPageReference(ref string) {
+	m.PageRef = ref
+}
+
 // CellData returns the cell data information for the given column.
 func (m *TraitModifier) CellData(columnID int, data *CellData) {
 	switch columnID {
@@ -12,6 +12,8 @@
 package model
 
 import (
+	"fmt"
+
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/xio"
@@ -120,3 +122,23 @@ func (a *TraitPrereq) Satisfied(entity *Entity, exclude any, tooltip *xio.ByteBu
 	}
 	return satisfied
 }
+
+// Description implements Prereq.
+func (a *TraitPrereq) Description(_ *Entity) string {
+	label := i18n.Text("Trait: ") + a.NameCriteria.CompactString()
+	if a.NotesCriteria.Compare.EnsureValid() != AnyString {
+		label += fmt.Sprintf(i18n.Text(" (notes %s)"), a.NotesCriteria.String())
+	}
+	var level string
+	if a.LevelCriteria.Compare != AtLeastNumber || a.LevelCriteria.Qualifier != 0 {
+		level = a.LevelCriteria.CompactString()
+	}
+	return compactPrereq(a.Has, label, level)
+}
+
+// Equal implements Prereq.
+func (a *TraitPrereq) Equal(other Prereq) bool {
+	o, ok := other.(*TraitPrereq)
+	return ok && a.Type == o.Type && a.Has == o.Has && a.NameCriteria == o.NameCriteria &&
+		a.LevelCriteria == o.LevelCriteria && a.NotesCriteria == o.NotesCriteria
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraitPrereqSummary(t *testing.T) {
+	trait := NewTrait(nil, nil, false)
+	var data CellData
+	trait.CellData(TraitPrereqsColumn, &data)
+	require.Empty(t, data.Primary)
+	require.Empty(t, data.Tooltip)
+
+	trait.Prereq = NewPrereqList()
+	short := NewTraitPrereq()
+	short.Parent = trait.Prereq
+	short.NameCriteria.Qualifier = "Luck"
+	trait.Prereq.Prereqs = append(trait.Prereq.Prereqs, short)
+	summary := trait.PrereqSummary()
+	require.Contains(t, summary, "Luck")
+	data = CellData{}
+	trait.CellData(TraitPrereqsColumn, &data)
+	require.Equal(t, summary, data.Primary)
+	require.Empty(t, data.Tooltip)
+
+	long := NewTraitPrereq()
+	long.Parent = trait.Prereq
+	long.NameCriteria.Qualifier = "Extraordinarily Lengthy Advantage Name"
+	trait.Prereq.Prereqs = append(trait.Prereq.Prereqs, long)
+	summary = trait.PrereqSummary()
+	data = CellData{}
+	trait.CellData(TraitPrereqsColumn, &data)
+	require.Equal(t, summary, data.Tooltip)
+	require.True(t, strings.HasSuffix(data.Primary, "…"))
+	require.Len(t, []rune(data.Primary), prereqSummaryMaxLength+1)
+}
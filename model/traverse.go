@@ -47,3 +47,15 @@ func Traverse[T NodeTypes](f func(T) bool, onlyEnabled, excludeContainers bool,
 		}
 	}
 }
+
+// Count returns the number of nodes, including their children, in the input list for which the predicate returns true.
+func Count[T NodeTypes](predicate func(T) bool, in ...T) int {
+	count := 0
+	Traverse(func(one T) bool {
+		if predicate(one) {
+			count++
+		}
+		return false
+	}, false, false, in...)
+	return count
+}
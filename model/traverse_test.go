@@ -0,0 +1,34 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCount(t *testing.T) {
+	container := NewSpell(nil, nil, true)
+	for _, pts := range []int{1, 4, 8} {
+		spell := NewSpell(nil, container, false)
+		spell.Points = fxp.From(pts)
+		container.Children = append(container.Children, spell)
+	}
+	spell := NewSpell(nil, nil, false)
+	spell.Points = fxp.Two
+	list := []*Spell{container, spell}
+	require.Equal(t, 5, Count(func(*Spell) bool { return true }, list...))
+	require.Equal(t, 2, Count(func(s *Spell) bool { return s.Points > fxp.Three }, list...))
+	require.Equal(t, 0, Count(func(*Spell) bool { return true }))
+}
@@ -66,7 +66,10 @@ type WeaponData struct {
 	Usage           string          `json:"usage,omitempty"`
 	UsageNotes      string          `json:"usage_notes,omitempty"`
 	Reach           string          `json:"reach,omitempty"`
+	ReachTwoHanded  bool            `json:"reach_two_handed,omitempty"`
+	ReachNotes      string          `json:"reach_notes,omitempty"`
 	Parry           string          `json:"parry,omitempty"`
+	ParryNotes      string          `json:"parry_notes,omitempty"`
 	Block           string          `json:"block,omitempty"`
 	Accuracy        string          `json:"accuracy,omitempty"`
 	Range           string          `json:"range,omitempty"`
@@ -187,9 +190,11 @@ func (w *Weapon) HashCode() uint32 {
 	h.Write([]byte(w.SkillLevel(nil).String()))
 	h.Write([]byte(w.Accuracy))
 	h.Write([]byte(w.Parry))
+	h.Write([]byte(w.ParryNotes))
 	h.Write([]byte(w.Block))
 	h.Write([]byte(w.Damage.ResolvedDamage(nil)))
 	h.Write([]byte(w.Reach))
+	h.Write([]byte(w.ReachAnnotations()))
 	h.Write([]byte(w.Range))
 	h.Write([]byte(w.RateOfFire))
 	h.Write([]byte(w.Shots))
@@ -308,16 +313,8 @@ func (w *Weapon) SkillLevel(tooltip *xio.ByteBuffer) fxp.Int {
 		primaryTooltip = &xio.ByteBuffer{}
 	}
 	adj := w.skillLevelBaseAdjustment(pc, primaryTooltip) + w.skillLevelPostAdjustment(pc, primaryTooltip)
-	best := fxp.Min
-	for _, def := range w.Defaults {
-		if level := def.SkillLevelFast(pc, false, nil, true); level != fxp.Min {
-			level += adj
-			if best < level {
-				best = level
-			}
-		}
-	}
-	if best == fxp.Min {
+	def, best := w.bestDefault(pc, adj)
+	if def == nil {
 		return 0
 	}
 	if tooltip != nil && primaryTooltip != nil && primaryTooltip.Len() != 0 {
@@ -326,17 +323,46 @@ func (w *Weapon) SkillLevel(tooltip *xio.ByteBuffer) fxp.Int {
 		}
 		tooltip.WriteString(primaryTooltip.String())
 	}
-	if best < 0 {
-		best = 0
-	}
 	return best
 }
 
+// BestDefault returns the default that currently yields the highest skill level for this weapon, along with the
+// resulting level. Returns nil if the weapon has no owning character or none of its defaults apply.
+func (w *Weapon) BestDefault() (def *SkillDefault, level fxp.Int) {
+	pc := w.PC()
+	if pc == nil {
+		return nil, 0
+	}
+	return w.bestDefault(pc, w.skillLevelBaseAdjustment(pc, nil)+w.skillLevelPostAdjustment(pc, nil))
+}
+
+func (w *Weapon) bestDefault(pc *Entity, adj fxp.Int) (def *SkillDefault, level fxp.Int) {
+	level = fxp.Min
+	for _, one := range w.Defaults {
+		if current := one.SkillLevelFast(pc, false, nil, true); current != fxp.Min {
+			current += adj
+			if level < current {
+				level = current
+				def = one
+			}
+		}
+	}
+	if def == nil {
+		return nil, 0
+	}
+	return def, level.Max(0)
+}
+
 func (w *Weapon) skillLevelBaseAdjustment(entity *Entity, tooltip *xio.ByteBuffer) fxp.Int {
-	var adj fxp.Int
+	adj := w.skillBonusAdjustment(entity, tooltip)
 	if minST := w.ResolvedMinimumStrength() - (entity.StrengthOrZero() + entity.StrikingStrengthBonus); minST > 0 {
 		adj -= minST
 	}
+	return adj
+}
+
+func (w *Weapon) skillBonusAdjustment(entity *Entity, tooltip *xio.ByteBuffer) fxp.Int {
+	var adj fxp.Int
 	nameQualifier := w.String()
 	for _, bonus := range entity.NamedWeaponSkillBonusesFor(nameQualifier, w.Usage, w.Owner.TagList(), tooltip) {
 		adj += bonus.AdjustedAmount()
@@ -668,12 +694,14 @@ func (w *Weapon) CellData(columnID int, data *CellData) {
 		data.Primary = w.SkillLevel(&buffer).String()
 	case WeaponParryColumn:
 		data.Primary = w.ResolvedParry(&buffer)
+		data.Tooltip = w.ParryAnnotations()
 	case WeaponBlockColumn:
 		data.Primary = w.ResolvedBlock(&buffer)
 	case WeaponDamageColumn:
 		data.Primary = w.Damage.ResolvedDamage(&buffer)
 	case WeaponReachColumn:
 		data.Primary = w.Reach
+		data.Tooltip = w.ReachAnnotations()
 	case WeaponSTColumn:
 		data.Primary = w.MinimumStrength
 	case WeaponAccColumn:
@@ -692,8 +720,29 @@ func (w *Weapon) CellData(columnID int, data *CellData) {
 		data.Type = PageRefCellType
 	}
 	if buffer.Len() > 0 {
-		data.Tooltip = i18n.Text("Includes modifiers from:") + buffer.String()
+		if data.Tooltip != "" {
+			data.Tooltip += "\n\n"
+		}
+		data.Tooltip += i18n.Text("Includes modifiers from:") + buffer.String()
+	}
+}
+
+// ReachAnnotations returns a description of the structured annotations on the reach, or an empty string if there are
+// none.
+func (w *Weapon) ReachAnnotations() string {
+	var parts []string
+	if w.ReachTwoHanded {
+		parts = append(parts, i18n.Text("+1 when wielded two-handed"))
+	}
+	if notes := strings.TrimSpace(w.ReachNotes); notes != "" {
+		parts = append(parts, notes)
 	}
+	return strings.Join(parts, ", ")
+}
+
+// ParryAnnotations returns a description of the annotations on the parry, or an empty string if there are none.
+func (w *Weapon) ParryAnnotations() string {
+	return strings.TrimSpace(w.ParryNotes)
 }
 
 // OwningEntity returns the owning Entity.
@@ -714,3 +763,57 @@ func (w *Weapon) CopyFrom(t *Weapon) {
 func (w *Weapon) ApplyTo(t *Weapon) {
 	*t = *w.Clone(t.Entity(), nil, true)
 }
+
+// FillFromLibraryWeapon replaces this weapon's data with a copy of the library weapon's data, retaining this weapon's
+// ID, type and owner.
+func (w *Weapon) FillFromLibraryWeapon(other *Weapon) {
+	id := w.ID
+	weaponType := w.Type
+	w.WeaponData = other.Clone(nil, nil, true).WeaponData
+	w.ID = id
+	w.Type = weaponType
+	w.Damage.Owner = w
+}
+
+// StatBlock returns a single-line summary of the weapon's stats, suitable for pasting into notes or chat. Melee weapons
+// list their reach, parry and block, while ranged weapons list their accuracy, range, rate of fire, shots, bulk and
+// recoil. Empty values are omitted.
+func (w *Weapon) StatBlock() string {
+	var parts []string
+	if damage := w.Damage.String(); damage != "" {
+		parts = append(parts, damage)
+	}
+	add := func(label, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			parts = append(parts, label+" "+value)
+		}
+	}
+	annotate := func(value, annotations string) string {
+		if annotations == "" || strings.TrimSpace(value) == "" {
+			return value
+		}
+		return strings.TrimSpace(value) + " (" + annotations + ")"
+	}
+	switch w.Type {
+	case MeleeWeaponType:
+		add(i18n.Text("Reach"), annotate(w.Reach, w.ReachAnnotations()))
+		add(i18n.Text("Parry"), annotate(w.Parry, w.ParryAnnotations()))
+		add(i18n.Text("Block"), w.Block)
+	case RangedWeaponType:
+		add(i18n.Text("Acc"), w.Accuracy)
+		add(i18n.Text("Range"), w.Range)
+		add(i18n.Text("RoF"), w.RateOfFire)
+		add(i18n.Text("Shots"), w.Shots)
+		add(i18n.Text("Bulk"), w.Bulk)
+		add(i18n.Text("Rcl"), w.Recoil)
+	}
+	add(i18n.Text("ST"), w.MinimumStrength)
+	stats := strings.Join(parts, "; ")
+	if usage := strings.TrimSpace(w.Usage); usage != "" {
+		if stats == "" {
+			return usage
+		}
+		return usage + ": " + stats
+	}
+	return stats
+}
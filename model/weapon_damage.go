@@ -36,6 +36,54 @@ type WeaponDamageData struct {
 	ModifierPerDie            fxp.Int        `json:"modifier_per_die,omitempty"`
 }
 
+// ResolvedWeaponDamage holds the damage for a weapon after all bonuses and the character's thrust or swing damage have
+// been applied.
+type ResolvedWeaponDamage struct {
+	Base                      *dice.Dice
+	ArmorDivisor              fxp.Int
+	Type                      string
+	Fragmentation             *dice.Dice
+	FragmentationArmorDivisor fxp.Int
+	FragmentationType         string
+	UseModifyingDicePlusAdds  bool
+}
+
+func (r *ResolvedWeaponDamage) String() string {
+	var buffer strings.Builder
+	if r.Base.Count != 0 || r.Base.Modifier != 0 {
+		buffer.WriteString(r.Base.StringExtra(r.UseModifyingDicePlusAdds))
+	}
+	if r.ArmorDivisor != fxp.One {
+		buffer.WriteByte('(')
+		buffer.WriteString(r.ArmorDivisor.String())
+		buffer.WriteByte(')')
+	}
+	if r.Type != "" {
+		if buffer.Len() != 0 {
+			buffer.WriteByte(' ')
+		}
+		buffer.WriteString(r.Type)
+	}
+	if r.Fragmentation != nil {
+		if frag := r.Fragmentation.StringExtra(r.UseModifyingDicePlusAdds); frag != "0" {
+			if buffer.Len() != 0 {
+				buffer.WriteByte(' ')
+			}
+			buffer.WriteByte('[')
+			buffer.WriteString(frag)
+			if r.FragmentationArmorDivisor != fxp.One {
+				buffer.WriteByte('(')
+				buffer.WriteString(r.FragmentationArmorDivisor.String())
+				buffer.WriteByte(')')
+			}
+			buffer.WriteByte(' ')
+			buffer.WriteString(r.FragmentationType)
+			buffer.WriteByte(']')
+		}
+	}
+	return buffer.String()
+}
+
 // WeaponDamage holds the damage information for a weapon.
 type WeaponDamage struct {
 	WeaponDamageData
@@ -156,20 +204,35 @@ func (w *WeaponDamage) DamageTooltip() string {
 
 // ResolvedDamage returns the damage, fully resolved for the user's sw or thr, if possible.
 func (w *WeaponDamage) ResolvedDamage(tooltip *xio.ByteBuffer) string {
+	if resolved := w.Resolve(tooltip); resolved != nil {
+		return resolved.String()
+	}
+	return w.String()
+}
+
+// Resolve returns the damage, fully resolved for the user's sw or thr. Returns nil if the weapon is not owned by a
+// character.
+func (w *WeaponDamage) Resolve(tooltip *xio.ByteBuffer) *ResolvedWeaponDamage {
 	if w.Owner == nil {
-		return w.String()
+		return nil
 	}
 	pc := w.Owner.PC()
 	if pc == nil {
-		return w.String()
+		return nil
 	}
+	return w.resolveForST(pc, pc.StrengthOrZero(), tooltip)
+}
+
+// resolveForST returns the damage, fully resolved for the sw or thr of the given ST, before any striking ST bonus the
+// character has.
+func (w *WeaponDamage) resolveForST(pc *Entity, st fxp.Int, tooltip *xio.ByteBuffer) *ResolvedWeaponDamage {
 	maxST := w.Owner.ResolvedMinimumStrength().Mul(fxp.Three)
-	st := pc.StrengthOrZero() + pc.StrikingStrengthBonus
+	st += pc.StrikingStrengthBonus
 	if maxST > 0 && maxST < st {
 		st = maxST
 	}
 	base := &dice.Dice{
-		Sides:      6,
+		Sides:      DefaultDieSides,
 		Multiplier: 1,
 	}
 	if w.Base != nil {
@@ -278,39 +341,19 @@ func (w *WeaponDamage) ResolvedDamage(tooltip *xio.ByteBuffer) string {
 	if percentDRDivisorBonus != 0 {
 		armorDivisor = armorDivisor.Mul(percentDRDivisorBonus).Div(fxp.Hundred)
 	}
-	var buffer strings.Builder
-	if base.Count != 0 || base.Modifier != 0 {
-		buffer.WriteString(base.StringExtra(pc.SheetSettings.UseModifyingDicePlusAdds))
-	}
-	if armorDivisor != fxp.One {
-		buffer.WriteByte('(')
-		buffer.WriteString(armorDivisor.String())
-		buffer.WriteByte(')')
-	}
-	if strings.TrimSpace(w.Type) != "" {
-		if buffer.Len() != 0 {
-			buffer.WriteByte(' ')
-		}
-		buffer.WriteString(w.Type)
+	resolved := &ResolvedWeaponDamage{
+		Base:                     base,
+		ArmorDivisor:             armorDivisor,
+		Type:                     strings.TrimSpace(w.Type),
+		UseModifyingDicePlusAdds: pc.SheetSettings.UseModifyingDicePlusAdds,
 	}
 	if w.Fragmentation != nil {
-		if frag := w.Fragmentation.StringExtra(pc.SheetSettings.UseModifyingDicePlusAdds); frag != "0" {
-			if buffer.Len() != 0 {
-				buffer.WriteByte(' ')
-			}
-			buffer.WriteByte('[')
-			buffer.WriteString(frag)
-			if w.FragmentationArmorDivisor != 1 {
-				buffer.WriteByte('(')
-				buffer.WriteString(w.FragmentationArmorDivisor.String())
-				buffer.WriteByte(')')
-			}
-			buffer.WriteByte(' ')
-			buffer.WriteString(w.FragmentationType)
-			buffer.WriteByte(']')
-		}
+		frag := *w.Fragmentation
+		resolved.Fragmentation = &frag
+		resolved.FragmentationArmorDivisor = w.FragmentationArmorDivisor
+		resolved.FragmentationType = w.FragmentationType
 	}
-	return buffer.String()
+	return resolved
 }
 
 func (w *WeaponDamage) extractWeaponBonus(f Feature, set map[*WeaponBonus]bool, dieCount, levels fxp.Int, tooltip *xio.ByteBuffer) {
@@ -0,0 +1,85 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// Possible values.
+const (
+	AddOnePerDieDamageScaling DamageScaling = iota
+	SubtractOnePerDieDamageScaling
+	AddOneDieDamageScaling
+	DoubleArmorDivisorDamageScaling
+	HalveArmorDivisorDamageScaling
+)
+
+// AllDamageScaling holds all possible values.
+var AllDamageScaling = []DamageScaling{
+	AddOnePerDieDamageScaling,
+	SubtractOnePerDieDamageScaling,
+	AddOneDieDamageScaling,
+	DoubleArmorDivisorDamageScaling,
+	HalveArmorDivisorDamageScaling,
+}
+
+// DamageScaling identifies a transform that can be applied to a weapon's damage, such as when building a higher tech
+// level variant of it.
+type DamageScaling byte
+
+// String implements fmt.Stringer.
+func (s DamageScaling) String() string {
+	switch s {
+	case AddOnePerDieDamageScaling:
+		return i18n.Text("+1 per die")
+	case SubtractOnePerDieDamageScaling:
+		return i18n.Text("-1 per die")
+	case AddOneDieDamageScaling:
+		return i18n.Text("+1 die")
+	case DoubleArmorDivisorDamageScaling:
+		return i18n.Text("×2 armor divisor")
+	case HalveArmorDivisorDamageScaling:
+		return i18n.Text("÷2 armor divisor")
+	default:
+		return ""
+	}
+}
+
+// Apply the transform to the damage. Per-die adjustments use the modifier per die, so they scale with strength-based
+// damage as well as with fixed dice. Adding a die to strength-based damage without base dice adds a 1d base.
+func (s DamageScaling) Apply(damage *WeaponDamage) {
+	switch s {
+	case AddOnePerDieDamageScaling:
+		damage.ModifierPerDie += fxp.One
+	case SubtractOnePerDieDamageScaling:
+		damage.ModifierPerDie -= fxp.One
+	case AddOneDieDamageScaling:
+		if damage.Base == nil {
+			damage.Base = &dice.Dice{Sides: DefaultDieSides, Multiplier: 1}
+		}
+		damage.Base.Count++
+	case DoubleArmorDivisorDamageScaling:
+		damage.ArmorDivisor = damage.effectiveArmorDivisor().Mul(fxp.Two)
+	case HalveArmorDivisorDamageScaling:
+		damage.ArmorDivisor = damage.effectiveArmorDivisor().Div(fxp.Two)
+	}
+}
+
+func (w *WeaponDamage) effectiveArmorDivisor() fxp.Int {
+	if w.ArmorDivisor <= 0 {
+		return fxp.One
+	}
+	return w.ArmorDivisor
+}
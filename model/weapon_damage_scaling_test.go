@@ -0,0 +1,47 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDamageScaling(t *testing.T) {
+	w := NewWeapon(nil, MeleeWeaponType)
+	w.Damage.Base = dice.New("2d+1")
+	w.Damage.Type = "cut"
+	AddOnePerDieDamageScaling.Apply(&w.Damage)
+	AddOnePerDieDamageScaling.Apply(&w.Damage)
+	require.Equal(t, fxp.Two, w.Damage.ModifierPerDie)
+	SubtractOnePerDieDamageScaling.Apply(&w.Damage)
+	require.Equal(t, fxp.One, w.Damage.ModifierPerDie)
+	AddOneDieDamageScaling.Apply(&w.Damage)
+	require.Equal(t, 3, w.Damage.Base.Count)
+	require.Equal(t, 1, w.Damage.Base.Modifier)
+
+	require.Equal(t, fxp.One, w.Damage.ArmorDivisor)
+	DoubleArmorDivisorDamageScaling.Apply(&w.Damage)
+	DoubleArmorDivisorDamageScaling.Apply(&w.Damage)
+	require.Equal(t, fxp.Four, w.Damage.ArmorDivisor)
+	HalveArmorDivisorDamageScaling.Apply(&w.Damage)
+	require.Equal(t, fxp.Two, w.Damage.ArmorDivisor)
+
+	st := NewWeapon(nil, MeleeWeaponType)
+	st.Damage.StrengthType = SwingStrengthDamage
+	st.Damage.Base = nil
+	AddOneDieDamageScaling.Apply(&st.Damage)
+	require.Equal(t, dice.Dice{Count: 1, Sides: 6, Multiplier: 1}, *st.Damage.Base)
+}
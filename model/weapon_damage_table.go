@@ -0,0 +1,66 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// WeaponDamageTableRow holds the damage a weapon does when wielded at a particular ST.
+type WeaponDamageTableRow struct {
+	ST     int
+	Damage *ResolvedWeaponDamage
+}
+
+// DamageTable returns the weapon's resolved damage for each ST from minST to maxST, inclusive. Thrust and swing based
+// damage scale with ST, subject to the usual cap of three times the weapon's minimum ST. When the weapon belongs to a
+// character, that character's bonuses and damage progression apply; otherwise, a new character with the default
+// settings is used.
+func (w *Weapon) DamageTable(minST, maxST int) []WeaponDamageTableRow {
+	if w.Owner == nil || maxST < minST {
+		return nil
+	}
+	pc := w.PC()
+	if pc == nil {
+		pc = NewEntity(PC)
+	}
+	damage := w.Damage
+	damage.Owner = w
+	rows := make([]WeaponDamageTableRow, 0, maxST-minST+1)
+	for st := minST; st <= maxST; st++ {
+		rows = append(rows, WeaponDamageTableRow{
+			ST:     st,
+			Damage: damage.resolveForST(pc, fxp.From(st), nil),
+		})
+	}
+	return rows
+}
+
+// DamageTableText returns the rows as tab-separated text with a header line, suitable for pasting into a spreadsheet.
+func DamageTableText(rows []WeaponDamageTableRow) string {
+	var buffer strings.Builder
+	buffer.WriteString(i18n.Text("ST"))
+	buffer.WriteByte('\t')
+	buffer.WriteString(i18n.Text("Damage"))
+	buffer.WriteByte('\n')
+	for _, row := range rows {
+		buffer.WriteString(strconv.Itoa(row.ST))
+		buffer.WriteByte('\t')
+		buffer.WriteString(row.Damage.String())
+		buffer.WriteByte('\n')
+	}
+	return buffer.String()
+}
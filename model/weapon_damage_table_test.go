@@ -0,0 +1,53 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeaponDamageTable(t *testing.T) {
+	entity := NewEntity(PC)
+	eqp := NewEquipment(entity, nil, false)
+	entity.CarriedEquipment = append(entity.CarriedEquipment, eqp)
+	w := NewWeapon(eqp, MeleeWeaponType)
+	w.Damage.StrengthType = SwingStrengthDamage
+	w.Damage.Base = dice.New("2")
+	w.Damage.Type = "cut"
+	eqp.Weapons = append(eqp.Weapons, w)
+	entity.Recalculate()
+
+	rows := w.DamageTable(8, 12)
+	require.Len(t, rows, 5)
+	require.Equal(t, 8, rows[0].ST)
+	require.Equal(t, 12, rows[4].ST)
+	for _, row := range rows {
+		expected := entity.SwingFor(row.ST)
+		expected.Modifier += 2
+		require.Equal(t, expected.String()+" cut", row.Damage.String(), "ST %d", row.ST)
+	}
+
+	w.MinimumStrength = "3"
+	rows = w.DamageTable(9, 10)
+	require.Equal(t, rows[0].Damage.String(), rows[1].Damage.String(), "damage is capped at 3× minimum ST")
+
+	require.Nil(t, w.DamageTable(10, 9))
+
+	text := DamageTableText(w.DamageTable(10, 10))
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	require.Len(t, lines, 2)
+	require.True(t, strings.HasPrefix(lines[1], "10\t"))
+}
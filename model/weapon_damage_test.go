@@ -0,0 +1,40 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeaponDamageFragmentationString(t *testing.T) {
+	entity := NewEntity(PC)
+	eqp := NewEquipment(entity, nil, false)
+	entity.CarriedEquipment = append(entity.CarriedEquipment, eqp)
+	w := NewWeapon(eqp, RangedWeaponType)
+	w.Damage.Base = dice.New("3d")
+	w.Damage.Type = "cr ex"
+	w.Damage.Fragmentation = dice.New("2d")
+	w.Damage.FragmentationArmorDivisor = fxp.One
+	w.Damage.FragmentationType = "cut"
+	eqp.Weapons = append(eqp.Weapons, w)
+	entity.Recalculate()
+	require.Equal(t, "3d cr ex [2d cut]", w.Damage.String())
+	require.Equal(t, "3d cr ex [2d cut]", w.Damage.Resolve(nil).String())
+
+	w.Damage.FragmentationArmorDivisor = fxp.From(2)
+	require.Equal(t, "3d cr ex [2d(2) cut]", w.Damage.String())
+	require.Equal(t, "3d cr ex [2d(2) cut]", w.Damage.Resolve(nil).String())
+}
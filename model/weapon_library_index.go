@@ -0,0 +1,103 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/log/jot"
+	"github.com/richardwilkes/toolbox/txt"
+)
+
+var weaponLibraryIndex = newBackgroundLibraryIndex(newWeaponLibraryIndex(), NewWeaponLibraryIndex)
+
+// WeaponLibraryEntry holds a weapon found in a library, along with the name to present it with.
+type WeaponLibraryEntry struct {
+	Name   string
+	Weapon *Weapon
+}
+
+func (e *WeaponLibraryEntry) String() string {
+	return e.Name
+}
+
+// WeaponLibraryIndex holds the weapons found on the equipment within a set of libraries.
+type WeaponLibraryIndex struct {
+	entries map[WeaponType][]*WeaponLibraryEntry
+	seen    map[string]bool
+}
+
+// GlobalWeaponLibraryIndex returns the index of the weapons found in the configured libraries. The index is built in
+// the background, starting with the first call, and is empty until that build completes.
+func GlobalWeaponLibraryIndex() *WeaponLibraryIndex {
+	return weaponLibraryIndex.get()
+}
+
+// NewWeaponLibraryIndex creates a new index of the weapons found on the equipment in the libraries. When the same
+// equipment and usage is found more than once, the first one encountered is used.
+func NewWeaponLibraryIndex(libraries Libraries) *WeaponLibraryIndex {
+	index := newWeaponLibraryIndex()
+	for _, lib := range libraries.List() {
+		index.addFrom(os.DirFS(lib.Path()), ".")
+	}
+	index.finish()
+	return index
+}
+
+func newWeaponLibraryIndex() *WeaponLibraryIndex {
+	return &WeaponLibraryIndex{
+		entries: make(map[WeaponType][]*WeaponLibraryEntry),
+		seen:    make(map[string]bool),
+	}
+}
+
+func (x *WeaponLibraryIndex) addFrom(fileSystem fs.FS, dirPath string) {
+	walkLibraryFiles(fileSystem, dirPath, EquipmentExt, func(filePath string) {
+		list, err := NewEquipmentFromFile(fileSystem, filePath)
+		if err != nil {
+			jot.Warn(err)
+			return
+		}
+		Traverse(func(eqp *Equipment) bool {
+			for _, w := range eqp.Weapons {
+				name := strings.TrimSpace(eqp.Name)
+				if usage := strings.TrimSpace(w.Usage); usage != "" {
+					name += " (" + usage + ")"
+				}
+				key := w.Type.Key() + "|" + strings.ToLower(name)
+				if name != "" && !x.seen[key] {
+					x.seen[key] = true
+					x.entries[w.Type] = append(x.entries[w.Type], &WeaponLibraryEntry{
+						Name:   name,
+						Weapon: w,
+					})
+				}
+			}
+			return false
+		}, false, false, list...)
+	})
+}
+
+func (x *WeaponLibraryIndex) finish() {
+	for _, list := range x.entries {
+		sort.Slice(list, func(i, j int) bool { return txt.NaturalLess(list[i].Name, list[j].Name, true) })
+	}
+	x.seen = nil
+}
+
+// Entries returns the indexed weapons of the given type, sorted by name.
+func (x *WeaponLibraryIndex) Entries(weaponType WeaponType) []*WeaponLibraryEntry {
+	return x.entries[weaponType]
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeaponLibraryIndex(t *testing.T) {
+	dir := t.TempDir()
+	sword := NewEquipment(nil, nil, false)
+	sword.Name = "Longsword"
+	swung := NewWeapon(sword, MeleeWeaponType)
+	swung.Usage = "Swung"
+	swung.Reach = "1,2"
+	swung.Damage.Type = "cut"
+	thrust := NewWeapon(sword, MeleeWeaponType)
+	thrust.Usage = "Thrust"
+	sword.Weapons = []*Weapon{swung, thrust}
+	pistol := NewEquipment(nil, nil, false)
+	pistol.Name = "Pistol"
+	shot := NewWeapon(pistol, RangedWeaponType)
+	shot.Accuracy = "2"
+	pistol.Weapons = []*Weapon{shot}
+	require.NoError(t, SaveEquipment([]*Equipment{sword, pistol}, filepath.Join(dir, "Weapons"+EquipmentExt)))
+
+	index := newWeaponLibraryIndex()
+	index.addFrom(os.DirFS(dir), ".")
+	index.finish()
+	melee := index.Entries(MeleeWeaponType)
+	require.Len(t, melee, 2)
+	require.Equal(t, "Longsword (Swung)", melee[0].Name)
+	require.Equal(t, "Longsword (Thrust)", melee[1].Name)
+	ranged := index.Entries(RangedWeaponType)
+	require.Len(t, ranged, 1)
+	require.Equal(t, "Pistol", ranged[0].Name)
+
+	axe := NewEquipment(nil, nil, false)
+	w := NewWeapon(axe, MeleeWeaponType)
+	id := w.ID
+	w.FillFromLibraryWeapon(melee[0].Weapon)
+	require.Equal(t, id, w.ID)
+	require.Equal(t, WeaponOwner(axe), w.Owner)
+	require.Equal(t, "Swung", w.Usage)
+	require.Equal(t, "1,2", w.Reach)
+	require.Equal(t, "cut", w.Damage.Type)
+	require.Equal(t, w, w.Damage.Owner)
+}
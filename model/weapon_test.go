@@ -0,0 +1,121 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeaponStatBlock(t *testing.T) {
+	melee := NewWeapon(nil, MeleeWeaponType)
+	melee.Usage = "Swung"
+	melee.Damage.StrengthType = SwingStrengthDamage
+	melee.Damage.Base = dice.New("+2")
+	melee.Damage.Type = "cut"
+	melee.Reach = "1,2"
+	melee.Parry = "0"
+	melee.MinimumStrength = "11"
+	require.Equal(t, "Swung: sw+2 cut; Reach 1,2; Parry 0; ST 11", melee.StatBlock())
+
+	ranged := NewWeapon(nil, RangedWeaponType)
+	ranged.Damage.Base = dice.New("2d+2")
+	ranged.Damage.Type = "pi"
+	ranged.Accuracy = "2"
+	ranged.Range = "150/1,500"
+	ranged.Shots = "17(3)"
+	ranged.Bulk = "-2"
+	ranged.Recoil = "2"
+	require.Equal(t, ranged.Damage.String()+"; Acc 2; Range 150/1,500; RoF 1; Shots 17(3); Bulk -2; Rcl 2",
+		ranged.StatBlock())
+
+	empty := NewWeapon(nil, MeleeWeaponType)
+	empty.Usage = "Kick"
+	empty.Damage.StrengthType = NoneStrengthDamage
+	empty.Damage.Type = ""
+	empty.Reach = ""
+	require.Equal(t, "Kick", empty.StatBlock())
+}
+
+func TestWeaponReachAndParryAnnotations(t *testing.T) {
+	w := NewWeapon(nil, MeleeWeaponType)
+	w.Damage.StrengthType = SwingStrengthDamage
+	w.Damage.Type = "cr"
+	w.Reach = "1,2*"
+	w.Parry = "0U"
+	require.Empty(t, w.ReachAnnotations())
+	require.Equal(t, "sw cr; Reach 1,2*; Parry 0U", w.StatBlock())
+
+	w.ReachTwoHanded = true
+	w.ReachNotes = "3 if ST 13+"
+	w.ParryNotes = "-1 when prone"
+	require.Equal(t, "+1 when wielded two-handed, 3 if ST 13+", w.ReachAnnotations())
+	require.Equal(t, "sw cr; Reach 1,2* (+1 when wielded two-handed, 3 if ST 13+); Parry 0U (-1 when prone)",
+		w.StatBlock())
+
+	var data CellData
+	w.CellData(WeaponReachColumn, &data)
+	require.Equal(t, "1,2*", data.Primary)
+	require.Equal(t, w.ReachAnnotations(), data.Tooltip)
+
+	buffer, err := json.Marshal(w)
+	require.NoError(t, err)
+	var loaded Weapon
+	require.NoError(t, json.Unmarshal(buffer, &loaded))
+	require.True(t, loaded.ReachTwoHanded)
+	require.Equal(t, w.ReachNotes, loaded.ReachNotes)
+	require.Equal(t, w.ParryNotes, loaded.ParryNotes)
+}
+
+func TestWeaponBestDefault(t *testing.T) {
+	entity := NewEntity(PC)
+	skill := NewSkill(entity, nil, false)
+	skill.Name = "Broadsword"
+	skill.Points = fxp.Four
+	entity.SetSkillList([]*Skill{skill})
+	eqp := NewEquipment(entity, nil, false)
+	eqp.Name = "Broadsword"
+	w := NewWeapon(eqp, MeleeWeaponType)
+	eqp.Weapons = []*Weapon{w}
+	entity.SetCarriedEquipmentList([]*Equipment{eqp})
+
+	def, level := w.BestDefault()
+	require.Nil(t, def)
+	require.Equal(t, fxp.Int(0), level)
+
+	dxDefault := &SkillDefault{DefaultType: DexterityID, Modifier: -fxp.Five}
+	skillDefault := &SkillDefault{DefaultType: SkillID, Name: "Broadsword"}
+	w.Defaults = []*SkillDefault{dxDefault, skillDefault}
+	entity.Recalculate()
+	def, level = w.BestDefault()
+	require.Same(t, skillDefault, def)
+	require.Equal(t, w.SkillLevel(nil), level)
+	require.Equal(t, skill.LevelData.Level, level)
+
+	skillDefault.Modifier = -fxp.Ten
+	def, level = w.BestDefault()
+	require.Same(t, dxDefault, def)
+	require.Equal(t, entity.ResolveAttributeCurrent(DexterityID)-fxp.Five, level)
+
+	dxDefault.Disabled = true
+	def, _ = w.BestDefault()
+	require.Same(t, skillDefault, def)
+	skillDefault.Disabled = true
+	def, level = w.BestDefault()
+	require.Nil(t, def)
+	require.Equal(t, fxp.Int(0), level)
+	require.Equal(t, fxp.Int(0), w.SkillLevel(nil))
+}
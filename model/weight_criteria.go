@@ -21,7 +21,8 @@ type WeightCriteria struct {
 	WeightCriteriaData
 }
 
-// WeightCriteriaData holds the criteria for matching a number that should be written to disk.
+// WeightCriteriaData holds the criteria for matching a number that should be written to disk. The qualifier is always
+// stored in pounds, regardless of the units used to display or edit it.
 type WeightCriteriaData struct {
 	Compare   NumericCompareType `json:"compare,omitempty"`
 	Qualifier Weight             `json:"qualifier,omitempty"`
@@ -35,7 +36,7 @@ func (w WeightCriteria) ShouldOmit() bool {
 // UnmarshalJSON implements json.Unmarshaler.
 func (w *WeightCriteria) UnmarshalJSON(data []byte) error {
 	err := json.Unmarshal(data, &w.WeightCriteriaData)
-	w.Compare = w.Compare.EnsureValid()
+	w.Compare = w.Compare.EnsureValidSingleValue()
 	return err
 }
 
@@ -45,5 +46,24 @@ func (w WeightCriteria) Matches(value Weight) bool {
 }
 
 func (w WeightCriteria) String() string {
-	return w.Compare.Describe(fxp.Int(w.Qualifier))
+	return w.Describe(Pound)
+}
+
+// Describe returns a description of the criteria, with the weight expressed in the given units, such as "is at most
+// 5 kg".
+func (w WeightCriteria) Describe(units WeightUnits) string {
+	v := w.Compare.EnsureValid()
+	if v == AnyNumber {
+		return v.String()
+	}
+	return v.String() + " " + units.Format(w.Qualifier)
+}
+
+// CompactString returns a compact description, such as "≤ 10 lb", or an empty string if any value matches.
+func (w WeightCriteria) CompactString(units WeightUnits) string {
+	symbol := w.Compare.EnsureValid().Symbol()
+	if symbol == "" {
+		return ""
+	}
+	return symbol + " " + units.Format(w.Qualifier)
 }
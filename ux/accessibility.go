@@ -0,0 +1,137 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/unison"
+)
+
+// accessibleNameClientDataKey is the client data key used to hold a panel's accessible name.
+const accessibleNameClientDataKey = "accessible_name"
+
+// accessibleScale is the amount editor buttons are enlarged by when the accessibility mode is enabled.
+const accessibleScale = 1.5
+
+var (
+	highContrastContentColor   = &unison.ThemeColor{Light: unison.White, Dark: unison.Black}
+	highContrastBandingColor   = &unison.ThemeColor{Light: unison.RGB(224, 224, 224), Dark: unison.RGB(48, 48, 48)}
+	highContrastOnContentColor = &unison.ThemeColor{Light: unison.Black, Dark: unison.White}
+)
+
+// Inks for the editor panels that switch to a high-contrast palette when the accessibility mode is enabled.
+var (
+	editorContentInk    = &accessibleInk{normal: unison.ContentColor, highContrast: highContrastContentColor}
+	editorBandingInk    = &accessibleInk{normal: unison.BandingColor, highContrast: highContrastBandingColor}
+	editorIconButtonInk = &accessibleInk{normal: unison.IconButtonColor, highContrast: highContrastOnContentColor}
+)
+
+func accessibilityModeEnabled() bool {
+	return model.GlobalSettings().General.AccessibilityMode
+}
+
+// accessibleInk uses its high-contrast ink in place of its normal ink while the accessibility mode is enabled.
+type accessibleInk struct {
+	normal       unison.Ink
+	highContrast unison.Ink
+}
+
+// Paint implements unison.Ink.
+func (a *accessibleInk) Paint(canvas *unison.Canvas, rect unison.Rect, style unison.PaintStyle) *unison.Paint {
+	if accessibilityModeEnabled() {
+		return a.highContrast.Paint(canvas, rect, style)
+	}
+	return a.normal.Paint(canvas, rect, style)
+}
+
+// accessibleDrawableSVG enlarges its logical size while the accessibility mode is enabled.
+type accessibleDrawableSVG struct {
+	unison.DrawableSVG
+}
+
+// LogicalSize implements unison.Drawable.
+func (d *accessibleDrawableSVG) LogicalSize() unison.Size {
+	if accessibilityModeEnabled() {
+		size := unison.NewSize(d.Size.Width*accessibleScale, d.Size.Height*accessibleScale)
+		return *size.GrowToInteger()
+	}
+	return d.Size
+}
+
+// SetAccessibleName sets the accessible name of the panel. Unison doesn't yet expose panels to the platform's
+// accessibility services, so the name is kept in the panel's client data where assistive tooling can query it.
+func SetAccessibleName(panel *unison.Panel, name string) {
+	panel.ClientData()[accessibleNameClientDataKey] = name
+}
+
+// AccessibleName returns the accessible name of the panel, or an empty string if it doesn't have one.
+func AccessibleName(panel *unison.Panel) string {
+	if name, ok := panel.ClientData()[accessibleNameClientDataKey].(string); ok {
+		return name
+	}
+	return ""
+}
+
+// NewSVGButtonWithTooltip creates a new SVG button with a tooltip, so that its purpose can be discovered without
+// recognizing the icon. The tooltip text also becomes the button's accessible name.
+func NewSVGButtonWithTooltip(svg *unison.SVG, tooltip string) *unison.Button {
+	b := unison.NewSVGButton(svg)
+	SetAccessibleName(b.AsPanel(), tooltip)
+	b.Tooltip = unison.NewTooltipWithText(tooltip)
+	return b
+}
+
+// NewSVGButtonWithSecondaryTooltip creates a new SVG button with a tooltip that also shows secondary text, such as a
+// keyboard shortcut. The primary tooltip text becomes the button's accessible name.
+func NewSVGButtonWithSecondaryTooltip(svg *unison.SVG, tooltip, secondary string) *unison.Button {
+	b := unison.NewSVGButton(svg)
+	SetAccessibleName(b.AsPanel(), tooltip)
+	b.Tooltip = unison.NewTooltipWithSecondaryText(tooltip, secondary)
+	return b
+}
+
+// newEditorSVGButton creates a new SVG button with a tooltip for use within editor panels. Its size, padding and colors
+// follow the accessibility mode, picking up changes to it on the next layout.
+func newEditorSVGButton(svg *unison.SVG, tooltip string) *unison.Button {
+	b := NewSVGButtonWithTooltip(svg, tooltip)
+	if drawable, ok := b.Drawable.(*unison.DrawableSVG); ok {
+		b.Drawable = &accessibleDrawableSVG{DrawableSVG: *drawable}
+	}
+	b.OnBackgroundInk = editorIconButtonInk
+	b.SetSizer(func(hint unison.Size) (min, pref, max unison.Size) {
+		b.DrawableOnlyHMargin = unison.DefaultSVGButtonTheme.DrawableOnlyHMargin
+		b.DrawableOnlyVMargin = unison.DefaultSVGButtonTheme.DrawableOnlyVMargin
+		if accessibilityModeEnabled() {
+			b.DrawableOnlyHMargin *= 2
+			b.DrawableOnlyVMargin *= 2
+		}
+		return b.DefaultSizes(hint)
+	})
+	return b
+}
+
+// appliedAccessibilityMode is the accessibility mode the windows were last refreshed for.
+var appliedAccessibilityMode bool
+
+// syncAccessibilityMode forces all windows to be laid out and redrawn if the accessibility mode setting differs from the
+// one they were last refreshed for, so that a change to it takes effect immediately.
+func syncAccessibilityMode() {
+	enabled := accessibilityModeEnabled()
+	if enabled == appliedAccessibilityMode {
+		return
+	}
+	appliedAccessibilityMode = enabled
+	for _, wnd := range unison.Windows() {
+		wnd.Content().MarkForLayoutRecursively()
+	}
+	unison.ThemeChanged()
+}
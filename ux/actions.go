@@ -32,10 +32,12 @@ var licenseMarkdownContent string
 // These actions are registered for key bindings.
 var (
 	addNaturalAttacksAction             *unison.Action
+	addTagToSelectionAction             *unison.Action
 	applyTemplateAction                 *unison.Action
 	clearPortraitAction                 *unison.Action
 	closeTabAction                      *unison.Action
 	colorSettingsAction                 *unison.Action
+	compareSheetsAction                 *unison.Action
 	convertToContainerAction            *unison.Action
 	convertToNonContainerAction         *unison.Action
 	copyToSheetAction                   *unison.Action
@@ -48,17 +50,24 @@ var (
 	defaultBodyTypeSettingsAction       *unison.Action
 	defaultSheetSettingsAction          *unison.Action
 	duplicateAction                     *unison.Action
+	duplicateSheetAction                *unison.Action
+	exportAsHTMLAction                  *unison.Action
 	exportAsJPEGAction                  *unison.Action
+	exportAsMarkdownAction              *unison.Action
 	exportAsPDFAction                   *unison.Action
 	exportAsPNGAction                   *unison.Action
 	exportAsWEBPAction                  *unison.Action
+	findAndReplaceInNotesAction         *unison.Action
+	findBrokenPageRefsAction            *unison.Action
 	fontSettingsAction                  *unison.Action
 	generalSettingsAction               *unison.Action
 	increaseSkillLevelAction            *unison.Action
 	increaseTechLevelAction             *unison.Action
 	increaseUsesAction                  *unison.Action
 	incrementAction                     *unison.Action
+	jumpToItemAction                    *unison.Action
 	menuKeySettingsAction               *unison.Action
+	mergeLibraryAction                  *unison.Action
 	newCarriedEquipmentAction           *unison.Action
 	newCarriedEquipmentContainerAction  *unison.Action
 	newCharacterSheetAction             *unison.Action
@@ -69,6 +78,7 @@ var (
 	newEquipmentModifiersLibraryAction  *unison.Action
 	newMarkdownFileAction               *unison.Action
 	newMeleeWeaponAction                *unison.Action
+	newMultipleSpellsAction             *unison.Action
 	newNoteAction                       *unison.Action
 	newNoteContainerAction              *unison.Action
 	newNotesLibraryAction               *unison.Action
@@ -80,7 +90,10 @@ var (
 	newSkillContainerAction             *unison.Action
 	newSkillsLibraryAction              *unison.Action
 	newSpellAction                      *unison.Action
+	newSpellAfterSelectionAction        *unison.Action
 	newSpellContainerAction             *unison.Action
+	newSpellHeadingAction               *unison.Action
+	newSpellInsideContainerAction       *unison.Action
 	newSpellsLibraryAction              *unison.Action
 	newTechniqueAction                  *unison.Action
 	newTraitAction                      *unison.Action
@@ -97,8 +110,14 @@ var (
 	perSheetAttributeSettingsAction     *unison.Action
 	perSheetBodyTypeSettingsAction      *unison.Action
 	perSheetSettingsAction              *unison.Action
+	prereqSandboxAction                 *unison.Action
+	previewAttributeChangeAction        *unison.Action
 	printAction                         *unison.Action
+	randomizeProfileAction              *unison.Action
+	rebasePageRefsAction                *unison.Action
 	redoAction                          *unison.Action
+	removeTagFromSelectionAction        *unison.Action
+	revertToLibraryAction               *unison.Action
 	saveAction                          *unison.Action
 	saveAsAction                        *unison.Action
 	scale25Action                       *unison.Action
@@ -114,6 +133,11 @@ var (
 	scaleDownAction                     *unison.Action
 	scaleUpAction                       *unison.Action
 	swapDefaultsAction                  *unison.Action
+	selectNextUnmetPrereqAction         *unison.Action
+	showCollegeIndexAction              *unison.Action
+	showOnlyPreparedSpellsAction        *unison.Action
+	showPointBudgetAction               *unison.Action
+	showRecentFilesAction               *unison.Action
 	toggleStateAction                   *unison.Action
 	undoAction                          *unison.Action
 )
@@ -198,6 +222,11 @@ func registerActions() {
 		Title:           i18n.Text("Colors…"),
 		ExecuteCallback: func(_ *unison.Action, _ any) { ShowColorSettings() },
 	})
+	compareSheetsAction = registerKeyBindableAction("compare.sheets", &unison.Action{
+		ID:              CompareSheetsItemID,
+		Title:           i18n.Text("Compare Sheets…"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { ShowCompareSheets() },
+	})
 	convertToContainerAction = registerKeyBindableAction("convert.to_container", &unison.Action{
 		ID:              ConvertToContainerItemID,
 		Title:           i18n.Text("Convert to Container"),
@@ -275,12 +304,30 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	duplicateSheetAction = registerKeyBindableAction("duplicate.sheet", &unison.Action{
+		ID:              DuplicateSheetItemID,
+		Title:           i18n.Text("Duplicate Sheet"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	exportAsHTMLAction = registerKeyBindableAction("export.html", &unison.Action{
+		ID:              ExportAsHTMLItemID,
+		Title:           i18n.Text("HTML"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	exportAsJPEGAction = registerKeyBindableAction("export.jpeg", &unison.Action{
 		ID:              ExportAsJPEGItemID,
 		Title:           i18n.Text("JPEG"),
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	exportAsMarkdownAction = registerKeyBindableAction("export.markdown", &unison.Action{
+		ID:              ExportAsMarkdownItemID,
+		Title:           i18n.Text("Markdown"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	exportAsPDFAction = registerKeyBindableAction("export.pdf", &unison.Action{
 		ID:              ExportAsPDFItemID,
 		Title:           i18n.Text("PDF"),
@@ -300,6 +347,18 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	findAndReplaceInNotesAction = registerKeyBindableAction("notes.replace", &unison.Action{
+		ID:              FindAndReplaceInNotesItemID,
+		Title:           i18n.Text("Find & Replace in Notes…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	findBrokenPageRefsAction = registerKeyBindableAction("pageref.broken", &unison.Action{
+		ID:              FindBrokenPageRefsItemID,
+		Title:           i18n.Text("Find Broken Page References"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	fontSettingsAction = registerKeyBindableAction("settings.fonts", &unison.Action{
 		ID:              FontSettingsItemID,
 		Title:           i18n.Text("Fonts…"),
@@ -338,11 +397,24 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	jumpToItemAction = registerKeyBindableAction("jump.item", &unison.Action{
+		ID:              JumpToItemItemID,
+		Title:           i18n.Text("Jump to Item…"),
+		KeyBinding:      unison.KeyBinding{KeyCode: unison.KeyP, Modifiers: unison.OptionModifier | unison.OSMenuCmdModifier()},
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	menuKeySettingsAction = registerKeyBindableAction("settings.keys", &unison.Action{
 		ID:              MenuKeySettingsItemID,
 		Title:           i18n.Text("Menu Keys…"),
 		ExecuteCallback: func(_ *unison.Action, _ any) { ShowMenuKeySettings() },
 	})
+	mergeLibraryAction = registerKeyBindableAction("merge.library", &unison.Action{
+		ID:              MergeLibraryItemID,
+		Title:           i18n.Text("Merge Library…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	newCarriedEquipmentAction = registerKeyBindableAction("new.eqp", &unison.Action{
 		ID:              NewCarriedEquipmentItemID,
 		Title:           i18n.Text("New Carried Equipment"),
@@ -505,6 +577,30 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	newSpellHeadingAction = registerKeyBindableAction("new.spl.heading", &unison.Action{
+		ID:              NewSpellHeadingItemID,
+		Title:           i18n.Text("New Spell Heading"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	newSpellInsideContainerAction = registerKeyBindableAction("new.spl.inside", &unison.Action{
+		ID:              NewSpellInsideContainerItemID,
+		Title:           i18n.Text("New Spell Inside Selected Container"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	newSpellAfterSelectionAction = registerKeyBindableAction("new.spl.after", &unison.Action{
+		ID:              NewSpellAfterSelectionItemID,
+		Title:           i18n.Text("New Spell After Selection"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	newMultipleSpellsAction = registerKeyBindableAction("new.spl.multiple", &unison.Action{
+		ID:              NewMultipleSpellsItemID,
+		Title:           i18n.Text("New Multiple Spells…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	newSpellsLibraryAction = registerKeyBindableAction("new.spl.lib", &unison.Action{
 		ID:    NewSpellsLibraryItemID,
 		Title: i18n.Text("New Spells Library"),
@@ -639,6 +735,17 @@ func registerActions() {
 			}
 		},
 	})
+	prereqSandboxAction = registerKeyBindableAction("prereq.sandbox", &unison.Action{
+		ID:              PrereqSandboxItemID,
+		Title:           i18n.Text("Prerequisite Sandbox…"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { ShowPrereqSandbox() },
+	})
+	previewAttributeChangeAction = registerKeyBindableAction("attributes.preview", &unison.Action{
+		ID:              PreviewAttributeChangeItemID,
+		Title:           i18n.Text("Preview Attribute Change…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	printAction = registerKeyBindableAction("print", &unison.Action{
 		ID:              PrintItemID,
 		Title:           i18n.Text("Print…"),
@@ -646,6 +753,12 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	randomizeProfileAction = registerKeyBindableAction("randomize.profile", &unison.Action{
+		ID:              RandomizeProfileItemID,
+		Title:           i18n.Text("Randomize Profile…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	redoAction = registerKeyBindableAction("redo", &unison.Action{
 		ID:         RedoItemID,
 		Title:      unison.CannotRedoTitle(),
@@ -773,6 +886,60 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	selectNextUnmetPrereqAction = registerKeyBindableAction("select.unmet_prereq", &unison.Action{
+		ID:              SelectNextUnmetPrereqItemID,
+		Title:           i18n.Text("Select Next Unmet Prerequisite"),
+		KeyBinding:      unison.KeyBinding{KeyCode: unison.KeyU, Modifiers: unison.ShiftModifier | unison.OSMenuCmdModifier()},
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	addTagToSelectionAction = registerKeyBindableAction("tag.add", &unison.Action{
+		ID:              AddTagToSelectionItemID,
+		Title:           i18n.Text("Add Tag to Selected…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	removeTagFromSelectionAction = registerKeyBindableAction("tag.remove", &unison.Action{
+		ID:              RemoveTagFromSelectionItemID,
+		Title:           i18n.Text("Remove Tag from Selected…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	rebasePageRefsAction = registerKeyBindableAction("pageref.rebase", &unison.Action{
+		ID:              RebasePageRefsItemID,
+		Title:           i18n.Text("Rebase Page References…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	revertToLibraryAction = registerKeyBindableAction("revert.library", &unison.Action{
+		ID:              RevertToLibraryItemID,
+		Title:           i18n.Text("Revert to Library Version…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	showCollegeIndexAction = registerKeyBindableAction("spells.colleges", &unison.Action{
+		ID:              ShowCollegeIndexItemID,
+		Title:           i18n.Text("Show Spell Colleges"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	showOnlyPreparedSpellsAction = registerKeyBindableAction("spells.prepared_only", &unison.Action{
+		ID:              ShowOnlyPreparedSpellsItemID,
+		Title:           i18n.Text("Show Only Prepared Spells"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	showPointBudgetAction = registerKeyBindableAction("points.budget", &unison.Action{
+		ID:              ShowPointBudgetItemID,
+		Title:           i18n.Text("Show Point Budget"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	showRecentFilesAction = registerKeyBindableAction("recent.show", &unison.Action{
+		ID:              ShowRecentFilesItemID,
+		Title:           i18n.Text("Show Recent Files"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { ShowRecentFiles() },
+	})
 	toggleStateAction = registerKeyBindableAction("toggle", &unison.Action{
 		ID:              ToggleStateItemID,
 		Title:           i18n.Text("Toggle State"),
@@ -0,0 +1,124 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+func resolveAttack(table *unison.Table[*Node[*model.Weapon]]) {
+	if rows := table.SelectedRows(false); len(rows) == 1 {
+		ShowAttackResolver(rows[0].Data())
+	}
+}
+
+// ShowAttackResolver displays a small panel that resolves attacks made with the weapon, taking a situational modifier
+// into account.
+func ShowAttackResolver(w *model.Weapon) {
+	entity := w.PC()
+	if entity == nil {
+		unison.ErrorDialogWithMessage(i18n.Text("Unable to resolve attack"),
+			i18n.Text("Attacks can only be resolved for weapons on a character sheet."))
+		return
+	}
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	title := w.String()
+	if w.Usage != "" {
+		title += " (" + w.Usage + ")"
+	}
+	titleLabel := unison.NewLabel()
+	titleLabel.Text = title
+	titleLabel.Font = unison.SystemFont
+	titleLabel.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	panel.AddChild(titleLabel)
+
+	if w.Type == model.MeleeWeaponType {
+		for _, one := range []struct {
+			label, value, annotations string
+		}{
+			{i18n.Text("Reach"), w.Reach, w.ReachAnnotations()},
+			{i18n.Text("Parry"), w.ResolvedParry(nil), w.ParryAnnotations()},
+		} {
+			if one.annotations != "" {
+				panel.AddChild(NewFieldLeadingLabel(one.label))
+				valueLabel := unison.NewLabel()
+				valueLabel.Text = one.value + " (" + one.annotations + ")"
+				panel.AddChild(valueLabel)
+			}
+		}
+	}
+
+	var modifier int
+	label := i18n.Text("Modifier")
+	panel.AddChild(NewFieldLeadingLabel(label))
+	panel.AddChild(NewIntegerField(nil, "", label, func() int { return modifier }, func(v int) { modifier = v },
+		-99, 99, true, false))
+
+	resultLabels := make([]*unison.Label, 0, 4)
+	for _, one := range []string{
+		i18n.Text("Effective Skill"),
+		i18n.Text("Roll"),
+		i18n.Text("Outcome"),
+		i18n.Text("Damage"),
+	} {
+		panel.AddChild(NewFieldLeadingLabel(one))
+		resultLabel := unison.NewLabel()
+		panel.AddChild(resultLabel)
+		resultLabels = append(resultLabels, resultLabel)
+	}
+
+	panel.AddChild(unison.NewPanel())
+	attackButton := unison.NewButton()
+	attackButton.Text = i18n.Text("Attack")
+	attackButton.ClickCallback = func() {
+		result, err := model.ResolveAttack(w, entity, modifier, nil)
+		if err != nil {
+			unison.ErrorDialogWithError(i18n.Text("Unable to resolve attack"), err)
+			return
+		}
+		resultLabels[0].Text = fmt.Sprintf(i18n.Text("%d (base %d, modifier %+d)"), result.EffectiveSkill,
+			result.BaseSkill, result.Modifier)
+		resultLabels[1].Text = result.Roll.String()
+		resultLabels[2].Text = result.Outcome.String()
+		resultLabels[3].Text = ""
+		if result.Damage != nil {
+			resultLabels[3].Text = result.Damage.String()
+			if result.Outcome == model.AttackCriticalHit {
+				resultLabels[3].Text += " " + i18n.Text("(roll on the Critical Hit Table)")
+			}
+		} else if result.Outcome == model.AttackCriticalMiss {
+			resultLabels[3].Text = i18n.Text("(roll on the Critical Miss Table)")
+		}
+		if wnd := panel.Window(); wnd != nil {
+			wnd.Pack()
+		}
+		panel.MarkForLayoutAndRedraw()
+	}
+	panel.AddChild(attackButton)
+
+	dialog, err := unison.NewDialog(nil, nil, panel,
+		[]*unison.DialogButtonInfo{unison.NewOKButtonInfoWithTitle(i18n.Text("Done"))})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create attack resolver"), err)
+		return
+	}
+	dialog.RunModal()
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+func (s *Sheet) canPreviewAttributeChange(_ any) bool {
+	return len(s.previewableAttributes()) != 0
+}
+
+// previewableAttributes returns the primary attributes whose values can be edited directly.
+func (s *Sheet) previewableAttributes() []*model.Attribute {
+	var list []*model.Attribute
+	for _, def := range model.SheetSettingsFor(s.entity).Attributes.List(false) {
+		if !def.Primary() || def.IsSeparator() || def.Type == model.IntegerRefAttributeType ||
+			def.Type == model.DecimalRefAttributeType {
+			continue
+		}
+		if attr, ok := s.entity.Attributes.Set[def.ID()]; ok {
+			list = append(list, attr)
+		}
+	}
+	return list
+}
+
+func (s *Sheet) previewAttributeChange(_ any) {
+	attrs := s.previewableAttributes()
+	if len(attrs) == 0 {
+		return
+	}
+	names := make([]string, len(attrs))
+	for i, attr := range attrs {
+		names[i] = attr.AttributeDef().CombinedName()
+	}
+	attr := attrs[0]
+	value := attr.Maximum()
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	preview := unison.NewPanel()
+	preview.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+	})
+	preview.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: unison.FillAlignment,
+		HGrab:  true,
+	})
+	var dialog *unison.Dialog
+	update := func() {
+		preview.RemoveAllChildren()
+		changes, err := model.PreviewAttributeChange(s.entity, attr.AttrID, value)
+		switch {
+		case err != nil:
+			addPreviewLine(preview, err.Error())
+		case len(changes) == 0:
+			addPreviewLine(preview, i18n.Text("No changes."))
+		default:
+			for _, one := range changes {
+				label := unison.NewLabel()
+				label.Text = fmt.Sprintf("%s: %s", one.Category, one.Name)
+				preview.AddChild(label)
+				label = unison.NewLabel()
+				label.Text = one.Before
+				label.HAlign = unison.EndAlignment
+				preview.AddChild(label)
+				label = unison.NewLabel()
+				label.Text = "→ " + one.After
+				preview.AddChild(label)
+			}
+		}
+		if dialog != nil {
+			dialog.Button(unison.ModalResponseOK).SetEnabled(err == nil && len(changes) != 0)
+			dialog.Window().Pack()
+		}
+		preview.MarkForLayoutAndRedraw()
+	}
+
+	title := i18n.Text("Attribute")
+	panel.AddChild(NewFieldLeadingLabel(title))
+	popup := unison.NewPopupMenu[string]()
+	popup.AddItem(names...)
+	popup.SelectIndex(0)
+	panel.AddChild(popup)
+
+	title = i18n.Text("New Value")
+	panel.AddChild(NewFieldLeadingLabel(title))
+	field := NewDecimalField(nil, "", title, func() fxp.Int { return value }, func(v fxp.Int) {
+		value = v
+		update()
+	}, fxp.Min, fxp.Max, false, false)
+	panel.AddChild(field)
+	popup.SelectionChangedCallback = func(p *unison.PopupMenu[string]) {
+		attr = attrs[p.SelectedIndex()]
+		value = attr.Maximum()
+		field.Sync()
+		update()
+	}
+	panel.AddChild(preview)
+	update()
+
+	var err error
+	dialog, err = unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon, unison.DefaultDialogTheme.QuestionIconInk,
+		panel, []*unison.DialogButtonInfo{
+			unison.NewCancelButtonInfo(),
+			unison.NewOKButtonInfoWithTitle(i18n.Text("Apply")),
+		})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create attribute change preview dialog"), err)
+		return
+	}
+	dialog.Button(unison.ModalResponseOK).SetEnabled(false)
+	if dialog.RunModal() != unison.ModalResponseOK || attr.Maximum() == value {
+		return
+	}
+	before := attr.Adjustment
+	attr.SetMaximum(value)
+	s.undoMgr.Add(&unison.UndoEdit[fxp.Int]{
+		ID:         unison.NextUndoID(),
+		EditName:   fmt.Sprintf(i18n.Text("Set %s"), attr.AttributeDef().CombinedName()),
+		UndoFunc:   func(edit *unison.UndoEdit[fxp.Int]) { s.setAttributeAdjustment(attr, edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[fxp.Int]) { s.setAttributeAdjustment(attr, edit.AfterData) },
+		BeforeData: before,
+		AfterData:  attr.Adjustment,
+	})
+	s.MarkModified(s)
+}
+
+func (s *Sheet) setAttributeAdjustment(attr *model.Attribute, adjustment fxp.Int) {
+	attr.Adjustment = adjustment
+	s.MarkModified(s)
+}
+
+func addPreviewLine(panel *unison.Panel, text string) {
+	label := unison.NewLabel()
+	label.Text = text
+	label.SetLayoutData(&unison.FlexLayoutData{HSpan: 3})
+	panel.AddChild(label)
+}
@@ -33,7 +33,6 @@ type attributeSettingsDockable struct {
 	targetMgr       *TargetMgr
 	undoMgr         *unison.UndoManager
 	defs            *model.AttributeDefs
-	originalCRC     uint64
 	toolbar         *unison.Panel
 	content         *unison.Panel
 	applyButton     *unison.Button
@@ -41,7 +40,6 @@ type attributeSettingsDockable struct {
 	dragTargetPool  *poolSettingsPanel
 	defInsert       int
 	thresholdInsert int
-	promptForSave   bool
 	inDragOver      bool
 }
 
@@ -53,16 +51,21 @@ type attributeSettingsDragData struct {
 
 // ShowAttributeSettings the Attribute Settings. Pass in nil to edit the defaults or a sheet to edit the sheet's.
 func ShowAttributeSettings(owner EntityPanel) {
+	showAttributeSettings(owner)
+}
+
+func showAttributeSettings(owner EntityPanel) *attributeSettingsDockable {
+	var existing *attributeSettingsDockable
 	ws, dc, found := Activate(func(d unison.Dockable) bool {
 		if s, ok := d.(*attributeSettingsDockable); ok && owner == s.owner {
+			existing = s
 			return true
 		}
 		return false
 	})
 	if !found && ws != nil {
 		d := &attributeSettingsDockable{
-			owner:         owner,
-			promptForSave: true,
+			owner: owner,
 		}
 		d.Self = d
 		d.targetMgr = NewTargetMgr(d)
@@ -75,16 +78,18 @@ func ShowAttributeSettings(owner EntityPanel) {
 		}
 		d.TabIcon = svg.Attributes
 		d.defs.ResetTargetKeyPrefixes(d.targetMgr.NextPrefix)
-		d.originalCRC = d.defs.CRC64()
 		d.Extensions = []string{model.AttributesExt, model.AttributesExtAlt1, model.AttributesExtAlt2}
 		d.undoMgr = unison.NewUndoManager(100, func(err error) { jot.Error(err) })
 		d.Loader = d.load
 		d.Saver = d.save
 		d.Resetter = d.reset
 		d.ModifiedCallback = d.modified
-		d.WillCloseCallback = d.willClose
+		d.CRCSource = func() uint64 { return d.defs.CRC64() }
+		d.Applier = d.apply
 		d.Setup(ws, dc, d.addToStartToolbar, nil, d.initContent)
+		return d
 	}
+	return existing
 }
 
 func (d *attributeSettingsDockable) UndoManager() *unison.UndoManager {
@@ -92,25 +97,12 @@ func (d *attributeSettingsDockable) UndoManager() *unison.UndoManager {
 }
 
 func (d *attributeSettingsDockable) modified() bool {
-	modified := d.originalCRC != d.defs.CRC64()
+	modified := d.HasUnappliedChanges()
 	d.applyButton.SetEnabled(modified)
 	d.cancelButton.SetEnabled(modified)
 	return modified
 }
 
-func (d *attributeSettingsDockable) willClose() bool {
-	if d.promptForSave && d.originalCRC != d.defs.CRC64() {
-		switch unison.YesNoCancelDialog(fmt.Sprintf(i18n.Text("Apply changes made to\n%s?"), d.Title()), "") {
-		case unison.ModalResponseDiscard:
-		case unison.ModalResponseOK:
-			d.apply()
-		case unison.ModalResponseCancel:
-			return false
-		}
-	}
-	return true
-}
-
 func (d *attributeSettingsDockable) CloseWithGroup(other unison.Paneler) bool {
 	return d.owner != nil && d.owner == other
 }
@@ -118,35 +110,26 @@ func (d *attributeSettingsDockable) CloseWithGroup(other unison.Paneler) bool {
 func (d *attributeSettingsDockable) addToStartToolbar(toolbar *unison.Panel) {
 	d.toolbar = toolbar
 
-	helpButton := unison.NewSVGButton(svg.Help)
-	helpButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Help"))
+	helpButton := NewSVGButtonWithTooltip(svg.Help, i18n.Text("Help"))
 	helpButton.ClickCallback = func() { HandleLink(nil, "md:Help/Interface/Attributes") }
 	toolbar.AddChild(helpButton)
 
-	d.applyButton = unison.NewSVGButton(svg.Checkmark)
-	d.applyButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Apply Changes"))
+	d.applyButton = NewSVGButtonWithSecondaryTooltip(svg.Checkmark, i18n.Text("Apply Changes"),
+		fmt.Sprintf(i18n.Text("%v%v or %v%v"), unison.OSMenuCmdModifier(), unison.KeyReturn, unison.OSMenuCmdModifier(),
+			unison.KeyNumPadEnter))
 	d.applyButton.SetEnabled(false)
-	d.applyButton.ClickCallback = func() {
-		d.apply()
-		d.promptForSave = false
-		d.AttemptClose()
-	}
+	d.applyButton.ClickCallback = d.ApplyAndClose
 	toolbar.AddChild(d.applyButton)
 
-	d.cancelButton = unison.NewSVGButton(svg.Not)
-	d.cancelButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Discard Changes"))
+	d.cancelButton = NewSVGButtonWithSecondaryTooltip(svg.Not, i18n.Text("Discard Changes"), unison.KeyEscape.String())
 	d.cancelButton.SetEnabled(false)
-	d.cancelButton.ClickCallback = func() {
-		d.promptForSave = false
-		d.AttemptClose()
-	}
+	d.cancelButton.ClickCallback = d.DiscardAndClose
 	toolbar.AddChild(d.cancelButton)
 
 	toolbar.AddChild(NewToolbarSeparator())
 
-	addButton := unison.NewSVGButton(svg.CircledAdd)
 	addAttributeText := i18n.Text("Add Attribute")
-	addButton.Tooltip = unison.NewTooltipWithText(addAttributeText)
+	addButton := NewSVGButtonWithTooltip(svg.CircledAdd, addAttributeText)
 	addButton.ClickCallback = func() {
 		undo := &unison.UndoEdit[*model.AttributeDefs]{
 			ID:         unison.NextUndoID(),
@@ -275,6 +258,11 @@ func (d *attributeSettingsDockable) save(filePath string) error {
 	return d.defs.Save(filePath)
 }
 
+func (d *attributeSettingsDockable) settingsRecoveryData() (kind, ownerPath string, crc uint64,
+	saver func(filePath string) error) {
+	return attributesRecoveryKind, settingsOwnerPath(d.owner), d.currentCRC(), d.save
+}
+
 func (d *attributeSettingsDockable) apply() {
 	d.Window().FocusNext() // Intentionally move the focus to ensure any pending edits are flushed
 	if d.owner == nil {
@@ -0,0 +1,266 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/log/jot"
+	"github.com/richardwilkes/unison"
+)
+
+const autosaveInterval = 2 * time.Minute
+
+var (
+	_ recoverable         = &Sheet{}
+	_ recoverable         = &Template{}
+	_ recoverable         = &TableDockable[*model.Trait]{}
+	_ recoverable         = &MarkdownDockable{}
+	_ recoverableSettings = &attributeSettingsDockable{}
+	_ recoverableSettings = &bodySettingsDockable{}
+)
+
+const (
+	attributesRecoveryKind = "attributes"
+	bodyRecoveryKind       = "body"
+)
+
+// recoverable is implemented by file-backed dockables whose unsaved changes can be autosaved for crash recovery.
+type recoverable interface {
+	FileBackedDockable
+	// recoveryData returns a checksum of the current data, whether the document has yet to be given a real location,
+	// and the function that writes the data to a file. A nil saver means the document can't be autosaved.
+	recoveryData() (crc uint64, needsSaveAs bool, saver func(filePath string) error)
+	// restoreFromRecovery is called after loading an autosaved copy to point the dockable back at its original
+	// location and mark it as modified.
+	restoreFromRecovery(originalPath string, needsSaveAs bool)
+}
+
+// recoverableSettings is implemented by settings dockables whose unapplied changes can be autosaved for crash recovery.
+type recoverableSettings interface {
+	unison.Dockable
+	HasUnappliedChanges() bool
+	// settingsRecoveryData returns the kind of settings, the path of the sheet they belong to (empty for the defaults),
+	// a checksum of the current data and the function that writes the data to a file.
+	settingsRecoveryData() (kind, ownerPath string, crc uint64, saver func(filePath string) error)
+}
+
+var (
+	recoveryIndex *model.RecoveryIndex
+	recoveryIDs   = make(map[unison.Dockable]string)
+)
+
+// startAutosave offers to restore any documents left behind by a previous session that didn't exit cleanly, then
+// begins periodically autosaving modified documents.
+func startAutosave() {
+	recoveryIndex = model.NewRecoveryIndex(model.RecoveryDir())
+	offerRecovery()
+	unison.InvokeTaskAfter(autosave, autosaveInterval)
+}
+
+// stopAutosave discards the autosaved documents. Only call this once all documents have been saved or discarded.
+func stopAutosave() {
+	if recoveryIndex != nil {
+		if err := recoveryIndex.Clear(); err != nil {
+			jot.Error(err)
+		}
+	}
+}
+
+func offerRecovery() {
+	if len(recoveryIndex.Entries) == 0 {
+		return
+	}
+	names := make([]string, len(recoveryIndex.Entries))
+	for i, entry := range recoveryIndex.Entries {
+		names[i] = recoveryEntryTitle(entry)
+	}
+	label := unison.NewLabel()
+	label.Text = i18n.Text("Unsaved changes from a previous session were found for:")
+	detail := unison.NewLabel()
+	detail.Text = "● " + strings.Join(names, "\n● ")
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(label)
+	panel.AddChild(detail)
+	dialog, err := unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon, unison.DefaultDialogTheme.QuestionIconInk,
+		panel, []*unison.DialogButtonInfo{
+			{
+				Title:        i18n.Text("Discard"),
+				ResponseCode: unison.ModalResponseDiscard,
+				KeyCodes:     []unison.KeyCode{unison.KeyD},
+			},
+			unison.NewOKButtonInfoWithTitle(i18n.Text("Restore")),
+		})
+	if err != nil {
+		jot.Error(err)
+		return
+	}
+	keep := make(map[string]bool)
+	if dialog.RunModal() == unison.ModalResponseOK {
+		// Documents are restored first, so that any settings belonging to a restored sheet are attached to it.
+		for _, entry := range recoveryIndex.Entries {
+			if entry.Settings == "" && restoreRecoveryEntry(entry) {
+				keep[entry.ID] = true
+			}
+		}
+		for _, entry := range recoveryIndex.Entries {
+			if entry.Settings != "" && restoreSettingsRecoveryEntry(entry) {
+				keep[entry.ID] = true
+			}
+		}
+	}
+	if err = recoveryIndex.Retain(keep); err != nil {
+		jot.Error(err)
+	}
+}
+
+func restoreRecoveryEntry(entry *model.RecoveryEntry) bool {
+	fi := model.FileInfoFor(entry.OriginalPath)
+	if fi.IsSpecial || fi.Load == nil {
+		return false
+	}
+	d, err := fi.Load(recoveryIndex.FilePath(entry))
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to restore ")+filepath.Base(entry.OriginalPath), err)
+		return false
+	}
+	r, ok := d.(recoverable)
+	if !ok {
+		return false
+	}
+	DisplayNewDockable(nil, r)
+	r.restoreFromRecovery(entry.OriginalPath, entry.NeedsSaveAs)
+	recoveryIDs[r] = entry.ID
+	return true
+}
+
+func recoveryEntryTitle(entry *model.RecoveryEntry) string {
+	var title string
+	switch entry.Settings {
+	case "":
+		return filepath.Base(entry.OriginalPath)
+	case attributesRecoveryKind:
+		title = i18n.Text("Attributes")
+	case bodyRecoveryKind:
+		title = i18n.Text("Body Type")
+	default:
+		title = entry.Settings
+	}
+	if entry.OriginalPath == "" {
+		return title + i18n.Text(" (defaults)")
+	}
+	return title + ": " + filepath.Base(entry.OriginalPath)
+}
+
+func restoreSettingsRecoveryEntry(entry *model.RecoveryEntry) bool {
+	var owner EntityPanel
+	if entry.OriginalPath != "" {
+		d, _ := OpenFile(nil, entry.OriginalPath)
+		sheet, ok := d.(*Sheet)
+		if !ok {
+			return false
+		}
+		owner = sheet
+	}
+	var d interface {
+		recoverableSettings
+		load(fileSystem fs.FS, filePath string) error
+	}
+	switch entry.Settings {
+	case attributesRecoveryKind:
+		d = showAttributeSettings(owner)
+	case bodyRecoveryKind:
+		d = showBodySettings(owner)
+	default:
+		return false
+	}
+	if toolbox.IsNil(d) {
+		return false
+	}
+	filePath := recoveryIndex.FilePath(entry)
+	if err := d.load(os.DirFS(filepath.Dir(filePath)), filepath.Base(filePath)); err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to restore ")+recoveryEntryTitle(entry), err)
+		return false
+	}
+	MarkModified(d)
+	recoveryIDs[d] = entry.ID
+	return true
+}
+
+// autosave writes a copy of each modified document to the recovery directory, skipping any whose data hasn't changed
+// since the last autosave, and drops the copies of documents that have since been saved or closed.
+func autosave() {
+	keep := make(map[string]bool)
+	live := make(map[unison.Dockable]bool)
+	for _, wnd := range unison.Windows() {
+		ws := WorkspaceFromWindow(wnd)
+		if ws == nil {
+			continue
+		}
+		ws.DocumentDock.RootDockLayout().ForEachDockContainer(func(dc *unison.DockContainer) bool {
+			for _, one := range dc.Dockables() {
+				if s, ok := one.(recoverableSettings); ok && s.HasUnappliedChanges() {
+					kind, ownerPath, crc, saver := s.settingsRecoveryData()
+					id, err := recoveryIndex.PutSettings(recoveryIDs[s], kind, ownerPath, crc, saver)
+					if err != nil {
+						jot.Error(err)
+						continue
+					}
+					recoveryIDs[s] = id
+					keep[id] = true
+					live[s] = true
+				}
+				if r, ok := one.(recoverable); ok && r.Modified() {
+					if crc, needsSaveAs, saver := r.recoveryData(); saver != nil {
+						id, err := recoveryIndex.Put(recoveryIDs[r], r.BackingFilePath(), needsSaveAs, crc, saver)
+						if err != nil {
+							jot.Error(err)
+							continue
+						}
+						recoveryIDs[r] = id
+						keep[id] = true
+						live[r] = true
+					}
+				}
+			}
+			return false
+		})
+	}
+	for r := range recoveryIDs {
+		if !live[r] {
+			delete(recoveryIDs, r)
+		}
+	}
+	if err := recoveryIndex.Retain(keep); err != nil {
+		jot.Error(err)
+	}
+	unison.InvokeTaskAfter(autosave, autosaveInterval)
+}
+
+// settingsOwnerPath returns the path of the sheet that owns a settings dockable, or an empty string for the defaults.
+func settingsOwnerPath(owner EntityPanel) string {
+	if fb, ok := owner.(FileBackedDockable); ok {
+		return fb.BackingFilePath()
+	}
+	return ""
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath"
+	"github.com/richardwilkes/unison"
+)
+
+const paperDollScale = 3
+
+// bodyPaperDoll shows the top-level hit locations of the body type being edited as regions of a schematic body
+// silhouette. Clicking a region moves the focus to the corresponding hit location in the list.
+type bodyPaperDoll struct {
+	unison.Panel
+	dockable *bodySettingsDockable
+	regions  []*model.PaperDollRegion
+	hover    *model.PaperDollRegion
+}
+
+func newBodyPaperDoll(d *bodySettingsDockable) *bodyPaperDoll {
+	p := &bodyPaperDoll{dockable: d}
+	p.Self = p
+	p.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.DividerColor, 0, unison.Insets{Bottom: 1}, false),
+		unison.NewEmptyBorder(unison.StdInsets())))
+	p.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: unison.FillAlignment,
+		HGrab:  true,
+	})
+	p.SetSizer(p.sizer)
+	p.DrawCallback = p.draw
+	p.MouseMoveCallback = p.mouseMove
+	p.MouseExitCallback = p.mouseExit
+	p.MouseDownCallback = p.mouseDown
+	p.UpdateTooltipCallback = p.updateTooltip
+	return p
+}
+
+func (p *bodyPaperDoll) sizer(_ unison.Size) (min, pref, max unison.Size) {
+	var width, height float32
+	for _, r := range p.dockable.body.PaperDoll() {
+		width = xmath.Max(width, r.X+r.Width)
+		height = xmath.Max(height, r.Y+r.Height)
+	}
+	pref = unison.NewSize(width*paperDollScale, height*paperDollScale)
+	pref.AddInsets(p.Border().Insets())
+	return pref, pref, unison.NewSize(unison.DefaultMaxSize, pref.Height)
+}
+
+// origin returns the location of the silhouette's top-left corner, which is centered horizontally.
+func (p *bodyPaperDoll) origin() unison.Point {
+	r := p.ContentRect(false)
+	_, pref, _ := p.sizer(unison.Size{})
+	insets := p.Border().Insets()
+	x := r.X + (r.Width-(pref.Width-insets.Width()))/2
+	if x < r.X {
+		x = r.X
+	}
+	return unison.NewPoint(x, r.Y)
+}
+
+func (p *bodyPaperDoll) draw(gc *unison.Canvas, dirty unison.Rect) {
+	gc.DrawRect(dirty, unison.ContentColor.Paint(gc, dirty, unison.Fill))
+	p.regions = p.dockable.body.PaperDoll()
+	origin := p.origin()
+	font := unison.LabelFont
+	for _, r := range p.regions {
+		rect := unison.NewRect(origin.X+r.X*paperDollScale, origin.Y+r.Y*paperDollScale, r.Width*paperDollScale,
+			r.Height*paperDollScale)
+		var bg, fg unison.Ink = unison.BandingColor, unison.OnBandingColor
+		if p.hover != nil && p.hover.Location == r.Location {
+			bg, fg = unison.SelectionColor, unison.OnSelectionColor
+		}
+		gc.DrawRect(rect, bg.Paint(gc, rect, unison.Fill))
+		gc.DrawRect(rect, unison.ControlEdgeColor.Paint(gc, rect, unison.Stroke))
+		text := strconv.Itoa(r.Location.HitPenalty)
+		if width := font.SimpleWidth(text); width <= rect.Width && font.LineHeight() <= rect.Height {
+			gc.DrawSimpleString(text, rect.X+(rect.Width-width)/2,
+				rect.Y+(rect.Height-font.LineHeight())/2+font.Baseline(), font, fg.Paint(gc, rect, unison.Fill))
+		}
+	}
+}
+
+func (p *bodyPaperDoll) regionAt(where unison.Point) *model.PaperDollRegion {
+	origin := p.origin()
+	return model.PaperDollRegionAt(p.regions, (where.X-origin.X)/paperDollScale, (where.Y-origin.Y)/paperDollScale)
+}
+
+func (p *bodyPaperDoll) mouseMove(where unison.Point, _ unison.Modifiers) bool {
+	if r := p.regionAt(where); r != p.hover {
+		p.hover = r
+		p.MarkForRedraw()
+	}
+	return true
+}
+
+func (p *bodyPaperDoll) mouseExit() bool {
+	if p.hover != nil {
+		p.hover = nil
+		p.MarkForRedraw()
+	}
+	return true
+}
+
+func (p *bodyPaperDoll) mouseDown(where unison.Point, button, _ int, _ unison.Modifiers) bool {
+	if button == unison.ButtonLeft {
+		if r := p.regionAt(where); r != nil {
+			if focus := p.dockable.targetMgr.Find(r.Location.KeyPrefix + "id"); focus != nil {
+				focus.RequestFocus()
+				focus.ScrollIntoView()
+			}
+		}
+	}
+	return true
+}
+
+func (p *bodyPaperDoll) updateTooltip(where unison.Point, avoid unison.Rect) unison.Rect {
+	p.Tooltip = nil
+	if r := p.regionAt(where); r != nil {
+		loc := r.Location
+		dr := strconv.Itoa(loc.DRBonus)
+		if entity := p.dockable.Entity(); entity != nil {
+			dr = loc.DisplayDR(entity, nil)
+		}
+		p.Tooltip = unison.NewTooltipWithSecondaryText(loc.TableName,
+			fmt.Sprintf(i18n.Text("Hit Penalty: %+d\nDR: %s"), loc.HitPenalty, dr))
+		origin := p.origin()
+		avoid = p.RectToRoot(unison.NewRect(origin.X+r.X*paperDollScale, origin.Y+r.Y*paperDollScale,
+			r.Width*paperDollScale, r.Height*paperDollScale))
+	}
+	return avoid
+}
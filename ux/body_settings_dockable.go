@@ -14,6 +14,7 @@ package ux
 import (
 	"fmt"
 	"io/fs"
+	"time"
 
 	"github.com/richardwilkes/gcs/v5/model"
 	"github.com/richardwilkes/gcs/v5/svg"
@@ -30,31 +31,49 @@ type bodySettingsDockable struct {
 	owner          EntityPanel
 	targetMgr      *TargetMgr
 	undoMgr        *unison.UndoManager
+	reorderUndo    *undoGroup[*model.Body]
 	body           *model.Body
-	originalCRC    uint64
 	toolbar        *unison.Panel
 	content        *unison.Panel
+	calculator     *hitLocationCalculator
+	paperDoll      *bodyPaperDoll
+	bodyPanel      *bodySettingsPanel
 	applyButton    *unison.Button
 	cancelButton   *unison.Button
 	dragTarget     *unison.Panel
 	dragTargetBody *model.Body
 	dragInsert     int
-	promptForSave  bool
+	previewBody    *model.Body
 	inDragOver     bool
+	livePreview    bool
+	previewPending bool
+	applied        bool
+	applyToAll     bool
 }
 
+const (
+	bodySettingsPreviewDelay = 250 * time.Millisecond
+	bodyReorderUndoWindow    = 2 * time.Second
+)
+
 // ShowBodySettings the Body Settings. Pass in nil to edit the defaults or a sheet to edit the sheet's.
 func ShowBodySettings(owner EntityPanel) {
+	showBodySettings(owner)
+}
+
+func showBodySettings(owner EntityPanel) *bodySettingsDockable {
+	var existing *bodySettingsDockable
 	ws, dc, found := Activate(func(d unison.Dockable) bool {
 		if s, ok := d.(*bodySettingsDockable); ok && owner == s.owner {
+			existing = s
 			return true
 		}
 		return false
 	})
 	if !found && ws != nil {
 		d := &bodySettingsDockable{
-			owner:         owner,
-			promptForSave: true,
+			owner:       owner,
+			reorderUndo: newUndoGroup[*model.Body](bodyReorderUndoWindow),
 		}
 		d.Self = d
 		d.targetMgr = NewTargetMgr(d)
@@ -68,16 +87,19 @@ func ShowBodySettings(owner EntityPanel) {
 		}
 		d.TabIcon = svg.BodyType
 		d.body.ResetTargetKeyPrefixes(d.targetMgr.NextPrefix)
-		d.originalCRC = d.body.CRC64()
 		d.Extensions = []string{model.BodyExt, model.BodyExtAlt}
 		d.undoMgr = unison.NewUndoManager(100, func(err error) { jot.Error(err) })
 		d.Loader = d.load
 		d.Saver = d.save
 		d.Resetter = d.reset
 		d.ModifiedCallback = d.modified
+		d.CRCSource = func() uint64 { return d.body.CRC64() }
+		d.Applier = d.apply
 		d.WillCloseCallback = d.willClose
 		d.Setup(ws, dc, d.addToStartToolbar, nil, d.initContent)
+		return d
 	}
+	return existing
 }
 
 func (d *bodySettingsDockable) UndoManager() *unison.UndoManager {
@@ -85,21 +107,26 @@ func (d *bodySettingsDockable) UndoManager() *unison.UndoManager {
 }
 
 func (d *bodySettingsDockable) modified() bool {
-	modified := d.originalCRC != d.body.CRC64()
+	modified := d.HasUnappliedChanges()
 	d.applyButton.SetEnabled(modified)
 	d.cancelButton.SetEnabled(modified)
+	if d.calculator != nil {
+		d.calculator.rebuildChoices()
+	}
+	if d.paperDoll != nil {
+		d.paperDoll.MarkForLayoutAndRedraw()
+	}
+	if d.livePreview && !d.previewPending {
+		d.previewPending = true
+		unison.InvokeTaskAfter(d.preview, bodySettingsPreviewDelay)
+	}
 	return modified
 }
 
 func (d *bodySettingsDockable) willClose() bool {
-	if d.promptForSave && d.originalCRC != d.body.CRC64() {
-		switch unison.YesNoCancelDialog(fmt.Sprintf(i18n.Text("Apply changes made to\n%s?"), d.Title()), "") {
-		case unison.ModalResponseDiscard:
-		case unison.ModalResponseOK:
-			d.apply()
-		case unison.ModalResponseCancel:
-			return false
-		}
+	d.livePreview = false
+	if !d.applied {
+		d.revertPreview()
 	}
 	return true
 }
@@ -111,29 +138,96 @@ func (d *bodySettingsDockable) CloseWithGroup(other unison.Paneler) bool {
 func (d *bodySettingsDockable) addToStartToolbar(toolbar *unison.Panel) {
 	d.toolbar = toolbar
 
-	helpButton := unison.NewSVGButton(svg.Help)
-	helpButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Help"))
+	helpButton := NewSVGButtonWithTooltip(svg.Help, i18n.Text("Help"))
 	helpButton.ClickCallback = func() { HandleLink(nil, "md:Help/Interface/Body Type") }
 	toolbar.AddChild(helpButton)
 
-	d.applyButton = unison.NewSVGButton(svg.Checkmark)
-	d.applyButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Apply Changes"))
+	d.applyButton = NewSVGButtonWithSecondaryTooltip(svg.Checkmark, i18n.Text("Apply Changes"),
+		fmt.Sprintf(i18n.Text("%v%v or %v%v"), unison.OSMenuCmdModifier(), unison.KeyReturn, unison.OSMenuCmdModifier(),
+			unison.KeyNumPadEnter))
 	d.applyButton.SetEnabled(false)
-	d.applyButton.ClickCallback = func() {
-		d.apply()
-		d.promptForSave = false
-		d.AttemptClose()
-	}
+	d.applyButton.ClickCallback = d.ApplyAndClose
 	toolbar.AddChild(d.applyButton)
 
-	d.cancelButton = unison.NewSVGButton(svg.Not)
-	d.cancelButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Discard Changes"))
+	d.cancelButton = NewSVGButtonWithSecondaryTooltip(svg.Not, i18n.Text("Discard Changes"), unison.KeyEscape.String())
 	d.cancelButton.SetEnabled(false)
-	d.cancelButton.ClickCallback = func() {
-		d.promptForSave = false
-		d.AttemptClose()
-	}
+	d.cancelButton.ClickCallback = d.DiscardAndClose
 	toolbar.AddChild(d.cancelButton)
+
+	d.addTemplatesButton(toolbar)
+
+	applyToAllCheckbox := unison.NewCheckBox()
+	applyToAllCheckbox.Text = i18n.Text("Apply to All Open Sheets")
+	applyToAllCheckbox.Tooltip = unison.NewTooltipWithText(
+		i18n.Text("When applied, also replace the body type of every other open sheet"))
+	applyToAllCheckbox.ClickCallback = func() { d.applyToAll = applyToAllCheckbox.State == unison.OnCheckState }
+	toolbar.AddChild(applyToAllCheckbox)
+
+	if d.owner != nil {
+		livePreviewCheckbox := unison.NewCheckBox()
+		livePreviewCheckbox.Text = i18n.Text("Live Preview")
+		livePreviewCheckbox.Tooltip = unison.NewTooltipWithText(i18n.Text("Show changes on the sheet as they are made"))
+		livePreviewCheckbox.ClickCallback = func() {
+			d.setLivePreview(livePreviewCheckbox.State == unison.OnCheckState)
+		}
+		toolbar.AddChild(livePreviewCheckbox)
+	}
+
+	reorganizeCheckbox := unison.NewCheckBox()
+	reorganizeCheckbox.Text = i18n.Text("Reorganize")
+	reorganizeCheckbox.Tooltip = unison.NewTooltipWithText(
+		i18n.Text("While checked, all hit location drags are combined into a single undo step"))
+	reorganizeCheckbox.ClickCallback = func() {
+		d.reorderUndo.setHeld(reorganizeCheckbox.State == unison.OnCheckState)
+	}
+	toolbar.AddChild(reorganizeCheckbox)
+
+	paperDollCheckbox := unison.NewCheckBox()
+	paperDollCheckbox.Text = i18n.Text("Paper Doll")
+	paperDollCheckbox.Tooltip = unison.NewTooltipWithText(
+		i18n.Text("Show the hit locations on a schematic body silhouette"))
+	paperDollCheckbox.ClickCallback = func() {
+		if paperDollCheckbox.State == unison.OnCheckState {
+			d.paperDoll = newBodyPaperDoll(d)
+		} else {
+			d.paperDoll = nil
+		}
+		d.sync()
+	}
+	toolbar.AddChild(paperDollCheckbox)
+}
+
+func (d *bodySettingsDockable) setLivePreview(enabled bool) {
+	d.livePreview = enabled
+	if enabled {
+		d.preview()
+	} else {
+		d.revertPreview()
+	}
+}
+
+// preview pushes the body type being edited to the owning sheet, keeping a snapshot of the sheet's original body type so
+// that it can be restored if the changes are not applied.
+func (d *bodySettingsDockable) preview() {
+	d.previewPending = false
+	if !d.livePreview || d.owner == nil {
+		return
+	}
+	entity := d.owner.Entity()
+	if d.previewBody == nil {
+		d.previewBody = entity.SheetSettings.BodyType
+	}
+	entity.SheetSettings.BodyType = d.body.Clone(entity, nil)
+	notifyOfSheetSettingsUpdate(entity)
+}
+
+func (d *bodySettingsDockable) revertPreview() {
+	if d.previewBody != nil {
+		entity := d.owner.Entity()
+		entity.SheetSettings.BodyType = d.previewBody
+		d.previewBody = nil
+		notifyOfSheetSettingsUpdate(entity)
+	}
 }
 
 func (d *bodySettingsDockable) initContent(content *unison.Panel) {
@@ -144,7 +238,10 @@ func (d *bodySettingsDockable) initContent(content *unison.Panel) {
 	d.content.DrawOverCallback = d.drawOver
 	content.SetBorder(nil)
 	content.SetLayout(&unison.FlexLayout{Columns: 1})
-	content.AddChild(newBodySettingsPanel(d))
+	d.calculator = newHitLocationCalculator(d)
+	content.AddChild(d.calculator)
+	d.bodyPanel = newBodySettingsPanel(d)
+	content.AddChild(d.bodyPanel)
 }
 
 func (d *bodySettingsDockable) Entity() *model.Entity {
@@ -191,8 +288,18 @@ func (d *bodySettingsDockable) sync() {
 	focusRefKey := d.targetMgr.CurrentFocusRef()
 	scrollRoot := d.content.ScrollRoot()
 	h, v := scrollRoot.Position()
-	d.content.RemoveAllChildren()
-	d.content.AddChild(newBodySettingsPanel(d))
+	d.bodyPanel.update()
+	panels := []*unison.Panel{d.calculator.AsPanel()}
+	if d.paperDoll != nil {
+		panels = append(panels, d.paperDoll.AsPanel())
+	}
+	panels = append(panels, d.bodyPanel.AsPanel())
+	if !slices.Equal(panels, d.content.Children()) {
+		d.content.RemoveAllChildren()
+		for _, panel := range panels {
+			d.content.AddChild(panel)
+		}
+	}
 	d.MarkForLayoutRecursively()
 	d.MarkForRedraw()
 	d.ValidateLayout()
@@ -218,14 +325,56 @@ func (d *bodySettingsDockable) save(filePath string) error {
 	return d.body.Save(filePath)
 }
 
+func (d *bodySettingsDockable) settingsRecoveryData() (kind, ownerPath string, crc uint64,
+	saver func(filePath string) error) {
+	return bodyRecoveryKind, settingsOwnerPath(d.owner), d.currentCRC(), d.save
+}
+
 func (d *bodySettingsDockable) apply() {
 	d.Window().FocusNext() // Intentionally move the focus to ensure any pending edits are flushed
+	d.applied = true
+	if d.applyToAll {
+		d.applyToOtherSheets()
+	}
 	if d.owner == nil {
 		model.GlobalSettings().Sheet.BodyType = d.body.Clone(nil, nil)
 		return
 	}
+	d.previewBody = nil
 	entity := d.owner.Entity()
 	entity.SheetSettings.BodyType = d.body.Clone(entity, nil)
+	notifyOfSheetSettingsUpdate(entity)
+}
+
+// applyToOtherSheets replaces the body type of every open sheet other than the owner with the body type being edited,
+// after confirming with the user.
+func (d *bodySettingsDockable) applyToOtherSheets() {
+	var sheets []*Sheet
+	for _, sheet := range OpenSheets(nil) {
+		if d.owner == nil || sheet.Entity() != d.owner.Entity() {
+			sheets = append(sheets, sheet)
+		}
+	}
+	if len(sheets) == 0 {
+		return
+	}
+	var msg string
+	if len(sheets) == 1 {
+		msg = i18n.Text("Apply this body type to 1 other open sheet?")
+	} else {
+		msg = fmt.Sprintf(i18n.Text("Apply this body type to %d other open sheets?"), len(sheets))
+	}
+	if unison.QuestionDialog(msg, i18n.Text("Their existing body types will be replaced.")) != unison.ModalResponseOK {
+		return
+	}
+	for _, sheet := range sheets {
+		entity := sheet.Entity()
+		entity.SheetSettings.BodyType = d.body.Clone(entity, nil)
+		notifyOfSheetSettingsUpdate(entity)
+	}
+}
+
+func notifyOfSheetSettingsUpdate(entity *model.Entity) {
 	for _, wnd := range unison.Windows() {
 		if ws := WorkspaceFromWindow(wnd); ws != nil {
 			ws.DocumentDock.RootDockLayout().ForEachDockContainer(func(dc *unison.DockContainer) bool {
@@ -296,7 +445,8 @@ func (d *bodySettingsDockable) dataDragDrop(_ unison.Point, data map[string]any)
 				}
 				table.Locations = slices.Insert(table.Locations, d.dragInsert, dd.loc)
 				table.Update(d.Entity())
-				d.finishAndPostUndo(undo)
+				undo.AfterData = d.body.Clone(d.Entity(), nil)
+				d.reorderUndo.add(d.UndoManager(), undo)
 				d.sync()
 			}
 		}
@@ -17,13 +17,15 @@ import (
 	"github.com/richardwilkes/rpgtools/dice"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/unison"
+	"golang.org/x/exp/slices"
 )
 
 const hitLocationDragDataKey = "drag.body"
 
 type bodySettingsPanel struct {
 	unison.Panel
-	dockable *bodySettingsDockable
+	dockable  *bodySettingsDockable
+	locations *unison.Panel
 }
 
 func newBodySettingsPanel(d *bodySettingsDockable) *bodySettingsPanel {
@@ -59,9 +61,8 @@ func (p *bodySettingsPanel) createButtons() *unison.Panel {
 	})
 	buttons.SetLayoutData(&unison.FlexLayoutData{HAlign: unison.MiddleAlignment})
 
-	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton := newEditorSVGButton(svg.CircledAdd, i18n.Text("Add hit location"))
 	addButton.ClickCallback = p.addHitLocation
-	addButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Add hit location"))
 	buttons.AddChild(addButton)
 	return buttons
 }
@@ -113,8 +114,40 @@ func (p *bodySettingsPanel) createContent() *unison.Panel {
 	wrapper.SetLayout(&unison.FlexLayout{Columns: 1})
 	content.AddChild(wrapper)
 
-	for _, loc := range p.dockable.body.Locations {
-		wrapper.AddChild(newHitLocationSettingsPanel(p.dockable, loc))
-	}
+	p.locations = wrapper
+	syncHitLocationPanels(p.dockable, wrapper, p.dockable.body.Locations)
 	return content
 }
+
+// update brings the panel up to date with the dockable's current body type.
+func (p *bodySettingsPanel) update() {
+	syncHitLocationPanels(p.dockable, p.locations, p.dockable.body.Locations)
+}
+
+// syncHitLocationPanels brings the hit location panels within the container up to date with the locations. Panels are
+// matched to locations by key prefix, which clones retain, so the panels of locations that are still present are reused
+// rather than recreated.
+func syncHitLocationPanels(d *bodySettingsDockable, container *unison.Panel, locations []*model.HitLocation) {
+	existing := make(map[string]*hitLocationSettingsPanel)
+	for _, child := range container.Children() {
+		if panel, ok := child.Self.(*hitLocationSettingsPanel); ok {
+			existing[panel.loc.KeyPrefix] = panel
+		}
+	}
+	panels := make([]*unison.Panel, len(locations))
+	for i, loc := range locations {
+		if panel, ok := existing[loc.KeyPrefix]; ok {
+			delete(existing, loc.KeyPrefix)
+			panel.update(loc)
+			panels[i] = panel.AsPanel()
+		} else {
+			panels[i] = newHitLocationSettingsPanel(d, loc).AsPanel()
+		}
+	}
+	if !slices.Equal(panels, container.Children()) {
+		container.RemoveAllChildren()
+		for _, panel := range panels {
+			container.AddChild(panel)
+		}
+	}
+}
@@ -24,6 +24,7 @@ type bodySettingsSubTablePanel struct {
 	body         *model.Body
 	addButton    *unison.Button
 	deleteButton *unison.Button
+	locations    *unison.Panel
 }
 
 func newBodySettingsSubTablePanel(d *bodySettingsDockable, body *model.Body) *bodySettingsSubTablePanel {
@@ -57,14 +58,12 @@ func (p *bodySettingsSubTablePanel) createButtons() *unison.Panel {
 	})
 	buttons.SetLayoutData(&unison.FlexLayoutData{HAlign: unison.MiddleAlignment})
 
-	p.deleteButton = unison.NewSVGButton(svg.Trash)
+	p.deleteButton = newEditorSVGButton(svg.Trash, i18n.Text("Remove sub-table"))
 	p.deleteButton.ClickCallback = p.removeSubTable
-	p.deleteButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Remove sub-table"))
 	buttons.AddChild(p.deleteButton)
 
-	p.addButton = unison.NewSVGButton(svg.CircledAdd)
+	p.addButton = newEditorSVGButton(svg.CircledAdd, i18n.Text("Add hit location"))
 	p.addButton.ClickCallback = p.addHitLocation
-	p.addButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Add hit location"))
 	buttons.AddChild(p.addButton)
 	return buttons
 }
@@ -97,9 +96,14 @@ func (p *bodySettingsSubTablePanel) createContent() *unison.Panel {
 	content.SetLayoutData(&unison.FlexLayoutData{HAlign: unison.FillAlignment})
 	content.SetBorder(unison.NewLineBorder(unison.DividerColor, 0, unison.NewUniformInsets(1), false))
 
-	for _, loc := range p.body.Locations {
-		content.AddChild(newHitLocationSettingsPanel(p.dockable, loc))
-	}
-
+	p.locations = content
+	syncHitLocationPanels(p.dockable, content, p.body.Locations)
 	return content
 }
+
+// update points the panel at the body, which may be a clone of the one it was showing, and brings its hit locations up
+// to date.
+func (p *bodySettingsSubTablePanel) update(body *model.Body) {
+	p.body = body
+	syncHitLocationPanels(p.dockable, p.locations, body.Locations)
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+func (d *bodySettingsDockable) addTemplatesButton(toolbar *unison.Panel) {
+	b := unison.NewSVGButton(svg.Stack)
+	b.Tooltip = unison.NewTooltipWithText(i18n.Text("Templates"))
+	b.ClickCallback = func() { d.showTemplatesMenu(b) }
+	toolbar.AddChild(b)
+}
+
+func (d *bodySettingsDockable) showTemplatesMenu(b *unison.Button) {
+	f := unison.DefaultMenuFactory()
+	id := unison.ContextMenuIDFlag
+	m := f.NewMenu(id, "", nil)
+	id++
+	m.InsertItem(-1, f.NewItem(id, i18n.Text("Save as Template…"), unison.KeyBinding{}, nil,
+		func(_ unison.MenuItem) { d.saveAsTemplate() }))
+	id++
+	templates := model.GlobalSettings().BodyTemplates
+	if len(templates) != 0 {
+		m.InsertItem(-1, f.NewItem(id, i18n.Text("Manage Templates…"), unison.KeyBinding{}, nil,
+			func(_ unison.MenuItem) { manageBodyTemplates() }))
+		id++
+		m.InsertSeparator(-1, false)
+		for _, one := range templates {
+			template := one
+			m.InsertItem(-1, f.NewItem(id, template.Name, unison.KeyBinding{}, nil,
+				func(_ unison.MenuItem) { d.instantiateTemplate(template) }))
+			id++
+		}
+	}
+	m.Popup(b.RectToRoot(b.ContentRect(true)), 0)
+}
+
+func (d *bodySettingsDockable) saveAsTemplate() {
+	d.Window().FocusNext() // Intentionally move the focus to ensure any pending edits are flushed
+	name := d.body.Name
+	field := NewStringField(nil, "", "", func() string { return name }, func(s string) { name = s })
+	field.SetMinimumTextWidthUsing(minTextWidthCandidate)
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Template Name")))
+	panel.AddChild(field)
+	dialog, err := unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon, unison.DefaultDialogTheme.QuestionIconInk,
+		panel, []*unison.DialogButtonInfo{unison.NewCancelButtonInfo(), unison.NewOKButtonInfoWithTitle(i18n.Text("Save"))})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create body type template dialog"), err)
+		return
+	}
+	field.ValidateCallback = func() bool {
+		valid := strings.TrimSpace(name) != ""
+		dialog.Button(unison.ModalResponseOK).SetEnabled(valid)
+		return valid
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	templates := &model.GlobalSettings().BodyTemplates
+	if existing := templates.Lookup(name); existing != nil && unison.QuestionDialog(
+		fmt.Sprintf(i18n.Text("Replace the existing template \"%s\"?"), existing.Name), "") != unison.ModalResponseOK {
+		return
+	}
+	templates.Set(name, d.body)
+}
+
+func (d *bodySettingsDockable) instantiateTemplate(template *model.BodyTemplate) {
+	undo := d.prepareUndo(i18n.Text("Use Body Type Template"))
+	d.body = template.Body.Clone(d.Entity(), nil)
+	d.body.ResetTargetKeyPrefixes(d.targetMgr.NextPrefix)
+	d.finishAndPostUndo(undo)
+	d.sync()
+}
+
+// manageBodyTemplates presents a dialog that allows the body type templates to be renamed or removed.
+func manageBodyTemplates() {
+	templates := &model.GlobalSettings().BodyTemplates
+	type row struct {
+		template *model.BodyTemplate
+		name     string
+		removed  bool
+	}
+	rows := make([]*row, 0, len(*templates))
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	var dialog *unison.Dialog
+	validate := func() bool {
+		seen := make(map[string]bool)
+		for _, one := range rows {
+			if one.removed {
+				continue
+			}
+			key := strings.ToLower(strings.TrimSpace(one.name))
+			if key == "" || seen[key] {
+				return false
+			}
+			seen[key] = true
+		}
+		return true
+	}
+	for _, one := range *templates {
+		r := &row{template: one, name: one.Name}
+		rows = append(rows, r)
+		field := NewStringField(nil, "", "", func() string { return r.name }, func(s string) { r.name = s })
+		field.SetMinimumTextWidthUsing(minTextWidthCandidate)
+		field.ValidateCallback = func() bool {
+			valid := validate()
+			if dialog != nil {
+				dialog.Button(unison.ModalResponseOK).SetEnabled(valid)
+			}
+			return valid
+		}
+		panel.AddChild(field)
+		removeButton := unison.NewSVGButton(svg.Trash)
+		removeButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Remove"))
+		removeButton.ClickCallback = func() {
+			r.removed = !r.removed
+			field.SetEnabled(!r.removed)
+			field.ValidateCallback()
+		}
+		panel.AddChild(removeButton)
+	}
+	var err error
+	dialog, err = unison.NewDialog(nil, nil, panel,
+		[]*unison.DialogButtonInfo{unison.NewCancelButtonInfo(), unison.NewOKButtonInfo()})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create body type template dialog"), err)
+		return
+	}
+	if dialog.RunModal() != unison.ModalResponseOK || !validate() {
+		return
+	}
+	for _, one := range rows {
+		if one.removed {
+			templates.Remove(one.template.Name)
+		}
+	}
+	for _, one := range rows {
+		if !one.removed {
+			one.template.Name = strings.TrimSpace(one.name)
+		}
+	}
+	templates.EnsureValidity()
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/txt"
+	"github.com/richardwilkes/unison"
+)
+
+const brokenPageRefsGroup = "broken_page_refs"
+
+var _ GroupedCloser = &brokenPageRefsDockable{}
+
+type brokenPageRefsDockable struct {
+	unison.Panel
+	owner unison.Dockable
+}
+
+// IsKnownPageRefKey returns true if the page reference key has been mapped to a PDF or is one of the standard keys.
+func IsKnownPageRefKey(key string) bool {
+	return model.GlobalSettings().PageRefs.Lookup(key) != nil || PageRefKeyToName(key) != ""
+}
+
+// ShowBrokenPageRefs displays the broken page references in a read-only dockable. Clicking on one brings the owner to
+// the front and calls reveal with the item containing it.
+func ShowBrokenPageRefs(owner unison.Dockable, refs []*model.BrokenPageRef, reveal func(item any)) {
+	ws := AnyWorkspace()
+	if ws == nil {
+		ShowUnableToLocateWorkspaceError()
+		return
+	}
+	d := &brokenPageRefsDockable{owner: owner}
+	d.Self = d
+	d.SetLayout(&unison.FlexLayout{Columns: 1})
+	scroll := unison.NewScrollPanel()
+	scroll.SetContent(d.createContent(refs, reveal), unison.FillBehavior, unison.FillBehavior)
+	scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: unison.FillAlignment,
+		VAlign: unison.FillAlignment,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	d.AddChild(scroll)
+	PlaceInDock(ws, ws.CurrentlyFocusedDockContainer(), d, brokenPageRefsGroup)
+}
+
+func (d *brokenPageRefsDockable) createContent(refs []*model.BrokenPageRef, reveal func(item any)) *unison.Panel {
+	content := unison.NewPanel()
+	content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	content.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing * 2,
+		VSpacing: unison.StdVSpacing,
+	})
+	header := unison.NewLabel()
+	header.Font = unison.SystemFont
+	header.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	content.AddChild(header)
+	if len(refs) == 0 {
+		header.Text = i18n.Text("No broken page references were found.")
+		return content
+	}
+	header.Text = fmt.Sprintf(i18n.Text("Broken Page References (%d)"), len(refs))
+	for _, ref := range refs {
+		ref := ref
+		link := unison.NewLink(fmt.Sprintf(i18n.Text("%s (%s)"), txt.Truncate(ref.Name, 40, true), ref.Kind), "",
+			"", unison.DefaultLinkTheme, func(_ unison.Paneler, _ string) {
+				if dc := unison.Ancestor[*unison.DockContainer](d.owner); dc != nil {
+					dc.SetCurrentDockable(d.owner)
+					dc.AcquireFocus()
+					reveal(ref.Item)
+				}
+			})
+		content.AddChild(link)
+		label := unison.NewLabel()
+		label.Text = fmt.Sprintf(i18n.Text("%s: %s"), ref.Ref, ref.Problem)
+		content.AddChild(label)
+	}
+	return content
+}
+
+// TitleIcon implements unison.Dockable
+func (d *brokenPageRefsDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Bookmark,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (d *brokenPageRefsDockable) Title() string {
+	return fmt.Sprintf(i18n.Text("Broken Page References in %s"), d.owner.Title())
+}
+
+// Tooltip implements unison.Dockable
+func (d *brokenPageRefsDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (d *brokenPageRefsDockable) Modified() bool {
+	return false
+}
+
+// CloseWithGroup implements GroupedCloser
+func (d *brokenPageRefsDockable) CloseWithGroup(other unison.Paneler) bool {
+	return d.owner != nil && d.owner == other
+}
+
+// MayAttemptClose implements GroupedCloser
+func (d *brokenPageRefsDockable) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(d)
+}
+
+// AttemptClose implements GroupedCloser
+func (d *brokenPageRefsDockable) AttemptClose() bool {
+	if !CloseGroup(d) {
+		return false
+	}
+	if dc := unison.Ancestor[*unison.DockContainer](d); dc != nil {
+		dc.Close(d)
+	}
+	return true
+}
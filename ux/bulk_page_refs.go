@@ -0,0 +1,156 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/txt"
+	"github.com/richardwilkes/unison"
+	"golang.org/x/exp/maps"
+)
+
+type pageRefEditList[T model.NodeTypes] struct {
+	Owner Rebuildable
+	List  []*pageRefEditor[T]
+}
+
+func (a *pageRefEditList[T]) Apply() {
+	for _, one := range a.List {
+		any(one.Target).(model.PageRefEditor).SetPageReference(one.PageRef)
+	}
+	a.Finish()
+}
+
+func (a *pageRefEditList[T]) Finish() {
+	MarkModified(a.Owner)
+}
+
+type pageRefEditor[T model.NodeTypes] struct {
+	Target  T
+	PageRef string
+}
+
+// selectedPageRefEditors returns the selected rows, along with all of their descendants, that have page references.
+func selectedPageRefEditors[T model.NodeTypes](table *unison.Table[*Node[T]]) []T {
+	rows := table.SelectedRows(true)
+	selection := make([]T, 0, len(rows))
+	for _, row := range rows {
+		selection = append(selection, row.Data())
+	}
+	var list []T
+	model.Traverse(func(data T) bool {
+		if editor, ok := any(data).(model.PageRefEditor); ok && editor.PageReference() != "" {
+			list = append(list, data)
+		}
+		return false
+	}, false, false, selection...)
+	return list
+}
+
+// selectedPageRefKeys returns the keys used by the page references of the selected rows and their descendants. Links
+// are skipped.
+func selectedPageRefKeys[T model.NodeTypes](table *unison.Table[*Node[T]]) []string {
+	set := make(map[string]bool)
+	for _, one := range selectedPageRefEditors(table) {
+		for _, ref := range ExtractPageReferences(any(one).(model.PageRefEditor).PageReference()) {
+			if model.IsLinkPageRef(ref) {
+				continue
+			}
+			if key, _, ok := model.SplitPageRef(ref); ok {
+				set[key] = true
+			}
+		}
+	}
+	keys := maps.Keys(set)
+	txt.SortStringsNaturalAscending(keys)
+	return keys
+}
+
+func canRebasePageRefs[T model.NodeTypes](table *unison.Table[*Node[T]]) bool {
+	return len(selectedPageRefKeys(table)) != 0
+}
+
+func rebasePageRefs[T model.NodeTypes](table *unison.Table[*Node[T]]) {
+	keys := selectedPageRefKeys(table)
+	if len(keys) == 0 {
+		return
+	}
+	key, offset, ok := promptForPageRefRebase(keys)
+	if !ok || offset == 0 {
+		return
+	}
+	owner := unison.AncestorOrSelf[Rebuildable](table)
+	before := &pageRefEditList[T]{Owner: owner}
+	after := &pageRefEditList[T]{Owner: owner}
+	for _, one := range selectedPageRefEditors(table) {
+		editor := any(one).(model.PageRefEditor)
+		if ref, changed := model.RebasePageRefs(editor.PageReference(), key, offset); changed {
+			before.List = append(before.List, &pageRefEditor[T]{Target: one, PageRef: editor.PageReference()})
+			editor.SetPageReference(ref)
+			after.List = append(after.List, &pageRefEditor[T]{Target: one, PageRef: ref})
+		}
+	}
+	if len(before.List) > 0 {
+		if mgr := unison.UndoManagerFor(table); mgr != nil {
+			mgr.Add(&unison.UndoEdit[*pageRefEditList[T]]{
+				ID:         unison.NextUndoID(),
+				EditName:   i18n.Text("Rebase Page References"),
+				UndoFunc:   func(edit *unison.UndoEdit[*pageRefEditList[T]]) { edit.BeforeData.Apply() },
+				RedoFunc:   func(edit *unison.UndoEdit[*pageRefEditList[T]]) { edit.AfterData.Apply() },
+				BeforeData: before,
+				AfterData:  after,
+			})
+		}
+		before.Finish()
+	}
+}
+
+// promptForPageRefRebase asks the user for the page reference key to rebase and the offset to apply to it.
+func promptForPageRefRebase(keys []string) (key string, offset int, ok bool) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	titleLabel := unison.NewLabel()
+	titleLabel.Text = i18n.Text("Rebase Page References")
+	titleLabel.Font = unison.SystemFont
+	titleLabel.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	panel.AddChild(titleLabel)
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Key")))
+	popup := unison.NewPopupMenu[string]()
+	popup.AddItem(keys...)
+	popup.SelectIndex(0)
+	panel.AddChild(popup)
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Page Offset")))
+	panel.AddChild(NewIntegerField(nil, "", "", func() int { return offset }, func(v int) { offset = v }, -9999, 9999,
+		true, false))
+
+	dialog, err := unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon, unison.DefaultDialogTheme.QuestionIconInk,
+		panel, []*unison.DialogButtonInfo{
+			unison.NewCancelButtonInfo(),
+			unison.NewOKButtonInfoWithTitle(i18n.Text("Rebase")),
+		})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create page reference rebase dialog"), err)
+		return "", 0, false
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return "", 0, false
+	}
+	key, ok = popup.Selected()
+	return key, offset, ok
+}
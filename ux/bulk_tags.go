@@ -0,0 +1,219 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/txt"
+	"github.com/richardwilkes/unison"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+type tagEditList[T model.NodeTypes] struct {
+	Owner Rebuildable
+	List  []*tagEditor[T]
+}
+
+func (a *tagEditList[T]) Apply() {
+	for _, one := range a.List {
+		one.Apply()
+	}
+	a.Finish()
+}
+
+func (a *tagEditList[T]) Finish() {
+	if entity := model.AsNode[T](a.List[0].Target).OwningEntity(); entity != nil {
+		entity.Recalculate()
+	}
+	MarkModified(a.Owner)
+}
+
+type tagEditor[T model.NodeTypes] struct {
+	Target T
+	Tags   []string
+}
+
+func newTagEditor[T model.NodeTypes](target T) *tagEditor[T] {
+	return &tagEditor[T]{
+		Target: target,
+		Tags:   slices.Clone(any(target).(model.TagEditor).TagList()),
+	}
+}
+
+func (a *tagEditor[T]) Apply() {
+	any(a.Target).(model.TagEditor).SetTagList(slices.Clone(a.Tags))
+}
+
+// selectedTagEditors returns the selected rows, along with all of their descendants, that have editable tags.
+func selectedTagEditors[T model.NodeTypes](table *unison.Table[*Node[T]]) []T {
+	rows := table.SelectedRows(true)
+	selection := make([]T, 0, len(rows))
+	for _, row := range rows {
+		selection = append(selection, row.Data())
+	}
+	var list []T
+	model.Traverse(func(data T) bool {
+		if _, ok := any(data).(model.TagEditor); ok {
+			list = append(list, data)
+		}
+		return false
+	}, false, false, selection...)
+	return list
+}
+
+func canAddTagToSelection[T model.NodeTypes](table *unison.Table[*Node[T]]) bool {
+	return len(selectedTagEditors(table)) != 0
+}
+
+func canRemoveTagFromSelection[T model.NodeTypes](table *unison.Table[*Node[T]]) bool {
+	for _, one := range selectedTagEditors(table) {
+		if len(any(one).(model.TagEditor).TagList()) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func addTagToSelection[T model.NodeTypes](table *unison.Table[*Node[T]], allTags []string) {
+	if tag, ok := promptForTag(i18n.Text("Add Tag to Selected"), i18n.Text("Add"), allTags, true); ok {
+		editSelectedTags(table, i18n.Text("Add Tag"), func(tags []string) []string { return model.AddTag(tags, tag) })
+	}
+}
+
+func removeTagFromSelection[T model.NodeTypes](table *unison.Table[*Node[T]]) {
+	set := make(map[string]bool)
+	for _, one := range selectedTagEditors(table) {
+		for _, tag := range any(one).(model.TagEditor).TagList() {
+			set[tag] = true
+		}
+	}
+	tags := maps.Keys(set)
+	txt.SortStringsNaturalAscending(tags)
+	if tag, ok := promptForTag(i18n.Text("Remove Tag from Selected"), i18n.Text("Remove"), tags, false); ok {
+		editSelectedTags(table, i18n.Text("Remove Tag"), func(tags []string) []string { return model.RemoveTag(tags, tag) })
+	}
+}
+
+func editSelectedTags[T model.NodeTypes](table *unison.Table[*Node[T]], name string, adjuster func([]string) []string) {
+	owner := unison.AncestorOrSelf[Rebuildable](table)
+	before := &tagEditList[T]{Owner: owner}
+	after := &tagEditList[T]{Owner: owner}
+	for _, one := range selectedTagEditors(table) {
+		editor := any(one).(model.TagEditor)
+		tags := adjuster(editor.TagList())
+		if !slices.Equal(tags, editor.TagList()) {
+			before.List = append(before.List, newTagEditor(one))
+			editor.SetTagList(tags)
+			after.List = append(after.List, newTagEditor(one))
+		}
+	}
+	if len(before.List) > 0 {
+		if mgr := unison.UndoManagerFor(table); mgr != nil {
+			mgr.Add(&unison.UndoEdit[*tagEditList[T]]{
+				ID:         unison.NextUndoID(),
+				EditName:   name,
+				UndoFunc:   func(edit *unison.UndoEdit[*tagEditList[T]]) { edit.BeforeData.Apply() },
+				RedoFunc:   func(edit *unison.UndoEdit[*tagEditList[T]]) { edit.AfterData.Apply() },
+				BeforeData: before,
+				AfterData:  after,
+			})
+		}
+		before.Finish()
+	}
+}
+
+// promptForTag asks the user for a tag. When allowNew is true, any tag may be typed in, with the choices used to
+// autocomplete it; otherwise, only one of the choices may be picked.
+func promptForTag(title, buttonTitle string, choices []string, allowNew bool) (string, bool) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	titleLabel := unison.NewLabel()
+	titleLabel.Text = title
+	titleLabel.Font = unison.SystemFont
+	titleLabel.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	panel.AddChild(titleLabel)
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Tag")))
+	popup := unison.NewPopupMenu[string]()
+	popup.AddItem(choices...)
+	var field *unison.Field
+	if allowNew {
+		wrapper := unison.NewPanel()
+		wrapper.SetLayout(&unison.FlexLayout{
+			Columns:  2,
+			HSpacing: unison.StdHSpacing,
+		})
+		wrapper.SetLayoutData(&unison.FlexLayoutData{
+			HAlign: unison.FillAlignment,
+			HGrab:  true,
+		})
+		field = unison.NewField()
+		field.SetMinimumTextWidthUsing(minTextWidthCandidate)
+		field.SetLayoutData(&unison.FlexLayoutData{
+			HAlign: unison.FillAlignment,
+			VAlign: unison.MiddleAlignment,
+			HGrab:  true,
+		})
+		installTextCompletion(field, func() []string { return choices })
+		wrapper.AddChild(field)
+		popup.Tooltip = unison.NewTooltipWithText(i18n.Text("Existing tags"))
+		popup.SetEnabled(len(choices) != 0)
+		popup.ChoiceMadeCallback = func(_ *unison.PopupMenu[string], _ int, item string) {
+			field.SetText(item)
+			field.SelectAll()
+			field.RequestFocus()
+		}
+		wrapper.AddChild(popup)
+		panel.AddChild(wrapper)
+	} else {
+		if len(choices) != 0 {
+			popup.SelectIndex(0)
+		}
+		panel.AddChild(popup)
+	}
+
+	dialog, err := unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon, unison.DefaultDialogTheme.QuestionIconInk,
+		panel, []*unison.DialogButtonInfo{
+			unison.NewCancelButtonInfo(),
+			unison.NewOKButtonInfoWithTitle(buttonTitle),
+		})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create tag dialog"), err)
+		return "", false
+	}
+	if field != nil {
+		okButton := dialog.Button(unison.ModalResponseOK)
+		okButton.SetEnabled(false)
+		previous := field.ModifiedCallback
+		field.ModifiedCallback = func(before, after *unison.FieldState) {
+			previous(before, after)
+			okButton.SetEnabled(strings.TrimSpace(after.Text) != "")
+		}
+		field.RequestFocus()
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return "", false
+	}
+	if field != nil {
+		tag := strings.TrimSpace(field.Text())
+		return tag, tag != ""
+	}
+	return popup.Selected()
+}
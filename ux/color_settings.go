@@ -125,8 +125,7 @@ func (d *colorSettingsDockable) createColorWellField(c *model.ThemedColor, light
 }
 
 func (d *colorSettingsDockable) createResetField(c *model.ThemedColor) {
-	b := unison.NewSVGButton(svg.Reset)
-	b.Tooltip = unison.NewTooltipWithText("Reset this color")
+	b := NewSVGButtonWithTooltip(svg.Reset, "Reset this color")
 	b.ClickCallback = func() {
 		if unison.QuestionDialog(fmt.Sprintf(i18n.Text("Are you sure you want to reset %s?"), c.Title), "") == unison.ModalResponseOK {
 			for _, v := range model.FactoryColors() {
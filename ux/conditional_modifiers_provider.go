@@ -22,6 +22,7 @@ var _ TableProvider[*model.ConditionalModifier] = &condModProvider{}
 
 type condModProvider struct {
 	table    *unison.Table[*Node[*model.ConditionalModifier]]
+	nodes    NodeCache[*model.ConditionalModifier]
 	provider model.ConditionalModifierListProvider
 }
 
@@ -34,6 +35,10 @@ func (p *condModProvider) RefKey() string {
 	return model.BlockLayoutConditionalModifiersKey
 }
 
+func (p *condModProvider) Count(predicate func(*model.ConditionalModifier) bool) int {
+	return model.Count(predicate, p.RootData()...)
+}
+
 func (p *condModProvider) AllTags() []string {
 	return nil
 }
@@ -47,12 +52,7 @@ func (p *condModProvider) RootRowCount() int {
 }
 
 func (p *condModProvider) RootRows() []*Node[*model.ConditionalModifier] {
-	data := p.provider.ConditionalModifiers()
-	rows := make([]*Node[*model.ConditionalModifier], 0, len(data))
-	for _, one := range data {
-		rows = append(rows, NewNode[*model.ConditionalModifier](p.table, nil, one, true))
-	}
-	return rows
+	return p.nodes.Rows(p.table, nil, p.provider.ConditionalModifiers(), true)
 }
 
 func (p *condModProvider) SetRootRows(_ []*Node[*model.ConditionalModifier]) {
@@ -121,7 +121,7 @@ func (p *condModProvider) ExcessWidthColumnID() int {
 func (p *condModProvider) OpenEditor(_ Rebuildable, _ *unison.Table[*Node[*model.ConditionalModifier]]) {
 }
 
-func (p *condModProvider) CreateItem(_ Rebuildable, _ *unison.Table[*Node[*model.ConditionalModifier]], _ ItemVariant) {
+func (p *condModProvider) CreateItem(_ Rebuildable, _ *unison.Table[*Node[*model.ConditionalModifier]], _ ItemVariant, _ InsertMode) {
 }
 
 func (p *condModProvider) Serialize() ([]byte, error) {
@@ -0,0 +1,69 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+const maxItemsCreatedAtOnce = 100
+
+// MultipleItemCreator may be implemented by a TableProvider that can create several blank items at once.
+type MultipleItemCreator[T model.NodeTypes] interface {
+	CreateItems(owner Rebuildable, table *unison.Table[*Node[T]], variant ItemVariant, mode InsertMode, count int)
+}
+
+// InstallCreateMultipleCmdHandler installs a handler for the command that asks how many new items to create and then
+// creates them. Does nothing if the provider doesn't implement MultipleItemCreator.
+func InstallCreateMultipleCmdHandler[T model.NodeTypes](panel *unison.Panel, owner Rebuildable, table *unison.Table[*Node[T]], provider TableProvider[T], id int) {
+	if creator, ok := provider.(MultipleItemCreator[T]); ok {
+		panel.InstallCmdHandlers(id, unison.AlwaysEnabled, func(_ any) {
+			_, plural := provider.ItemNames()
+			if count, ok2 := promptForItemCount(plural); ok2 {
+				creator.CreateItems(owner, table, NoItemVariant, AutoInsertMode, count)
+			}
+		})
+	}
+}
+
+func promptForItemCount(plural string) (int, bool) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	count := 5
+	label := fmt.Sprintf(i18n.Text("Number of %s"), plural)
+	panel.AddChild(NewFieldLeadingLabel(label))
+	field := NewIntegerField(nil, "", label, func() int { return count }, func(v int) { count = v }, 1,
+		maxItemsCreatedAtOnce, false, false)
+	panel.AddChild(field)
+	dialog, err := unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon, unison.DefaultDialogTheme.QuestionIconInk,
+		panel, []*unison.DialogButtonInfo{
+			unison.NewCancelButtonInfo(),
+			unison.NewOKButtonInfoWithTitle(i18n.Text("Create")),
+		})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create dialog"), err)
+		return 0, false
+	}
+	field.RequestFocus()
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return 0, false
+	}
+	return count, true
+}
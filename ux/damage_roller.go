@@ -0,0 +1,100 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+func canRollDamage(table *unison.Table[*Node[*model.Weapon]]) bool {
+	return table.SelectionCount() == 1
+}
+
+func rollDamage(table *unison.Table[*Node[*model.Weapon]]) {
+	if rows := table.SelectedRows(false); len(rows) == 1 {
+		ShowDamageRoller(rows[0].Data())
+	}
+}
+
+// ShowDamageRoller displays a small panel that rolls the resolved damage of the weapon.
+func ShowDamageRoller(w *model.Weapon) {
+	resolved := w.Damage.Resolve(nil)
+	if resolved == nil || (resolved.Base.Count == 0 && resolved.Base.Modifier == 0) {
+		unison.ErrorDialogWithMessage(i18n.Text("Unable to roll damage"),
+			fmt.Sprintf(i18n.Text("%s has no damage dice that can be rolled."), w.String()))
+		return
+	}
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	title := w.String()
+	if w.Usage != "" {
+		title += " (" + w.Usage + ")"
+	}
+	titleLabel := unison.NewLabel()
+	titleLabel.Text = title
+	titleLabel.Font = unison.SystemFont
+	titleLabel.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	panel.AddChild(titleLabel)
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Damage")))
+	damageLabel := unison.NewLabel()
+	damageLabel.Text = resolved.String()
+	panel.AddChild(damageLabel)
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Rolls")))
+	rollsLabel := unison.NewLabel()
+	panel.AddChild(rollsLabel)
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Total")))
+	totalLabel := unison.NewLabel()
+	totalLabel.Font = unison.SystemFont
+	panel.AddChild(totalLabel)
+
+	roll := func() {
+		result := model.Roll(resolved.Base, nil)
+		rollsLabel.Text = result.String()
+		totalLabel.Text = strconv.Itoa(result.Total)
+		if resolved.Type != "" {
+			totalLabel.Text += " " + resolved.Type
+		}
+		panel.MarkForLayoutAndRedraw()
+	}
+	roll()
+
+	panel.AddChild(unison.NewPanel())
+	rollButton := unison.NewButton()
+	rollButton.Text = i18n.Text("Roll Again")
+	rollButton.ClickCallback = func() {
+		roll()
+		if wnd := panel.Window(); wnd != nil {
+			wnd.Pack()
+		}
+	}
+	panel.AddChild(rollButton)
+
+	dialog, err := unison.NewDialog(nil, nil, panel,
+		[]*unison.DialogButtonInfo{unison.NewOKButtonInfoWithTitle(i18n.Text("Done"))})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create damage roller"), err)
+		return
+	}
+	dialog.RunModal()
+}
@@ -11,12 +11,15 @@
 
 package ux
 
-import "github.com/richardwilkes/gcs/v5/model/fxp"
+import (
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+)
 
 // DecimalField is field that holds a decimal (fixed-point) number.
 type DecimalField = NumericField[fxp.Int]
 
-// NewDecimalField creates a new field that holds a fixed-point number.
+// NewDecimalField creates a new field that holds a fixed-point number, formatted with the display number formatter.
 func NewDecimalField(targetMgr *TargetMgr, targetKey, undoTitle string, get func() fxp.Int, set func(fxp.Int), min, max fxp.Int, forceSign, noMinWidth bool) *DecimalField {
 	var getPrototypes func(min, max fxp.Int) []fxp.Int
 	if !noMinWidth {
@@ -34,9 +37,12 @@ func NewDecimalField(targetMgr *TargetMgr, targetKey, undoTitle string, get func
 	}
 	format := func(value fxp.Int) string {
 		if forceSign {
-			return value.StringWithSign()
+			return model.DisplayNumberFormatter().FormatWithSign(value)
 		}
-		return value.String()
+		return model.DisplayNumberFormatter().Format(value)
 	}
-	return NewNumericField[fxp.Int](targetMgr, targetKey, undoTitle, getPrototypes, get, set, format, fxp.FromString, min, max)
+	extract := func(s string) (fxp.Int, error) {
+		return model.DisplayNumberFormatter().Extract(s)
+	}
+	return NewNumericField[fxp.Int](targetMgr, targetKey, undoTitle, getPrototypes, get, set, format, extract, min, max)
 }
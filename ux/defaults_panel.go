@@ -12,6 +12,8 @@
 package ux
 
 import (
+	"fmt"
+
 	"github.com/richardwilkes/gcs/v5/model"
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/svg"
@@ -20,12 +22,24 @@ import (
 	"golang.org/x/exp/slices"
 )
 
+const defaultDragDataKey = "drag.default"
+
 var lastDefaultTypeUsed = model.DexterityID
 
 type defaultsPanel struct {
 	unison.Panel
-	entity   *model.Entity
-	defaults *[]*model.SkillDefault
+	entity     *model.Entity
+	defaults   *[]*model.SkillDefault
+	header     *unison.Panel
+	dragInsert int
+	inDragOver bool
+}
+
+// defaultDragData holds the data for a default being dragged within its panel.
+type defaultDragData struct {
+	owner *defaultsPanel
+	def   *model.SkillDefault
+	panel *unison.Panel
 }
 
 func newDefaultsPanel(entity *model.Entity, defaults *[]*model.SkillDefault) *defaultsPanel {
@@ -53,6 +67,10 @@ func newDefaultsPanel(entity *model.Entity, defaults *[]*model.SkillDefault) *de
 	p.DrawCallback = func(gc *unison.Canvas, rect unison.Rect) {
 		gc.DrawRect(rect, unison.ContentColor.Paint(gc, rect, unison.Fill))
 	}
+	p.DrawOverCallback = p.drawOver
+	p.DataDragOverCallback = p.dataDragOver
+	p.DataDragExitCallback = p.dataDragExit
+	p.DataDragDropCallback = p.dataDragDrop
 	addButton := unison.NewSVGButton(svg.CircledAdd)
 	addButton.ClickCallback = func() {
 		def := &model.SkillDefault{DefaultType: lastDefaultTypeUsed}
@@ -61,22 +79,73 @@ func newDefaultsPanel(entity *model.Entity, defaults *[]*model.SkillDefault) *de
 		unison.Ancestor[*unison.DockContainer](p).MarkForLayoutRecursively()
 		MarkModified(p)
 	}
-	p.AddChild(addButton)
+	p.header = unison.NewPanel()
+	p.header.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	p.header.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: unison.FillAlignment,
+		HGrab:  true,
+	})
+	p.header.AddChild(addButton)
+	p.AddChild(p.header)
 	for i, one := range *defaults {
 		p.insertDefaultsPanel(i+1, one)
 	}
 	return p
 }
 
+// rebuild recreates the rows from the current defaults, for use after they have been replaced wholesale.
+func (p *defaultsPanel) rebuild() {
+	for _, child := range p.Children()[1:] {
+		child.RemoveFromParent()
+	}
+	for i, one := range *p.defaults {
+		p.insertDefaultsPanel(i+1, one)
+	}
+	unison.Ancestor[*unison.DockContainer](p).MarkForLayoutRecursively()
+}
+
+// addBestDefaultPreview adds a line next to the add button showing the default that currently yields the best level
+// for the entity, along with that level. Does nothing if there is no entity to evaluate the defaults against.
+func (p *defaultsPanel) addBestDefaultPreview(best func() (*model.SkillDefault, fxp.Int)) {
+	if p.entity == nil {
+		return
+	}
+	preview := NewNonEditableField(func(field *NonEditableField) {
+		if def, level := best(); def != nil {
+			field.Text = fmt.Sprintf(i18n.Text("Best: %s%s, resulting in a level of %s"), def.FullName(p.entity),
+				def.ModifierAsString(), level.String())
+		} else {
+			field.Text = i18n.Text("Best: none of the defaults currently apply")
+		}
+		field.MarkForLayoutAndRedraw()
+	})
+	preview.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: unison.FillAlignment,
+		VAlign: unison.MiddleAlignment,
+		HGrab:  true,
+	})
+	p.header.AddChild(preview)
+}
+
 func (p *defaultsPanel) insertDefaultsPanel(index int, def *model.SkillDefault) {
 	panel := unison.NewPanel()
 	panel.SetLayout(&unison.FlexLayout{
-		Columns:  5,
+		Columns:  8,
 		HAlign:   unison.FillAlignment,
 		HSpacing: unison.StdHSpacing,
 		VSpacing: unison.StdVSpacing,
 	})
 
+	panel.AddChild(NewDragHandle(map[string]any{defaultDragDataKey: &defaultDragData{
+		owner: p,
+		def:   def,
+		panel: panel,
+	}}))
+
 	deleteButton := unison.NewSVGButton(svg.Trash)
 	deleteButton.ClickCallback = func() {
 		if i := slices.IndexFunc(*p.defaults, func(elem *model.SkillDefault) bool { return elem == def }); i != -1 {
@@ -88,6 +157,11 @@ func (p *defaultsPanel) insertDefaultsPanel(index int, def *model.SkillDefault)
 	}
 	panel.AddChild(deleteButton)
 
+	enabled := NewCheckBox(nil, "", "", func() unison.CheckState { return unison.CheckStateFromBool(!def.Disabled) },
+		func(state unison.CheckState) { def.Disabled = state == unison.OffCheckState })
+	enabled.Tooltip = unison.NewTooltipWithText(i18n.Text("Enabled"))
+	panel.AddChild(enabled)
+
 	name := i18n.Text("Name")
 	nameField := NewStringField(nil, "", name, func() string { return def.Name },
 		func(s string) { def.Name = s })
@@ -114,6 +188,7 @@ func (p *defaultsPanel) insertDefaultsPanel(index int, def *model.SkillDefault)
 	panel.AddChild(nameField)
 	panel.AddChild(specializationField)
 	panel.AddChild(modifierField)
+	panel.AddChild(p.newMissingSkillWarning(def))
 	adjustFieldBlank(nameField, def.DefaultType != model.SkillID)
 	adjustFieldBlank(specializationField, def.DefaultType != model.SkillID)
 
@@ -123,3 +198,93 @@ func (p *defaultsPanel) insertDefaultsPanel(index int, def *model.SkillDefault)
 	})
 	p.AddChildAtIndex(panel, index)
 }
+
+// newMissingSkillWarning creates an icon that is shown while the default refers to a skill the entity doesn't have.
+func (p *defaultsPanel) newMissingSkillWarning(def *model.SkillDefault) *NonEditableField {
+	warning := NewNonEditableField(func(field *NonEditableField) {
+		if def.ReferencesMissingSkill(p.entity) {
+			height := field.Font.LineHeight()
+			field.Drawable = &unison.DrawableSVG{
+				SVG:  unison.TriangleExclamationSVG,
+				Size: unison.NewSize(height, height),
+			}
+			field.Tooltip = unison.NewTooltipWithText(i18n.Text(
+				"The character has no skill with this name and specialization, so this default will not be used"))
+		} else {
+			field.Drawable = nil
+			field.Tooltip = nil
+		}
+		field.MarkForLayoutAndRedraw()
+	})
+	warning.SetBorder(nil)
+	warning.OnBackgroundInk = unison.ErrorColor
+	return warning
+}
+
+func (p *defaultsPanel) dataDragOver(where unison.Point, data map[string]any) bool {
+	prevInDragOver := p.inDragOver
+	dragInsert := p.dragInsert
+	p.inDragOver = false
+	p.dragInsert = -1
+	if dd, ok := data[defaultDragDataKey].(*defaultDragData); ok && dd.owner == p {
+		children := p.Children()
+		for i := 1; i < len(children); i++ {
+			rect := children[i].FrameRect()
+			if rect.ContainsPoint(where) {
+				if rect.CenterY() <= where.Y {
+					p.dragInsert = i
+				} else {
+					p.dragInsert = i - 1
+				}
+				p.inDragOver = true
+				break
+			}
+		}
+	}
+	if prevInDragOver != p.inDragOver || dragInsert != p.dragInsert {
+		p.MarkForRedraw()
+	}
+	return true
+}
+
+func (p *defaultsPanel) dataDragExit() {
+	p.inDragOver = false
+	p.dragInsert = -1
+	p.MarkForRedraw()
+}
+
+func (p *defaultsPanel) dataDragDrop(_ unison.Point, data map[string]any) {
+	if p.inDragOver && p.dragInsert != -1 {
+		if dd, ok := data[defaultDragDataKey].(*defaultDragData); ok && dd.owner == p {
+			if i := slices.IndexFunc(*p.defaults, func(one *model.SkillDefault) bool { return one == dd.def }); i != -1 {
+				*p.defaults = slices.Delete(*p.defaults, i, i+1)
+				if i < p.dragInsert {
+					p.dragInsert--
+				}
+				*p.defaults = slices.Insert(*p.defaults, p.dragInsert, dd.def)
+				dd.panel.RemoveFromParent()
+				p.AddChildAtIndex(dd.panel, p.dragInsert+1)
+				unison.Ancestor[*unison.DockContainer](p).MarkForLayoutRecursively()
+				MarkModified(p)
+			}
+		}
+	}
+	p.dataDragExit()
+}
+
+func (p *defaultsPanel) drawOver(gc *unison.Canvas, rect unison.Rect) {
+	if p.inDragOver && p.dragInsert != -1 {
+		children := p.Children()
+		index := p.dragInsert + 1
+		var y float32
+		if index < len(children) {
+			y = children[index].FrameRect().Y
+		} else {
+			y = children[len(children)-1].FrameRect().Bottom()
+		}
+		paint := unison.DropAreaColor.Paint(gc, rect, unison.Stroke)
+		paint.SetStrokeWidth(2)
+		r := p.ContentRect(false)
+		gc.DrawLine(r.X, y, r.Right(), y, paint)
+	}
+}
@@ -148,7 +148,7 @@ func (d *DescriptionPanel) createColumn2() *unison.Panel {
 	column.AddChild(NewPageLabelWithRandomizer(title,
 		i18n.Text("Randomize the weight using the current ancestry"), func() {
 			d.entity.Profile.Weight = d.entity.Ancestry().RandomWeight(d.entity, d.entity.Profile.Gender, d.entity.Profile.Weight)
-			SetTextAndMarkModified(weightField.Field, d.entity.Profile.Weight.String())
+			SetTextAndMarkModified(weightField.Field, weightField.Format(d.entity.Profile.Weight))
 		}))
 	weightField.ClientData()[SkipDeepSync] = true
 	column.AddChild(weightField)
@@ -91,6 +91,13 @@ func NewEnabledHeader[T model.NodeTypes](forPage bool) unison.TableColumnHeader[
 		forPage)
 }
 
+// NewPreparedHeader creates a new prepared header.
+func NewPreparedHeader[T model.NodeTypes](forPage bool) unison.TableColumnHeader[*Node[T]] {
+	return NewEditorListSVGHeader[T](svg.Checkmark,
+		i18n.Text(`Whether this spell is currently prepared, i.e. memorized and ready to be cast.`),
+		forPage)
+}
+
 // NewMoneyHeader creates a new money header.
 func NewMoneyHeader[T model.NodeTypes](forPage bool) unison.TableColumnHeader[*Node[T]] {
 	return NewEditorListSVGHeader[T](svg.Coins,
@@ -0,0 +1,168 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/i18n"
+	xfs "github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/unison"
+)
+
+const compareGroup = "compare"
+
+var (
+	_ unison.Dockable  = &entityDiffDockable{}
+	_ unison.TabCloser = &entityDiffDockable{}
+)
+
+type entityDiffDockable struct {
+	unison.Panel
+	beforePath string
+	afterPath  string
+}
+
+// ShowCompareSheets prompts for two character sheets and displays the differences between them side-by-side. The
+// first sheet chosen is treated as the earlier of the two.
+func ShowCompareSheets() {
+	ws := AnyWorkspace()
+	if ws == nil {
+		ShowUnableToLocateWorkspaceError()
+		return
+	}
+	dialog := unison.NewOpenDialog()
+	dialog.SetAllowsMultipleSelection(true)
+	dialog.SetResolvesAliases(true)
+	dialog.SetAllowedExtensions(model.SheetExt)
+	dialog.SetCanChooseDirectories(false)
+	dialog.SetCanChooseFiles(true)
+	global := model.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(model.DefaultLastDirKey))
+	if !dialog.RunModal() {
+		return
+	}
+	paths := dialog.Paths()
+	if len(paths) != 2 {
+		unison.ErrorDialogWithMessage(i18n.Text("Unable to compare sheets"),
+			i18n.Text("Exactly two character sheets must be selected."))
+		return
+	}
+	global.SetLastDir(model.DefaultLastDirKey, filepath.Dir(paths[0]))
+	entities := make([]*model.Entity, len(paths))
+	for i, p := range paths {
+		entity, err := model.NewEntityFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p))
+		if err != nil {
+			unison.ErrorDialogWithError(i18n.Text("Unable to load ")+p, err)
+			return
+		}
+		entities[i] = entity
+	}
+	changes, err := model.DiffEntities(entities[0], entities[1])
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to compare sheets"), err)
+		return
+	}
+	d := &entityDiffDockable{
+		beforePath: paths[0],
+		afterPath:  paths[1],
+	}
+	d.Self = d
+	d.SetLayout(&unison.FlexLayout{Columns: 1})
+	scroll := unison.NewScrollPanel()
+	scroll.SetContent(d.createContent(changes), unison.FillBehavior, unison.FillBehavior)
+	scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: unison.FillAlignment,
+		VAlign: unison.FillAlignment,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	d.AddChild(scroll)
+	PlaceInDock(ws, ws.CurrentlyFocusedDockContainer(), d, compareGroup)
+}
+
+func (d *entityDiffDockable) createContent(changes []model.Change) *unison.Panel {
+	content := unison.NewPanel()
+	content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	content.SetLayout(&unison.FlexLayout{
+		Columns:  5,
+		HSpacing: unison.StdHSpacing * 2,
+		VSpacing: unison.StdVSpacing,
+	})
+	for _, title := range []string{
+		i18n.Text("Change"),
+		i18n.Text("Type"),
+		i18n.Text("Name"),
+		xfs.BaseName(d.beforePath),
+		xfs.BaseName(d.afterPath),
+	} {
+		header := unison.NewLabel()
+		header.Text = title
+		header.Font = unison.SystemFont
+		content.AddChild(header)
+	}
+	if len(changes) == 0 {
+		label := unison.NewLabel()
+		label.Text = i18n.Text("No differences were found.")
+		label.SetLayoutData(&unison.FlexLayoutData{HSpan: 5})
+		content.AddChild(label)
+		return content
+	}
+	for _, change := range changes {
+		for _, text := range []string{change.Kind.String(), change.Category, change.Name, change.Before, change.After} {
+			label := unison.NewLabel()
+			label.Text = text
+			content.AddChild(label)
+		}
+	}
+	return content
+}
+
+// TitleIcon implements unison.Dockable
+func (d *entityDiffDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.GCSSheet,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (d *entityDiffDockable) Title() string {
+	return fmt.Sprintf(i18n.Text("%s vs. %s"), xfs.BaseName(d.beforePath), xfs.BaseName(d.afterPath))
+}
+
+// Tooltip implements unison.Dockable
+func (d *entityDiffDockable) Tooltip() string {
+	return d.beforePath + "\n" + d.afterPath
+}
+
+// Modified implements unison.Dockable
+func (d *entityDiffDockable) Modified() bool {
+	return false
+}
+
+// MayAttemptClose implements unison.TabCloser
+func (d *entityDiffDockable) MayAttemptClose() bool {
+	return true
+}
+
+// AttemptClose implements unison.TabCloser
+func (d *entityDiffDockable) AttemptClose() bool {
+	if dc := unison.Ancestor[*unison.DockContainer](d); dc != nil {
+		dc.Close(d)
+	}
+	return true
+}
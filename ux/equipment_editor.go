@@ -84,7 +84,7 @@ func EditEquipment(owner Rebuildable, equipment *model.Equipment, carried bool)
 			addTagsLabelAndField(content, &e.editorData.Tags)
 			addPageRefLabelAndField(content, &e.editorData.PageRef)
 			adjustFieldBlank(usesField, e.editorData.MaxUses <= 0)
-			content.AddChild(newPrereqPanel(e.target.Entity, &e.editorData.Prereq))
+			content.AddChild(newPrereqPanel(e.target.Entity, e.target, &e.editorData.Prereq))
 			content.AddChild(newFeaturesPanel(e.target.Entity, e.target, &e.editorData.Features))
 			modifiersPanel := newEquipmentModifiersPanel(e.target.Entity, &e.editorData.Modifiers)
 			content.AddChild(modifiersPanel)
@@ -92,9 +92,13 @@ func EditEquipment(owner Rebuildable, equipment *model.Equipment, carried bool)
 				content.AddChild(newWeaponsPanel(e, e.target, wt, &e.editorData.Weapons))
 			}
 			e.InstallCmdHandlers(NewEquipmentModifierItemID, unison.AlwaysEnabled,
-				func(_ any) { modifiersPanel.provider.CreateItem(e, modifiersPanel.table, NoItemVariant) })
+				func(_ any) {
+					modifiersPanel.provider.CreateItem(e, modifiersPanel.table, NoItemVariant, AutoInsertMode)
+				})
 			e.InstallCmdHandlers(NewEquipmentContainerModifierItemID, unison.AlwaysEnabled,
-				func(_ any) { modifiersPanel.provider.CreateItem(e, modifiersPanel.table, ContainerItemVariant) })
+				func(_ any) {
+					modifiersPanel.provider.CreateItem(e, modifiersPanel.table, ContainerItemVariant, AutoInsertMode)
+				})
 			return func() {
 				if e.editorData.Uses > e.editorData.MaxUses {
 					usesField.SetText(strconv.Itoa(e.editorData.MaxUses))
@@ -27,6 +27,7 @@ var _ TableProvider[*model.EquipmentModifier] = &eqpModProvider{}
 
 type eqpModProvider struct {
 	table     *unison.Table[*Node[*model.EquipmentModifier]]
+	nodes     NodeCache[*model.EquipmentModifier]
 	provider  model.EquipmentModifierListProvider
 	forEditor bool
 }
@@ -43,6 +44,10 @@ func (p *eqpModProvider) RefKey() string {
 	return equipmentModifierDragKey
 }
 
+func (p *eqpModProvider) Count(predicate func(*model.EquipmentModifier) bool) int {
+	return model.Count(predicate, p.RootData()...)
+}
+
 func (p *eqpModProvider) AllTags() []string {
 	set := make(map[string]struct{})
 	model.Traverse(func(modifier *model.EquipmentModifier) bool {
@@ -65,12 +70,7 @@ func (p *eqpModProvider) RootRowCount() int {
 }
 
 func (p *eqpModProvider) RootRows() []*Node[*model.EquipmentModifier] {
-	data := p.provider.EquipmentModifierList()
-	rows := make([]*Node[*model.EquipmentModifier], 0, len(data))
-	for _, one := range data {
-		rows = append(rows, NewNode[*model.EquipmentModifier](p.table, nil, one, false))
-	}
-	return rows
+	return p.nodes.Rows(p.table, nil, p.provider.EquipmentModifierList(), false)
 }
 
 func (p *eqpModProvider) SetRootRows(rows []*Node[*model.EquipmentModifier]) {
@@ -159,9 +159,9 @@ func (p *eqpModProvider) OpenEditor(owner Rebuildable, table *unison.Table[*Node
 	})
 }
 
-func (p *eqpModProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*model.EquipmentModifier]], variant ItemVariant) {
+func (p *eqpModProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*model.EquipmentModifier]], variant ItemVariant, mode InsertMode) {
 	item := model.NewEquipmentModifier(p.Entity(), nil, variant == ContainerItemVariant)
-	InsertItems[*model.EquipmentModifier](owner, table, p.provider.EquipmentModifierList,
+	InsertItems[*model.EquipmentModifier](owner, table, mode, p.provider.EquipmentModifierList,
 		p.provider.SetEquipmentModifierList,
 		func(_ *unison.Table[*Node[*model.EquipmentModifier]]) []*Node[*model.EquipmentModifier] {
 			return p.RootRows()
@@ -29,6 +29,7 @@ var _ TableProvider[*model.Equipment] = &equipmentProvider{}
 
 type equipmentProvider struct {
 	table    *unison.Table[*Node[*model.Equipment]]
+	nodes    NodeCache[*model.Equipment]
 	provider model.EquipmentListProvider
 	forPage  bool
 	carried  bool
@@ -50,6 +51,10 @@ func (p *equipmentProvider) RefKey() string {
 	return model.BlockLayoutOtherEquipmentKey
 }
 
+func (p *equipmentProvider) Count(predicate func(*model.Equipment) bool) int {
+	return model.Count(predicate, p.RootData()...)
+}
+
 func (p *equipmentProvider) AllTags() []string {
 	set := make(map[string]struct{})
 	model.Traverse(func(modifier *model.Equipment) bool {
@@ -72,12 +77,7 @@ func (p *equipmentProvider) RootRowCount() int {
 }
 
 func (p *equipmentProvider) RootRows() []*Node[*model.Equipment] {
-	data := p.equipmentList()
-	rows := make([]*Node[*model.Equipment], 0, len(data))
-	for _, one := range data {
-		rows = append(rows, NewNode[*model.Equipment](p.table, nil, one, p.forPage))
-	}
-	return rows
+	return p.nodes.Rows(p.table, nil, p.equipmentList(), p.forPage)
 }
 
 func (p *equipmentProvider) SetRootRows(rows []*Node[*model.Equipment]) {
@@ -264,7 +264,7 @@ func (p *equipmentProvider) OpenEditor(owner Rebuildable, table *unison.Table[*N
 	OpenEditor[*model.Equipment](table, func(item *model.Equipment) { EditEquipment(owner, item, p.carried) })
 }
 
-func (p *equipmentProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*model.Equipment]], variant ItemVariant) {
+func (p *equipmentProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*model.Equipment]], variant ItemVariant, mode InsertMode) {
 	topListFunc := p.provider.OtherEquipmentList
 	setTopListFunc := p.provider.SetOtherEquipmentList
 	if p.carried {
@@ -272,7 +272,7 @@ func (p *equipmentProvider) CreateItem(owner Rebuildable, table *unison.Table[*N
 		setTopListFunc = p.provider.SetCarriedEquipmentList
 	}
 	item := model.NewEquipment(p.Entity(), nil, variant == ContainerItemVariant)
-	InsertItems[*model.Equipment](owner, table, topListFunc, setTopListFunc,
+	InsertItems[*model.Equipment](owner, table, mode, topListFunc, setTopListFunc,
 		func(_ *unison.Table[*Node[*model.Equipment]]) []*Node[*model.Equipment] {
 			return p.RootRows()
 		}, item)
@@ -40,6 +40,9 @@ type generalSettingsDockable struct {
 	autoFillProfileCheckbox       *CheckBox
 	autoAddNaturalAttacksCheckbox *CheckBox
 	groupContainersOnSortCheckbox *CheckBox
+	showMaintenanceRateCheckbox   *CheckBox
+	accessibilityModeCheckbox     *CheckBox
+	showRecentOnStartupCheckbox   *CheckBox
 	pointsField                   *DecimalField
 	techLevelField                *StringField
 	calendarPopup                 *unison.PopupMenu[string]
@@ -53,6 +56,7 @@ type generalSettingsDockable struct {
 	scrollWheelMultiplierField    *DecimalField
 	externalPDFCmdlineField       *StringField
 	localeField                   *StringField
+	decimalSeparatorPopup         *unison.PopupMenu[model.DecimalSeparator]
 }
 
 // ShowGeneralSettings the General Settings window.
@@ -77,8 +81,7 @@ func ShowGeneralSettings() {
 }
 
 func (d *generalSettingsDockable) addToStartToolbar(toolbar *unison.Panel) {
-	helpButton := unison.NewSVGButton(svg.Help)
-	helpButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Help"))
+	helpButton := NewSVGButtonWithTooltip(svg.Help, i18n.Text("Help"))
 	helpButton.ClickCallback = func() { HandleLink(nil, "md:Help/Interface/General Settings") }
 	toolbar.AddChild(helpButton)
 }
@@ -125,6 +128,7 @@ func (d *generalSettingsDockable) initContent(content *unison.Panel) {
 	d.createPathInfoField(content, i18n.Text("Log Path"), jotrotate.PathToLog)
 	d.createExternalPDFCmdLineField(content)
 	d.createLocaleField(content)
+	d.createDecimalSeparatorPopup(content)
 }
 
 func (d *generalSettingsDockable) createPlayerAndDescFields(content *unison.Panel) {
@@ -164,6 +168,18 @@ func (d *generalSettingsDockable) createCheckboxBlock(content *unison.Panel) {
 	content.AddChild(NewFieldLeadingLabel(""))
 	content.AddChild(d.groupContainersOnSortCheckbox)
 
+	d.showMaintenanceRateCheckbox = NewCheckBox(nil, "", i18n.Text("Show spell maintenance cost per turn in libraries"),
+		func() unison.CheckState {
+			return unison.CheckStateFromBool(model.GlobalSettings().General.ShowMaintenanceRate)
+		},
+		func(state unison.CheckState) {
+			model.GlobalSettings().General.ShowMaintenanceRate = state == unison.OnCheckState
+			syncDefaultSheetSettings()
+		})
+	d.showMaintenanceRateCheckbox.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	content.AddChild(NewFieldLeadingLabel(""))
+	content.AddChild(d.showMaintenanceRateCheckbox)
+
 	d.autoAddNaturalAttacksCheckbox = NewCheckBox(nil, "", i18n.Text("Add natural attacks to new sheets"),
 		func() unison.CheckState {
 			return unison.CheckStateFromBool(model.GlobalSettings().General.AutoAddNaturalAttacks)
@@ -174,6 +190,30 @@ func (d *generalSettingsDockable) createCheckboxBlock(content *unison.Panel) {
 	d.autoAddNaturalAttacksCheckbox.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
 	content.AddChild(NewFieldLeadingLabel(""))
 	content.AddChild(d.autoAddNaturalAttacksCheckbox)
+
+	d.accessibilityModeCheckbox = NewCheckBox(nil, "",
+		i18n.Text("Use larger buttons and high contrast colors in editors"),
+		func() unison.CheckState {
+			return unison.CheckStateFromBool(model.GlobalSettings().General.AccessibilityMode)
+		},
+		func(state unison.CheckState) {
+			model.GlobalSettings().General.AccessibilityMode = state == unison.OnCheckState
+			syncAccessibilityMode()
+		})
+	d.accessibilityModeCheckbox.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	content.AddChild(NewFieldLeadingLabel(""))
+	content.AddChild(d.accessibilityModeCheckbox)
+
+	d.showRecentOnStartupCheckbox = NewCheckBox(nil, "", i18n.Text("Show recent files at startup"),
+		func() unison.CheckState {
+			return unison.CheckStateFromBool(model.GlobalSettings().General.ShowRecentOnStartup)
+		},
+		func(state unison.CheckState) {
+			model.GlobalSettings().General.ShowRecentOnStartup = state == unison.OnCheckState
+		})
+	d.showRecentOnStartupCheckbox.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	content.AddChild(NewFieldLeadingLabel(""))
+	content.AddChild(d.showRecentOnStartupCheckbox)
 }
 
 func (d *generalSettingsDockable) createInitialPointsFields(content *unison.Panel) {
@@ -282,8 +322,7 @@ func (d *generalSettingsDockable) createPathInfoField(content *unison.Panel, tit
 	content.AddChild(NewNonEditableField(func(field *NonEditableField) {
 		field.Text = value
 	}))
-	addButton := unison.NewSVGButton(svg.Copy)
-	addButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Copy to clipboard"))
+	addButton := NewSVGButtonWithTooltip(svg.Copy, i18n.Text("Copy to clipboard"))
 	addButton.ClickCallback = func() {
 		unison.GlobalClipboard.SetText(value)
 	}
@@ -329,6 +368,21 @@ func (d *generalSettingsDockable) createLocaleField(content *unison.Panel) {
 	content.AddChild(d.localeField)
 }
 
+func (d *generalSettingsDockable) createDecimalSeparatorPopup(content *unison.Panel) {
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Decimal Separator")))
+	d.decimalSeparatorPopup = unison.NewPopupMenu[model.DecimalSeparator]()
+	d.decimalSeparatorPopup.AddItem(model.AllDecimalSeparator...)
+	d.decimalSeparatorPopup.Select(model.GlobalSettings().General.DecimalSeparator)
+	d.decimalSeparatorPopup.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	d.decimalSeparatorPopup.Tooltip = unison.NewTooltipWithText(txt.Wrap("", i18n.Text(`The character used to separate the whole and fractional parts of numbers entered into and displayed by editable fields. This does not affect the content of data files.`), 100))
+	d.decimalSeparatorPopup.SelectionChangedCallback = func(p *unison.PopupMenu[model.DecimalSeparator]) {
+		if item, ok := p.Selected(); ok {
+			model.GlobalSettings().General.DecimalSeparator = item
+		}
+	}
+	content.AddChild(d.decimalSeparatorPopup)
+}
+
 func (d *generalSettingsDockable) reset() {
 	*model.GlobalSettings().General = *model.NewGeneralSheetSettings()
 	languageSetting = ""
@@ -340,8 +394,12 @@ func (d *generalSettingsDockable) sync() {
 	d.nameField.SetText(s.DefaultPlayerName)
 	SetCheckBoxState(d.autoFillProfileCheckbox, s.AutoFillProfile)
 	SetCheckBoxState(d.groupContainersOnSortCheckbox, s.GroupContainersOnSort)
+	maintenanceRateChanged := d.showMaintenanceRateCheckbox.State != unison.CheckStateFromBool(s.ShowMaintenanceRate)
+	SetCheckBoxState(d.showMaintenanceRateCheckbox, s.ShowMaintenanceRate)
 	SetCheckBoxState(d.autoAddNaturalAttacksCheckbox, s.AutoAddNaturalAttacks)
-	d.pointsField.SetText(s.InitialPoints.String())
+	SetCheckBoxState(d.accessibilityModeCheckbox, s.AccessibilityMode)
+	SetCheckBoxState(d.showRecentOnStartupCheckbox, s.ShowRecentOnStartup)
+	d.pointsField.SetText(d.pointsField.Format(s.InitialPoints))
 	d.techLevelField.SetText(s.DefaultTechLevel)
 	d.calendarPopup.Select(s.CalendarRef(model.GlobalSettings().Libraries()).Name)
 	SetFieldValue(d.initialListScaleField.Field, d.initialListScaleField.Format(s.InitialListUIScale))
@@ -349,14 +407,36 @@ func (d *generalSettingsDockable) sync() {
 	SetFieldValue(d.initialSheetScaleField.Field, d.initialSheetScaleField.Format(s.InitialSheetUIScale))
 	d.maxAutoColWidthField.SetText(strconv.Itoa(s.MaximumAutoColWidth))
 	d.exportResolutionField.SetText(strconv.Itoa(s.ImageResolution))
-	d.tooltipDelayField.SetText(s.TooltipDelay.String())
-	d.tooltipDismissalField.SetText(s.TooltipDismissal.String())
-	d.scrollWheelMultiplierField.SetText(s.ScrollWheelMultiplier.String())
+	d.tooltipDelayField.SetText(d.tooltipDelayField.Format(s.TooltipDelay))
+	d.tooltipDismissalField.SetText(d.tooltipDismissalField.Format(s.TooltipDismissal))
+	d.scrollWheelMultiplierField.SetText(d.scrollWheelMultiplierField.Format(s.ScrollWheelMultiplier))
 	SetFieldValue(d.externalPDFCmdlineField.Field, s.ExternalPDFCmdLine)
 	SetFieldValue(d.localeField.Field, languageSetting)
+	d.decimalSeparatorPopup.Select(s.DecimalSeparator)
+	syncAccessibilityMode()
+	if maintenanceRateChanged {
+		syncDefaultSheetSettings()
+	}
 	d.MarkForRedraw()
 }
 
+// syncDefaultSheetSettings notifies all dockables that the default sheet settings have changed in a way that may alter
+// their columns.
+func syncDefaultSheetSettings() {
+	for _, wnd := range unison.Windows() {
+		if ws := WorkspaceFromWindow(wnd); ws != nil {
+			ws.DocumentDock.RootDockLayout().ForEachDockContainer(func(dc *unison.DockContainer) bool {
+				for _, one := range dc.Dockables() {
+					if s, ok := one.(model.SheetSettingsResponder); ok {
+						s.SheetSettingsUpdated(nil, true)
+					}
+				}
+				return false
+			})
+		}
+	}
+}
+
 func (d *generalSettingsDockable) load(fileSystem fs.FS, filePath string) error {
 	s, err := model.NewGeneralSheetSettingsFromFile(fileSystem, filePath)
 	if err != nil {
@@ -0,0 +1,133 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+// hitLocationCalculator is a play aid that shows the net to-hit for an attack against a particular hit location of the
+// body type being edited.
+type hitLocationCalculator struct {
+	unison.Panel
+	dockable      *bodySettingsDockable
+	skill         int
+	locationID    string
+	locationPopup *unison.PopupMenu[*hitLocationCalculatorChoice]
+	result        *unison.Label
+}
+
+type hitLocationCalculatorChoice struct {
+	location *model.HitLocation
+	title    string
+}
+
+func (c *hitLocationCalculatorChoice) String() string {
+	return c.title
+}
+
+func newHitLocationCalculator(d *bodySettingsDockable) *hitLocationCalculator {
+	c := &hitLocationCalculator{
+		dockable: d,
+		skill:    10,
+	}
+	c.Self = c
+	c.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.DividerColor, 0, unison.Insets{Bottom: 1}, false),
+		unison.NewEmptyBorder(unison.StdInsets())))
+	c.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: unison.FillAlignment,
+		HGrab:  true,
+	})
+
+	c.AddChild(NewFieldLeadingLabel(i18n.Text("Attacker Skill")))
+	skillField := unison.NewField()
+	skillField.SetText(strconv.Itoa(c.skill))
+	skillField.SetMinimumTextWidthUsing("-999")
+	skillField.ModifiedCallback = func(_, _ *unison.FieldState) {
+		if v, err := strconv.Atoi(strings.TrimSpace(skillField.Text())); err == nil {
+			c.skill = v
+			c.update()
+		}
+	}
+	skillField.ValidateCallback = func() bool {
+		_, err := strconv.Atoi(strings.TrimSpace(skillField.Text()))
+		return err == nil
+	}
+	c.AddChild(skillField)
+
+	c.AddChild(NewFieldLeadingLabel(i18n.Text("Location")))
+	c.locationPopup = unison.NewPopupMenu[*hitLocationCalculatorChoice]()
+	c.locationPopup.SelectionChangedCallback = func(p *unison.PopupMenu[*hitLocationCalculatorChoice]) {
+		if choice, ok := p.Selected(); ok {
+			c.locationID = choice.location.LocID
+			c.update()
+		}
+	}
+	c.AddChild(c.locationPopup)
+
+	c.AddChild(NewFieldLeadingLabel(i18n.Text("Net To-Hit")))
+	c.result = unison.NewLabel()
+	c.AddChild(c.result)
+
+	c.SetLayout(&unison.FlexLayout{
+		Columns:  len(c.Children()),
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	c.rebuildChoices()
+	return c
+}
+
+// rebuildChoices refreshes the list of locations from the body type being edited, retaining the current selection when
+// possible.
+func (c *hitLocationCalculator) rebuildChoices() {
+	c.locationPopup.RemoveAllItems()
+	selected := -1
+	for _, loc := range c.dockable.body.AllHitLocations() {
+		title := loc.ChoiceName
+		if owner := loc.OwningTable().OwningLocation(); owner != nil {
+			title = owner.ChoiceName + " / " + title
+		}
+		c.locationPopup.AddItem(&hitLocationCalculatorChoice{location: loc, title: title})
+		if selected == -1 && loc.LocID == c.locationID {
+			selected = c.locationPopup.ItemCount() - 1
+		}
+	}
+	if selected == -1 && c.locationPopup.ItemCount() != 0 {
+		selected = 0
+	}
+	if selected != -1 {
+		c.locationPopup.SelectIndex(selected)
+		if choice, ok := c.locationPopup.Selected(); ok {
+			c.locationID = choice.location.LocID
+		}
+	}
+	c.update()
+}
+
+func (c *hitLocationCalculator) update() {
+	text := "-"
+	if choice, ok := c.locationPopup.Selected(); ok {
+		penalty := choice.location.HitPenalty
+		text = fmt.Sprintf(i18n.Text("%d (%+d for location)"), c.skill+penalty, penalty)
+	}
+	if c.result.Text != text {
+		c.result.Text = text
+		c.MarkForLayoutAndRedraw()
+	}
+}
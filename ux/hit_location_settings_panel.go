@@ -18,6 +18,7 @@ import (
 	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/rpgtools/dice"
 	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/unison"
 )
 
@@ -32,6 +33,8 @@ type hitLocationSettingsPanel struct {
 	loc          *model.HitLocation
 	addButton    *unison.Button
 	deleteButton *unison.Button
+	content      *unison.Panel
+	subTable     *bodySettingsSubTablePanel
 }
 
 func newHitLocationSettingsPanel(dockable *bodySettingsDockable, loc *model.HitLocation) *hitLocationSettingsPanel {
@@ -53,20 +56,93 @@ func newHitLocationSettingsPanel(dockable *bodySettingsDockable, loc *model.HitL
 		Right:  unison.StdHSpacing,
 	}))
 	p.DrawCallback = func(gc *unison.Canvas, rect unison.Rect) {
-		color := unison.ContentColor
+		var ink unison.Ink = editorContentInk
 		if p.Parent().IndexOfChild(p)%2 == 1 {
-			color = unison.BandingColor
+			ink = editorBandingInk
 		}
-		gc.DrawRect(rect, color.Paint(gc, rect, unison.Fill))
+		gc.DrawRect(rect, ink.Paint(gc, rect, unison.Fill))
 	}
 
 	p.AddChild(NewDragHandle(map[string]any{hitLocationDragDataKey: p}))
 	p.AddChild(p.createButtons())
-	p.AddChild(p.createContent())
+	p.content = p.createContent()
+	p.AddChild(p.content)
+	p.MouseDownCallback = p.mouseDown
 
 	return p
 }
 
+// update points the panel at the location, which may be a clone of the one it was showing, and brings its buttons and
+// sub-table up to date.
+func (p *hitLocationSettingsPanel) update(loc *model.HitLocation) {
+	p.loc = loc
+	p.updateButtons()
+	switch {
+	case (p.subTable == nil) != (loc.SubTable == nil):
+		p.content.RemoveFromParent()
+		p.content = p.createContent()
+		p.AddChild(p.content)
+	case p.subTable != nil:
+		p.subTable.update(loc.SubTable)
+	}
+}
+
+func (p *hitLocationSettingsPanel) updateButtons() {
+	owningTable := p.loc.OwningTable()
+	p.deleteButton.SetEnabled(owningTable != nil && len(owningTable.Locations) > 1)
+	p.addButton.SetEnabled(p.loc.SubTable == nil)
+}
+
+func (p *hitLocationSettingsPanel) mouseDown(where unison.Point, button, clickCount int, _ unison.Modifiers) bool {
+	if button != unison.ButtonRight || clickCount != 1 || p.loc.OwningTable() == nil {
+		return false
+	}
+	f := unison.DefaultMenuFactory()
+	cm := f.NewMenu(unison.PopupMenuTemporaryBaseID|unison.ContextMenuIDFlag, "", nil)
+	cm.InsertItem(-1, f.NewItem(unison.PopupMenuTemporaryBaseID+1, i18n.Text("Duplicate with Offset…"),
+		unison.KeyBinding{}, nil, func(_ unison.MenuItem) { p.duplicateWithOffset() }))
+	p.FlushDrawing()
+	cm.Popup(geom.Rect[float32]{
+		Point: p.PointToRoot(where),
+		Size: geom.Size[float32]{
+			Width:  1,
+			Height: 1,
+		},
+	}, 0)
+	cm.Dispose()
+	return true
+}
+
+// duplicateWithOffset asks for a hit penalty adjustment and then inserts a copy of the hit location, with that
+// adjustment applied, immediately after it.
+func (p *hitLocationSettingsPanel) duplicateWithOffset() {
+	delta := 0
+	field := NewIntegerField(nil, "", "", func() int { return delta }, func(v int) { delta = v }, -99, 99, true, false)
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Hit Penalty Adjustment")))
+	panel.AddChild(field)
+	dialog, err := unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon, unison.DefaultDialogTheme.QuestionIconInk,
+		panel, []*unison.DialogButtonInfo{unison.NewCancelButtonInfo(), unison.NewOKButtonInfoWithTitle(i18n.Text("Duplicate"))})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create hit location duplication dialog"), err)
+		return
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	undo := p.dockable.prepareUndo(i18n.Text("Duplicate Hit Location"))
+	if p.loc.OwningTable().DuplicateLocation(p.dockable.Entity(), p.loc, delta, p.dockable.targetMgr.NextPrefix) == nil {
+		return
+	}
+	p.dockable.finishAndPostUndo(undo)
+	p.dockable.sync()
+}
+
 func (p *hitLocationSettingsPanel) createButtons() *unison.Panel {
 	buttons := unison.NewPanel()
 	buttons.SetLayout(&unison.FlexLayout{
@@ -76,18 +152,14 @@ func (p *hitLocationSettingsPanel) createButtons() *unison.Panel {
 	})
 	buttons.SetLayoutData(&unison.FlexLayoutData{HAlign: unison.MiddleAlignment})
 
-	p.deleteButton = unison.NewSVGButton(svg.Trash)
+	p.deleteButton = newEditorSVGButton(svg.Trash, i18n.Text("Remove hit location"))
 	p.deleteButton.ClickCallback = p.removeHitLocation
-	p.deleteButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Remove hit location"))
-	owningTable := p.loc.OwningTable()
-	p.deleteButton.SetEnabled(owningTable != nil && len(owningTable.Locations) > 1)
 	buttons.AddChild(p.deleteButton)
 
-	p.addButton = unison.NewSVGButton(svg.CircledAdd)
+	p.addButton = newEditorSVGButton(svg.CircledAdd, i18n.Text("Add sub-table"))
 	p.addButton.ClickCallback = p.addSubTable
-	p.addButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Add sub-table"))
-	p.addButton.SetEnabled(p.loc.SubTable == nil)
 	buttons.AddChild(p.addButton)
+	p.updateButtons()
 	return buttons
 }
 
@@ -155,6 +227,15 @@ func (p *hitLocationSettingsPanel) createContent() *unison.Panel {
 		func(s string) { p.loc.TableName = s })
 	field.SetMinimumTextWidthUsing(prototypeMinNameWidth)
 	field.Tooltip = unison.NewTooltipWithText(i18n.Text("The name of this hit location as it should appear in the hit location table"))
+	installTextCompletion(field.Field, model.StandardHitLocationNames)
+	tableNameField := field
+	lostFocus := field.LostFocusCallback
+	field.LostFocusCallback = func() {
+		lostFocus()
+		if suggestion := model.LookupStandardHitLocation(tableNameField.Text()); suggestion != nil {
+			unison.InvokeTask(func() { p.applySuggestion(suggestion) })
+		}
+	}
 	content.AddChild(field)
 
 	text = i18n.Text("Slots")
@@ -203,11 +284,27 @@ func (p *hitLocationSettingsPanel) createContent() *unison.Panel {
 		field.Tooltip = unison.NewTooltipWithText(i18n.Text("The dice to roll on the sub-table"))
 		content.AddChild(field)
 
-		content.AddChild(newBodySettingsSubTablePanel(p.dockable, p.loc.SubTable))
+		p.subTable = newBodySettingsSubTablePanel(p.dockable, p.loc.SubTable)
+		content.AddChild(p.subTable)
+	} else {
+		p.subTable = nil
 	}
 	return content
 }
 
+// applySuggestion fills in the conventional values for a standard hit location, but only if the hit location's slots,
+// hit penalty and DR bonus haven't been set yet, so that existing data isn't overwritten.
+func (p *hitLocationSettingsPanel) applySuggestion(suggestion *model.HitLocationSuggestion) {
+	if p.loc.Slots != 0 || p.loc.HitPenalty != 0 || p.loc.DRBonus != 0 || p.loc.OwningTable() == nil {
+		return
+	}
+	undo := p.dockable.prepareUndo(i18n.Text("Use Standard Hit Location"))
+	suggestion.ApplyTo(p.loc)
+	p.loc.OwningTable().Update(p.dockable.Entity())
+	p.dockable.finishAndPostUndo(undo)
+	p.dockable.sync()
+}
+
 func (p *hitLocationSettingsPanel) validateLocID(locID string) bool {
 	if key := strings.TrimSpace(strings.ToLower(locID)); key != "" {
 		return key == model.SanitizeID(key, false, model.ReservedIDs...)
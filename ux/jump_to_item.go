@@ -0,0 +1,169 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+func (s *Sheet) jumpToItem(_ any) {
+	targets := s.entity.JumpTargets()
+	list := unison.NewList[*model.JumpTarget]()
+	list.SetAllowMultipleSelection(false)
+	var dialog *unison.Dialog
+	list.DoubleClickCallback = func() {
+		if dialog != nil {
+			dialog.Button(unison.ModalResponseOK).Click()
+		}
+	}
+	list.NewSelectionCallback = func() {
+		if dialog != nil {
+			dialog.Button(unison.ModalResponseOK).SetEnabled(list.Selection.Count() != 0)
+		}
+	}
+	var matches []*model.JumpTarget
+	update := func(query string) {
+		matches = model.FilterJumpTargets(targets, query)
+		list.Selection.Reset()
+		list.RemoveRange(0, list.Count()-1)
+		list.Append(matches...)
+		if len(matches) != 0 {
+			list.Select(false, 0)
+		}
+		if dialog != nil {
+			dialog.Button(unison.ModalResponseOK).SetEnabled(len(matches) != 0)
+		}
+		list.MarkForLayoutAndRedraw()
+	}
+	update("")
+
+	field := unison.NewField()
+	field.Watermark = i18n.Text("Name")
+	field.SetMinimumTextWidthUsing(minTextWidthCandidate)
+	field.ModifiedCallback = func(_, after *unison.FieldState) { update(after.Text) }
+	field.KeyDownCallback = func(keyCode unison.KeyCode, mod unison.Modifiers, repeat bool) bool {
+		switch keyCode {
+		case unison.KeyDown, unison.KeyUp:
+			return list.DefaultKeyDown(keyCode, mod, repeat)
+		case unison.KeyReturn, unison.KeyNumPadEnter:
+			if len(matches) != 0 && dialog != nil {
+				dialog.Button(unison.ModalResponseOK).Click()
+			}
+			return true
+		default:
+			return field.DefaultKeyDown(keyCode, mod, repeat)
+		}
+	}
+	field.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: unison.FillAlignment,
+		HGrab:  true,
+	})
+
+	scroll := unison.NewScrollPanel()
+	scroll.SetBorder(unison.NewLineBorder(unison.DividerColor, 0, unison.NewUniformInsets(1), false))
+	scroll.SetContent(list, unison.FillBehavior, unison.FillBehavior)
+	scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign:  unison.FillAlignment,
+		VAlign:  unison.FillAlignment,
+		MinSize: unison.NewSize(0, 10*unison.LabelFont.LineHeight()),
+		HGrab:   true,
+		VGrab:   true,
+	})
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+		HAlign:   unison.FillAlignment,
+		VAlign:   unison.FillAlignment,
+	})
+	panel.AddChild(field)
+	panel.AddChild(scroll)
+
+	var err error
+	dialog, err = unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon, unison.DefaultDialogTheme.QuestionIconInk,
+		panel, []*unison.DialogButtonInfo{
+			unison.NewCancelButtonInfo(),
+			unison.NewOKButtonInfoWithTitle(i18n.Text("Jump")),
+		})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create jump to item dialog"), err)
+		return
+	}
+	dialog.Button(unison.ModalResponseOK).SetEnabled(len(matches) != 0)
+	field.RequestFocus()
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	if i := list.Selection.FirstSet(); i >= 0 && i < len(matches) {
+		s.showJumpTarget(matches[i])
+	}
+}
+
+func (s *Sheet) showJumpTarget(target *model.JumpTarget) {
+	switch item := target.Item.(type) {
+	case *model.Weapon:
+		EditWeapon(s, item)
+	case *model.Trait:
+		s.clearTableSelections()
+		showJumpTargetRow(s.Traits, item)
+	case *model.Skill:
+		s.clearTableSelections()
+		showJumpTargetRow(s.Skills, item)
+	case *model.Spell:
+		s.clearTableSelections()
+		showJumpTargetRow(s.Spells, item)
+	case *model.Equipment:
+		s.clearTableSelections()
+		if !showJumpTargetRow(s.CarriedEquipment, item) {
+			showJumpTargetRow(s.OtherEquipment, item)
+		}
+	case *model.Note:
+		s.clearTableSelections()
+		showJumpTargetRow(s.Notes, item)
+	}
+}
+
+func showJumpTargetRow[T model.NodeTypes](pageList *PageList[T], item T) bool {
+	if pageList == nil {
+		return false
+	}
+	return revealTableRow(pageList.Table, item)
+}
+
+// revealTableRow selects the row holding the item, disclosing and scrolling to it as needed, and focuses the table.
+func revealTableRow[T model.NodeTypes](table *unison.Table[*Node[T]], item T) bool {
+	for _, row := range table.RootRows() {
+		if found := findRowForData(row, item); found != nil {
+			showSearchResolvedRef(table, found)
+			table.RequestFocus()
+			return true
+		}
+	}
+	return false
+}
+
+func findRowForData[T model.NodeTypes](row *Node[T], item T) *Node[T] {
+	if any(row.Data()) == any(item) {
+		return row
+	}
+	if row.CanHaveChildren() {
+		for _, child := range row.Children() {
+			if found := findRowForData(child, item); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
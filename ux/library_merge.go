@@ -0,0 +1,173 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/txt"
+	"github.com/richardwilkes/unison"
+)
+
+// InstallMergeLibraryCmdHandler installs a handler that merges another library file of the same type into the
+// dockable's list, letting the user resolve any conflicts.
+func InstallMergeLibraryCmdHandler[T model.Mergeable[T]](d *TableDockable[T], loader func(fileSystem fs.FS, filePath string) ([]T, error), key func(T) string) {
+	d.InstallCmdHandlers(MergeLibraryItemID, func(_ any) bool { return !d.table.IsFiltered() },
+		func(_ any) { mergeLibrary(d, loader, key) })
+}
+
+func mergeLibrary[T model.Mergeable[T]](d *TableDockable[T], loader func(fileSystem fs.FS, filePath string) ([]T, error), key func(T) string) {
+	dialog := unison.NewOpenDialog()
+	dialog.SetAllowsMultipleSelection(false)
+	dialog.SetResolvesAliases(true)
+	dialog.SetAllowedExtensions(d.extension)
+	dialog.SetCanChooseDirectories(false)
+	dialog.SetCanChooseFiles(true)
+	global := model.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(model.DefaultLastDirKey))
+	if !dialog.RunModal() {
+		return
+	}
+	p := dialog.Path()
+	global.SetLastDir(model.DefaultLastDirKey, filepath.Dir(p))
+	theirs, err := loader(os.DirFS(filepath.Dir(p)), filepath.Base(p))
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to load ")+p, err)
+		return
+	}
+	merged, conflicts := model.MergeLists(d.provider.RootData(), theirs, key)
+	if len(conflicts) != 0 && !resolveMergeConflicts(conflicts) {
+		return
+	}
+	undo := &unison.UndoEdit[*TableUndoEditData[T]]{
+		ID:         unison.NextUndoID(),
+		EditName:   i18n.Text("Merge Library"),
+		UndoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.BeforeData.Apply() },
+		RedoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.AfterData.Apply() },
+		BeforeData: NewTableUndoEditData(d.table),
+	}
+	d.provider.SetRootData(merged)
+	d.Rebuild(true)
+	undo.AfterData = NewTableUndoEditData(d.table)
+	d.undoMgr.Add(undo)
+	d.MarkModified(nil)
+}
+
+func resolveMergeConflicts[T model.Mergeable[T]](conflicts []*model.MergeConflict[T]) bool {
+	list := unison.NewPanel()
+	list.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing)))
+	list.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	mine := i18n.Text("Keep Mine")
+	other := i18n.Text("Use Theirs")
+	popups := make([]*unison.PopupMenu[string], len(conflicts))
+	for i, conflict := range conflicts {
+		if i != 0 {
+			sep := unison.NewSeparator()
+			sep.SetLayoutData(&unison.FlexLayoutData{
+				HSpan:  2,
+				HAlign: unison.FillAlignment,
+				VAlign: unison.MiddleAlignment,
+				HGrab:  true,
+			})
+			list.AddChild(sep)
+		}
+		label := unison.NewLabel()
+		label.Text = fmt.Sprint(conflict.Ours)
+		label.Font = unison.SystemFont
+		label.SetLayoutData(&unison.FlexLayoutData{
+			HAlign: unison.FillAlignment,
+			VAlign: unison.MiddleAlignment,
+			HGrab:  true,
+		})
+		list.AddChild(label)
+		popup := unison.NewPopupMenu[string]()
+		popup.AddItem(mine, other)
+		popup.SelectIndex(0)
+		list.AddChild(popup)
+		popups[i] = popup
+		list.AddChild(newMergeDifferencesPanel(conflict.Differences()))
+	}
+	scroll := unison.NewScrollPanel()
+	scroll.SetBorder(unison.NewLineBorder(unison.DividerColor, 0, unison.NewUniformInsets(1), false))
+	scroll.SetContent(list, unison.FillBehavior, unison.FillBehavior)
+	scroll.BackgroundInk = unison.ContentColor
+	scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: unison.FillAlignment,
+		VAlign: unison.FillAlignment,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+		HAlign:   unison.FillAlignment,
+		VAlign:   unison.FillAlignment,
+	})
+	label := unison.NewLabel()
+	label.Text = i18n.Text("These entries differ between the two libraries:")
+	panel.AddChild(label)
+	panel.AddChild(scroll)
+	if unison.QuestionDialogWithPanel(panel) != unison.ModalResponseOK {
+		return false
+	}
+	for i, popup := range popups {
+		if popup.SelectedIndex() == 1 {
+			conflicts[i].UseTheirs()
+		}
+	}
+	return true
+}
+
+// newMergeDifferencesPanel creates a panel listing each differing field along with the values from both libraries.
+func newMergeDifferencesPanel(differences []*model.MergeDifference) *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing * 2,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: unison.FillAlignment,
+		HGrab:  true,
+	})
+	panel.SetBorder(unison.NewEmptyBorder(unison.Insets{Left: unison.StdHSpacing * 2}))
+	for _, title := range []string{i18n.Text("Field"), i18n.Text("Mine"), i18n.Text("Theirs")} {
+		header := unison.NewLabel()
+		header.Text = title
+		header.Font = unison.SystemFont
+		panel.AddChild(header)
+	}
+	for _, one := range differences {
+		for _, text := range []string{one.Field, one.Ours, one.Theirs} {
+			label := unison.NewLabel()
+			label.Text = txt.Truncate(strings.ReplaceAll(text, "\n", " "), 60, true)
+			if len(text) > 60 || strings.Contains(text, "\n") {
+				label.Tooltip = unison.NewTooltipWithText(text)
+			}
+			panel.AddChild(label)
+		}
+	}
+	return panel
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+// selectedLibraryModified returns the selected rows and their descendants whose data was modified from its library
+// version when the library status was last refreshed. Containers are never included.
+func selectedLibraryModified[T model.NodeTypes](table *unison.Table[*Node[T]]) []T {
+	rows := table.SelectedRows(true)
+	selection := make([]T, 0, len(rows))
+	for _, row := range rows {
+		selection = append(selection, row.Data())
+	}
+	var list []T
+	model.Traverse(func(data T) bool {
+		if !model.AsNode(data).Container() && model.ModifiedFromLibrary(data) {
+			list = append(list, data)
+		}
+		return false
+	}, false, true, selection...)
+	return list
+}
+
+func canRevertSelectionToLibrary[T model.NodeTypes](table *unison.Table[*Node[T]]) bool {
+	return !table.IsFiltered() && len(selectedLibraryModified(table)) != 0
+}
+
+// revertSelectionToLibrary replaces the selected rows and their descendants with their library versions, after asking
+// whether their local notes should be kept.
+func revertSelectionToLibrary[T model.NodeTypes](table *unison.Table[*Node[T]], provider TableProvider[T]) {
+	list := selectedLibraryModified(table)
+	if len(list) == 0 {
+		return
+	}
+	keepNotes := true
+	label := unison.NewLabel()
+	label.Text = fmt.Sprintf(i18n.Text("Revert %s to their library versions?"),
+		model.CountWithNoun(len(list), i18n.Text("item"), i18n.Text("items")))
+	checkbox := unison.NewCheckBox()
+	checkbox.Text = i18n.Text("Keep local notes")
+	checkbox.State = unison.OnCheckState
+	checkbox.ClickCallback = func() { keepNotes = checkbox.State == unison.OnCheckState }
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(label)
+	panel.AddChild(checkbox)
+	if unison.QuestionDialogWithPanel(panel) == unison.ModalResponseOK {
+		replaceWithLibraryVersions(table, provider, list, keepNotes, i18n.Text("Revert to Library Version"))
+	}
+}
+
+func replaceWithLibraryVersions[T model.NodeTypes](table *unison.Table[*Node[T]], provider TableProvider[T], list []T, keepNotes bool, name string) {
+	if len(list) == 0 {
+		return
+	}
+	var undo *unison.UndoEdit[*TableUndoEditData[T]]
+	mgr := unison.UndoManagerFor(table)
+	if mgr != nil {
+		undo = &unison.UndoEdit[*TableUndoEditData[T]]{
+			ID:         unison.NextUndoID(),
+			EditName:   name,
+			UndoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.BeforeData.Apply() },
+			RedoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.AfterData.Apply() },
+			BeforeData: NewTableUndoEditData(table),
+		}
+	}
+	for _, item := range list {
+		replacement, ok := model.RevertToLibrary(item, keepNotes)
+		if !ok {
+			continue
+		}
+		if parent := model.AsNode(item).Parent(); !toolbox.IsNil(parent) {
+			parentNode := model.AsNode(parent)
+			parentNode.SetChildren(replaceInList(parentNode.NodeChildren(), item, replacement))
+		} else {
+			provider.SetRootData(replaceInList(provider.RootData(), item, replacement))
+		}
+	}
+	if entity := model.AsNode(list[0]).OwningEntity(); entity != nil {
+		entity.Recalculate()
+	}
+	unison.Ancestor[Rebuildable](table).Rebuild(true)
+	if mgr != nil && undo != nil {
+		undo.AfterData = NewTableUndoEditData(table)
+		mgr.Add(undo)
+	}
+	MarkModified(table)
+}
+
+func replaceInList[T model.NodeTypes](list []T, target, replacement T) []T {
+	result := make([]T, len(list))
+	for i, one := range list {
+		if any(one) == any(target) {
+			result[i] = replacement
+		} else {
+			result[i] = one
+		}
+	}
+	return result
+}
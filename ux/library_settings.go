@@ -75,8 +75,7 @@ func ShowLibrarySettings(lib *model.Library) {
 
 func (d *librarySettingsDockable) addToStartToolbar(toolbar *unison.Panel) {
 	d.toolbar = toolbar
-	d.applyButton = unison.NewSVGButton(svg.Checkmark)
-	d.applyButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Apply Changes"))
+	d.applyButton = NewSVGButtonWithTooltip(svg.Checkmark, i18n.Text("Apply Changes"))
 	d.applyButton.SetEnabled(false)
 	d.applyButton.ClickCallback = func() {
 		d.apply()
@@ -85,8 +84,7 @@ func (d *librarySettingsDockable) addToStartToolbar(toolbar *unison.Panel) {
 	}
 	toolbar.AddChild(d.applyButton)
 
-	d.cancelButton = unison.NewSVGButton(svg.Not)
-	d.cancelButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Discard Changes"))
+	d.cancelButton = NewSVGButtonWithTooltip(svg.Not, i18n.Text("Discard Changes"))
 	d.cancelButton.SetEnabled(false)
 	d.cancelButton.ClickCallback = func() {
 		d.promptForSave = false
@@ -169,7 +167,7 @@ func (d *librarySettingsDockable) initContent(content *unison.Panel) {
 		})
 	d.pathField.ValidateCallback = func() bool { return len(d.path) > 1 && filepath.IsAbs(d.path) }
 
-	locateButton := unison.NewSVGButton(svg.ClosedFolder)
+	locateButton := NewSVGButtonWithTooltip(svg.ClosedFolder, i18n.Text("Choose folder"))
 	locateButton.ClickCallback = d.choosePath
 
 	wrapper := unison.NewPanel()
@@ -340,6 +340,20 @@ func (d *MarkdownDockable) save(forceSaveAs bool) bool {
 	return success
 }
 
+func (d *MarkdownDockable) recoveryData() (crc uint64, needsSaveAs bool, saver func(filePath string) error) {
+	if strings.HasPrefix(d.path, markdownContentOnlyPrefix) {
+		return 0, false, nil
+	}
+	return model.CRCBytes(0, []byte(d.content)), d.needsSaveAsPrompt, d.saveData
+}
+
+func (d *MarkdownDockable) restoreFromRecovery(originalPath string, needsSaveAs bool) {
+	d.original = ""
+	d.needsSaveAsPrompt = needsSaveAs
+	d.markdown.WorkingDir = filepath.Dir(originalPath)
+	d.SetBackingFilePath(originalPath)
+}
+
 func (d *MarkdownDockable) saveData(filePath string) error {
 	dirPath := filepath.Dir(filePath)
 	if err := os.MkdirAll(dirPath, 0o750); err != nil {
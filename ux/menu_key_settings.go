@@ -14,6 +14,7 @@ package ux
 import (
 	"fmt"
 	"io/fs"
+	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model"
 	"github.com/richardwilkes/gcs/v5/svg"
@@ -81,6 +82,10 @@ func (d *menuKeySettingsDockable) createBindingButton(binding *model.Binding) {
 	b := unison.NewButton()
 	b.Font = unison.KeyboardFont
 	b.Text = binding.Action.KeyBinding.String()
+	if conflicts := model.GlobalSettings().KeyBindings.Conflicts(binding.ID, binding.Action.KeyBinding); len(conflicts) != 0 {
+		b.OnBackgroundInk = unison.ErrorColor
+		b.Tooltip = unison.NewTooltipWithText(fmt.Sprintf(i18n.Text("Also used by %s"), bindingTitles(conflicts)))
+	}
 	b.SetLayoutData(&unison.FlexLayoutData{
 		HAlign: unison.FillAlignment,
 		VAlign: unison.MiddleAlignment,
@@ -136,12 +141,14 @@ func (d *menuKeySettingsDockable) createBindingButton(binding *model.Binding) {
 				localBinding = unison.KeyBinding{}
 				fallthrough
 			case unison.ModalResponseOK:
+				if !d.resolveConflicts(binding, localBinding) {
+					return
+				}
 				binding.KeyBinding = localBinding
 				g := model.GlobalSettings()
 				g.KeyBindings.Set(binding.ID, localBinding)
 				g.KeyBindings.MakeCurrent()
-				b.Text = localBinding.String()
-				b.MarkForRedraw()
+				d.sync()
 			default:
 			}
 		}
@@ -149,6 +156,32 @@ func (d *menuKeySettingsDockable) createBindingButton(binding *model.Binding) {
 	d.content.AddChild(b)
 }
 
+// resolveConflicts asks whether the key binding should be taken away from any other actions already using it. Returns
+// false if the user declines.
+func (d *menuKeySettingsDockable) resolveConflicts(binding *model.Binding, keyBinding unison.KeyBinding) bool {
+	g := model.GlobalSettings()
+	conflicts := g.KeyBindings.Conflicts(binding.ID, keyBinding)
+	if len(conflicts) == 0 {
+		return true
+	}
+	if unison.QuestionDialog(fmt.Sprintf(i18n.Text("%s is already used by %s."), keyBinding.String(),
+		bindingTitles(conflicts)), i18n.Text("Remove it from there and use it here instead?")) != unison.ModalResponseOK {
+		return false
+	}
+	for _, one := range conflicts {
+		g.KeyBindings.Set(one.ID, unison.KeyBinding{})
+	}
+	return true
+}
+
+func bindingTitles(bindings []*model.Binding) string {
+	titles := make([]string, len(bindings))
+	for i, one := range bindings {
+		titles[i] = one.Action.Title
+	}
+	return strings.Join(titles, ", ")
+}
+
 func (d *menuKeySettingsDockable) createResetField(binding *model.Binding) {
 	b := unison.NewSVGButton(svg.Reset)
 	b.Tooltip = unison.NewTooltipWithText("Reset this key binding")
@@ -158,10 +191,7 @@ func (d *menuKeySettingsDockable) createResetField(binding *model.Binding) {
 			g.KeyBindings.ResetOne(binding.ID)
 			g.KeyBindings.MakeCurrent()
 			binding.KeyBinding = g.KeyBindings.Current(binding.ID)
-			parent := b.Parent()
-			if other, ok := parent.Children()[parent.IndexOfChild(b)-1].Self.(*unison.Button); ok {
-				other.Text = binding.KeyBinding.String()
-			}
+			d.sync()
 		}
 	}
 	b.SetLayoutData(&unison.FlexLayoutData{
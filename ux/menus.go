@@ -13,6 +13,7 @@ package ux
 
 import (
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -41,8 +42,13 @@ const (
 	NewSpellsLibraryItemID
 	NewMarkdownFileItemID
 	OpenItemID
+	CompareSheetsItemID
+	PrereqSandboxItemID
+	DuplicateSheetItemID
+	MergeLibraryItemID
 	CloseTabID
 	RecentFilesMenuID
+	ShowRecentFilesItemID
 	SaveItemID
 	SaveAsItemID
 	ExportToMenuID
@@ -50,11 +56,16 @@ const (
 	ExportAsWEBPItemID
 	ExportAsPNGItemID
 	ExportAsJPEGItemID
+	ExportAsHTMLItemID
+	ExportAsMarkdownItemID
 	PrintItemID
 	UndoItemID
 	RedoItemID
 	DuplicateItemID
 	ClearPortraitItemID
+	RandomizeProfileItemID
+	PreviewAttributeChangeItemID
+	FindAndReplaceInNotesItemID
 	ConvertToContainerItemID
 	ConvertToNonContainerItemID
 	ToggleStateItemID
@@ -67,11 +78,29 @@ const (
 	IncrementTechLevelItemID
 	DecrementTechLevelItemID
 	SwapDefaultsItemID
+	SelectNextUnmetPrereqItemID
+	JumpToItemItemID
+	ShowCollegeIndexItemID
+	FindBrokenPageRefsItemID
+	ShowPointBudgetItemID
+	ShowOnlyPreparedSpellsItemID
+	AddTagToSelectionItemID
+	RemoveTagFromSelectionItemID
+	RebasePageRefsItemID
+	RollDamageItemID
+	ResolveAttackItemID
+	ScaleWeaponDamageItemID
 	ItemMenuID
 	AddNaturalAttacksItemID
+	NewSpellInsideContainerItemID
+	NewSpellAfterSelectionItemID
+	NewMultipleSpellsItemID
+	NewSpellHeadingItemID
 	OpenEditorItemID
 	CopyToSheetItemID
 	CopyToTemplateItemID
+	RevertToLibraryItemID
+	ResetColumnLayoutItemID
 	ApplyTemplateItemID
 	OpenOnePageReferenceItemID
 	OpenEachPageReferenceItemID
@@ -194,7 +223,11 @@ func (s menuBarScope) setupFileMenu(bar unison.Menu) {
 
 	i = s.insertMenuSeparator(m, i)
 	i = s.insertMenuItem(m, i, openAction.NewMenuItem(f))
-	s.insertMenu(m, i, f.NewMenu(RecentFilesMenuID, i18n.Text("Recent Files"), s.recentFilesUpdater))
+	i = s.insertMenu(m, i, f.NewMenu(RecentFilesMenuID, i18n.Text("Recent Files"), s.recentFilesUpdater))
+	i = s.insertMenuItem(m, i, compareSheetsAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, prereqSandboxAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, duplicateSheetAction.NewMenuItem(f))
+	s.insertMenuItem(m, i, mergeLibraryAction.NewMenuItem(f))
 
 	i = m.Item(unison.CloseItemID).Index()
 	m.RemoveItem(i)
@@ -218,16 +251,30 @@ func (s menuBarScope) setupEditMenu(bar unison.Menu) {
 	s.insertMenuSeparator(m, i)
 
 	deleteIndex := m.Item(unison.DeleteItemID).Index()
+	m.InsertItem(deleteIndex+1, findAndReplaceInNotesAction.NewMenuItem(f))
+	m.InsertItem(deleteIndex+1, previewAttributeChangeAction.NewMenuItem(f))
+	m.InsertItem(deleteIndex+1, randomizeProfileAction.NewMenuItem(f))
 	m.InsertItem(deleteIndex+1, clearPortraitAction.NewMenuItem(f))
 	m.InsertItem(deleteIndex, duplicateAction.NewMenuItem(f))
 
 	i = s.insertMenuSeparator(m, m.Item(unison.SelectAllItemID).Index()+1)
 	i = s.insertMenuItem(m, i, openEditorAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, selectNextUnmetPrereqAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, jumpToItemAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, showCollegeIndexAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, findBrokenPageRefsAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, showPointBudgetAction.NewMenuItem(f))
 
 	i = s.insertMenuSeparator(m, i)
 	i = s.insertMenuItem(m, i, copyToSheetAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, copyToTemplateAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, applyTemplateAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, revertToLibraryAction.NewMenuItem(f))
+
+	i = s.insertMenuSeparator(m, i)
+	i = s.insertMenuItem(m, i, addTagToSelectionAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, removeTagFromSelectionAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, rebasePageRefsAction.NewMenuItem(f))
 
 	i = s.insertMenuSeparator(m, i)
 	i = s.insertMenuItem(m, i, incrementAction.NewMenuItem(f))
@@ -241,6 +288,7 @@ func (s menuBarScope) setupEditMenu(bar unison.Menu) {
 
 	i = s.insertMenuSeparator(m, i)
 	i = s.insertMenuItem(m, i, toggleStateAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, showOnlyPreparedSpellsAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, swapDefaultsAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, convertToContainerAction.NewMenuItem(f))
 	s.insertMenuItem(m, i, convertToNonContainerAction.NewMenuItem(f))
@@ -263,7 +311,11 @@ func (s menuBarScope) createItemMenu(f unison.MenuFactory) unison.Menu {
 	m.InsertSeparator(-1, false)
 	m.InsertItem(-1, newSpellAction.NewMenuItem(f))
 	m.InsertItem(-1, newSpellContainerAction.NewMenuItem(f))
+	m.InsertItem(-1, newSpellInsideContainerAction.NewMenuItem(f))
+	m.InsertItem(-1, newSpellAfterSelectionAction.NewMenuItem(f))
+	m.InsertItem(-1, newMultipleSpellsAction.NewMenuItem(f))
 	m.InsertItem(-1, newRitualMagicSpellAction.NewMenuItem(f))
+	m.InsertItem(-1, newSpellHeadingAction.NewMenuItem(f))
 
 	m.InsertSeparator(-1, false)
 	m.InsertItem(-1, newCarriedEquipmentAction.NewMenuItem(f))
@@ -344,28 +396,54 @@ func (s menuBarScope) setupHelpMenu(bar unison.Menu) {
 
 func (s menuBarScope) recentFilesUpdater(menu unison.Menu) {
 	menu.RemoveAll()
-	list := model.GlobalSettings().ListRecentFiles()
+	global := model.GlobalSettings()
+	pinned := global.ListPinnedFiles()
+	recent := global.ListRecentFiles()
+	titles := recentFileTitles(append(append([]string{}, pinned...), recent...))
+	for i, f := range pinned {
+		menu.InsertItem(-1, s.createOpenRecentFileAction(i, f, titles[f]).NewMenuItem(menu.Factory()))
+	}
+	if len(pinned) != 0 && len(recent) != 0 {
+		menu.InsertSeparator(-1, false)
+	}
+	for i, f := range recent {
+		menu.InsertItem(-1, s.createOpenRecentFileAction(len(pinned)+i, f, titles[f]).NewMenuItem(menu.Factory()))
+	}
+	if menu.Count() == 0 {
+		s.appendDisabledMenuItem(menu, i18n.Text("No recent files available"))
+	}
+	menu.InsertSeparator(-1, false)
+	menu.InsertItem(-1, showRecentFilesAction.NewMenuItem(menu.Factory()))
+}
+
+// recentFileTitles returns the titles to use for the file paths, which is just the base name unless that would be
+// ambiguous.
+func recentFileTitles(list []string) map[string]string {
 	m := make(map[string]int, len(list))
 	for _, f := range list {
 		title := filepath.Base(f)
 		m[title] = m[title] + 1
 	}
-	for i, f := range list {
+	titles := make(map[string]string, len(list))
+	for _, f := range list {
 		title := filepath.Base(f)
 		if m[title] > 1 {
 			title = f
 		}
-		menu.InsertItem(-1, s.createOpenRecentFileAction(i, f, title).NewMenuItem(menu.Factory()))
-	}
-	if menu.Count() == 0 {
-		s.appendDisabledMenuItem(menu, i18n.Text("No recent files available"))
+		titles[f] = title
 	}
+	return titles
 }
 
 func (s menuBarScope) createOpenRecentFileAction(index int, path, title string) *unison.Action {
+	available := xfs.FileIsReadable(path)
+	if !available {
+		title = fmt.Sprintf(i18n.Text("%s (unavailable)"), title)
+	}
 	return &unison.Action{
 		ID:              RecentFieldBaseItemID + index,
 		Title:           title,
+		EnabledCallback: func(_ *unison.Action, _ any) bool { return available },
 		ExecuteCallback: func(_ *unison.Action, _ any) { OpenFile(nil, path) },
 	}
 }
@@ -378,6 +456,8 @@ func (s menuBarScope) exportToUpdater(menu unison.Menu) {
 	menu.InsertItem(-1, exportAsWEBPAction.NewMenuItem(factory))
 	menu.InsertItem(-1, exportAsPNGAction.NewMenuItem(factory))
 	menu.InsertItem(-1, exportAsJPEGAction.NewMenuItem(factory))
+	menu.InsertItem(-1, exportAsHTMLAction.NewMenuItem(factory))
+	menu.InsertItem(-1, exportAsMarkdownAction.NewMenuItem(factory))
 	menu.InsertSeparator(-1, false)
 	index := 0
 	for _, lib := range model.GlobalSettings().Libraries().List() {
@@ -468,6 +548,11 @@ func AppendDefaultContextMenuItems(list []ContextMenuItem) []ContextMenuItem {
 		ContextMenuItem{i18n.Text("Apply Template to Character Sheet"), ApplyTemplateItemID},
 		ContextMenuItem{i18n.Text("Copy to Character Sheet"), CopyToSheetItemID},
 		ContextMenuItem{i18n.Text("Copy to Template"), CopyToTemplateItemID},
+		ContextMenuItem{i18n.Text("Revert to Library Version…"), RevertToLibraryItemID},
+		ContextMenuItem{"", -1},
+		ContextMenuItem{i18n.Text("Add Tag to Selected"), AddTagToSelectionItemID},
+		ContextMenuItem{i18n.Text("Remove Tag from Selected"), RemoveTagFromSelectionItemID},
+		ContextMenuItem{i18n.Text("Rebase Page References"), RebasePageRefsItemID},
 		ContextMenuItem{"", -1},
 		ContextMenuItem{i18n.Text("Increment"), IncrementItemID},
 		ContextMenuItem{i18n.Text("Decrement"), DecrementItemID},
@@ -113,6 +113,7 @@ func ProcessNameables[T model.NodeTypes](owner unison.Paneler, rows []T) {
 		if unison.QuestionDialogWithPanel(panel) == unison.ModalResponseOK {
 			for i, row := range data {
 				model.AsNode(row).ApplyNameableKeys(nameables[i])
+				recordNameables(row, nameables[i])
 			}
 			unison.Ancestor[Rebuildable](owner).Rebuild(true)
 		}
@@ -133,3 +134,21 @@ func createNameableField(key string, m map[string]string) *unison.Field {
 	}
 	return field
 }
+
+// recordNameables remembers the substitutions made on an item copied from a library, so that they aren't mistaken for
+// local modifications. The source is replaced rather than altered, since clones share it.
+func recordNameables[T model.NodeTypes](row T, m map[string]string) {
+	sourced, ok := any(row).(model.LibrarySourced)
+	if !ok || sourced.LibrarySource() == nil {
+		return
+	}
+	source := *sourced.LibrarySource()
+	source.Nameables = make(map[string]string, len(source.Nameables)+len(m))
+	for k, v := range sourced.LibrarySource().Nameables {
+		source.Nameables[k] = v
+	}
+	for k, v := range m {
+		source.Nameables[k] = v
+	}
+	sourced.SetLibrarySource(&source)
+}
@@ -615,6 +615,7 @@ func (n *Navigator) EventuallyReload() {
 // Reload the content of the navigator view.
 func (n *Navigator) Reload() {
 	n.needReload = false
+	gsettings.InvalidateLibraryIndexes()
 	for _, token := range n.tokens {
 		token.Stop()
 	}
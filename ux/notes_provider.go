@@ -25,6 +25,7 @@ var _ TableProvider[*model.Note] = &notesProvider{}
 
 type notesProvider struct {
 	table    *unison.Table[*Node[*model.Note]]
+	nodes    NodeCache[*model.Note]
 	provider model.NoteListProvider
 	forPage  bool
 }
@@ -41,6 +42,10 @@ func (p *notesProvider) RefKey() string {
 	return model.BlockLayoutNotesKey
 }
 
+func (p *notesProvider) Count(predicate func(*model.Note) bool) int {
+	return model.Count(predicate, p.RootData()...)
+}
+
 func (p *notesProvider) AllTags() []string {
 	return nil
 }
@@ -54,12 +59,7 @@ func (p *notesProvider) RootRowCount() int {
 }
 
 func (p *notesProvider) RootRows() []*Node[*model.Note] {
-	data := p.provider.NoteList()
-	rows := make([]*Node[*model.Note], 0, len(data))
-	for _, one := range data {
-		rows = append(rows, NewNode[*model.Note](p.table, nil, one, p.forPage))
-	}
-	return rows
+	return p.nodes.Rows(p.table, nil, p.provider.NoteList(), p.forPage)
 }
 
 func (p *notesProvider) SetRootRows(rows []*Node[*model.Note]) {
@@ -130,9 +130,9 @@ func (p *notesProvider) OpenEditor(owner Rebuildable, table *unison.Table[*Node[
 	OpenEditor[*model.Note](table, func(item *model.Note) { EditNote(owner, item) })
 }
 
-func (p *notesProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*model.Note]], variant ItemVariant) {
+func (p *notesProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*model.Note]], variant ItemVariant, mode InsertMode) {
 	item := model.NewNote(p.Entity(), nil, variant == ContainerItemVariant)
-	InsertItems[*model.Note](owner, table, p.provider.NoteList, p.provider.SetNoteList,
+	InsertItems[*model.Note](owner, table, mode, p.provider.NoteList, p.provider.SetNoteList,
 		func(_ *unison.Table[*Node[*model.Note]]) []*Node[*model.Note] { return p.RootRows() }, item)
 	EditNote(owner, item)
 }
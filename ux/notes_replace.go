@@ -0,0 +1,154 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+const maxNotesReplacementPreviews = 10
+
+func (s *Sheet) findAndReplaceInNotes(_ any) {
+	var find, replace string
+	var useRegex bool
+	var replacements []*model.NotesReplacement
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	preview := unison.NewPanel()
+	preview.SetLayout(&unison.FlexLayout{Columns: 1})
+	preview.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: unison.FillAlignment,
+		HGrab:  true,
+	})
+	var dialog *unison.Dialog
+	update := func() {
+		var lines []string
+		var err error
+		replacements, err = model.FindNotesReplacements(s.entity, find, replace, useRegex)
+		switch {
+		case err != nil:
+			lines = []string{err.Error()}
+		case find != "":
+			lines = notesReplacementsPreview(replacements)
+		}
+		preview.RemoveAllChildren()
+		for _, line := range lines {
+			label := unison.NewLabel()
+			label.Text = line
+			preview.AddChild(label)
+		}
+		if dialog != nil {
+			dialog.Button(unison.ModalResponseOK).SetEnabled(len(replacements) != 0)
+			dialog.Window().Pack()
+		}
+		preview.MarkForLayoutAndRedraw()
+	}
+
+	title := i18n.Text("Find")
+	panel.AddChild(NewFieldLeadingLabel(title))
+	findField := NewStringField(nil, "", title, func() string { return find }, func(v string) {
+		find = v
+		update()
+	})
+	findField.SetMinimumTextWidthUsing(minTextWidthCandidate)
+	panel.AddChild(findField)
+
+	title = i18n.Text("Replace")
+	panel.AddChild(NewFieldLeadingLabel(title))
+	replaceField := NewStringField(nil, "", title, func() string { return replace }, func(v string) {
+		replace = v
+		update()
+	})
+	replaceField.SetMinimumTextWidthUsing(minTextWidthCandidate)
+	panel.AddChild(replaceField)
+
+	panel.AddChild(unison.NewPanel())
+	regexCheckbox := unison.NewCheckBox()
+	regexCheckbox.Text = i18n.Text("Use regular expressions")
+	regexCheckbox.ClickCallback = func() {
+		useRegex = regexCheckbox.State == unison.OnCheckState
+		update()
+	}
+	panel.AddChild(regexCheckbox)
+	panel.AddChild(preview)
+
+	var err error
+	dialog, err = unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon, unison.DefaultDialogTheme.QuestionIconInk,
+		panel, []*unison.DialogButtonInfo{
+			unison.NewCancelButtonInfo(),
+			unison.NewOKButtonInfoWithTitle(i18n.Text("Replace")),
+		})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create find & replace dialog"), err)
+		return
+	}
+	dialog.Button(unison.ModalResponseOK).SetEnabled(false)
+	if dialog.RunModal() != unison.ModalResponseOK || len(replacements) == 0 {
+		return
+	}
+	s.undoMgr.Add(&unison.UndoEdit[[]*model.NotesReplacement]{
+		ID:       unison.NextUndoID(),
+		EditName: i18n.Text("Replace in Notes"),
+		UndoFunc: func(edit *unison.UndoEdit[[]*model.NotesReplacement]) {
+			for _, one := range edit.BeforeData {
+				one.Revert()
+			}
+			s.Rebuild(true)
+		},
+		RedoFunc: func(edit *unison.UndoEdit[[]*model.NotesReplacement]) {
+			for _, one := range edit.AfterData {
+				one.Apply()
+			}
+			s.Rebuild(true)
+		},
+		BeforeData: replacements,
+		AfterData:  replacements,
+	})
+	for _, one := range replacements {
+		one.Apply()
+	}
+	s.Rebuild(true)
+}
+
+func notesReplacementsPreview(replacements []*model.NotesReplacement) []string {
+	if len(replacements) == 0 {
+		return []string{i18n.Text("No matches found.")}
+	}
+	lines := make([]string, 0, maxNotesReplacementPreviews+2)
+	if len(replacements) == 1 {
+		lines = append(lines, i18n.Text("1 notes field will be changed:"))
+	} else {
+		lines = append(lines, fmt.Sprintf(i18n.Text("%d notes fields will be changed:"), len(replacements)))
+	}
+	for i, one := range replacements {
+		if i == maxNotesReplacementPreviews {
+			lines = append(lines, fmt.Sprintf(i18n.Text("…and %d more"), len(replacements)-i))
+			break
+		}
+		name := one.Category
+		if one.Name != "" {
+			name += " \"" + one.Name + "\""
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s", name, strings.ReplaceAll(one.After, "\n", " ")))
+	}
+	return lines
+}
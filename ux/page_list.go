@@ -28,7 +28,9 @@ type PageList[T model.NodeTypes] struct {
 	unison.Panel
 	tableHeader *unison.TableHeader[*Node[T]]
 	Table       *unison.Table[*Node[T]]
+	footer      *tableFooter[T]
 	provider    TableProvider[T]
+	filter      func(row *Node[T]) bool
 }
 
 // NewTraitsPageList creates the traits page list.
@@ -86,6 +88,10 @@ func NewSpellsPageList(owner Rebuildable, provider model.SpellListProvider) *Pag
 	p.installDecrementPointsHandler(owner)
 	p.installIncrementSkillHandler(owner)
 	p.installDecrementSkillHandler(owner)
+	p.installPreparedSpellsFilterHandler(owner)
+	InstallInsertModeCmdHandlers(p.AsPanel(), owner, p.Table, p.provider, NewSpellInsideContainerItemID,
+		NewSpellAfterSelectionItemID)
+	InstallCreateMultipleCmdHandler(p.AsPanel(), owner, p.Table, p.provider, NewMultipleSpellsItemID)
 	return p
 }
 
@@ -108,12 +114,16 @@ func NewReactionsPageList(entity *model.Entity) *PageList[*model.ConditionalModi
 
 // NewMeleeWeaponsPageList creates the melee weapons page list.
 func NewMeleeWeaponsPageList(entity *model.Entity) *PageList[*model.Weapon] {
-	return newPageList(nil, NewWeaponsProvider(entity, model.MeleeWeaponType, true))
+	p := newPageList(nil, NewWeaponsProvider(entity, model.MeleeWeaponType, true))
+	p.installWeaponRollHandlers()
+	return p
 }
 
 // NewRangedWeaponsPageList creates the ranged weapons page list.
 func NewRangedWeaponsPageList(entity *model.Entity) *PageList[*model.Weapon] {
-	return newPageList(nil, NewWeaponsProvider(entity, model.RangedWeaponType, true))
+	p := newPageList(nil, NewWeaponsProvider(entity, model.RangedWeaponType, true))
+	p.installWeaponRollHandlers()
+	return p
 }
 
 func newPageList[T model.NodeTypes](owner Rebuildable, provider TableProvider[T]) *PageList[T] {
@@ -151,9 +161,14 @@ func newPageList[T model.NodeTypes](owner Rebuildable, provider TableProvider[T]
 			p.tableHeader.DefaultDraw(gc, dirty)
 		}
 	}
+	installTableSortTracking(p.tableHeader, p.Table)
 	p.Table.SyncToModel()
+	restoreTableSort(p.tableHeader, p.Table)
 	p.AddChild(p.tableHeader)
 	p.AddChild(p.Table)
+	if p.footer = newTableFooter(p.Table, p.provider, model.PageFieldPrimaryFont); p.footer != nil {
+		p.AddChild(p.footer)
+	}
 	if owner != nil {
 		InstallTableDropSupport(p.Table, p.provider)
 		p.InstallCmdHandlers(OpenEditorItemID,
@@ -184,6 +199,18 @@ func (p *PageList[T]) installOpenPageReferenceHandlers() {
 		func(_ any) { OpenEachPageRef(p.Table) })
 }
 
+func (p *PageList[T]) installWeaponRollHandlers() {
+	if t, ok := (any(p.Table)).(*unison.Table[*Node[*model.Weapon]]); ok {
+		p.InstallCmdHandlers(RollDamageItemID,
+			func(_ any) bool { return canRollDamage(t) },
+			func(_ any) { rollDamage(t) })
+		p.InstallCmdHandlers(ResolveAttackItemID,
+			func(_ any) bool { return canRollDamage(t) },
+			func(_ any) { resolveAttack(t) })
+		installScaleWeaponDamageHandler(t)
+	}
+}
+
 func (p *PageList[T]) installToggleDisabledHandler(owner Rebuildable) {
 	if t, ok := (any(p.Table)).(*unison.Table[*Node[*model.Trait]]); ok {
 		p.InstallCmdHandlers(ToggleStateItemID,
@@ -200,6 +227,27 @@ func (p *PageList[T]) installToggleEquippedHandler(owner Rebuildable) {
 	}
 }
 
+func (p *PageList[T]) installPreparedSpellsFilterHandler(owner Rebuildable) {
+	p.InstallCmdHandlers(ShowOnlyPreparedSpellsItemID,
+		func(_ any) bool { return true },
+		func(_ any) {
+			if p.filter == nil {
+				p.filter = excludeUnpreparedSpells[T]
+			} else {
+				p.filter = nil
+			}
+			p.Sync()
+			if owner != nil {
+				owner.Rebuild(true)
+			}
+		})
+}
+
+func excludeUnpreparedSpells[T model.NodeTypes](row *Node[T]) bool {
+	spell, ok := any(row.Data()).(*model.Spell)
+	return !ok || spell.Container() || !spell.Prepared
+}
+
 func (p *PageList[T]) installIncrementPointsHandler(owner Rebuildable) {
 	p.InstallCmdHandlers(IncrementItemID,
 		func(_ any) bool { return canAdjustRawPoints(p.Table, true) },
@@ -320,10 +368,16 @@ func (p *PageList[T]) ApplySelection(selection map[uuid.UUID]bool) {
 
 // Sync the underlying data.
 func (p *PageList[T]) Sync() {
+	InvalidateTagCache(p.provider)
 	p.provider.SyncHeader(p.tableHeader.ColumnHeaders)
 	selection := p.RecordSelection()
 	p.Table.SyncToModel()
+	p.Table.ApplyFilter(p.filter)
+	restoreTableSort(p.tableHeader, p.Table)
 	p.ApplySelection(selection)
+	if p.footer != nil {
+		p.footer.Sync()
+	}
 	p.Table.NeedsLayout = true
 	p.NeedsLayout = true
 	if parent := p.Parent(); parent != nil {
@@ -332,15 +386,20 @@ func (p *PageList[T]) Sync() {
 }
 
 // CreateItem calls CreateItem on the contained TableProvider.
-func (p *PageList[T]) CreateItem(owner Rebuildable, variant ItemVariant) {
-	p.provider.CreateItem(owner, p.Table, variant)
+func (p *PageList[T]) CreateItem(owner Rebuildable, variant ItemVariant, mode InsertMode) {
+	p.provider.CreateItem(owner, p.Table, variant, mode)
 }
 
 // OverheadHeight returns the overhead for this page list, i.e. the border and header space.
 func (p *PageList[T]) OverheadHeight() float32 {
 	_, pref, _ := p.tableHeader.Sizes(unison.Size{})
 	insets := p.Border().Insets()
-	return insets.Height() + pref.Height
+	height := insets.Height() + pref.Height
+	if p.footer != nil {
+		_, pref, _ = p.footer.Sizes(unison.Size{})
+		height += pref.Height
+	}
+	return height
 }
 
 // RowHeights returns the heights of each row.
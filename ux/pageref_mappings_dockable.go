@@ -35,13 +35,7 @@ type pageRefMappingsDockable struct {
 
 // ExtractPageReferences extracts any page references from the string.
 func ExtractPageReferences(s string) []string {
-	var list []string
-	for _, one := range strings.FieldsFunc(s, func(ch rune) bool { return ch == ',' || ch == ';' }) {
-		if one = strings.TrimSpace(one); one != "" {
-			list = append(list, one)
-		}
-	}
-	return list
+	return model.ExtractPageRefs(s)
 }
 
 // OpenPageReference opens the given page reference in the given window, which should contain a workspace. May pass nil
@@ -84,22 +78,7 @@ func openPDFPageReference(wnd *unison.Window, ref, highlight string, promptConte
 	if promptContext == nil {
 		promptContext = make(map[string]bool)
 	}
-	i := len(ref) - 1
-	for i >= 0 {
-		ch := ref[i]
-		if ch >= '0' && ch <= '9' {
-			i--
-		} else {
-			i++
-			break
-		}
-	}
-	if i > 0 {
-		page, err := strconv.Atoi(ref[i:])
-		if err != nil {
-			return false
-		}
-		key := ref[:i]
+	if key, page, ok := model.SplitPageRef(ref); ok {
 		s := model.GlobalSettings()
 		pageRef := s.PageRefs.Lookup(key)
 		if pageRef == nil && !promptContext[key] {
@@ -129,8 +108,7 @@ Would you like to create one by choosing a PDF to map to this key?`), key), pdfN
 					}
 				}
 			} else {
-				var parts []string
-				parts, err = cmdline.Parse(strings.TrimSpace(strings.ReplaceAll(strings.ReplaceAll(s.General.ExternalPDFCmdLine, "$FILE", pageRef.Path), "$PAGE", strconv.Itoa(page+pageRef.Offset))))
+				parts, err := cmdline.Parse(strings.TrimSpace(strings.ReplaceAll(strings.ReplaceAll(s.General.ExternalPDFCmdLine, "$FILE", pageRef.Path), "$PAGE", strconv.Itoa(page+pageRef.Offset))))
 				errTitle := i18n.Text("Unable to use external PDF command line")
 				if err != nil {
 					unison.ErrorDialogWithError(errTitle, err)
@@ -222,8 +200,7 @@ func ShowPageRefMappings() {
 }
 
 func (d *pageRefMappingsDockable) addToStartToolbar(toolbar *unison.Panel) {
-	helpButton := unison.NewSVGButton(svg.Help)
-	helpButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Help"))
+	helpButton := NewSVGButtonWithTooltip(svg.Help, i18n.Text("Help"))
 	helpButton.ClickCallback = func() { HandleLink(nil, "md:Help/Interface/Page Reference Mappings") }
 	toolbar.AddChild(helpButton)
 }
@@ -306,7 +283,7 @@ func (d *pageRefMappingsDockable) createNameField(ref *model.PageRef) {
 }
 
 func (d *pageRefMappingsDockable) createEditField(ref *model.PageRef) {
-	b := unison.NewSVGButton(svg.Edit)
+	b := NewSVGButtonWithTooltip(svg.Edit, i18n.Text("Edit page reference"))
 	b.ClickCallback = func() {
 		askUserForPageRefPath(ref.ID, ref.Offset)
 	}
@@ -318,7 +295,7 @@ func (d *pageRefMappingsDockable) createEditField(ref *model.PageRef) {
 }
 
 func (d *pageRefMappingsDockable) createTrashField(ref *model.PageRef) {
-	b := unison.NewSVGButton(svg.Trash)
+	b := NewSVGButtonWithTooltip(svg.Trash, i18n.Text("Remove page reference"))
 	b.ClickCallback = func() {
 		if unison.QuestionDialog(fmt.Sprintf(i18n.Text("Are you sure you want to remove\n%s (%s)?"), ref.ID,
 			filepath.Base(ref.Path)), "") == unison.ModalResponseOK {
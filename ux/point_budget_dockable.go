@@ -0,0 +1,206 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/log/jot"
+	"github.com/richardwilkes/unison"
+)
+
+var (
+	_ GroupedCloser              = &pointBudgetDockable{}
+	_ unison.UndoManagerProvider = &pointBudgetDockable{}
+)
+
+// pointBudgetDockable shows the points a sheet has spent in each category against its point budget.
+type pointBudgetDockable struct {
+	unison.Panel
+	sheet      *Sheet
+	undoMgr    *unison.UndoManager
+	content    *unison.Panel
+	status     *unison.Label
+	categories []*unison.Label
+}
+
+// DisplayPointBudget displays the point budget for the given Sheet.
+func DisplayPointBudget(sheet *Sheet) {
+	ws, dc, found := Activate(func(d unison.Dockable) bool {
+		if b, ok := d.(*pointBudgetDockable); ok {
+			return b.sheet == sheet
+		}
+		return false
+	})
+	if !found && ws != nil {
+		d := &pointBudgetDockable{sheet: sheet}
+		d.Self = d
+		d.undoMgr = unison.NewUndoManager(100, func(err error) { jot.Error(err) })
+		d.SetLayout(&unison.FlexLayout{Columns: 1})
+		d.createContent()
+		scroll := unison.NewScrollPanel()
+		scroll.SetContent(d.content, unison.HintedFillBehavior, unison.FillBehavior)
+		scroll.SetLayoutData(&unison.FlexLayoutData{
+			HAlign: unison.FillAlignment,
+			VAlign: unison.FillAlignment,
+			HGrab:  true,
+			VGrab:  true,
+		})
+		d.AddChild(scroll)
+		d.ClientData()[AssociatedUUIDKey] = sheet.Entity().ID
+		d.update()
+		PlaceInDock(ws, dc, d, EditorGroup)
+	}
+}
+
+// UpdatePointBudget for the given owner.
+func UpdatePointBudget(sheet *Sheet) {
+	for _, wnd := range unison.Windows() {
+		if ws := WorkspaceFromWindow(wnd); ws != nil {
+			ws.DocumentDock.RootDockLayout().ForEachDockContainer(func(dc *unison.DockContainer) bool {
+				for _, other := range dc.Dockables() {
+					if d, ok := other.(*pointBudgetDockable); ok && d.sheet == sheet {
+						d.update()
+						return true
+					}
+				}
+				return false
+			})
+		}
+	}
+}
+
+func (d *pointBudgetDockable) createContent() {
+	d.content = unison.NewPanel()
+	d.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	d.content.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+
+	title := i18n.Text("Point Budget")
+	d.content.AddChild(NewFieldLeadingLabel(title))
+	field := NewDecimalField(nil, "", title, func() fxp.Int { return d.sheet.Entity().PointBudget },
+		func(v fxp.Int) {
+			if entity := d.sheet.Entity(); entity.PointBudget != v {
+				entity.PointBudget = v
+				MarkModified(d.sheet)
+			}
+		}, 0, fxp.Max, false, false)
+	field.Tooltip = unison.NewTooltipWithText(i18n.Text("The number of points the character may spend; 0 for no budget"))
+	d.content.AddChild(field)
+
+	for _, one := range d.sheet.Entity().PointBudgetCategories() {
+		d.content.AddChild(NewFieldLeadingLabel(one.Name))
+		label := unison.NewLabel()
+		label.HAlign = unison.EndAlignment
+		d.categories = append(d.categories, label)
+		d.content.AddChild(label)
+	}
+
+	d.status = unison.NewLabel()
+	d.status.Font = unison.SystemFont
+	d.status.SetBorder(unison.NewEmptyBorder(unison.NewHorizontalInsets(unison.StdHSpacing)))
+	d.status.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: unison.FillAlignment,
+		HGrab:  true,
+	})
+	d.status.DrawCallback = func(gc *unison.Canvas, rect unison.Rect) {
+		if d.sheet.Entity().OverPointBudget() {
+			gc.DrawRect(rect, unison.ErrorColor.Paint(gc, rect, unison.Fill))
+		}
+		d.status.DefaultDraw(gc, rect)
+	}
+	d.content.AddChild(d.status)
+}
+
+func (d *pointBudgetDockable) update() {
+	entity := d.sheet.Entity()
+	for i, one := range entity.PointBudgetCategories() {
+		if i < len(d.categories) {
+			d.categories[i].Text = one.Points.String()
+		}
+	}
+	spent := entity.PointsBreakdown().Total()
+	switch {
+	case entity.PointBudget <= 0:
+		d.status.Text = fmt.Sprintf(i18n.Text("%s points spent"), spent.String())
+	case entity.OverPointBudget():
+		d.status.Text = fmt.Sprintf(i18n.Text("%s of %s points spent; %s over budget"), spent.String(),
+			entity.PointBudget.String(), (spent - entity.PointBudget).String())
+	default:
+		d.status.Text = fmt.Sprintf(i18n.Text("%s of %s points spent"), spent.String(), entity.PointBudget.String())
+	}
+	if entity.OverPointBudget() {
+		d.status.OnBackgroundInk = unison.OnErrorColor
+	} else {
+		d.status.OnBackgroundInk = unison.OnContentColor
+	}
+	DeepSync(d.content)
+	d.content.MarkForLayoutRecursively()
+	d.content.MarkForRedraw()
+}
+
+// TitleIcon implements unison.Dockable
+func (d *pointBudgetDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Stack,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (d *pointBudgetDockable) Title() string {
+	return fmt.Sprintf(i18n.Text("Point Budget for %s"), d.sheet.String())
+}
+
+func (d *pointBudgetDockable) String() string {
+	return d.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (d *pointBudgetDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (d *pointBudgetDockable) Modified() bool {
+	return false
+}
+
+// CloseWithGroup implements GroupedCloser
+func (d *pointBudgetDockable) CloseWithGroup(other unison.Paneler) bool {
+	return d.sheet != nil && d.sheet == other
+}
+
+// MayAttemptClose implements GroupedCloser
+func (d *pointBudgetDockable) MayAttemptClose() bool {
+	return true
+}
+
+// AttemptClose implements GroupedCloser
+func (d *pointBudgetDockable) AttemptClose() bool {
+	if dc := unison.Ancestor[*unison.DockContainer](d); dc != nil {
+		dc.Close(d)
+	}
+	return true
+}
+
+// UndoManager implements unison.UndoManagerProvider
+func (d *pointBudgetDockable) UndoManager() *unison.UndoManager {
+	return d.undoMgr
+}
@@ -12,6 +12,10 @@
 package ux
 
 import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 
 	"github.com/richardwilkes/gcs/v5/model"
@@ -20,26 +24,50 @@ import (
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/log/jot"
+	"github.com/richardwilkes/toolbox/xio"
 	"github.com/richardwilkes/unison"
 	"golang.org/x/exp/slices"
 )
 
-const noAndOr = ""
-
-var lastPrereqTypeUsed = model.TraitPrereqType
+const (
+	noAndOr           = ""
+	prereqDragDataKey = "drag.prereq"
+)
 
 type prereqPanel struct {
 	unison.Panel
-	entity   *model.Entity
-	root     **model.PrereqList
-	andOrMap map[model.Prereq]*unison.Label
+	entity     *model.Entity
+	exclude    any
+	root       **model.PrereqList
+	andOrMap   map[model.Prereq]*unison.Label
+	dragTarget *unison.Panel
+	dragInsert int
+	inDragOver bool
+	showStatus bool
+}
+
+// prereqDragData holds the data for a prerequisite being dragged within its list.
+type prereqDragData struct {
+	owner  *prereqPanel
+	prereq model.Prereq
+	panel  *unison.Panel
+}
+
+// prereqStatus shows whether a single prerequisite is currently satisfied by the entity. It refreshes itself whenever
+// the owning editor syncs.
+type prereqStatus struct {
+	unison.Label
+	owner  *prereqPanel
+	prereq model.Prereq
 }
 
-func newPrereqPanel(entity *model.Entity, root **model.PrereqList) *prereqPanel {
+func newPrereqPanel(entity *model.Entity, exclude any, root **model.PrereqList) *prereqPanel {
 	p := &prereqPanel{
-		entity:   entity,
-		root:     root,
-		andOrMap: make(map[model.Prereq]*unison.Label),
+		entity:     entity,
+		exclude:    exclude,
+		root:       root,
+		andOrMap:   make(map[model.Prereq]*unison.Label),
+		dragInsert: -1,
 	}
 	p.Self = p
 	p.SetLayout(&unison.FlexLayout{Columns: 1})
@@ -55,8 +83,12 @@ func newPrereqPanel(entity *model.Entity, root **model.PrereqList) *prereqPanel
 		},
 		unison.NewEmptyBorder(unison.NewUniformInsets(2))))
 	p.DrawCallback = func(gc *unison.Canvas, rect unison.Rect) {
-		gc.DrawRect(rect, unison.ContentColor.Paint(gc, rect, unison.Fill))
+		gc.DrawRect(rect, editorContentInk.Paint(gc, rect, unison.Fill))
 	}
+	p.DataDragOverCallback = p.dataDragOver
+	p.DataDragExitCallback = p.dataDragExit
+	p.DataDragDropCallback = p.dataDragDrop
+	p.DrawOverCallback = p.drawOver
 	p.AddChild(p.createPrereqListPanel(0, *root))
 	return p
 }
@@ -70,11 +102,15 @@ func (p *prereqPanel) createPrereqListPanel(depth int, list *model.PrereqList) *
 	}
 	addNumericCriteriaPanel(panel, nil, "", i18n.Text("When the Tech Level"), i18n.Text("When Tech Level"),
 		&list.WhenTL, 0, fxp.Twelve, 1, true, true)
-	popup := addBoolPopup(panel, i18n.Text("requires all of:"), i18n.Text("requires at least one of:"), &list.All)
-	callback := popup.SelectionChangedCallback
-	popup.SelectionChangedCallback = func(pop *unison.PopupMenu[string]) {
-		callback(pop)
-		p.adjustAndOrForList(list)
+	for _, popup := range []*unison.PopupMenu[string]{
+		addBoolPopup(panel, i18n.Text("does not satisfy"), i18n.Text("requires"), &list.Negate),
+		addBoolPopup(panel, i18n.Text("all of:"), i18n.Text("at least one of:"), &list.All),
+	} {
+		callback := popup.SelectionChangedCallback
+		popup.SelectionChangedCallback = func(pop *unison.PopupMenu[string]) {
+			callback(pop)
+			p.adjustAndOrForList(list)
+		}
 	}
 	if !inFront {
 		p.addAndOr(panel, list)
@@ -115,6 +151,10 @@ func (p *prereqPanel) addToList(parent *unison.Panel, depth, index int, child mo
 		panel = p.createSkillPrereqPanel(depth, one)
 	case *model.SpellPrereq:
 		panel = p.createSpellPrereqPanel(depth, one)
+	case *model.PointsPrereq:
+		panel = p.createPointsPrereqPanel(depth, one)
+	case *model.CampaignSettingPrereq:
+		panel = p.createCampaignSettingPrereqPanel(depth, one)
 	default:
 		jot.Warn(errs.Newf("unknown prerequisite type: %s", reflect.TypeOf(child).String()))
 	}
@@ -137,21 +177,41 @@ func (p *prereqPanel) createButtonsPanel(parent *unison.Panel, depth int, data m
 	buttons := unison.NewPanel()
 	buttons.SetBorder(unison.NewEmptyBorder(unison.Insets{Left: float32(depth * 20)}))
 	parent.AddChild(buttons)
+	if data.ParentList() != nil {
+		handle := NewDragHandle(map[string]any{prereqDragDataKey: &prereqDragData{
+			owner:  p,
+			prereq: data,
+			panel:  parent,
+		}})
+		handle.UpdateTooltipCallback = func(_ unison.Point, avoid unison.Rect) unison.Rect {
+			// The path is derived from the current position, so it tracks any rearrangement of the tree.
+			handle.Tooltip = unison.NewTooltipWithText(fmt.Sprintf(i18n.Text("Click and drag this handle to rearrange\nPath: %s"),
+				model.PrereqPath(data)))
+			return avoid
+		}
+		buttons.AddChild(handle)
+	}
+	if p.entity != nil {
+		buttons.AddChild(p.newPrereqStatus(data))
+	}
 	if prereqList, ok := data.(*model.PrereqList); ok {
-		addPrereqButton := unison.NewSVGButton(svg.CircledAdd)
+		addPrereqButton := newEditorSVGButton(svg.CircledAdd, i18n.Text("Add prerequisite"))
 		addPrereqButton.ClickCallback = func() {
-			if created := p.createPrereqForType(lastPrereqTypeUsed, prereqList); created != nil {
+			if created := p.createPrereqForType(model.GlobalSettings().LastPrereqTypeUsed, prereqList); created != nil {
+				undo := p.prepareUndo(i18n.Text("Add Prerequisite"))
 				prereqList.Prereqs = slices.Insert(prereqList.Prereqs, 0, created)
 				p.addToList(parent, depth+1, 0, created)
 				p.adjustAndOrForList(prereqList)
 				unison.Ancestor[*unison.DockContainer](p).MarkForLayoutRecursively()
 				MarkModified(p)
+				p.finishAndPostUndo(undo)
 			}
 		}
 		buttons.AddChild(addPrereqButton)
 
-		addPrereqListButton := unison.NewSVGButton(svg.CircledVerticalEllipsis)
+		addPrereqListButton := newEditorSVGButton(svg.CircledVerticalEllipsis, i18n.Text("Add prerequisite list"))
 		addPrereqListButton.ClickCallback = func() {
+			undo := p.prepareUndo(i18n.Text("Add Prerequisite List"))
 			newList := model.NewPrereqList()
 			newList.Parent = prereqList
 			prereqList.Prereqs = slices.Insert(prereqList.Prereqs, 0, model.Prereq(newList))
@@ -159,13 +219,52 @@ func (p *prereqPanel) createButtonsPanel(parent *unison.Panel, depth int, data m
 			p.adjustAndOrForList(prereqList)
 			unison.Ancestor[*unison.DockContainer](p).MarkForLayoutRecursively()
 			MarkModified(p)
+			p.finishAndPostUndo(undo)
 		}
 		buttons.AddChild(addPrereqListButton)
+
+		deMorganButton := newEditorSVGButton(svg.Not,
+			i18n.Text("Switch between requiring all and at least one using De Morgan's laws"))
+		deMorganButton.ClickCallback = func() { p.applyDeMorgan(prereqList) }
+		buttons.AddChild(deMorganButton)
+
+		if prereqList.ParentList() == nil {
+			dedupButton := newEditorSVGButton(svg.Filter, i18n.Text("Remove duplicate prerequisites"))
+			dedupButton.ClickCallback = p.removeDuplicates
+			buttons.AddChild(dedupButton)
+
+			clipboardButton := newEditorSVGButton(svg.Menu, i18n.Text("Copy or paste prerequisites"))
+			clipboardButton.ClickCallback = func() { p.showClipboardMenu(clipboardButton) }
+			buttons.AddChild(clipboardButton)
+
+			summary := NewInfoPop()
+			summary.UpdateTooltipCallback = func(_ unison.Point, avoid unison.Rect) unison.Rect {
+				text := (*p.root).Description(p.entity)
+				if text == "" {
+					text = i18n.Text("No prerequisites")
+				}
+				summary.Tooltip = unison.NewTooltipWithText(text)
+				return avoid
+			}
+			buttons.AddChild(summary)
+
+			if p.entity != nil {
+				statusButton := newEditorSVGButton(svg.Checkmark, i18n.Text("Toggle evaluation of prerequisites against the current character"))
+				statusButton.ClickCallback = func() {
+					p.showStatus = !p.showStatus
+					DeepSync(p)
+					p.MarkForLayoutRecursively()
+					p.MarkForRedraw()
+				}
+				buttons.AddChild(statusButton)
+			}
+		}
 	}
 	parentList := data.ParentList()
 	if parentList != nil {
-		deleteButton := unison.NewSVGButton(svg.Trash)
+		deleteButton := newEditorSVGButton(svg.Trash, i18n.Text("Remove prerequisite"))
 		deleteButton.ClickCallback = func() {
+			undo := p.prepareUndo(i18n.Text("Delete Prerequisite"))
 			delete(p.andOrMap, data)
 			if i := slices.IndexFunc(parentList.Prereqs, func(elem model.Prereq) bool { return elem == data }); i != -1 {
 				parentList.Prereqs = slices.Delete(parentList.Prereqs, i, i+1)
@@ -174,6 +273,7 @@ func (p *prereqPanel) createButtonsPanel(parent *unison.Panel, depth int, data m
 			p.adjustAndOrForList(parentList)
 			unison.Ancestor[*unison.DockContainer](p).MarkForLayoutRecursively()
 			MarkModified(p)
+			p.finishAndPostUndo(undo)
 		}
 		buttons.AddChild(deleteButton)
 	}
@@ -182,6 +282,277 @@ func (p *prereqPanel) createButtonsPanel(parent *unison.Panel, depth int, data m
 	})
 }
 
+func (p *prereqPanel) newPrereqStatus(data model.Prereq) *prereqStatus {
+	status := &prereqStatus{
+		Label:  unison.Label{LabelTheme: unison.DefaultLabelTheme},
+		owner:  p,
+		prereq: data,
+	}
+	status.Self = status
+	status.SetSizer(status.DefaultSizes)
+	status.DrawCallback = status.DefaultDraw
+	status.Sync()
+	return status
+}
+
+// Sync implements Syncer.
+func (s *prereqStatus) Sync() {
+	if !s.owner.showStatus {
+		s.Drawable = nil
+		s.Tooltip = nil
+		return
+	}
+	const prefix = "\n● "
+	var tooltip xio.ByteBuffer
+	var eqpPenalty bool
+	if s.prereq.Satisfied(s.owner.entity, s.owner.exclude, &tooltip, prefix, &eqpPenalty) {
+		s.Drawable = &unison.DrawableSVG{
+			SVG:  svg.Checkmark,
+			Size: unison.NewSize(12, 12),
+		}
+		s.OnBackgroundInk = unison.Green
+		if s.prereq.ParentList() == nil {
+			s.Tooltip = unison.NewTooltipWithText(i18n.Text("All prerequisites are currently satisfied"))
+		} else {
+			s.Tooltip = unison.NewTooltipWithText(i18n.Text("Currently satisfied"))
+		}
+	} else {
+		s.Drawable = &unison.DrawableSVG{
+			SVG:  svg.Not,
+			Size: unison.NewSize(12, 12),
+		}
+		s.OnBackgroundInk = unison.ErrorColor
+		s.Tooltip = unison.NewTooltipWithText(i18n.Text("Not currently satisfied:") + tooltip.String())
+	}
+	s.MarkForLayoutAndRedraw()
+}
+
+func (p *prereqPanel) showClipboardMenu(b *unison.Button) {
+	pasted, err := model.PrereqListFromText(unison.GlobalClipboard.GetText())
+	canPaste := err == nil && len(pasted.Prereqs) != 0
+	f := unison.DefaultMenuFactory()
+	id := unison.ContextMenuIDFlag
+	m := f.NewMenu(id, "", nil)
+	id++
+	m.InsertItem(-1, f.NewItem(id, i18n.Text("Copy Prerequisites"), unison.KeyBinding{},
+		func(_ unison.MenuItem) bool { return len((*p.root).Prereqs) != 0 },
+		func(_ unison.MenuItem) { p.copyPrereqs() }))
+	id++
+	m.InsertSeparator(-1, false)
+	m.InsertItem(-1, f.NewItem(id, i18n.Text("Paste Prerequisites, Replacing Existing"), unison.KeyBinding{},
+		func(_ unison.MenuItem) bool { return canPaste },
+		func(_ unison.MenuItem) { p.pastePrereqs(pasted, false) }))
+	id++
+	m.InsertItem(-1, f.NewItem(id, i18n.Text("Paste Prerequisites, Merging as a Sub-List"), unison.KeyBinding{},
+		func(_ unison.MenuItem) bool { return canPaste },
+		func(_ unison.MenuItem) { p.pastePrereqs(pasted, true) }))
+	id++
+	m.InsertSeparator(-1, false)
+	canFlatten := (*p.root).CanFlatten()
+	m.InsertItem(-1, f.NewItem(id, i18n.Text("Flatten Nested Prerequisite Lists"), unison.KeyBinding{},
+		func(_ unison.MenuItem) bool { return canFlatten },
+		func(_ unison.MenuItem) { p.flattenPrereqs() }))
+	id++
+	m.InsertItem(-1, f.NewItem(id, i18n.Text("Export Prerequisites as Graphviz DOT…"), unison.KeyBinding{},
+		func(_ unison.MenuItem) bool { return len((*p.root).Prereqs) != 0 },
+		func(_ unison.MenuItem) { p.exportPrereqsAsDOT() }))
+	m.Popup(b.RectToRoot(b.ContentRect(true)), 0)
+}
+
+func (p *prereqPanel) copyPrereqs() {
+	text, err := (*p.root).ToText()
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to copy prerequisites"), err)
+		return
+	}
+	unison.GlobalClipboard.SetText(text)
+}
+
+func (p *prereqPanel) exportPrereqsAsDOT() {
+	global := model.GlobalSettings()
+	dialog := unison.NewSaveDialog()
+	dialog.SetInitialDirectory(global.LastDir(model.DefaultLastDirKey))
+	dialog.SetAllowedExtensions("dot")
+	if !dialog.RunModal() {
+		return
+	}
+	filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), "dot", false)
+	if !ok {
+		return
+	}
+	global.SetLastDir(model.DefaultLastDirKey, filepath.Dir(filePath))
+	var buffer bytes.Buffer
+	if err := (*p.root).ToDOT(&buffer, p.entity); err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to export prerequisites!"), err)
+		return
+	}
+	if err := os.WriteFile(filePath, buffer.Bytes(), 0o640); err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to export prerequisites!"), errs.Wrap(err))
+	}
+}
+
+func (p *prereqPanel) pastePrereqs(pasted *model.PrereqList, merge bool) {
+	undo := p.prepareUndo(i18n.Text("Paste Prerequisites"))
+	if merge {
+		(*p.root).Merge(pasted)
+	} else {
+		*p.root = pasted
+	}
+	p.rebuild()
+	p.finishAndPostUndo(undo)
+}
+
+// prepareUndo captures the current prerequisites in a new undo record. Call finishAndPostUndo once the structural
+// change has been made.
+func (p *prereqPanel) prepareUndo(title string) *unison.UndoEdit[*model.PrereqList] {
+	return &unison.UndoEdit[*model.PrereqList]{
+		ID:         unison.NextUndoID(),
+		EditName:   title,
+		UndoFunc:   func(e *unison.UndoEdit[*model.PrereqList]) { p.applyPrereqs(e.BeforeData) },
+		RedoFunc:   func(e *unison.UndoEdit[*model.PrereqList]) { p.applyPrereqs(e.AfterData) },
+		AbsorbFunc: func(e *unison.UndoEdit[*model.PrereqList], other unison.Undoable) bool { return false },
+		BeforeData: (*p.root).CloneAsPrereqList(nil),
+	}
+}
+
+func (p *prereqPanel) finishAndPostUndo(undo *unison.UndoEdit[*model.PrereqList]) {
+	undo.AfterData = (*p.root).CloneAsPrereqList(nil)
+	if mgr := unison.UndoManagerFor(p); mgr != nil {
+		mgr.Add(undo)
+	}
+}
+
+func (p *prereqPanel) applyPrereqs(list *model.PrereqList) {
+	*p.root = list.CloneAsPrereqList(nil)
+	p.rebuild()
+}
+
+func (p *prereqPanel) rebuild() {
+	p.andOrMap = make(map[model.Prereq]*unison.Label)
+	p.RemoveAllChildren()
+	p.AddChild(p.createPrereqListPanel(0, *p.root))
+	unison.Ancestor[*unison.DockContainer](p).MarkForLayoutRecursively()
+	MarkModified(p)
+}
+
+func (p *prereqPanel) removeDuplicates() {
+	root := *p.root
+	count := root.CountDuplicates()
+	if count == 0 {
+		return
+	}
+	prompt := fmt.Sprintf(i18n.Text("Remove %s?"), model.CountWithNoun(count, i18n.Text("duplicate prerequisite"),
+		i18n.Text("duplicate prerequisites")))
+	if unison.QuestionDialog(prompt, "") != unison.ModalResponseOK {
+		return
+	}
+	undo := p.prepareUndo(i18n.Text("Remove Duplicate Prerequisites"))
+	root.RemoveDuplicates()
+	p.rebuild()
+	p.finishAndPostUndo(undo)
+}
+
+func (p *prereqPanel) flattenPrereqs() {
+	undo := p.prepareUndo(i18n.Text("Flatten Prerequisites"))
+	if (*p.root).Flatten() {
+		p.rebuild()
+		p.finishAndPostUndo(undo)
+	}
+}
+
+func (p *prereqPanel) applyDeMorgan(list *model.PrereqList) {
+	if !list.CanApplyDeMorgan() {
+		unison.WarningDialogWithMessage(i18n.Text("Unable to apply De Morgan's laws"),
+			i18n.Text("The list must not be empty and may not contain empty lists or lists restricted to a tech level."))
+		return
+	}
+	undo := p.prepareUndo(i18n.Text("Apply De Morgan's Laws"))
+	list.ApplyDeMorgan()
+	p.rebuild()
+	p.finishAndPostUndo(undo)
+}
+
+func (p *prereqPanel) dataDragOver(where unison.Point, data map[string]any) bool {
+	prevInDragOver := p.inDragOver
+	dragInsert := p.dragInsert
+	dragTarget := p.dragTarget
+	p.inDragOver = false
+	p.dragInsert = -1
+	p.dragTarget = nil
+	if dd, ok := data[prereqDragDataKey].(*prereqDragData); ok && dd.owner == p {
+		parent := dd.panel.Parent()
+		where = parent.PointFromRoot(p.PointToRoot(where))
+		columns := parent.Layout().(*unison.FlexLayout).Columns
+		children := parent.Children()
+		for i := columns; i < len(children); i++ {
+			rect := children[i].FrameRect()
+			if rect.ContainsPoint(where) {
+				p.dragTarget = parent
+				if rect.CenterY() <= where.Y {
+					p.dragInsert = i - columns + 1
+				} else {
+					p.dragInsert = i - columns
+				}
+				p.inDragOver = true
+				break
+			}
+		}
+	}
+	if prevInDragOver != p.inDragOver || dragInsert != p.dragInsert || dragTarget != p.dragTarget {
+		p.MarkForRedraw()
+	}
+	return true
+}
+
+func (p *prereqPanel) dataDragExit() {
+	p.inDragOver = false
+	p.dragInsert = -1
+	p.dragTarget = nil
+	p.MarkForRedraw()
+}
+
+func (p *prereqPanel) dataDragDrop(_ unison.Point, data map[string]any) {
+	if p.inDragOver && p.dragInsert != -1 {
+		if dd, ok := data[prereqDragDataKey].(*prereqDragData); ok && dd.owner == p {
+			list := dd.prereq.ParentList()
+			if i := slices.IndexFunc(list.Prereqs, func(one model.Prereq) bool { return one == dd.prereq }); i != -1 {
+				undo := p.prepareUndo(i18n.Text("Prerequisite Drag"))
+				list.Prereqs = slices.Delete(list.Prereqs, i, i+1)
+				if i < p.dragInsert {
+					p.dragInsert--
+				}
+				list.Prereqs = slices.Insert(list.Prereqs, p.dragInsert, dd.prereq)
+				parent := dd.panel.Parent()
+				dd.panel.RemoveFromParent()
+				parent.AddChildAtIndex(dd.panel, parent.Layout().(*unison.FlexLayout).Columns+p.dragInsert)
+				p.adjustAndOrForList(list)
+				unison.Ancestor[*unison.DockContainer](p).MarkForLayoutRecursively()
+				MarkModified(p)
+				p.finishAndPostUndo(undo)
+			}
+		}
+	}
+	p.dataDragExit()
+}
+
+func (p *prereqPanel) drawOver(gc *unison.Canvas, rect unison.Rect) {
+	if p.inDragOver && p.dragInsert != -1 {
+		children := p.dragTarget.Children()
+		index := p.dragTarget.Layout().(*unison.FlexLayout).Columns + p.dragInsert
+		var y float32
+		if index < len(children) {
+			y = children[index].FrameRect().Y
+		} else {
+			y = children[len(children)-1].FrameRect().Bottom()
+		}
+		pt := p.PointFromRoot(p.dragTarget.PointToRoot(unison.Point{Y: y}))
+		paint := unison.DropAreaColor.Paint(gc, rect, unison.Stroke)
+		paint.SetStrokeWidth(2)
+		r := p.RectFromRoot(p.dragTarget.RectToRoot(p.dragTarget.ContentRect(false)))
+		gc.DrawLine(r.X, pt.Y, r.Right(), pt.Y, paint)
+	}
+}
+
 func (p *prereqPanel) addAndOr(parent *unison.Panel, data model.Prereq) {
 	label := NewFieldLeadingLabel(andOrText(data))
 	parent.AddChild(label)
@@ -218,6 +589,9 @@ func andOrText(pr model.Prereq) string {
 	if list.All {
 		return i18n.Text("and")
 	}
+	if list.Negate {
+		return i18n.Text("nor")
+	}
 	return i18n.Text("or")
 }
 
@@ -228,7 +602,8 @@ func (p *prereqPanel) addPrereqTypeSwitcher(parent *unison.Panel, depth int, pr
 		if item, ok := pop.Selected(); ok {
 			parentList := pr.ParentList()
 			if newPrereq := p.createPrereqForType(item, parentList); newPrereq != nil {
-				lastPrereqTypeUsed = item
+				model.GlobalSettings().LastPrereqTypeUsed = item
+				undo := p.prepareUndo(i18n.Text("Change Prerequisite Type"))
 				parentOfParent := parent.Parent()
 				parent.RemoveFromParent()
 				list := parentList.Prereqs
@@ -237,6 +612,7 @@ func (p *prereqPanel) addPrereqTypeSwitcher(parent *unison.Panel, depth int, pr
 				p.addToList(parentOfParent, depth, i, newPrereq)
 				unison.Ancestor[*unison.DockContainer](p).MarkForLayoutRecursively()
 				MarkModified(p)
+				p.finishAndPostUndo(undo)
 			}
 		}
 	}
@@ -276,6 +652,14 @@ func (p *prereqPanel) createPrereqForType(prereqType model.PrereqType, parentLis
 		one := model.NewSpellPrereq()
 		one.Parent = parentList
 		return one
+	case model.PointsPrereqType:
+		one := model.NewPointsPrereq()
+		one.Parent = parentList
+		return one
+	case model.CampaignSettingPrereqType:
+		one := model.NewCampaignSettingPrereq()
+		one.Parent = parentList
+		return one
 	default:
 		jot.Warn(errs.Newf("unknown prerequisite type: %s", prereqType.Key()))
 		return nil
@@ -327,8 +711,19 @@ func (p *prereqPanel) createAttributePrereqPanel(depth int, pr *model.AttributeP
 	second := unison.NewPanel()
 	second.SetLayoutData(&unison.FlexLayoutData{HSpan: columns - 1})
 	extra := model.SizeFlag | model.DodgeFlag | model.ParryFlag | model.BlockFlag
-	addAttributeChoicePopup(second, p.entity, noAndOr, &pr.Which, extra)
-	addAttributeChoicePopup(second, p.entity, i18n.Text("combined with"), &pr.CombinedWith, extra|model.BlankFlag)
+	var maximumPopup *unison.PopupMenu[string]
+	for _, one := range []*unison.PopupMenu[*model.AttributeChoice]{
+		addAttributeChoicePopup(second, p.entity, noAndOr, &pr.Which, extra),
+		addAttributeChoicePopup(second, p.entity, i18n.Text("combined with"), &pr.CombinedWith, extra|model.BlankFlag),
+	} {
+		callback := one.SelectionChangedCallback
+		one.SelectionChangedCallback = func(popup *unison.PopupMenu[*model.AttributeChoice]) {
+			callback(popup)
+			adjustPopupBlank(maximumPopup, !pr.IsPool(p.entity))
+		}
+	}
+	maximumPopup = addBoolPopup(second, i18n.Text("maximum"), i18n.Text("current"), &pr.UseMaximum)
+	adjustPopupBlank(maximumPopup, !pr.IsPool(p.entity))
 	addNumericCriteriaPanel(second, nil, "", i18n.Text("which"), i18n.Text("Attribute Qualifier"),
 		&pr.QualifierCriteria, fxp.Min, fxp.Max, 1, false, false)
 	second.SetLayout(&unison.FlexLayout{
@@ -401,7 +796,8 @@ func (p *prereqPanel) createEquippedEquipmentPrereqPanel(depth int, pr *model.Eq
 	if inFront {
 		p.addAndOr(panel, pr)
 	}
-	// addHasPopup(panel, &pr.Has)
+	addHasPopup(panel, &pr.Has)
+	addQuantityCriteriaPanel(panel, nil, "", &pr.QuantityCriteria)
 	p.addPrereqTypeSwitcher(panel, depth, pr)
 	if !inFront {
 		p.addAndOr(panel, pr)
@@ -413,6 +809,7 @@ func (p *prereqPanel) createEquippedEquipmentPrereqPanel(depth int, pr *model.Eq
 		VSpacing: unison.StdVSpacing,
 	})
 	addNameCriteriaPanel(panel, &pr.NameCriteria, columns-1, true)
+	addTagCriteriaPanel(panel, &pr.TagCriteria, columns-1, true)
 	return panel
 }
 
@@ -434,6 +831,9 @@ func (p *prereqPanel) createSkillPrereqPanel(depth int, pr *model.SkillPrereq) *
 		HSpacing: unison.StdHSpacing,
 		VSpacing: unison.StdVSpacing,
 	})
+	panel.AddChild(unison.NewPanel())
+	kindPopup := addPopup(panel, model.AllSkillKind, &pr.Kind)
+	kindPopup.SetLayoutData(&unison.FlexLayoutData{HSpan: columns - 1})
 	addNameCriteriaPanel(panel, &pr.NameCriteria, columns-1, true)
 	addSpecializationCriteriaPanel(panel, &pr.SpecializationCriteria, columns-1, true)
 	addLevelCriteriaPanel(panel, nil, "", &pr.LevelCriteria, columns-1, true)
@@ -481,3 +881,100 @@ func (p *prereqPanel) createSpellPrereqPanel(depth int, pr *model.SpellPrereq) *
 	panel.AddChild(second)
 	return panel
 }
+
+func (p *prereqPanel) createPointsPrereqPanel(depth int, pr *model.PointsPrereq) *unison.Panel {
+	panel := unison.NewPanel()
+	p.createButtonsPanel(panel, depth, pr)
+	inFront := andOrText(pr) != noAndOr
+	if inFront {
+		p.addAndOr(panel, pr)
+	}
+	addHasPopup(panel, &pr.Has)
+	p.addPrereqTypeSwitcher(panel, depth, pr)
+	if !inFront {
+		p.addAndOr(panel, pr)
+	}
+	columns := len(panel.Children())
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  columns,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	second := unison.NewPanel()
+	second.SetLayoutData(&unison.FlexLayoutData{HSpan: columns - 1})
+	categoryPopup := addPopup(second, model.AllPointsCategory, &pr.Category)
+	var adjustTag func()
+	if tags := model.PointsTagsFor(p.entity); len(tags) != 0 {
+		if pr.Tag != "" && !slices.Contains(tags, pr.Tag) {
+			tags = append(tags, pr.Tag)
+		}
+		tagPopup := addPopup(second, tags, &pr.Tag)
+		adjustTag = func() { adjustPopupBlank(tagPopup, pr.Category != model.TagPointsCategory) }
+	} else {
+		title := i18n.Text("Tag")
+		tagField := NewStringField(nil, "", title, func() string { return pr.Tag }, func(v string) {
+			pr.Tag = v
+			MarkModified(second)
+		})
+		second.AddChild(tagField)
+		adjustTag = func() { adjustFieldBlank(tagField, pr.Category != model.TagPointsCategory) }
+	}
+	savedCallback := categoryPopup.SelectionChangedCallback
+	categoryPopup.SelectionChangedCallback = func(pop *unison.PopupMenu[model.PointsCategory]) {
+		savedCallback(pop)
+		adjustTag()
+	}
+	adjustTag()
+	addNumericCriteriaPanel(second, nil, "", i18n.Text("which"), i18n.Text("Points Qualifier"),
+		&pr.QualifierCriteria, fxp.Min, fxp.Max, 1, false, false)
+	second.SetLayout(&unison.FlexLayout{
+		Columns:  len(second.Children()),
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(unison.NewPanel())
+	panel.AddChild(second)
+	return panel
+}
+
+func (p *prereqPanel) createCampaignSettingPrereqPanel(depth int, pr *model.CampaignSettingPrereq) *unison.Panel {
+	panel := unison.NewPanel()
+	p.createButtonsPanel(panel, depth, pr)
+	inFront := andOrText(pr) != noAndOr
+	if inFront {
+		p.addAndOr(panel, pr)
+	}
+	addHasPopup(panel, &pr.Has)
+	p.addPrereqTypeSwitcher(panel, depth, pr)
+	if !inFront {
+		p.addAndOr(panel, pr)
+	}
+	columns := len(panel.Children())
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  columns,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	flags := model.SheetSettingsFlags()
+	current := model.SheetSettingsFlagFor(pr.Which)
+	if current == nil {
+		current = flags[0]
+	}
+	popup := unison.NewPopupMenu[*model.SheetSettingsFlag]()
+	for _, one := range flags {
+		popup.AddItem(one)
+		if one.Key == current.Key {
+			popup.SelectIndex(popup.ItemCount() - 1)
+		}
+	}
+	popup.SelectionChangedCallback = func(pop *unison.PopupMenu[*model.SheetSettingsFlag]) {
+		if item, ok := pop.Selected(); ok {
+			pr.Which = item.Key
+			MarkModified(panel)
+		}
+	}
+	popup.SetLayoutData(&unison.FlexLayoutData{HSpan: columns - 1})
+	panel.AddChild(unison.NewPanel())
+	panel.AddChild(popup)
+	return panel
+}
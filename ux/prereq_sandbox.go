@@ -0,0 +1,274 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"golang.org/x/exp/slices"
+)
+
+const prereqSandboxGroup = "prereq_sandbox"
+
+var (
+	_ unison.Dockable  = &prereqSandboxDockable{}
+	_ unison.TabCloser = &prereqSandboxDockable{}
+	_ ModifiableRoot   = &prereqSandboxDockable{}
+)
+
+// prereqSandboxDockable lets prerequisites be tested against a hypothetical character rather than a real one.
+type prereqSandboxDockable struct {
+	unison.Panel
+	sandbox  *model.PrereqSandbox
+	list     *model.PrereqList
+	prereqs  *prereqPanel
+	result   *unison.Label
+	updating bool
+}
+
+// ShowPrereqSandbox opens a new prerequisite sandbox.
+func ShowPrereqSandbox() {
+	ws := AnyWorkspace()
+	if ws == nil {
+		ShowUnableToLocateWorkspaceError()
+		return
+	}
+	d := &prereqSandboxDockable{
+		sandbox: model.NewPrereqSandbox(),
+		list:    model.NewPrereqList(),
+	}
+	d.Self = d
+	d.SetLayout(&unison.FlexLayout{Columns: 1})
+
+	entity := d.sandbox.Entity()
+	content := unison.NewPanel()
+	content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	content.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		VSpacing: unison.StdVSpacing * 2,
+	})
+	content.AddChild(d.createAttributesPanel(entity))
+	content.AddChild(d.createItemsPanel(i18n.Text("Traits"), i18n.Text("Add trait"), &d.sandbox.Traits,
+		addSandboxTraitFields))
+	content.AddChild(d.createItemsPanel(i18n.Text("Skills"), i18n.Text("Add skill"), &d.sandbox.Skills,
+		addSandboxSkillFields))
+	content.AddChild(d.createItemsPanel(i18n.Text("Spells"), i18n.Text("Add spell"), &d.sandbox.Spells,
+		addSandboxSpellFields))
+
+	d.prereqs = newPrereqPanel(entity, nil, &d.list)
+	d.prereqs.showStatus = true
+	d.prereqs.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: unison.FillAlignment,
+		HGrab:  true,
+	})
+	content.AddChild(d.prereqs)
+
+	d.result = unison.NewLabel()
+	content.AddChild(d.result)
+
+	scroll := unison.NewScrollPanel()
+	scroll.SetContent(content, unison.FillBehavior, unison.FillBehavior)
+	scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: unison.FillAlignment,
+		VAlign: unison.FillAlignment,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	d.AddChild(scroll)
+	d.update()
+	PlaceInDock(ws, ws.CurrentlyFocusedDockContainer(), d, prereqSandboxGroup)
+}
+
+func (d *prereqSandboxDockable) createAttributesPanel(entity *model.Entity) *unison.Panel {
+	panel := newSandboxSection(i18n.Text("Attributes"), 4)
+	for _, def := range entity.SheetSettings.Attributes.List(false) {
+		if !def.Primary() || def.IsSeparator() || def.Type == model.IntegerRefAttributeType ||
+			def.Type == model.DecimalRefAttributeType {
+			continue
+		}
+		id := def.ID()
+		attr, ok := entity.Attributes.Set[id]
+		if !ok {
+			continue
+		}
+		d.sandbox.Attributes[id] = attr.Maximum()
+		name := def.CombinedName()
+		panel.AddChild(NewFieldLeadingLabel(name))
+		panel.AddChild(NewDecimalField(nil, "", name, func() fxp.Int { return d.sandbox.Attributes[id] },
+			func(v fxp.Int) { d.sandbox.Attributes[id] = v }, fxp.Min, fxp.Max, false, false))
+	}
+	return panel
+}
+
+func (d *prereqSandboxDockable) createItemsPanel(title, addTitle string, items *[]*model.PrereqSandboxItem, addFields func(parent *unison.Panel, item *model.PrereqSandboxItem)) *unison.Panel {
+	panel := newSandboxSection(title, 1)
+	rows := unison.NewPanel()
+	rows.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		VSpacing: unison.StdVSpacing,
+	})
+	rows.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: unison.FillAlignment,
+		HGrab:  true,
+	})
+	var rebuild func()
+	rebuild = func() {
+		rows.RemoveAllChildren()
+		for _, item := range *items {
+			row := unison.NewPanel()
+			row.SetLayoutData(&unison.FlexLayoutData{
+				HAlign: unison.FillAlignment,
+				HGrab:  true,
+			})
+			addFields(row, item)
+			target := item
+			deleteButton := newEditorSVGButton(svg.Trash, i18n.Text("Remove"))
+			deleteButton.ClickCallback = func() {
+				if i := slices.Index(*items, target); i != -1 {
+					*items = slices.Delete(*items, i, i+1)
+				}
+				rebuild()
+				d.update()
+			}
+			row.AddChild(deleteButton)
+			row.SetLayout(&unison.FlexLayout{
+				Columns:  len(row.Children()),
+				HSpacing: unison.StdHSpacing,
+			})
+			rows.AddChild(row)
+		}
+		MarkForLayoutWithinDockable(rows)
+	}
+	addButton := newEditorSVGButton(svg.CircledAdd, addTitle)
+	addButton.ClickCallback = func() {
+		*items = append(*items, &model.PrereqSandboxItem{})
+		rebuild()
+		d.update()
+	}
+	panel.AddChild(addButton)
+	panel.AddChild(rows)
+	rebuild()
+	return panel
+}
+
+func addSandboxTraitFields(parent *unison.Panel, item *model.PrereqSandboxItem) {
+	addSandboxStringField(parent, i18n.Text("Name"), &item.Name)
+	addSandboxDecimalField(parent, i18n.Text("Levels"), &item.Level, 0)
+}
+
+func addSandboxSkillFields(parent *unison.Panel, item *model.PrereqSandboxItem) {
+	addSandboxStringField(parent, i18n.Text("Name"), &item.Name)
+	addSandboxStringField(parent, i18n.Text("Specialization"), &item.Specialization)
+	addSandboxDecimalField(parent, i18n.Text("Level"), &item.Level, fxp.Min)
+}
+
+func addSandboxSpellFields(parent *unison.Panel, item *model.PrereqSandboxItem) {
+	addSandboxStringField(parent, i18n.Text("Name"), &item.Name)
+	addSandboxStringField(parent, i18n.Text("Colleges"), &item.Colleges)
+}
+
+func addSandboxStringField(parent *unison.Panel, title string, fieldData *string) {
+	parent.AddChild(NewFieldLeadingLabel(title))
+	parent.AddChild(NewStringField(nil, "", title, func() string { return *fieldData },
+		func(value string) { *fieldData = value }))
+}
+
+func addSandboxDecimalField(parent *unison.Panel, title string, fieldData *fxp.Int, min fxp.Int) {
+	parent.AddChild(NewFieldLeadingLabel(title))
+	parent.AddChild(NewDecimalField(nil, "", title, func() fxp.Int { return *fieldData },
+		func(value fxp.Int) { *fieldData = value }, min, fxp.Max, false, false))
+}
+
+func newSandboxSection(title string, columns int) *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  columns,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: unison.FillAlignment,
+		HGrab:  true,
+	})
+	panel.SetBorder(unison.NewCompoundBorder(
+		&TitledBorder{
+			Title: title,
+			Font:  unison.LabelFont,
+		},
+		unison.NewEmptyBorder(unison.NewUniformInsets(2))))
+	return panel
+}
+
+// update rebuilds the hypothetical character and re-evaluates the prerequisites against it.
+func (d *prereqSandboxDockable) update() {
+	if d.updating || d.prereqs == nil {
+		return
+	}
+	d.updating = true
+	defer func() { d.updating = false }()
+	entity := d.sandbox.Entity()
+	d.prereqs.entity = entity
+	DeepSync(d.prereqs)
+	satisfied, explanation := model.EvaluatePrereqs(entity, d.list)
+	if satisfied {
+		d.result.Text = i18n.Text("All prerequisites are satisfied.")
+		d.result.OnBackgroundInk = unison.OnContentColor
+	} else {
+		d.result.Text = i18n.Text("Prerequisites have not been met:") + explanation
+		d.result.OnBackgroundInk = unison.ErrorColor
+	}
+	MarkForLayoutWithinDockable(d.result)
+}
+
+// MarkModified implements ModifiableRoot.
+func (d *prereqSandboxDockable) MarkModified(_ unison.Paneler) {
+	d.update()
+}
+
+// TitleIcon implements unison.Dockable
+func (d *prereqSandboxDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Checkmark,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (d *prereqSandboxDockable) Title() string {
+	return i18n.Text("Prerequisite Sandbox")
+}
+
+// Tooltip implements unison.Dockable
+func (d *prereqSandboxDockable) Tooltip() string {
+	return i18n.Text("Test prerequisites against a hypothetical character")
+}
+
+// Modified implements unison.Dockable
+func (d *prereqSandboxDockable) Modified() bool {
+	return false
+}
+
+// MayAttemptClose implements unison.TabCloser
+func (d *prereqSandboxDockable) MayAttemptClose() bool {
+	return true
+}
+
+// AttemptClose implements unison.TabCloser
+func (d *prereqSandboxDockable) AttemptClose() bool {
+	if dc := unison.Ancestor[*unison.DockContainer](d); dc != nil {
+		dc.Close(d)
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// quickFilter converts the text of a quick filter field into a matcher for cell text. Text with a leading "/" is treated
+// as a case-insensitive regular expression, while anything else is a case-insensitive substring. The last expression
+// compiled is cached, along with any error that resulted from compiling it.
+type quickFilter struct {
+	text string
+	re   *regexp.Regexp
+	err  error
+}
+
+// matcher returns a function that reports whether a cell's text satisfies the filter, or nil if the filter is empty or
+// its expression is invalid.
+func (q *quickFilter) matcher(text string) func(cell string) bool {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "/") {
+		q.text = text
+		q.re = nil
+		q.err = nil
+		if text == "" {
+			return nil
+		}
+		text = strings.ToLower(text)
+		return func(cell string) bool { return strings.Contains(strings.ToLower(cell), text) }
+	}
+	if text != q.text || (q.re == nil && q.err == nil) {
+		q.text = text
+		q.re, q.err = regexp.Compile("(?i)" + text[1:])
+	}
+	if q.err != nil || text == "/" {
+		return nil
+	}
+	return q.re.MatchString
+}
+
+// valid returns true if the last text passed to matcher() was usable.
+func (q *quickFilter) valid() bool {
+	return q.err == nil
+}
+
+func quickFilterTooltip() string {
+	return i18n.Text("Quick Filter\n\nShows rows with this text in any column, including tags, along with any containers holding them. Start with \"/\" to use a regular expression instead, e.g. \"/fire|ice\".")
+}
@@ -22,6 +22,7 @@ var _ TableProvider[*model.ConditionalModifier] = &reactionModProvider{}
 
 type reactionModProvider struct {
 	table    *unison.Table[*Node[*model.ConditionalModifier]]
+	nodes    NodeCache[*model.ConditionalModifier]
 	provider model.ReactionModifierListProvider
 }
 
@@ -34,6 +35,10 @@ func (p *reactionModProvider) RefKey() string {
 	return model.BlockLayoutReactionsKey
 }
 
+func (p *reactionModProvider) Count(predicate func(*model.ConditionalModifier) bool) int {
+	return model.Count(predicate, p.RootData()...)
+}
+
 func (p *reactionModProvider) AllTags() []string {
 	return nil
 }
@@ -47,12 +52,7 @@ func (p *reactionModProvider) RootRowCount() int {
 }
 
 func (p *reactionModProvider) RootRows() []*Node[*model.ConditionalModifier] {
-	data := p.provider.Reactions()
-	rows := make([]*Node[*model.ConditionalModifier], 0, len(data))
-	for _, one := range data {
-		rows = append(rows, NewNode[*model.ConditionalModifier](p.table, nil, one, true))
-	}
-	return rows
+	return p.nodes.Rows(p.table, nil, p.provider.Reactions(), true)
 }
 
 func (p *reactionModProvider) SetRootRows(_ []*Node[*model.ConditionalModifier]) {
@@ -121,7 +121,7 @@ func (p *reactionModProvider) ExcessWidthColumnID() int {
 func (p *reactionModProvider) OpenEditor(_ Rebuildable, _ *unison.Table[*Node[*model.ConditionalModifier]]) {
 }
 
-func (p *reactionModProvider) CreateItem(_ Rebuildable, _ *unison.Table[*Node[*model.ConditionalModifier]], _ ItemVariant) {
+func (p *reactionModProvider) CreateItem(_ Rebuildable, _ *unison.Table[*Node[*model.ConditionalModifier]], _ ItemVariant, _ InsertMode) {
 }
 
 func (p *reactionModProvider) Serialize() ([]byte, error) {
@@ -0,0 +1,151 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/toolbox/i18n"
+	xfs "github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/unison"
+)
+
+const recentFilesGroup = "recent"
+
+var (
+	_ unison.Dockable  = &recentFilesDockable{}
+	_ unison.TabCloser = &recentFilesDockable{}
+)
+
+type recentFilesDockable struct {
+	unison.Panel
+	scroll *unison.ScrollPanel
+}
+
+// ShowRecentFiles displays the pinned and recently opened files, allowing them to be opened, pinned and unpinned.
+func ShowRecentFiles() {
+	ws, dc, found := Activate(func(d unison.Dockable) bool {
+		if rd, ok := d.(*recentFilesDockable); ok {
+			rd.rebuild()
+			return true
+		}
+		return false
+	})
+	if found || ws == nil {
+		return
+	}
+	d := &recentFilesDockable{scroll: unison.NewScrollPanel()}
+	d.Self = d
+	d.SetLayout(&unison.FlexLayout{Columns: 1})
+	d.scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: unison.FillAlignment,
+		VAlign: unison.FillAlignment,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	d.AddChild(d.scroll)
+	d.rebuild()
+	PlaceInDock(ws, dc, d, recentFilesGroup)
+}
+
+func (d *recentFilesDockable) rebuild() {
+	content := unison.NewPanel()
+	content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	content.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	global := model.GlobalSettings()
+	pinned := global.ListPinnedFiles()
+	recent := global.ListRecentFiles()
+	titles := recentFileTitles(append(append([]string{}, pinned...), recent...))
+	d.addSection(content, i18n.Text("Pinned"), pinned, titles)
+	d.addSection(content, i18n.Text("Recent"), recent, titles)
+	d.scroll.SetContent(content, unison.FillBehavior, unison.FillBehavior)
+	d.MarkForLayoutAndRedraw()
+}
+
+func (d *recentFilesDockable) addSection(content *unison.Panel, title string, list []string, titles map[string]string) {
+	header := unison.NewLabel()
+	header.Text = title
+	header.Font = unison.SystemFont
+	header.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	content.AddChild(header)
+	if len(list) == 0 {
+		label := unison.NewLabel()
+		label.Text = i18n.Text("None")
+		label.SetBorder(unison.NewEmptyBorder(unison.Insets{Left: unison.StdHSpacing * 2}))
+		label.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+		content.AddChild(label)
+		return
+	}
+	for _, f := range list {
+		f := f
+		pin := NewCheckBox(nil, "", "", func() unison.CheckState {
+			return unison.CheckStateFromBool(model.GlobalSettings().IsFilePinned(f))
+		}, func(state unison.CheckState) {
+			model.GlobalSettings().SetFilePinned(f, state == unison.OnCheckState)
+			unison.InvokeTask(d.rebuild)
+		})
+		pin.Tooltip = unison.NewTooltipWithText(i18n.Text("Pinned files are kept in the list until unpinned"))
+		pin.SetBorder(unison.NewEmptyBorder(unison.Insets{Left: unison.StdHSpacing * 2}))
+		content.AddChild(pin)
+		if xfs.FileIsReadable(f) {
+			content.AddChild(unison.NewLink(titles[f], f, f, unison.DefaultLinkTheme,
+				func(_ unison.Paneler, target string) { OpenFile(nil, target) }))
+		} else {
+			label := unison.NewLabel()
+			label.Text = fmt.Sprintf(i18n.Text("%s (unavailable)"), titles[f])
+			label.Tooltip = unison.NewTooltipWithText(f)
+			label.SetEnabled(false)
+			content.AddChild(label)
+		}
+	}
+}
+
+// TitleIcon implements unison.Dockable
+func (d *recentFilesDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  unison.DocumentSVG,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (d *recentFilesDockable) Title() string {
+	return i18n.Text("Recent Files")
+}
+
+// Tooltip implements unison.Dockable
+func (d *recentFilesDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (d *recentFilesDockable) Modified() bool {
+	return false
+}
+
+// MayAttemptClose implements unison.TabCloser
+func (d *recentFilesDockable) MayAttemptClose() bool {
+	return true
+}
+
+// AttemptClose implements unison.TabCloser
+func (d *recentFilesDockable) AttemptClose() bool {
+	if dc := unison.Ancestor[*unison.DockContainer](d); dc != nil {
+		dc.Close(d)
+	}
+	return true
+}
@@ -16,6 +16,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/richardwilkes/gcs/v5/model"
 	"github.com/richardwilkes/gcs/v5/svg"
@@ -48,10 +49,23 @@ type SettingsDockable struct {
 	Resetter          func()
 	ModifiedCallback  func() bool
 	WillCloseCallback func() bool
+	CRCSource         func() uint64
+	Applier           func()
+	originalCRC       uint64
+	crcLock           sync.Mutex
+	cachedCRC         uint64
+	crcValid          bool
+	skipClosePrompt   bool
 }
 
 // Setup the dockable and display it.
 func (d *SettingsDockable) Setup(ws *Workspace, dc *unison.DockContainer, addToStartToolbar, addToEndToolbar, initContent func(*unison.Panel)) {
+	if d.CRCSource != nil {
+		d.originalCRC = d.CRCSource()
+	}
+	if d.Applier != nil {
+		d.KeyDownCallback = d.keyDown
+	}
 	d.SetLayout(&unison.FlexLayout{Columns: 1})
 	toolbar := d.createToolbar(addToStartToolbar, addToEndToolbar)
 	d.AddChild(toolbar)
@@ -71,6 +85,21 @@ func (d *SettingsDockable) Setup(ws *Workspace, dc *unison.DockContainer, addToS
 	FocusFirstContent(toolbar, content)
 }
 
+func (d *SettingsDockable) keyDown(keyCode unison.KeyCode, mod unison.Modifiers, _ bool) bool {
+	switch {
+	case mod.OSMenuCmdModifierDown() && (keyCode == unison.KeyReturn || keyCode == unison.KeyNumPadEnter):
+		if d.HasUnappliedChanges() {
+			d.ApplyAndClose()
+		}
+		return true
+	case mod == 0 && keyCode == unison.KeyEscape:
+		d.AttemptClose()
+		return true
+	default:
+		return false
+	}
+}
+
 // TitleIcon implements unison.Dockable
 func (d *SettingsDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
 	return &unison.DrawableSVG{
@@ -99,6 +128,7 @@ func (d *SettingsDockable) Modified() bool {
 
 // MarkModified implements widget.ModifiableRoot
 func (d *SettingsDockable) MarkModified(_ unison.Paneler) {
+	d.InvalidateCRC()
 	d.Modified()
 	if dc := unison.Ancestor[*unison.DockContainer](d); dc != nil {
 		dc.UpdateTitle(d)
@@ -106,6 +136,45 @@ func (d *SettingsDockable) MarkModified(_ unison.Paneler) {
 	DeepSync(d)
 }
 
+// HasUnappliedChanges returns true if the CRC returned by CRCSource differs from the one recorded when the dockable was
+// set up.
+func (d *SettingsDockable) HasUnappliedChanges() bool {
+	return d.CRCSource != nil && d.originalCRC != d.currentCRC()
+}
+
+// InvalidateCRC discards the cached CRC, causing it to be recomputed from CRCSource the next time it is needed.
+// MarkModified calls this, so it only needs to be called directly when the data is changed without marking the
+// dockable as modified.
+func (d *SettingsDockable) InvalidateCRC() {
+	d.crcLock.Lock()
+	d.crcValid = false
+	d.crcLock.Unlock()
+}
+
+func (d *SettingsDockable) currentCRC() uint64 {
+	d.crcLock.Lock()
+	defer d.crcLock.Unlock()
+	if !d.crcValid {
+		d.cachedCRC = d.CRCSource()
+		d.crcValid = true
+	}
+	return d.cachedCRC
+}
+
+// ApplyAndClose calls the Applier and then closes the dockable without prompting.
+func (d *SettingsDockable) ApplyAndClose() {
+	if d.Applier != nil {
+		d.Applier()
+	}
+	d.DiscardAndClose()
+}
+
+// DiscardAndClose closes the dockable without prompting, discarding any unapplied changes.
+func (d *SettingsDockable) DiscardAndClose() {
+	d.skipClosePrompt = true
+	d.AttemptClose()
+}
+
 // MayAttemptClose implements unison.TabCloser
 func (d *SettingsDockable) MayAttemptClose() bool {
 	return MayAttemptCloseOfGroup(d)
@@ -116,6 +185,17 @@ func (d *SettingsDockable) AttemptClose() bool {
 	if !CloseGroup(d) {
 		return false
 	}
+	if !d.skipClosePrompt && d.HasUnappliedChanges() {
+		switch unison.YesNoCancelDialog(fmt.Sprintf(i18n.Text("Apply changes made to\n%s?"), d.Title()), "") {
+		case unison.ModalResponseDiscard:
+		case unison.ModalResponseOK:
+			if d.Applier != nil {
+				d.Applier()
+			}
+		case unison.ModalResponseCancel:
+			return false
+		}
+	}
 	if d.WillCloseCallback != nil {
 		if !d.WillCloseCallback() {
 			return false
@@ -143,14 +223,12 @@ func (d *SettingsDockable) createToolbar(addToStartToolbar, addToEndToolbar func
 		addToEndToolbar(toolbar)
 	}
 	if d.Resetter != nil {
-		b := unison.NewSVGButton(svg.Reset)
-		b.Tooltip = unison.NewTooltipWithText(i18n.Text("Reset"))
+		b := NewSVGButtonWithTooltip(svg.Reset, i18n.Text("Reset"))
 		b.ClickCallback = d.handleReset
 		toolbar.AddChild(b)
 	}
 	if d.Loader != nil || d.Saver != nil {
-		b := unison.NewSVGButton(svg.Menu)
-		b.Tooltip = unison.NewTooltipWithText(i18n.Text("Menu"))
+		b := NewSVGButtonWithTooltip(svg.Menu, i18n.Text("Menu"))
 		b.ClickCallback = func() { d.showMenu(b) }
 		toolbar.AddChild(b)
 	}
@@ -48,7 +48,7 @@ var (
 )
 
 type itemCreator interface {
-	CreateItem(Rebuildable, ItemVariant)
+	CreateItem(Rebuildable, ItemVariant, InsertMode)
 }
 
 // Sheet holds the view for a GURPS character sheet.
@@ -108,8 +108,14 @@ func OpenSheets(exclude *Sheet) []*Sheet {
 
 // NewSheetFromFile loads a GURPS character sheet file and creates a new unison.Dockable for it.
 func NewSheetFromFile(filePath string) (unison.Dockable, error) {
-	entity, err := model.NewEntityFromFile(os.DirFS(filepath.Dir(filePath)), filepath.Base(filePath))
+	fileSystem := os.DirFS(filepath.Dir(filePath))
+	entity, err := model.NewEntityFromFile(fileSystem, filepath.Base(filePath))
 	if err != nil {
+		// Fall back to the legacy v4 importer. Since the data isn't in the current format, the user will be prompted
+		// for a location the first time the sheet is saved.
+		if legacy, legacyErr := model.LoadLegacyV4(fileSystem, filepath.Base(filePath)); legacyErr == nil {
+			return NewSheet(filePath, legacy), nil
+		}
 		return nil, err
 	}
 	s := NewSheet(filePath, entity)
@@ -182,6 +188,7 @@ func NewSheet(filePath string, entity *model.Entity) *Sheet {
 	var top *Page
 	top, s.modifiedFunc = createPageTopBlock(s.entity, s.targetMgr)
 	s.content.AddChild(top)
+	s.entity.RefreshLibraryStatus()
 	s.createLists()
 	s.scroll.SetContent(s.content, unison.UnmodifiedBehavior, unison.UnmodifiedBehavior)
 	s.scroll.SetLayoutData(&unison.FlexLayoutData{
@@ -210,6 +217,10 @@ func NewSheet(filePath string, entity *model.Entity) *Sheet {
 	bodyTypeButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Body Type"))
 	bodyTypeButton.ClickCallback = func() { ShowBodySettings(s) }
 
+	randomizeButton := unison.NewSVGButton(svg.Randomize)
+	randomizeButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Randomize the profile using the current ancestry"))
+	randomizeButton.ClickCallback = func() { s.randomizeProfile(nil) }
+
 	calcButton := unison.NewSVGButton(svg.Calculator)
 	calcButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Calculators (jumping, throwing, hiking, etc.)"))
 	calcButton.ClickCallback = func() { DisplayCalculator(s) }
@@ -239,20 +250,10 @@ func NewSheet(filePath string, entity *model.Entity) *Sheet {
 	s.toolbar.AddChild(attributesButton)
 	s.toolbar.AddChild(bodyTypeButton)
 	s.toolbar.AddChild(NewToolbarSeparator())
+	s.toolbar.AddChild(randomizeButton)
 	s.toolbar.AddChild(calcButton)
 	s.toolbar.AddChild(NewToolbarSeparator())
-	installSearchTracker(s.toolbar, func() {
-		s.Reactions.Table.ClearSelection()
-		s.ConditionalModifiers.Table.ClearSelection()
-		s.MeleeWeapons.Table.ClearSelection()
-		s.RangedWeapons.Table.ClearSelection()
-		s.Traits.Table.ClearSelection()
-		s.Skills.Table.ClearSelection()
-		s.Spells.Table.ClearSelection()
-		s.CarriedEquipment.Table.ClearSelection()
-		s.OtherEquipment.Table.ClearSelection()
-		s.Notes.Table.ClearSelection()
-	}, func(refList *[]*searchRef, text string) {
+	installSearchTracker(s.toolbar, s.clearTableSelections, func(refList *[]*searchRef, text string) {
 		searchSheetTable(refList, text, s.Traits)
 		searchSheetTable(refList, text, s.Skills)
 		searchSheetTable(refList, text, s.Spells)
@@ -275,13 +276,15 @@ func NewSheet(filePath string, entity *model.Entity) *Sheet {
 	s.installNewItemCmdHandlers(NewTechniqueItemID, -1, s.Skills)
 	s.installNewItemCmdHandlers(NewSpellItemID, NewSpellContainerItemID, s.Spells)
 	s.installNewItemCmdHandlers(NewRitualMagicSpellItemID, -1, s.Spells)
+	s.InstallCmdHandlers(NewSpellHeadingItemID, unison.AlwaysEnabled,
+		func(_ any) { s.Spells.CreateItem(s, HeadingItemVariant, AutoInsertMode) })
 	s.installNewItemCmdHandlers(NewCarriedEquipmentItemID, NewCarriedEquipmentContainerItemID,
 		s.CarriedEquipment)
 	s.installNewItemCmdHandlers(NewOtherEquipmentItemID, NewOtherEquipmentContainerItemID,
 		s.OtherEquipment)
 	s.installNewItemCmdHandlers(NewNoteItemID, NewNoteContainerItemID, s.Notes)
 	s.InstallCmdHandlers(AddNaturalAttacksItemID, unison.AlwaysEnabled, func(_ any) {
-		InsertItems[*model.Trait](s, s.Traits.Table, s.entity.TraitList, s.entity.SetTraitList,
+		InsertItems[*model.Trait](s, s.Traits.Table, AutoInsertMode, s.entity.TraitList, s.entity.SetTraitList,
 			func(_ *unison.Table[*Node[*model.Trait]]) []*Node[*model.Trait] {
 				return s.Traits.provider.RootRows()
 			}, model.NewNaturalAttacks(s.entity, nil))
@@ -291,12 +294,40 @@ func NewSheet(filePath string, entity *model.Entity) *Sheet {
 	s.InstallCmdHandlers(ExportAsWEBPItemID, unison.AlwaysEnabled, func(_ any) { s.exportToWEBP() })
 	s.InstallCmdHandlers(ExportAsPNGItemID, unison.AlwaysEnabled, func(_ any) { s.exportToPNG() })
 	s.InstallCmdHandlers(ExportAsJPEGItemID, unison.AlwaysEnabled, func(_ any) { s.exportToJPEG() })
+	s.InstallCmdHandlers(ExportAsHTMLItemID, unison.AlwaysEnabled, func(_ any) { s.exportToHTML() })
+	s.InstallCmdHandlers(ExportAsMarkdownItemID, unison.AlwaysEnabled, func(_ any) { s.exportToMarkdown() })
 	s.InstallCmdHandlers(PrintItemID, unison.AlwaysEnabled, func(_ any) { s.print() })
 	s.InstallCmdHandlers(ClearPortraitItemID, s.canClearPortrait, s.clearPortrait)
+	s.InstallCmdHandlers(RandomizeProfileItemID, unison.AlwaysEnabled, s.randomizeProfile)
+	s.InstallCmdHandlers(PreviewAttributeChangeItemID, s.canPreviewAttributeChange, s.previewAttributeChange)
+	s.InstallCmdHandlers(FindAndReplaceInNotesItemID, unison.AlwaysEnabled, s.findAndReplaceInNotes)
+	s.InstallCmdHandlers(DuplicateSheetItemID, unison.AlwaysEnabled, s.duplicateSheet)
+	s.InstallCmdHandlers(JumpToItemItemID, unison.AlwaysEnabled, s.jumpToItem)
+	s.InstallCmdHandlers(ShowCollegeIndexItemID, unison.AlwaysEnabled, func(_ any) {
+		ShowSpellCollegeIndex(s, s.entity.SpellList, func(spell *model.Spell) {
+			s.clearTableSelections()
+			showJumpTargetRow(s.Spells, spell)
+		})
+	})
+	s.InstallCmdHandlers(ShowPointBudgetItemID, unison.AlwaysEnabled, func(_ any) { DisplayPointBudget(s) })
+	s.InstallCmdHandlers(FindBrokenPageRefsItemID, unison.AlwaysEnabled, func(_ any) {
+		ShowBrokenPageRefs(s, s.entity.BrokenPageRefs(IsKnownPageRefKey), func(item any) {
+			s.showJumpTarget(&model.JumpTarget{Item: item})
+		})
+	})
 
 	return s
 }
 
+func (s *Sheet) duplicateSheet(_ any) {
+	entity, err := s.entity.CloneWithNewIDs(nil)
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to duplicate sheet"), err)
+		return
+	}
+	DisplayNewDockable(nil, NewSheet(entity.Profile.Name+model.SheetExt, entity))
+}
+
 func (s *Sheet) canClearPortrait(_ any) bool {
 	return len(s.entity.Profile.PortraitData) != 0
 }
@@ -322,6 +353,50 @@ func (s *Sheet) updatePortrait(data []byte) {
 	s.MarkModified(s)
 }
 
+func (s *Sheet) randomizeProfile(_ any) {
+	var opts model.RandomizeProfileOptions
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	label := unison.NewLabel()
+	label.Text = i18n.Text("Randomize the profile, keeping these fields unchanged:")
+	panel.AddChild(label)
+	addCheckBox(panel, i18n.Text("Name"), &opts.LockName)
+	addCheckBox(panel, i18n.Text("Gender"), &opts.LockGender)
+	addCheckBox(panel, i18n.Text("Height"), &opts.LockHeight)
+	addCheckBox(panel, i18n.Text("Weight"), &opts.LockWeight)
+	addCheckBox(panel, i18n.Text("Appearance (hair, eyes, skin & handedness)"), &opts.LockAppearance)
+	dialog, err := unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon, unison.DefaultDialogTheme.QuestionIconInk,
+		panel, []*unison.DialogButtonInfo{unison.NewCancelButtonInfo(), unison.NewOKButtonInfo()})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create randomize profile dialog"), err)
+		return
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	before := *s.entity.Profile
+	model.RandomizeProfile(s.entity, opts)
+	s.undoMgr.Add(&unison.UndoEdit[model.Profile]{
+		ID:         unison.NextUndoID(),
+		EditName:   i18n.Text("Randomize Profile"),
+		UndoFunc:   func(edit *unison.UndoEdit[model.Profile]) { s.updateProfile(edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[model.Profile]) { s.updateProfile(edit.AfterData) },
+		BeforeData: before,
+		AfterData:  *s.entity.Profile,
+	})
+	s.MarkModified(s)
+}
+
+func (s *Sheet) updateProfile(profile model.Profile) {
+	*s.entity.Profile = profile
+	s.MarkForRedraw()
+	s.MarkModified(s)
+}
+
 func (s *Sheet) keyToPanel(key string) *unison.Panel {
 	var p unison.Paneler
 	switch key {
@@ -347,9 +422,9 @@ func (s *Sheet) installNewItemCmdHandlers(itemID, containerID int, creator itemC
 		variant = AlternateItemVariant
 	} else {
 		s.InstallCmdHandlers(containerID, unison.AlwaysEnabled,
-			func(_ any) { creator.CreateItem(s, ContainerItemVariant) })
+			func(_ any) { creator.CreateItem(s, ContainerItemVariant, AutoInsertMode) })
 	}
-	s.InstallCmdHandlers(itemID, unison.AlwaysEnabled, func(_ any) { creator.CreateItem(s, variant) })
+	s.InstallCmdHandlers(itemID, unison.AlwaysEnabled, func(_ any) { creator.CreateItem(s, variant, AutoInsertMode) })
 }
 
 // DockableKind implements widget.DockableKind
@@ -422,6 +497,7 @@ func (s *Sheet) MarkModified(src unison.Paneler) {
 		if !toolbox.IsNil(src) {
 			_, skipDeepSync = src.AsPanel().ClientData()[SkipDeepSync]
 		}
+		s.entity.RefreshLibraryStatus()
 		if !skipDeepSync {
 			DeepSync(s)
 		}
@@ -432,6 +508,8 @@ func (s *Sheet) MarkModified(src unison.Paneler) {
 		s.targetMgr.ReacquireFocus(focusRefKey, s.toolbar, s.scroll.Content())
 		s.scroll.SetPosition(h, v)
 		UpdateCalculator(s)
+		UpdatePointBudget(s)
+		UpdateSpellCollegeIndex(s)
 	}
 }
 
@@ -478,6 +556,16 @@ func (s *Sheet) save(forceSaveAs bool) bool {
 	return success
 }
 
+func (s *Sheet) recoveryData() (crc uint64, needsSaveAs bool, saver func(filePath string) error) {
+	return s.entity.CRC64(), s.needsSaveAsPrompt, s.entity.Save
+}
+
+func (s *Sheet) restoreFromRecovery(originalPath string, needsSaveAs bool) {
+	s.crc = 0
+	s.needsSaveAsPrompt = needsSaveAs
+	s.SetBackingFilePath(originalPath)
+}
+
 func (s *Sheet) print() {
 	data, err := newPageExporter(s.entity).exportAsPDFBytes()
 	if err != nil {
@@ -558,6 +646,79 @@ func (s *Sheet) exportToJPEG() {
 	}
 }
 
+func (s *Sheet) exportToHTML() {
+	s.Window().ShowCursor()
+	templatePath, ok := promptForHTMLTemplate()
+	if !ok {
+		return
+	}
+	dialog := unison.NewSaveDialog()
+	dialog.SetInitialDirectory(filepath.Dir(s.BackingFilePath()))
+	dialog.SetAllowedExtensions("html")
+	if dialog.RunModal() {
+		if filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), "html", false); ok {
+			model.GlobalSettings().SetLastDir(model.DefaultLastDirKey, filepath.Dir(filePath))
+			if err := model.ExportSheetToHTMLFile(s.entity, templatePath, filePath); err != nil {
+				unison.ErrorDialogWithError(i18n.Text("Unable to export as HTML!"), err)
+			}
+		}
+	}
+}
+
+// promptForHTMLTemplate asks the user whether to use the built-in HTML template or a template file of their own. An
+// empty path means the built-in template should be used.
+func promptForHTMLTemplate() (templatePath string, ok bool) {
+	const chooseResponse = unison.ModalResponseUserBase
+	dialog, err := unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon, unison.DefaultDialogTheme.QuestionIconInk,
+		unison.NewMessagePanel(i18n.Text("Which template should be used for the HTML export?"),
+			i18n.Text("Templates use the Go text/template syntax.")),
+		[]*unison.DialogButtonInfo{
+			unison.NewCancelButtonInfo(),
+			{Title: i18n.Text("Choose Template…"), ResponseCode: chooseResponse},
+			unison.NewOKButtonInfoWithTitle(i18n.Text("Use Built-In")),
+		})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create HTML template dialog"), err)
+		return "", false
+	}
+	switch dialog.RunModal() {
+	case unison.ModalResponseOK:
+		return "", true
+	case chooseResponse:
+		open := unison.NewOpenDialog()
+		open.SetAllowsMultipleSelection(false)
+		open.SetResolvesAliases(true)
+		open.SetAllowedExtensions("html", "htm", "tmpl")
+		open.SetCanChooseDirectories(false)
+		open.SetCanChooseFiles(true)
+		global := model.GlobalSettings()
+		open.SetInitialDirectory(global.LastDir(model.DefaultLastDirKey))
+		if !open.RunModal() {
+			return "", false
+		}
+		templatePath = open.Path()
+		global.SetLastDir(model.DefaultLastDirKey, filepath.Dir(templatePath))
+		return templatePath, true
+	default:
+		return "", false
+	}
+}
+
+func (s *Sheet) exportToMarkdown() {
+	s.Window().ShowCursor()
+	dialog := unison.NewSaveDialog()
+	dialog.SetInitialDirectory(filepath.Dir(s.BackingFilePath()))
+	dialog.SetAllowedExtensions("md")
+	if dialog.RunModal() {
+		if filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), "md", false); ok {
+			model.GlobalSettings().SetLastDir(model.DefaultLastDirKey, filepath.Dir(filePath))
+			if err := model.ExportSheetToMarkdownFile(s.entity, filePath); err != nil {
+				unison.ErrorDialogWithError(i18n.Text("Unable to export as Markdown!"), err)
+			}
+		}
+	}
+}
+
 func (s *Sheet) createLists() {
 	children := s.content.Children()
 	if len(children) == 0 {
@@ -667,6 +828,19 @@ func (s *Sheet) createLists() {
 	page.ApplyPreferredSize()
 }
 
+func (s *Sheet) clearTableSelections() {
+	s.Reactions.Table.ClearSelection()
+	s.ConditionalModifiers.Table.ClearSelection()
+	s.MeleeWeapons.Table.ClearSelection()
+	s.RangedWeapons.Table.ClearSelection()
+	s.Traits.Table.ClearSelection()
+	s.Skills.Table.ClearSelection()
+	s.Spells.Table.ClearSelection()
+	s.CarriedEquipment.Table.ClearSelection()
+	s.OtherEquipment.Table.ClearSelection()
+	s.Notes.Table.ClearSelection()
+}
+
 func (s *Sheet) canSwapDefaults(_ any) bool {
 	canSwap := false
 	for _, skillNode := range s.Skills.SelectedNodes(true) {
@@ -722,7 +896,11 @@ func (s *Sheet) SheetSettingsUpdated(entity *model.Entity, blockLayout bool) {
 func (s *Sheet) Rebuild(full bool) {
 	h, v := s.scroll.Position()
 	focusRefKey := s.targetMgr.CurrentFocusRef()
+	// Sorting here, rather than when items are created or edited, catches every path that can alter a name or add an
+	// item. While enabled, this also overrides any manual drag reordering.
+	s.entity.ApplyAutoSort()
 	s.entity.Recalculate()
+	s.entity.RefreshLibraryStatus()
 	if full {
 		reactionsSelMap := s.Reactions.RecordSelection()
 		conditionalModifiersSelMap := s.ConditionalModifiers.RecordSelection()
@@ -755,6 +933,8 @@ func (s *Sheet) Rebuild(full bool) {
 	s.targetMgr.ReacquireFocus(focusRefKey, s.toolbar, s.scroll.Content())
 	s.scroll.SetPosition(h, v)
 	UpdateCalculator(s)
+	UpdatePointBudget(s)
+	UpdateSpellCollegeIndex(s)
 }
 
 func drawBandedBackground(p unison.Paneler, gc *unison.Canvas, rect unison.Rect, start, step int) {
@@ -35,10 +35,13 @@ type sheetSettingsDockable struct {
 	showTraitModifier                  *unison.CheckBox
 	showEquipmentModifier              *unison.CheckBox
 	showSpellAdjustments               *unison.CheckBox
+	showLevelDerivation                *unison.CheckBox
 	showTitleInsteadOfNameInPageFooter *unison.CheckBox
 	useMultiplicativeModifiers         *unison.CheckBox
 	useModifyDicePlusAdds              *unison.CheckBox
 	excludeUnspentPointsFromTotal      *unison.CheckBox
+	autoSortSkills                     *unison.CheckBox
+	autoSortSpells                     *unison.CheckBox
 	useHalfStatDefaults                *unison.CheckBox
 	lengthUnitsPopup                   *unison.PopupMenu[model.LengthUnits]
 	weightUnitsPopup                   *unison.PopupMenu[model.WeightUnits]
@@ -81,8 +84,7 @@ func ShowSheetSettings(owner EntityPanel) {
 }
 
 func (d *sheetSettingsDockable) addToStartToolbar(toolbar *unison.Panel) {
-	helpButton := unison.NewSVGButton(svg.Help)
-	helpButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Help"))
+	helpButton := NewSVGButtonWithTooltip(svg.Help, i18n.Text("Help"))
 	helpButton.ClickCallback = func() { HandleLink(nil, "md:Help/Interface/Sheet Settings") }
 	toolbar.AddChild(helpButton)
 }
@@ -152,6 +154,11 @@ func (d *sheetSettingsDockable) createOptions(content *unison.Panel) {
 			d.settings().ShowSpellAdj = d.showSpellAdjustments.State == unison.OnCheckState
 			d.syncSheet(false)
 		})
+	d.showLevelDerivation = d.addCheckBox(panel, i18n.Text("Show how spell levels are derived"),
+		s.ShowLevelDerivation, func() {
+			d.settings().ShowLevelDerivation = d.showLevelDerivation.State == unison.OnCheckState
+			d.syncSheet(false)
+		})
 	d.showTitleInsteadOfNameInPageFooter = d.addCheckBox(panel,
 		i18n.Text("Show the title instead of the name in the footer"), s.UseTitleInFooter, func() {
 			d.settings().UseTitleInFooter = d.showTitleInsteadOfNameInPageFooter.State == unison.OnCheckState
@@ -177,6 +184,14 @@ func (d *sheetSettingsDockable) createOptions(content *unison.Panel) {
 			d.settings().ExcludeUnspentPointsFromTotal = d.excludeUnspentPointsFromTotal.State == unison.OnCheckState
 			d.syncSheet(false)
 		})
+	d.autoSortSkills = d.addCheckBox(panel, i18n.Text("Keep skills sorted by name"), s.AutoSortSkills, func() {
+		d.settings().AutoSortSkills = d.autoSortSkills.State == unison.OnCheckState
+		d.syncSheet(false)
+	})
+	d.autoSortSpells = d.addCheckBox(panel, i18n.Text("Keep spells sorted by name"), s.AutoSortSpells, func() {
+		d.settings().AutoSortSpells = d.autoSortSpells.State == unison.OnCheckState
+		d.syncSheet(false)
+	})
 	content.AddChild(panel)
 }
 
@@ -388,11 +403,14 @@ func (d *sheetSettingsDockable) sync() {
 	d.showTraitModifier.State = unison.CheckStateFromBool(s.ShowTraitModifierAdj)
 	d.showEquipmentModifier.State = unison.CheckStateFromBool(s.ShowEquipmentModifierAdj)
 	d.showSpellAdjustments.State = unison.CheckStateFromBool(s.ShowSpellAdj)
+	d.showLevelDerivation.State = unison.CheckStateFromBool(s.ShowLevelDerivation)
 	d.showTitleInsteadOfNameInPageFooter.State = unison.CheckStateFromBool(s.UseTitleInFooter)
 	d.useMultiplicativeModifiers.State = unison.CheckStateFromBool(s.UseMultiplicativeModifiers)
 	d.useHalfStatDefaults.State = unison.CheckStateFromBool(s.UseHalfStatDefaults)
 	d.useModifyDicePlusAdds.State = unison.CheckStateFromBool(s.UseModifyingDicePlusAdds)
 	d.excludeUnspentPointsFromTotal.State = unison.CheckStateFromBool(s.ExcludeUnspentPointsFromTotal)
+	d.autoSortSkills.State = unison.CheckStateFromBool(s.AutoSortSkills)
+	d.autoSortSpells.State = unison.CheckStateFromBool(s.AutoSortSpells)
 	d.lengthUnitsPopup.Select(s.DefaultLengthUnits)
 	d.weightUnitsPopup.Select(s.DefaultWeightUnits)
 	d.userDescDisplayPopup.Select(s.UserDescriptionDisplay)
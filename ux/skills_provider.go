@@ -27,6 +27,7 @@ var _ TableProvider[*model.Skill] = &skillsProvider{}
 
 type skillsProvider struct {
 	table    *unison.Table[*Node[*model.Skill]]
+	nodes    NodeCache[*model.Skill]
 	provider model.SkillListProvider
 	forPage  bool
 }
@@ -43,6 +44,10 @@ func (p *skillsProvider) RefKey() string {
 	return model.BlockLayoutSkillsKey
 }
 
+func (p *skillsProvider) Count(predicate func(*model.Skill) bool) int {
+	return model.Count(predicate, p.RootData()...)
+}
+
 func (p *skillsProvider) AllTags() []string {
 	set := make(map[string]struct{})
 	model.Traverse(func(modifier *model.Skill) bool {
@@ -65,12 +70,7 @@ func (p *skillsProvider) RootRowCount() int {
 }
 
 func (p *skillsProvider) RootRows() []*Node[*model.Skill] {
-	data := p.provider.SkillList()
-	rows := make([]*Node[*model.Skill], 0, len(data))
-	for _, one := range data {
-		rows = append(rows, NewNode[*model.Skill](p.table, nil, one, p.forPage))
-	}
-	return rows
+	return p.nodes.Rows(p.table, nil, p.provider.SkillList(), p.forPage)
 }
 
 func (p *skillsProvider) SetRootRows(rows []*Node[*model.Skill]) {
@@ -186,7 +186,7 @@ func (p *skillsProvider) OpenEditor(owner Rebuildable, table *unison.Table[*Node
 	OpenEditor[*model.Skill](table, func(item *model.Skill) { EditSkill(owner, item) })
 }
 
-func (p *skillsProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*model.Skill]], variant ItemVariant) {
+func (p *skillsProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*model.Skill]], variant ItemVariant, mode InsertMode) {
 	var item *model.Skill
 	switch variant {
 	case NoItemVariant:
@@ -198,7 +198,7 @@ func (p *skillsProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node
 	default:
 		jot.Fatal(1, "unhandled variant")
 	}
-	InsertItems[*model.Skill](owner, table, p.provider.SkillList, p.provider.SetSkillList,
+	InsertItems[*model.Skill](owner, table, mode, p.provider.SkillList, p.provider.SetSkillList,
 		func(_ *unison.Table[*Node[*model.Skill]]) []*Node[*model.Skill] { return p.RootRows() }, item)
 	EditSkill(owner, item)
 }
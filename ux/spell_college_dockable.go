@@ -0,0 +1,188 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+var _ GroupedCloser = &spellCollegeDockable{}
+
+type spellCollegeDockable struct {
+	unison.Panel
+	owner  unison.Dockable
+	spells func() []*model.Spell
+	reveal func(spell *model.Spell)
+	scroll *unison.ScrollPanel
+}
+
+// ShowSpellCollegeIndex displays the spells grouped by college in a read-only dockable. Clicking on a spell brings the
+// owner to the front and calls reveal with the spell.
+func ShowSpellCollegeIndex(owner unison.Dockable, spells func() []*model.Spell, reveal func(spell *model.Spell)) {
+	ws, dc, found := Activate(func(d unison.Dockable) bool {
+		if c, ok := d.(*spellCollegeDockable); ok {
+			return c.owner == owner
+		}
+		return false
+	})
+	if !found && ws != nil {
+		d := &spellCollegeDockable{
+			owner:  owner,
+			spells: spells,
+			reveal: reveal,
+		}
+		d.Self = d
+		d.SetLayout(&unison.FlexLayout{Columns: 1})
+		d.scroll = unison.NewScrollPanel()
+		d.scroll.SetLayoutData(&unison.FlexLayoutData{
+			HAlign: unison.FillAlignment,
+			VAlign: unison.FillAlignment,
+			HGrab:  true,
+			VGrab:  true,
+		})
+		d.AddChild(d.scroll)
+		d.update()
+		if provider, ok := owner.(interface{ Entity() *model.Entity }); ok {
+			if entity := provider.Entity(); entity != nil {
+				d.ClientData()[AssociatedUUIDKey] = entity.ID
+			}
+		}
+		PlaceInDock(ws, dc, d, EditorGroup)
+	}
+}
+
+// UpdateSpellCollegeIndex for the given owner.
+func UpdateSpellCollegeIndex(owner unison.Dockable) {
+	for _, wnd := range unison.Windows() {
+		if ws := WorkspaceFromWindow(wnd); ws != nil {
+			ws.DocumentDock.RootDockLayout().ForEachDockContainer(func(dc *unison.DockContainer) bool {
+				for _, other := range dc.Dockables() {
+					if d, ok := other.(*spellCollegeDockable); ok && d.owner == owner {
+						d.update()
+						return true
+					}
+				}
+				return false
+			})
+		}
+	}
+}
+
+func (d *spellCollegeDockable) update() {
+	h, v := d.scroll.Position()
+	d.scroll.SetContent(d.createContent(model.SpellsByCollege(d.spells())), unison.FillBehavior,
+		unison.FillBehavior)
+	d.scroll.SetPosition(h, v)
+	d.MarkForLayoutAndRedraw()
+}
+
+func (d *spellCollegeDockable) createContent(groups []*model.SpellCollegeGroup) *unison.Panel {
+	content := unison.NewPanel()
+	content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	content.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	if len(groups) == 0 {
+		label := unison.NewLabel()
+		label.Text = i18n.Text("There are no spells.")
+		content.AddChild(label)
+		return content
+	}
+	for _, group := range groups {
+		header := unison.NewLabel()
+		college := group.College
+		if college == "" {
+			college = i18n.Text("No College")
+		}
+		header.Text = fmt.Sprintf(i18n.Text("%s (%d)"), college, len(group.Spells))
+		header.Font = unison.SystemFont
+		content.AddChild(header)
+		for _, spell := range group.Spells {
+			spell := spell
+			link := unison.NewLink(spell.String(), "", "", unison.DefaultLinkTheme, func(_ unison.Paneler, _ string) {
+				if !d.contains(spell) {
+					d.update()
+					return
+				}
+				if dc := unison.Ancestor[*unison.DockContainer](d.owner); dc != nil {
+					dc.SetCurrentDockable(d.owner)
+					dc.AcquireFocus()
+					d.reveal(spell)
+				}
+			})
+			link.SetBorder(unison.NewEmptyBorder(unison.Insets{Left: unison.StdHSpacing * 2}))
+			content.AddChild(link)
+		}
+	}
+	return content
+}
+
+// contains returns true if the spell is still present in the owner's spells.
+func (d *spellCollegeDockable) contains(spell *model.Spell) bool {
+	found := false
+	model.Traverse(func(one *model.Spell) bool {
+		found = one == spell
+		return found
+	}, false, false, d.spells()...)
+	return found
+}
+
+// TitleIcon implements unison.Dockable
+func (d *spellCollegeDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.GCSSpells,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (d *spellCollegeDockable) Title() string {
+	return fmt.Sprintf(i18n.Text("Colleges in %s"), d.owner.Title())
+}
+
+// Tooltip implements unison.Dockable
+func (d *spellCollegeDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (d *spellCollegeDockable) Modified() bool {
+	return false
+}
+
+// CloseWithGroup implements GroupedCloser
+func (d *spellCollegeDockable) CloseWithGroup(other unison.Paneler) bool {
+	return d.owner != nil && d.owner == other
+}
+
+// MayAttemptClose implements GroupedCloser
+func (d *spellCollegeDockable) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(d)
+}
+
+// AttemptClose implements GroupedCloser
+func (d *spellCollegeDockable) AttemptClose() bool {
+	if !CloseGroup(d) {
+		return false
+	}
+	if dc := unison.Ancestor[*unison.DockContainer](d); dc != nil {
+		dc.Close(d)
+	}
+	return true
+}
@@ -12,6 +12,7 @@
 package ux
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model"
@@ -32,8 +33,14 @@ func initSpellEditor(e *editor[*model.Spell, *model.SpellEditData], content *uni
 	_, ownerIsSheet := owner.(*Sheet)
 	_, ownerIsTemplate := owner.(*Template)
 	isRitualMagic := strings.HasPrefix(e.target.Type, model.RitualMagicSpellID)
-	addNameLabelAndField(content, &e.editorData.Name)
+	var prereqs *prereqPanel
+	nameField := addLabelAndStringField(content, i18n.Text("Name"), "", &e.editorData.Name)
+	if e.target.Heading() {
+		addNotesLabelAndField(content, &e.editorData.LocalNotes)
+		return nil
+	}
 	if !e.target.Container() {
+		installSpellNameCompletion(e, content, nameField, func() { prereqs.rebuild() })
 		addTechLevelRequired(content, &e.editorData.TechLevel, ownerIsSheet)
 		addLabelAndListField(content, i18n.Text("College"), i18n.Text("colleges"), (*[]string)(&e.editorData.College))
 		addLabelAndStringField(content, i18n.Text("Class"), "", &e.editorData.Class)
@@ -82,9 +89,17 @@ func initSpellEditor(e *editor[*model.Spell, *model.SpellEditData], content *uni
 		}
 		addLabelAndStringField(content, i18n.Text("Resistance"), "", &e.editorData.Resist)
 		addLabelAndStringField(content, i18n.Text("Casting Cost"), "", &e.editorData.CastingCost)
+		addLabelAndStringField(content, i18n.Text("Casting Cost Override"),
+			i18n.Text("When set, this value is used in place of the casting cost"), &e.editorData.CostOverride)
 		addLabelAndStringField(content, i18n.Text("Maintenance Cost"), "", &e.editorData.MaintenanceCost)
 		addLabelAndStringField(content, i18n.Text("Casting Time"), "", &e.editorData.CastingTime)
 		addLabelAndStringField(content, i18n.Text("Casting Duration"), "", &e.editorData.Duration)
+		rateLabel := i18n.Text("Maintenance Rate")
+		content.AddChild(NewFieldLeadingLabel(rateLabel))
+		content.AddChild(NewNonEditableField(func(field *NonEditableField) {
+			field.Text = model.MaintenanceRateText(e.editorData.MaintenanceCost, e.editorData.Duration)
+			field.MarkForLayoutAndRedraw()
+		}))
 	}
 	addNotesLabelAndField(content, &e.editorData.LocalNotes)
 	addVTTNotesLabelAndField(content, &e.editorData.VTTNotes)
@@ -94,7 +109,8 @@ func initSpellEditor(e *editor[*model.Spell, *model.SpellEditData], content *uni
 	}
 	addPageRefLabelAndField(content, &e.editorData.PageRef)
 	if !e.target.Container() {
-		content.AddChild(newPrereqPanel(e.target.Entity, &e.editorData.Prereq))
+		prereqs = newPrereqPanel(e.target.Entity, e.target, &e.editorData.Prereq)
+		content.AddChild(prereqs)
 		for _, wt := range model.AllWeaponType {
 			content.AddChild(newWeaponsPanel(e, e.target, wt, &e.editorData.Weapons))
 		}
@@ -102,3 +118,57 @@ func initSpellEditor(e *editor[*model.Spell, *model.SpellEditData], content *uni
 	}
 	return nil
 }
+
+func installSpellNameCompletion(e *editor[*model.Spell, *model.SpellEditData], content *unison.Panel,
+	field *StringField, filled func()) {
+	installTextCompletion(field.Field, func() []string { return model.GlobalSpellLibraryIndex().Names() })
+	spacer := unison.NewPanel()
+	suggestion := unison.NewPanel()
+	suggestion.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+	})
+	label := unison.NewLabel()
+	label.SetLayoutData(&unison.FlexLayoutData{VAlign: unison.MiddleAlignment})
+	suggestion.AddChild(label)
+	button := unison.NewButton()
+	button.Text = i18n.Text("Fill In")
+	button.SetLayoutData(&unison.FlexLayoutData{VAlign: unison.MiddleAlignment})
+	suggestion.AddChild(button)
+	var offered *model.Spell
+	hide := func() {
+		if offered != nil {
+			offered = nil
+			spacer.RemoveFromParent()
+			suggestion.RemoveFromParent()
+			content.MarkForLayoutAndRedraw()
+		}
+	}
+	button.ClickCallback = func() {
+		if offered != nil {
+			e.editorData.FillFromLibrarySpell(offered)
+			hide()
+			filled()
+			e.MarkModified(nil)
+		}
+	}
+	offeredFor := strings.ToLower(strings.TrimSpace(e.editorData.Name))
+	lostFocus := field.LostFocusCallback
+	field.LostFocusCallback = func() {
+		lostFocus()
+		name := strings.ToLower(strings.TrimSpace(e.editorData.Name))
+		if name == offeredFor {
+			return
+		}
+		offeredFor = name
+		hide()
+		if spell := model.GlobalSpellLibraryIndex().Lookup(name); spell != nil {
+			offered = spell
+			label.Text = fmt.Sprintf(i18n.Text("Library data is available for %s"), spell.Name)
+			i := content.IndexOfChild(field) + 1
+			content.AddChildAtIndex(spacer, i)
+			content.AddChildAtIndex(suggestion, i+1)
+			content.MarkForLayoutAndRedraw()
+		}
+	}
+}
@@ -49,7 +49,15 @@ func NewSpellTableDockableFromFile(filePath string) (unison.Dockable, error) {
 // NewSpellTableDockable creates a new unison.Dockable for spell list files.
 func NewSpellTableDockable(filePath string, spells []*model.Spell) *TableDockable[*model.Spell] {
 	provider := &spellListProvider{spells: spells}
-	return NewTableDockable(filePath, model.SpellsExt, NewSpellsProvider(provider, false),
+	d := NewTableDockable(filePath, model.SpellsExt, NewSpellsProvider(provider, false),
 		func(path string) error { return model.SaveSpells(provider.SpellList(), path) },
-		NewSpellItemID, NewSpellContainerItemID, NewRitualMagicSpellItemID)
+		NewSpellItemID, NewSpellContainerItemID, NewRitualMagicSpellItemID, NewSpellHeadingItemID)
+	InstallInsertModeCmdHandlers(d.AsPanel(), d, d.table, d.provider, NewSpellInsideContainerItemID,
+		NewSpellAfterSelectionItemID)
+	InstallCreateMultipleCmdHandler(d.AsPanel(), d, d.table, d.provider, NewMultipleSpellsItemID)
+	InstallMergeLibraryCmdHandler(d, model.NewSpellsFromFile, model.SpellMergeKey)
+	d.InstallCmdHandlers(ShowCollegeIndexItemID, unison.AlwaysEnabled, func(_ any) {
+		ShowSpellCollegeIndex(d, provider.SpellList, func(spell *model.Spell) { revealTableRow(d.table, spell) })
+	})
+	return d
 }
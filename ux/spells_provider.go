@@ -13,6 +13,7 @@ package ux
 
 import (
 	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/jio"
 	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/toolbox"
@@ -23,10 +24,15 @@ import (
 	"golang.org/x/exp/maps"
 )
 
-var _ TableProvider[*model.Spell] = &spellsProvider{}
+var (
+	_ TableProvider[*model.Spell]       = &spellsProvider{}
+	_ MultipleItemCreator[*model.Spell] = &spellsProvider{}
+)
 
 type spellsProvider struct {
+	tagCache
 	table    *unison.Table[*Node[*model.Spell]]
+	nodes    NodeCache[*model.Spell]
 	provider model.SpellListProvider
 	forPage  bool
 }
@@ -43,7 +49,15 @@ func (p *spellsProvider) RefKey() string {
 	return model.BlockLayoutSpellsKey
 }
 
+func (p *spellsProvider) Count(predicate func(*model.Spell) bool) int {
+	return model.Count(predicate, p.RootData()...)
+}
+
 func (p *spellsProvider) AllTags() []string {
+	return p.tagCache.get(p.computeAllTags)
+}
+
+func (p *spellsProvider) computeAllTags() []string {
 	set := make(map[string]struct{})
 	model.Traverse(func(modifier *model.Spell) bool {
 		for _, tag := range modifier.Tags {
@@ -65,12 +79,7 @@ func (p *spellsProvider) RootRowCount() int {
 }
 
 func (p *spellsProvider) RootRows() []*Node[*model.Spell] {
-	data := p.provider.SpellList()
-	rows := make([]*Node[*model.Spell], 0, len(data))
-	for _, one := range data {
-		rows = append(rows, NewNode[*model.Spell](p.table, nil, one, p.forPage))
-	}
-	return rows
+	return p.nodes.Rows(p.table, nil, p.provider.SpellList(), p.forPage)
 }
 
 func (p *spellsProvider) SetRootRows(rows []*Node[*model.Spell]) {
@@ -132,6 +141,8 @@ func (p *spellsProvider) Headers() []unison.TableColumnHeader[*Node[*model.Spell
 	headers := make([]unison.TableColumnHeader[*Node[*model.Spell]], 0, len(ids))
 	for _, id := range ids {
 		switch id {
+		case model.SpellPreparedColumn:
+			headers = append(headers, NewPreparedHeader[*model.Spell](p.forPage))
 		case model.SpellDescriptionColumn, model.SpellDescriptionForPageColumn:
 			headers = append(headers, NewEditorListHeader[*model.Spell](i18n.Text("Spell"), "", p.forPage))
 		case model.SpellResistColumn:
@@ -146,6 +157,9 @@ func (p *spellsProvider) Headers() []unison.TableColumnHeader[*Node[*model.Spell
 		case model.SpellMaintainCostColumn:
 			headers = append(headers, NewEditorListHeader[*model.Spell](i18n.Text("Maintain"), i18n.Text("The mana cost to maintain the spell"),
 				p.forPage))
+		case model.SpellMaintainRateColumn:
+			headers = append(headers, NewEditorListHeader[*model.Spell](i18n.Text("Maintain/Turn"),
+				i18n.Text("The mana cost to maintain the spell for one turn"), p.forPage))
 		case model.SpellCastTimeColumn:
 			headers = append(headers, NewEditorListHeader[*model.Spell](i18n.Text("Time"), i18n.Text("The time required to cast the spell"),
 				p.forPage))
@@ -172,10 +186,11 @@ func (p *spellsProvider) SyncHeader(_ []unison.TableColumnHeader[*Node[*model.Sp
 }
 
 func (p *spellsProvider) ColumnIDs() []int {
-	columnIDs := make([]int, 0, 11)
+	columnIDs := make([]int, 0, 12)
 	if p.forPage {
 		if _, ok := p.provider.(*model.Entity); ok {
 			columnIDs = append(columnIDs,
+				model.SpellPreparedColumn,
 				model.SpellDescriptionForPageColumn,
 				model.SpellLevelColumn,
 				model.SpellRelativeLevelColumn,
@@ -196,6 +211,11 @@ func (p *spellsProvider) ColumnIDs() []int {
 			model.SpellClassColumn,
 			model.SpellCastCostColumn,
 			model.SpellMaintainCostColumn,
+		)
+		if model.GlobalSettings().General.ShowMaintenanceRate {
+			columnIDs = append(columnIDs, model.SpellMaintainRateColumn)
+		}
+		columnIDs = append(columnIDs,
 			model.SpellCastTimeColumn,
 			model.SpellDurationColumn,
 			model.SpellDifficultyColumn,
@@ -205,6 +225,29 @@ func (p *spellsProvider) ColumnIDs() []int {
 	return append(columnIDs, model.SpellReferenceColumn)
 }
 
+func (p *spellsProvider) AggregateColumns() map[int]model.Aggregation {
+	return map[int]model.Aggregation{model.SpellPointsColumn: model.SumAggregation}
+}
+
+func (p *spellsProvider) Summary(rows []*model.Spell) string {
+	if summary := model.SpellCollegeSummary(rows); summary != "" {
+		return i18n.Text("Colleges: ") + summary
+	}
+	return ""
+}
+
+func (p *spellsProvider) InlineEditable(data *model.Spell, columnID int) bool {
+	return p.forPage && columnID == model.SpellPointsColumn && !data.Container() && !data.Heading()
+}
+
+func (p *spellsProvider) InlineValue(data *model.Spell, _ int) fxp.Int {
+	return data.RawPoints()
+}
+
+func (p *spellsProvider) SetInlineValue(data *model.Spell, _ int, value fxp.Int) {
+	data.SetRawPoints(value.Max(0))
+}
+
 func (p *spellsProvider) HierarchyColumnID() int {
 	if p.forPage {
 		return model.SpellDescriptionForPageColumn
@@ -220,21 +263,32 @@ func (p *spellsProvider) OpenEditor(owner Rebuildable, table *unison.Table[*Node
 	OpenEditor[*model.Spell](table, func(item *model.Spell) { EditSpell(owner, item) })
 }
 
-func (p *spellsProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*model.Spell]], variant ItemVariant) {
-	var item *model.Spell
-	switch variant {
-	case NoItemVariant:
-		item = model.NewSpell(p.Entity(), nil, false)
-	case ContainerItemVariant:
-		item = model.NewSpell(p.Entity(), nil, true)
-	case AlternateItemVariant:
-		item = model.NewRitualMagicSpell(p.Entity(), nil, false)
-	default:
-		jot.Fatal(1, "unhandled variant")
+func (p *spellsProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*model.Spell]], variant ItemVariant, mode InsertMode) {
+	p.CreateItems(owner, table, variant, mode, 1)
+}
+
+func (p *spellsProvider) CreateItems(owner Rebuildable, table *unison.Table[*Node[*model.Spell]], variant ItemVariant, mode InsertMode, count int) {
+	if count < 1 {
+		count = 1
+	}
+	items := make([]*model.Spell, 0, count)
+	for i := 0; i < count; i++ {
+		switch variant {
+		case NoItemVariant:
+			items = append(items, model.NewSpell(p.Entity(), nil, false))
+		case ContainerItemVariant:
+			items = append(items, model.NewSpell(p.Entity(), nil, true))
+		case AlternateItemVariant:
+			items = append(items, model.NewRitualMagicSpell(p.Entity(), nil, false))
+		case HeadingItemVariant:
+			items = append(items, model.NewSpellHeading(p.Entity(), nil, false))
+		default:
+			jot.Fatal(1, "unhandled variant")
+		}
 	}
-	InsertItems[*model.Spell](owner, table, p.provider.SpellList, p.provider.SetSpellList,
-		func(_ *unison.Table[*Node[*model.Spell]]) []*Node[*model.Spell] { return p.RootRows() }, item)
-	EditSpell(owner, item)
+	InsertItems[*model.Spell](owner, table, mode, p.provider.SpellList, p.provider.SetSpellList,
+		func(_ *unison.Table[*Node[*model.Spell]]) []*Node[*model.Spell] { return p.RootRows() }, items...)
+	EditSpell(owner, items[0])
 }
 
 func (p *spellsProvider) Serialize() ([]byte, error) {
@@ -255,7 +309,13 @@ func (p *spellsProvider) ContextMenuItems() []ContextMenuItem {
 	list = append(list,
 		ContextMenuItem{i18n.Text("New Spell"), NewSpellItemID},
 		ContextMenuItem{i18n.Text("New Spell Container"), NewSpellContainerItemID},
+		ContextMenuItem{i18n.Text("New Spell Inside Selected Container"), NewSpellInsideContainerItemID},
+		ContextMenuItem{i18n.Text("New Spell After Selection"), NewSpellAfterSelectionItemID},
+		ContextMenuItem{i18n.Text("New Multiple Spells…"), NewMultipleSpellsItemID},
 		ContextMenuItem{i18n.Text("New Ritual Magic Spell"), NewRitualMagicSpellItemID},
+		ContextMenuItem{i18n.Text("New Spell Heading"), NewSpellHeadingItemID},
+		ContextMenuItem{"", -1},
+		ContextMenuItem{i18n.Text("Show Only Prepared Spells"), ShowOnlyPreparedSpellsItemID},
 	)
 	return AppendDefaultContextMenuItems(list)
 }
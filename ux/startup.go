@@ -33,6 +33,7 @@ func Start(files []string) {
 	unison.Start(
 		unison.StartupFinishedCallback(func() {
 			performPlatformStartup()
+			appliedAccessibilityMode = accessibilityModeEnabled()
 			unison.DefaultMarkdownTheme.LinkHandler = HandleLink
 			if appIcon, err := unison.NewImageFromBytes(appIconBytes, 0.5); err != nil {
 				jot.Error(err)
@@ -44,7 +45,11 @@ func Start(files []string) {
 			jot.FatalIfErr(err)
 			SetupMenuBar(wnd)
 			NewWorkspace(wnd)
+			startAutosave()
 			OpenFiles(files)
+			if len(files) == 0 && model.GlobalSettings().General.ShowRecentOnStartup {
+				ShowRecentFiles()
+			}
 			go func() {
 				for paths := range pathsChan {
 					unison.InvokeTask(func() { OpenFiles(paths) })
@@ -61,6 +66,7 @@ func Start(files []string) {
 			}
 			return true
 		}),
+		unison.QuittingCallback(stopAutosave),
 	) // Never returns
 }
 
@@ -20,7 +20,6 @@ import (
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/txt"
-	"github.com/richardwilkes/toolbox/xmath"
 	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/unison"
 	"golang.org/x/exp/slices"
@@ -36,6 +35,20 @@ const (
 	NoItemVariant ItemVariant = iota
 	ContainerItemVariant
 	AlternateItemVariant
+	HeadingItemVariant
+)
+
+// InsertMode determines where new items are placed relative to the current selection.
+type InsertMode int
+
+// Possible values for InsertMode.
+const (
+	// AutoInsertMode places new items inside a selected container, otherwise after the selected item.
+	AutoInsertMode InsertMode = iota
+	// SiblingInsertMode places new items after the selected item, even when it is a container.
+	SiblingInsertMode
+	// ChildInsertMode places new items inside the selected container.
+	ChildInsertMode
 )
 
 // TableProvider defines the methods a table provider must contain.
@@ -58,11 +71,64 @@ type TableProvider[T model.NodeTypes] interface {
 	ExcessWidthColumnID() int
 	ContextMenuItems() []ContextMenuItem
 	OpenEditor(owner Rebuildable, table *unison.Table[*Node[T]])
-	CreateItem(owner Rebuildable, table *unison.Table[*Node[T]], variant ItemVariant)
+	CreateItem(owner Rebuildable, table *unison.Table[*Node[T]], variant ItemVariant, mode InsertMode)
 	Serialize() ([]byte, error)
 	Deserialize(data []byte) error
 	RefKey() string
 	AllTags() []string
+	Count(predicate func(T) bool) int
+}
+
+// ItemCountText returns the count followed by the provider's item name in the form appropriate for that count.
+func ItemCountText[T model.NodeTypes](provider TableProvider[T], count int) string {
+	singular, plural := provider.ItemNames()
+	return model.CountWithNoun(count, singular, plural)
+}
+
+// TagCacheInvalidator may be implemented by a TableProvider that caches the result of AllTags().
+type TagCacheInvalidator interface {
+	// InvalidateTagCache discards the cached tags, forcing the next call to AllTags() to recompute them.
+	InvalidateTagCache()
+}
+
+// InvalidateTagCache discards the cached tags of the provider, if it has any.
+func InvalidateTagCache(provider any) {
+	if invalidator, ok := provider.(TagCacheInvalidator); ok {
+		invalidator.InvalidateTagCache()
+	}
+}
+
+// tagCache holds the tags computed for a provider's data until they are invalidated.
+type tagCache struct {
+	tags  []string
+	valid bool
+}
+
+func (c *tagCache) get(compute func() []string) []string {
+	if !c.valid {
+		c.tags = compute()
+		c.valid = true
+	}
+	return slices.Clone(c.tags)
+}
+
+// InvalidateTagCache implements TagCacheInvalidator.
+func (c *tagCache) InvalidateTagCache() {
+	c.valid = false
+	c.tags = nil
+}
+
+// TableAggregator may be implemented by a TableProvider that wants a footer row showing aggregated values for some of
+// its columns.
+type TableAggregator interface {
+	// AggregateColumns returns the aggregation to use, keyed by column ID.
+	AggregateColumns() map[int]model.Aggregation
+}
+
+// TableSummarizer may be implemented by a TableProvider that wants a footer line summarizing the rows of its table.
+type TableSummarizer[T model.NodeTypes] interface {
+	// Summary returns the summary for the rows currently shown by the table.
+	Summary(rows []T) string
 }
 
 // NewNodeTable creates a new node table of the specified type, returning the header and table. Pass nil for 'font' if
@@ -86,18 +152,8 @@ func NewNodeTable[T model.NodeTypes](provider TableProvider[T], font unison.Font
 	}
 	table.SetLayoutData(layoutData)
 
-	ids := provider.ColumnIDs()
 	headers := provider.Headers()
-	table.Columns = make([]unison.ColumnInfo, len(headers))
-	for i := range table.Columns {
-		_, pref, _ := headers[i].AsPanel().Sizes(unison.Size{})
-		pref.Width += table.Padding.Left + table.Padding.Right
-		table.Columns[i].ID = ids[i]
-		table.Columns[i].AutoMinimum = pref.Width
-		table.Columns[i].AutoMaximum = xmath.Max(float32(model.GlobalSettings().General.MaximumAutoColWidth), pref.Width)
-		table.Columns[i].Minimum = pref.Width
-		table.Columns[i].Maximum = 10000
-	}
+	table.Columns = defaultTableColumns(provider, table, headers)
 	header = unison.NewTableHeader(table, headers...)
 	header.Less = flexibleLess
 	header.BackgroundInk = model.HeaderColor
@@ -125,6 +181,9 @@ func NewNodeTable[T model.NodeTypes](provider TableProvider[T], font unison.Font
 	}
 
 	table.MouseDownCallback = func(where unison.Point, button, clickCount int, mod unison.Modifiers) bool {
+		if button == unison.ButtonLeft && clickCount == 2 && startInlineEdit(table, provider, where) {
+			return true
+		}
 		stop := table.DefaultMouseDown(where, button, clickCount, mod)
 		if button == unison.ButtonRight && clickCount == 1 && !table.Window().InDrag() {
 			f := unison.DefaultMenuFactory()
@@ -137,6 +196,10 @@ func NewNodeTable[T model.NodeTypes](provider TableProvider[T], font unison.Font
 					InsertCmdContextMenuItem(table, one.Title, one.ID, &id, cm)
 				}
 			}
+			if cm.Count() > 0 {
+				cm.InsertSeparator(-1, true)
+			}
+			InsertCmdContextMenuItem(table, i18n.Text("Reset Column Layout"), ResetColumnLayoutItemID, &id, cm)
 			count := cm.Count()
 			if count > 0 {
 				count--
@@ -157,10 +220,22 @@ func NewNodeTable[T model.NodeTypes](provider TableProvider[T], font unison.Font
 		return stop
 	}
 
+	table.InstallCmdHandlers(SelectNextUnmetPrereqItemID, func(_ any) bool { return canSelectNextUnmetPrereq(table) },
+		func(_ any) { selectNextUnmetPrereq(table) })
+	table.InstallCmdHandlers(AddTagToSelectionItemID, func(_ any) bool { return canAddTagToSelection(table) },
+		func(_ any) { addTagToSelection(table, provider.AllTags()) })
+	table.InstallCmdHandlers(RemoveTagFromSelectionItemID, func(_ any) bool { return canRemoveTagFromSelection(table) },
+		func(_ any) { removeTagFromSelection(table) })
+	table.InstallCmdHandlers(RebasePageRefsItemID, func(_ any) bool { return canRebasePageRefs(table) },
+		func(_ any) { rebasePageRefs(table) })
 	table.InstallCmdHandlers(CopyToSheetItemID, func(_ any) bool { return canCopySelectionToSheet(table) },
 		func(_ any) { copySelectionToSheet(table) })
 	table.InstallCmdHandlers(CopyToTemplateItemID, func(_ any) bool { return canCopySelectionToTemplate(table) },
 		func(_ any) { copySelectionToTemplate(table) })
+	table.InstallCmdHandlers(RevertToLibraryItemID, func(_ any) bool { return canRevertSelectionToLibrary(table) },
+		func(_ any) { revertSelectionToLibrary(table, provider) })
+	table.InstallCmdHandlers(ResetColumnLayoutItemID, unison.AlwaysEnabled,
+		func(_ any) { resetColumnLayout(provider, header, table, font != nil) })
 
 	return header, table
 }
@@ -0,0 +1,59 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/toolbox/xmath"
+	"github.com/richardwilkes/unison"
+)
+
+// defaultTableColumns returns the canonical set of columns for the provider, in the order given by its ColumnIDs().
+func defaultTableColumns[T model.NodeTypes](provider TableProvider[T], table *unison.Table[*Node[T]], headers []unison.TableColumnHeader[*Node[T]]) []unison.ColumnInfo {
+	ids := provider.ColumnIDs()
+	columns := make([]unison.ColumnInfo, len(headers))
+	for i := range columns {
+		_, pref, _ := headers[i].AsPanel().Sizes(unison.Size{})
+		pref.Width += table.Padding.Left + table.Padding.Right
+		columns[i].ID = ids[i]
+		columns[i].AutoMinimum = pref.Width
+		columns[i].AutoMaximum = xmath.Max(float32(model.GlobalSettings().General.MaximumAutoColWidth), pref.Width)
+		columns[i].Minimum = pref.Width
+		columns[i].Maximum = 10000
+	}
+	return columns
+}
+
+// resetColumnLayout discards any remembered layout for the table's RefKey, including its sort, and rebuilds the columns
+// from the provider's defaults.
+func resetColumnLayout[T model.NodeTypes](provider TableProvider[T], header *unison.TableHeader[*Node[T]], table *unison.Table[*Node[T]], excessWidth bool) {
+	if table.RefKey != "" {
+		delete(tableSortStates(table, false), table.RefKey)
+	}
+	for _, hdr := range header.ColumnHeaders {
+		state := hdr.SortState()
+		state.Order = -1
+		state.Ascending = false
+		hdr.SetSortState(state)
+	}
+	table.Columns = defaultTableColumns(provider, table, header.ColumnHeaders)
+	selection := table.CopySelectionMap()
+	table.SyncToModel()
+	table.SetSelectionMap(selection)
+	if excessWidth {
+		table.SizeColumnsToFitWithExcessIn(provider.ExcessWidthColumnID())
+	} else {
+		table.SizeColumnsToFit(true)
+	}
+	header.MarkForLayoutAndRedraw()
+	table.MarkForLayoutAndRedraw()
+}
@@ -25,14 +25,16 @@ import (
 	"github.com/richardwilkes/toolbox/xio/fs"
 	"github.com/richardwilkes/unison"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 var (
-	_ FileBackedDockable         = &TableDockable[*model.Trait]{}
-	_ unison.UndoManagerProvider = &TableDockable[*model.Trait]{}
-	_ ModifiableRoot             = &TableDockable[*model.Trait]{}
-	_ Rebuildable                = &TableDockable[*model.Trait]{}
-	_ unison.TabCloser           = &TableDockable[*model.Trait]{}
+	_ FileBackedDockable           = &TableDockable[*model.Trait]{}
+	_ unison.UndoManagerProvider   = &TableDockable[*model.Trait]{}
+	_ ModifiableRoot               = &TableDockable[*model.Trait]{}
+	_ Rebuildable                  = &TableDockable[*model.Trait]{}
+	_ unison.TabCloser             = &TableDockable[*model.Trait]{}
+	_ model.SheetSettingsResponder = &TableDockable[*model.Trait]{}
 )
 
 // TableDockable holds the view for a file that contains a (potentially hierarchical) list of data.
@@ -48,9 +50,12 @@ type TableDockable[T model.NodeTypes] struct {
 	sizeToFitButton   *unison.Button
 	filterPopup       *unison.PopupMenu[string]
 	filterField       *unison.Field
+	selectionLabel    *unison.Label
+	quickFilter       quickFilter
 	scroll            *unison.ScrollPanel
 	tableHeader       *unison.TableHeader[*Node[T]]
 	table             *unison.Table[*Node[T]]
+	footer            *tableFooter[T]
 	crc               uint64
 	scale             int
 	needsSaveAsPrompt bool
@@ -93,7 +98,11 @@ func NewTableDockable[T model.NodeTypes](filePath, extension string, provider Ta
 	})
 
 	d.AddChild(d.createToolbar())
+	d.table.SelectionChangedCallback = d.updateSelectionLabel
 	d.AddChild(d.scroll)
+	if d.footer = newTableFooter(d.table, d.provider, nil); d.footer != nil {
+		d.AddChild(d.footer)
+	}
 
 	d.InstallCmdHandlers(OpenEditorItemID,
 		func(_ any) bool { return d.table.HasSelection() },
@@ -114,6 +123,14 @@ func NewTableDockable[T model.NodeTypes](filePath, extension string, provider Ta
 	d.InstallCmdHandlers(DuplicateItemID,
 		func(_ any) bool { return !d.table.IsFiltered() && d.table.HasSelection() },
 		func(_ any) { DuplicateSelection(d.table) })
+	d.InstallCmdHandlers(FindBrokenPageRefsItemID, unison.AlwaysEnabled, func(_ any) {
+		ShowBrokenPageRefs(d, model.FindBrokenPageRefs(IsKnownPageRefKey, d.provider.RootData()...),
+			func(item any) {
+				if data, ok := item.(T); ok {
+					revealTableRow(d.table, data)
+				}
+			})
+	})
 	for _, id := range canCreateIDs {
 		variant := ItemVariant(-1)
 		switch {
@@ -123,10 +140,12 @@ func NewTableDockable[T model.NodeTypes](filePath, extension string, provider Ta
 			variant = ContainerItemVariant
 		case id > FirstAlternateNonContainerMarker && id < LastAlternateNonContainerMarker:
 			variant = AlternateItemVariant
+		case id == NewSpellHeadingItemID:
+			variant = HeadingItemVariant
 		}
 		if variant != -1 {
 			d.InstallCmdHandlers(id, unison.AlwaysEnabled,
-				func(_ any) { d.provider.CreateItem(d, d.table, variant) })
+				func(_ any) { d.provider.CreateItem(d, d.table, variant, AutoInsertMode) })
 		}
 	}
 	d.crc = d.crc64()
@@ -143,10 +162,11 @@ func (d *TableDockable[T]) createToolbar() *unison.Panel {
 	d.sizeToFitButton.ClickCallback = d.sizeToFit
 
 	d.filterField = unison.NewField()
-	filter := i18n.Text("Content Filter")
+	filter := i18n.Text("Quick Filter")
 	d.filterField.Watermark = filter
-	d.filterField.Tooltip = unison.NewTooltipWithText(filter)
+	d.filterField.Tooltip = unison.NewTooltipWithText(quickFilterTooltip())
 	d.filterField.ModifiedCallback = d.applyFilter
+	d.filterField.ValidateCallback = d.quickFilter.valid
 	d.filterField.SetLayoutData(&unison.FlexLayoutData{
 		HAlign: unison.FillAlignment,
 		VAlign: unison.MiddleAlignment,
@@ -208,6 +228,10 @@ func (d *TableDockable[T]) createToolbar() *unison.Panel {
 		VAlign: unison.MiddleAlignment,
 	})
 
+	d.selectionLabel = unison.NewLabel()
+	d.selectionLabel.Font = unison.FieldFont
+	d.selectionLabel.SetLayoutData(&unison.FlexLayoutData{VAlign: unison.MiddleAlignment})
+
 	toolbar := unison.NewPanel()
 	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.DividerColor, 0, unison.Insets{Bottom: 1},
 		false), unison.NewEmptyBorder(unison.StdInsets())))
@@ -228,6 +252,7 @@ func (d *TableDockable[T]) createToolbar() *unison.Panel {
 	toolbar.AddChild(d.sizeToFitButton)
 	toolbar.AddChild(d.filterField)
 	toolbar.AddChild(d.filterPopup)
+	toolbar.AddChild(d.selectionLabel)
 	toolbar.SetLayoutData(&unison.FlexLayoutData{
 		HAlign: unison.FillAlignment,
 		HGrab:  true,
@@ -239,6 +264,17 @@ func (d *TableDockable[T]) createToolbar() *unison.Panel {
 	return toolbar
 }
 
+func (d *TableDockable[T]) updateSelectionLabel() {
+	text := ""
+	if count := d.table.SelectionCount(); count != 0 {
+		text = fmt.Sprintf(i18n.Text("%s selected"), ItemCountText(d.provider, count))
+	}
+	if d.selectionLabel.Text != text {
+		d.selectionLabel.Text = text
+		d.selectionLabel.Parent().MarkForLayoutAndRedraw()
+	}
+}
+
 // Entity implements gurps.EntityProvider
 func (d *TableDockable[T]) Entity() *model.Entity {
 	return nil
@@ -296,6 +332,7 @@ func (d *TableDockable[T]) Modified() bool {
 
 // MarkModified implements widget.ModifiableRoot.
 func (d *TableDockable[T]) MarkModified(_ unison.Paneler) {
+	InvalidateTagCache(d.provider)
 	if dc := unison.Ancestor[*unison.DockContainer](d); dc != nil {
 		dc.UpdateTitle(d)
 	}
@@ -344,6 +381,16 @@ func (d *TableDockable[T]) save(forceSaveAs bool) bool {
 	return success
 }
 
+func (d *TableDockable[T]) recoveryData() (crc uint64, needsSaveAs bool, saver func(filePath string) error) {
+	return d.crc64(), d.needsSaveAsPrompt, d.saver
+}
+
+func (d *TableDockable[T]) restoreFromRecovery(originalPath string, needsSaveAs bool) {
+	d.crc = 0
+	d.needsSaveAsPrompt = needsSaveAs
+	d.SetBackingFilePath(originalPath)
+}
+
 func (d *TableDockable[T]) toggleHierarchy() {
 	first := true
 	open := false
@@ -382,12 +429,18 @@ func (d *TableDockable[T]) applyFilter(_, after *unison.FieldState) {
 			}
 		}
 	}
-	text := strings.TrimSpace(after.Text)
-	if len(tags) == 0 && text == "" {
+	matcher := d.quickFilter.matcher(after.Text)
+	if d.quickFilter.valid() {
+		d.filterField.Tooltip = unison.NewTooltipWithText(quickFilterTooltip())
+	} else {
+		d.filterField.Tooltip = unison.NewTooltipWithSecondaryText(i18n.Text("Invalid regular expression"),
+			d.quickFilter.err.Error())
+	}
+	if len(tags) == 0 && matcher == nil {
 		d.table.ApplyFilter(nil)
 	} else {
 		d.table.ApplyFilter(func(row *Node[T]) bool {
-			if row.PartialMatchExceptTag(text) {
+			if matcher == nil || row.QuickFilterMatch(matcher) {
 				for tag := range tags {
 					if !row.HasTag(tag) {
 						return true
@@ -398,18 +451,56 @@ func (d *TableDockable[T]) applyFilter(_, after *unison.FieldState) {
 			return true
 		})
 	}
+	if d.footer != nil {
+		d.footer.Sync()
+	}
+}
+
+// SheetSettingsUpdated implements model.SheetSettingsResponder.
+func (d *TableDockable[T]) SheetSettingsUpdated(entity *model.Entity, blockLayout bool) {
+	if entity == nil && blockLayout {
+		d.syncColumns()
+	}
+}
+
+// syncColumns rebuilds the columns if the provider's set of columns no longer matches the table's.
+func (d *TableDockable[T]) syncColumns() {
+	ids := d.provider.ColumnIDs()
+	if len(ids) == len(d.table.Columns) {
+		same := true
+		for _, column := range d.table.Columns {
+			if !slices.Contains(ids, column.ID) {
+				same = false
+				break
+			}
+		}
+		if same {
+			return
+		}
+	}
+	d.tableHeader.ColumnHeaders = d.provider.Headers()
+	d.table.Columns = defaultTableColumns(d.provider, d.table, d.tableHeader.ColumnHeaders)
+	d.Rebuild(true)
+	d.table.SizeColumnsToFit(true)
+	d.tableHeader.MarkForLayoutAndRedraw()
+	d.table.MarkForLayoutAndRedraw()
 }
 
 // Rebuild implements widget.Rebuildable.
 func (d *TableDockable[T]) Rebuild(_ bool) {
+	InvalidateTagCache(d.provider)
 	h, v := d.scroll.Position()
 	sel := d.table.CopySelectionMap()
 	d.table.SyncToModel()
 	d.table.SetSelectionMap(sel)
+	if d.footer != nil {
+		d.footer.Sync()
+	}
 	if dc := unison.Ancestor[*unison.DockContainer](d); dc != nil {
 		dc.UpdateTitle(d)
 	}
 	d.scroll.SetPosition(h, v)
+	UpdateSpellCollegeIndex(d)
 }
 
 func (d *TableDockable[T]) crc64() uint64 {
@@ -0,0 +1,185 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/toolbox/xmath"
+	"github.com/richardwilkes/unison"
+)
+
+var _ Syncer = &tableFooter[*model.Spell]{}
+
+// tableFooter displays a row of aggregated column values and/or a summary line beneath a table.
+type tableFooter[T model.NodeTypes] struct {
+	unison.Panel
+	table      *unison.Table[*Node[T]]
+	font       unison.Font
+	columns    map[int]model.Aggregation
+	values     map[int]string
+	summarizer TableSummarizer[T]
+	summary    string
+}
+
+// newTableFooter creates a new footer for the table. Returns nil if the provider implements neither TableAggregator nor
+// TableSummarizer, or none of its aggregated columns are present in the table and it has no summary.
+func newTableFooter[T model.NodeTypes](table *unison.Table[*Node[T]], provider TableProvider[T], font unison.Font) *tableFooter[T] {
+	columns := make(map[int]model.Aggregation)
+	if aggregator, ok := provider.(TableAggregator); ok {
+		for id, aggregation := range aggregator.AggregateColumns() {
+			if table.ColumnIndexForID(id) != -1 {
+				columns[id] = aggregation
+			}
+		}
+	}
+	summarizer, _ := provider.(TableSummarizer[T])
+	if len(columns) == 0 && summarizer == nil {
+		return nil
+	}
+	if font == nil {
+		font = unison.FieldFont
+	}
+	f := &tableFooter[T]{
+		table:      table,
+		font:       font,
+		columns:    columns,
+		values:     make(map[int]string),
+		summarizer: summarizer,
+	}
+	f.Self = f
+	f.SetBorder(unison.NewLineBorder(unison.DividerColor, 0, unison.Insets{Top: 1}, false))
+	f.SetSizer(f.sizes)
+	f.DrawCallback = f.draw
+	f.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: unison.FillAlignment,
+		HGrab:  true,
+	})
+	f.Sync()
+	return f
+}
+
+// Sync implements Syncer.
+func (f *tableFooter[T]) Sync() {
+	roots := f.table.RootRows()
+	data := make([]T, 0, len(roots))
+	for _, row := range roots {
+		data = append(data, row.Data())
+	}
+	var nodes []T
+	if f.table.IsFiltered() {
+		nodes = make([]T, 0, f.table.LastRowIndex()+1)
+		for i := 0; i <= f.table.LastRowIndex(); i++ {
+			nodes = append(nodes, f.table.RowFromIndex(i).Data())
+		}
+	} else {
+		model.Traverse(func(one T) bool {
+			nodes = append(nodes, one)
+			return false
+		}, false, true, data...)
+	}
+	for id, aggregation := range f.columns {
+		f.values[id] = model.AggregateColumn(aggregation, id, nodes).String()
+	}
+	if f.summarizer != nil {
+		lineCount := len(f.summaryLines())
+		f.summary = f.summarizer.Summary(data)
+		if lineCount != len(f.summaryLines()) {
+			if parent := f.Parent(); parent != nil {
+				parent.MarkForLayoutAndRedraw()
+			}
+		}
+	}
+	f.MarkForRedraw()
+}
+
+// summaryLines returns the summary wrapped to the width spanned by the table's columns.
+func (f *tableFooter[T]) summaryLines() []*unison.Text {
+	if f.summary == "" {
+		return nil
+	}
+	decoration := &unison.TextDecoration{
+		Font:       f.font,
+		Foreground: model.OnHeaderColor,
+	}
+	left, _ := f.table.ColumnEdges(0)
+	_, right := f.table.ColumnEdges(len(f.table.Columns) - 1)
+	if width := right - left; width > 0 {
+		return unison.NewTextWrappedLines(f.summary, decoration, width)
+	}
+	return unison.NewTextLines(f.summary, decoration)
+}
+
+func (f *tableFooter[T]) sizes(_ unison.Size) (min, pref, max unison.Size) {
+	lines := 0
+	if len(f.columns) != 0 {
+		lines++
+	}
+	if f.summarizer != nil {
+		lines += xmath.Max(len(f.summaryLines()), 1)
+	}
+	pref.Height = float32(lines)*f.font.LineHeight() + f.table.Padding.Top + f.table.Padding.Bottom
+	if b := f.Border(); b != nil {
+		pref.AddInsets(b.Insets())
+	}
+	pref.GrowToInteger()
+	return pref, pref, unison.MaxSize(pref)
+}
+
+func (f *tableFooter[T]) draw(gc *unison.Canvas, rect unison.Rect) {
+	gc.DrawRect(rect, model.HeaderColor.Paint(gc, rect, unison.Fill))
+	r := f.ContentRect(false)
+	offset := f.table.FrameRect().X - f.FrameRect().X
+	y := r.Y + f.table.Padding.Top + f.font.Baseline()
+	if len(f.columns) != 0 {
+		f.drawAggregates(gc, offset, y)
+		y += f.font.LineHeight()
+	}
+	left, _ := f.table.ColumnEdges(0)
+	for _, line := range f.summaryLines() {
+		line.Draw(gc, left+offset, y)
+		y += f.font.LineHeight()
+	}
+}
+
+func (f *tableFooter[T]) drawAggregates(gc *unison.Canvas, offset, y float32) {
+	for i, column := range f.table.Columns {
+		left, right := f.table.ColumnEdges(i)
+		left += offset
+		right += offset
+		if value, ok := f.values[column.ID]; ok {
+			text := unison.NewText(value, &unison.TextDecoration{
+				Font:       f.font,
+				Foreground: model.OnHeaderColor,
+			})
+			text.Draw(gc, right-text.Width(), y)
+		} else if i == 0 {
+			text := unison.NewText(f.label(), &unison.TextDecoration{
+				Font:       f.font,
+				Foreground: model.OnHeaderColor,
+			})
+			text.Draw(gc, left, y)
+		}
+	}
+}
+
+// label returns the text used to describe the footer's values, which is only meaningful when all aggregated columns
+// share the same aggregation.
+func (f *tableFooter[T]) label() string {
+	label := ""
+	for _, aggregation := range f.columns {
+		if label != "" && label != aggregation.String() {
+			return ""
+		}
+		label = aggregation.String()
+	}
+	return label
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+// InlineEditProvider may be implemented by a TableProvider that allows some of its numeric columns to be edited in
+// place by double-clicking on a cell.
+type InlineEditProvider[T model.NodeTypes] interface {
+	// InlineEditable returns true if the column of the given row data may be edited in place.
+	InlineEditable(data T, columnID int) bool
+	// InlineValue returns the current value to be edited.
+	InlineValue(data T, columnID int) fxp.Int
+	// SetInlineValue sets the edited value.
+	SetInlineValue(data T, columnID int, value fxp.Int)
+}
+
+type inlineEditData[T model.NodeTypes] struct {
+	Owner    Rebuildable
+	Provider InlineEditProvider[T]
+	Target   T
+	ColumnID int
+	Value    fxp.Int
+}
+
+func (d *inlineEditData[T]) Apply() {
+	d.Provider.SetInlineValue(d.Target, d.ColumnID, d.Value)
+	if entity := model.AsNode(d.Target).OwningEntity(); entity != nil {
+		entity.Recalculate()
+	}
+	MarkModified(d.Owner)
+}
+
+// startInlineEdit places a field over the cell at the given point if the provider allows it to be edited in place.
+// Returns true if editing was started.
+func startInlineEdit[T model.NodeTypes](table *unison.Table[*Node[T]], provider TableProvider[T], where unison.Point) bool {
+	editor, ok := provider.(InlineEditProvider[T])
+	if !ok {
+		return false
+	}
+	row := table.OverRow(where.Y)
+	col := table.OverColumn(where.X)
+	if row == -1 || col == -1 {
+		return false
+	}
+	target := table.RowFromIndex(row).Data()
+	columnID := table.Columns[col].ID
+	if !editor.InlineEditable(target, columnID) {
+		return false
+	}
+	owner := unison.Ancestor[Rebuildable](table)
+	if owner == nil {
+		return false
+	}
+	original := editor.InlineValue(target, columnID)
+	field := unison.NewField()
+	field.SetText(original.String())
+	field.HAlign = unison.EndAlignment
+	field.SetFrameRect(table.CellFrame(row, col))
+	field.ValidateCallback = func() bool {
+		_, err := fxp.FromString(strings.TrimSpace(field.Text()))
+		return err == nil
+	}
+	done := false
+	finish := func(commit bool) {
+		if done {
+			return
+		}
+		done = true
+		field.RemoveFromParent()
+		table.MarkForRedraw()
+		table.RequestFocus()
+		if !commit {
+			return
+		}
+		value, err := fxp.FromString(strings.TrimSpace(field.Text()))
+		if err != nil || value == original {
+			return
+		}
+		before := &inlineEditData[T]{Owner: owner, Provider: editor, Target: target, ColumnID: columnID, Value: original}
+		after := &inlineEditData[T]{Owner: owner, Provider: editor, Target: target, ColumnID: columnID, Value: value}
+		if mgr := unison.UndoManagerFor(table); mgr != nil {
+			mgr.Add(&unison.UndoEdit[*inlineEditData[T]]{
+				ID:         unison.NextUndoID(),
+				EditName:   fmt.Sprintf(i18n.Text("Edit %s"), model.AsNode(target).Kind()),
+				UndoFunc:   func(edit *unison.UndoEdit[*inlineEditData[T]]) { edit.BeforeData.Apply() },
+				RedoFunc:   func(edit *unison.UndoEdit[*inlineEditData[T]]) { edit.AfterData.Apply() },
+				BeforeData: before,
+				AfterData:  after,
+			})
+		}
+		after.Apply()
+	}
+	field.KeyDownCallback = func(keyCode unison.KeyCode, mod unison.Modifiers, repeat bool) bool {
+		switch keyCode {
+		case unison.KeyReturn, unison.KeyNumPadEnter:
+			finish(true)
+			return true
+		case unison.KeyEscape:
+			finish(false)
+			return true
+		default:
+			return field.DefaultKeyDown(keyCode, mod, repeat)
+		}
+	}
+	field.LostFocusCallback = func() {
+		field.DefaultFocusLost()
+		finish(true)
+	}
+	table.AddChild(field)
+	field.RequestFocus()
+	field.SelectAll()
+	return true
+}
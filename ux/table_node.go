@@ -60,7 +60,6 @@ func NewNode[T model.NodeTypes](table *unison.Table[*Node[T]], parent *Node[T],
 		parent:     parent,
 		data:       data,
 		dataAsNode: model.AsNode(data),
-		cellCache:  make([]*CellCache, len(table.Columns)),
 		forPage:    forPage,
 	}
 }
@@ -72,11 +71,33 @@ func NewNodeLike[T model.NodeTypes](like *Node[T], data T) *Node[T] {
 		parent:     like.parent,
 		data:       data,
 		dataAsNode: model.AsNode(data),
-		cellCache:  make([]*CellCache, len(like.table.Columns)),
 		forPage:    like.forPage,
 	}
 }
 
+// NodeCache retains the nodes created for a table's rows across rebuilds, keyed by the data they represent, so that
+// unchanged rows keep their nodes and cell caches rather than being recreated each time the table syncs to its model.
+type NodeCache[T model.NodeTypes] struct {
+	nodes map[T]*Node[T]
+}
+
+// Rows returns nodes for the provided data, reusing any previously returned for the same data and parent. Nodes no
+// longer present in the data are released.
+func (c *NodeCache[T]) Rows(table *unison.Table[*Node[T]], parent *Node[T], data []T, forPage bool) []*Node[T] {
+	nodes := make(map[T]*Node[T], len(data))
+	rows := make([]*Node[T], len(data))
+	for i, one := range data {
+		node, exists := c.nodes[one]
+		if !exists || node.table != table || node.parent != parent || node.forPage != forPage {
+			node = NewNode[T](table, parent, one, forPage)
+		}
+		nodes[one] = node
+		rows[i] = node
+	}
+	c.nodes = nodes
+	return rows
+}
+
 // CloneForTarget implements unison.TableRowData.
 func (n *Node[T]) CloneForTarget(target unison.Paneler, newParent *Node[T]) *Node[T] {
 	table, ok := target.(*unison.Table[*Node[T]])
@@ -84,12 +105,52 @@ func (n *Node[T]) CloneForTarget(target unison.Paneler, newParent *Node[T]) *Nod
 		jot.Fatal(1, "unable to convert to table")
 	}
 	if provider := unison.AncestorOrSelf[model.EntityProvider](target); provider != nil {
-		return NewNode[T](table, newParent, n.dataAsNode.Clone(provider.Entity(), newParent.Data(), false), n.forPage)
+		clone := n.dataAsNode.Clone(provider.Entity(), newParent.Data(), false)
+		n.recordLibrarySource(clone, table)
+		return NewNode[T](table, newParent, clone, n.forPage)
 	}
 	jot.Fatal(1, "unable to locate entity provider")
 	return nil // Never reaches here
 }
 
+// recordLibrarySource remembers where the clone and its children came from when this node is being copied out of a
+// library list and into something other than a library list.
+func (n *Node[T]) recordLibrarySource(clone T, target *unison.Table[*Node[T]]) {
+	if _, ok := any(clone).(model.LibrarySourced); !ok || !isLibraryListTable(n.table) || isLibraryListTable(target) {
+		return
+	}
+	d := unison.Ancestor[FileBackedDockable](n.table)
+	if d == nil {
+		return
+	}
+	var originals []T
+	model.Traverse(func(one T) bool {
+		originals = append(originals, one)
+		return false
+	}, false, false, n.data)
+	libs := model.GlobalSettings().LibrarySet
+	i := 0
+	model.Traverse(func(one T) bool {
+		if i < len(originals) {
+			source := libs.SourceFor(d.BackingFilePath(), model.AsNode(originals[i]).UUID())
+			if source == nil {
+				return true
+			}
+			any(one).(model.LibrarySourced).SetLibrarySource(source)
+		}
+		i++
+		return false
+	}, false, false, clone)
+}
+
+func isLibraryListTable[T model.NodeTypes](table *unison.Table[*Node[T]]) bool {
+	if table == nil {
+		return false
+	}
+	d, ok := unison.Ancestor[FileBackedDockable](table).(interface{ DockableKind() string })
+	return ok && d.DockableKind() == ListDockableKind
+}
+
 // UUID implements unison.TableRowData.
 func (n *Node[T]) UUID() uuid.UUID {
 	return n.dataAsNode.UUID()
@@ -112,16 +173,42 @@ func (n *Node[T]) CanHaveChildren() bool {
 
 // Children implements unison.TableRowData.
 func (n *Node[T]) Children() []*Node[T] {
-	if n.dataAsNode.Container() && n.children == nil {
+	if n.dataAsNode.Container() && !n.childrenMatchData() {
+		existing := make(map[T]*Node[T], len(n.children))
+		for _, one := range n.children {
+			existing[one.data] = one
+		}
 		children := n.dataAsNode.NodeChildren()
 		n.children = make([]*Node[T], len(children))
 		for i, one := range children {
-			n.children[i] = NewNode[T](n.table, n, one, n.forPage)
+			if node, ok := existing[one]; ok {
+				n.children[i] = node
+			} else {
+				n.children[i] = NewNode[T](n.table, n, one, n.forPage)
+			}
 		}
 	}
 	return n.children
 }
 
+// childrenMatchData returns true if the cached child nodes still represent the data's children, which may have been
+// replaced directly in the model (e.g. by an undo) since the nodes were created.
+func (n *Node[T]) childrenMatchData() bool {
+	if n.children == nil {
+		return false
+	}
+	children := n.dataAsNode.NodeChildren()
+	if len(children) != len(n.children) {
+		return false
+	}
+	for i, one := range children {
+		if n.children[i].data != one {
+			return false
+		}
+	}
+	return true
+}
+
 // SetChildren implements unison.TableRowData.
 func (n *Node[T]) SetChildren(children []*Node[T]) {
 	if n.dataAsNode.Container() {
@@ -130,11 +217,15 @@ func (n *Node[T]) SetChildren(children []*Node[T]) {
 	}
 }
 
-// CellDataForSort implements unison.TableRowData.
+// CellDataForSort implements unison.TableRowData. Only used when sorting, so a cell's SortKey takes precedence over its
+// displayed text.
 func (n *Node[T]) CellDataForSort(index int) string {
 	var data model.CellData
 	n.dataAsNode.CellData(n.table.Columns[index].ID, &data)
 	s := data.ForSort()
+	if data.SortKey != "" {
+		s = data.SortKey
+	}
 	if model.GlobalSettings().General.GroupContainersOnSort && n.dataAsNode.Container() {
 		return containerMarker + s
 	}
@@ -146,6 +237,9 @@ func (n *Node[T]) ColumnCell(row, col int, foreground, _ unison.Ink, _, _, _ boo
 	var cellData model.CellData
 	n.dataAsNode.CellData(n.table.Columns[col].ID, &cellData)
 	width := n.table.CellWidth(row, col)
+	if len(n.cellCache) != len(n.table.Columns) {
+		n.cellCache = make([]*CellCache, len(n.table.Columns))
+	}
 	if n.cellCache[col].Matches(width, &cellData) {
 		applyForegroundInkRecursively(n.cellCache[col].Panel.AsPanel(), foreground)
 		return n.cellCache[col].Panel
@@ -214,18 +308,19 @@ func (n *Node[T]) HasTag(tag string) bool {
 	return false
 }
 
-// PartialMatchExceptTag returns true if the specified text is present in the node's displayable columns other than the
-// the tags column. An empty text will match all nodes.
-func (n *Node[T]) PartialMatchExceptTag(text string) bool {
-	if text == "" {
-		return true
-	}
-	text = strings.ToLower(text)
+// QuickFilterMatch returns true if the matcher accepts any of the node's displayed cells, or those of any of its
+// descendants, so that containers remain visible when something inside them matches.
+func (n *Node[T]) QuickFilterMatch(matcher func(cell string) bool) bool {
 	for i := range n.table.Columns {
 		var data model.CellData
 		n.dataAsNode.CellData(n.table.Columns[i].ID, &data)
-		if data.Type != model.TagsCellType {
-			if strings.Contains(strings.ToLower(data.ForSort()), text) {
+		if matcher(data.ForSort()) {
+			return true
+		}
+	}
+	if n.CanHaveChildren() {
+		for _, child := range n.Children() {
+			if child.QuickFilterMatch(matcher) {
 				return true
 			}
 		}
@@ -238,7 +333,9 @@ func (n *Node[T]) PartialMatchExceptTag(text string) bool {
 func (n *Node[T]) Match(text string) bool {
 	if text != "" {
 		for i := range n.table.Columns {
-			if strings.Contains(strings.ToLower(n.CellDataForSort(i)), text) {
+			var data model.CellData
+			n.dataAsNode.CellData(n.table.Columns[i].ID, &data)
+			if strings.Contains(strings.ToLower(data.ForSort()), text) {
 				return true
 			}
 		}
@@ -280,7 +377,13 @@ func (n *Node[T]) createLabelCell(c *model.CellData, width float32, foreground u
 		Columns: 1,
 		HAlign:  c.Alignment,
 	})
-	n.addLabelCell(c, p, width, c.Primary, n.primaryFieldFont(), foreground, true)
+	primaryFont := n.primaryFieldFont()
+	if c.Heading {
+		fd := primaryFont.Descriptor()
+		fd.Weight = unison.BoldFontWeight
+		primaryFont = fd.Font()
+	}
+	n.addLabelCell(c, p, width, c.Primary, primaryFont, foreground, true)
 	if c.Secondary != "" {
 		n.addLabelCell(c, p, width, c.Secondary, n.secondaryFieldFont(), foreground, false)
 	}
@@ -332,8 +435,42 @@ func (n *Node[T]) createLabelCell(c *model.CellData, width float32, foreground u
 		}
 		p.AddChild(label)
 	}
+	if c.LibraryInfo != "" && !n.forPage {
+		label := unison.NewLabel()
+		label.Font = n.secondaryFieldFont()
+		height := label.Font.LineHeight()
+		label.Drawable = &unison.DrawableSVG{
+			SVG:  svg.Link,
+			Size: unison.NewSize(height, height),
+		}
+		label.Text = i18n.Text("Modified from library")
+		label.Tooltip = unison.NewTooltipWithText(c.LibraryInfo)
+		label.HAlign = c.Alignment
+		label.VAlign = unison.MiddleAlignment
+		label.ClientData()[invertColorsMarker] = true
+		label.OnBackgroundInk = model.OnMarkerColor
+		label.SetBorder(unison.NewEmptyBorder(unison.Insets{
+			Left:  4,
+			Right: 4,
+		}))
+		label.DrawCallback = func(gc *unison.Canvas, rect unison.Rect) {
+			gc.DrawRect(rect, model.MarkerColor.Paint(gc, rect, unison.Fill))
+			label.DefaultDraw(gc, rect)
+		}
+		p.AddChild(label)
+	}
 	if tooltip != "" {
-		p.Tooltip = unison.NewTooltipWithText(strings.ReplaceAll(txt.Wrap("", strings.ReplaceAll(tooltip, " ", "␣"), 120), "␣", " "))
+		tooltip = strings.ReplaceAll(txt.Wrap("", strings.ReplaceAll(tooltip, " ", "␣"), 120), "␣", " ")
+	}
+	switch {
+	case c.InfoPop != "":
+		if tooltip != "" {
+			AddHelpToInfoPop(p, c.InfoPop+"\n\n"+tooltip)
+		} else {
+			AddHelpToInfoPop(p, c.InfoPop)
+		}
+	case tooltip != "":
+		p.Tooltip = unison.NewTooltipWithText(tooltip)
 	}
 	return p
 }
@@ -425,6 +562,27 @@ func handleCheck(data any, check unison.Paneler, checked bool) {
 		if item.Entity != nil {
 			item.Entity.Recalculate()
 		}
+	case *model.Spell:
+		item.Prepared = checked
+		if mgr := unison.UndoManagerFor(check); mgr != nil {
+			owner := unison.AncestorOrSelf[Rebuildable](check)
+			mgr.Add(&unison.UndoEdit[*spellPreparedAdjuster]{
+				ID:       unison.NextUndoID(),
+				EditName: i18n.Text("Toggle Prepared"),
+				UndoFunc: func(edit *unison.UndoEdit[*spellPreparedAdjuster]) { edit.BeforeData.Apply() },
+				RedoFunc: func(edit *unison.UndoEdit[*spellPreparedAdjuster]) { edit.AfterData.Apply() },
+				BeforeData: &spellPreparedAdjuster{
+					Owner:    owner,
+					Target:   item,
+					Prepared: !item.Prepared,
+				},
+				AfterData: &spellPreparedAdjuster{
+					Owner:    owner,
+					Target:   item,
+					Prepared: item.Prepared,
+				},
+			})
+		}
 	case *model.TraitModifier:
 		item.Disabled = !checked
 		if mgr := unison.UndoManagerFor(check); mgr != nil {
@@ -490,6 +648,17 @@ func (a *equipmentAdjuster) Apply() {
 	MarkModified(a.Owner)
 }
 
+type spellPreparedAdjuster struct {
+	Owner    Rebuildable
+	Target   *model.Spell
+	Prepared bool
+}
+
+func (a *spellPreparedAdjuster) Apply() {
+	a.Target.Prepared = a.Prepared
+	MarkModified(a.Owner)
+}
+
 type equipmentModifierAdjuster struct {
 	Owner    Rebuildable
 	Target   *model.EquipmentModifier
@@ -655,8 +824,31 @@ func rowIndex[T model.NodeTypes](id uuid.UUID, startIndex int, rows []*Node[T])
 	return startIndex, -1
 }
 
-// InsertItems into a table.
-func InsertItems[T model.NodeTypes](owner Rebuildable, table *unison.Table[*Node[T]], topList func() []T, setTopList func([]T), rowData func(table *unison.Table[*Node[T]]) []*Node[T], items ...T) {
+// CanInsertItems returns true if items may be inserted into the table using the given mode.
+func CanInsertItems[T model.NodeTypes](table *unison.Table[*Node[T]], mode InsertMode) bool {
+	switch mode {
+	case SiblingInsertMode:
+		return table.HasSelection()
+	case ChildInsertMode:
+		i := table.FirstSelectedRowIndex()
+		return i != -1 && table.RowFromIndex(i).CanHaveChildren()
+	default:
+		return true
+	}
+}
+
+// InstallInsertModeCmdHandlers installs handlers for the commands that create a new item inside the selected container
+// and after the selection, respectively.
+func InstallInsertModeCmdHandlers[T model.NodeTypes](panel *unison.Panel, owner Rebuildable, table *unison.Table[*Node[T]], provider TableProvider[T], childID, siblingID int) {
+	panel.InstallCmdHandlers(childID, func(_ any) bool { return CanInsertItems(table, ChildInsertMode) },
+		func(_ any) { provider.CreateItem(owner, table, NoItemVariant, ChildInsertMode) })
+	panel.InstallCmdHandlers(siblingID, func(_ any) bool { return CanInsertItems(table, SiblingInsertMode) },
+		func(_ any) { provider.CreateItem(owner, table, NoItemVariant, SiblingInsertMode) })
+}
+
+// InsertItems into a table. The mode determines whether the items become children of a selected container or siblings
+// of the selection.
+func InsertItems[T model.NodeTypes](owner Rebuildable, table *unison.Table[*Node[T]], mode InsertMode, topList func() []T, setTopList func([]T), rowData func(table *unison.Table[*Node[T]]) []*Node[T], items ...T) {
 	if len(items) == 0 {
 		return
 	}
@@ -677,12 +869,13 @@ func InsertItems[T model.NodeTypes](owner Rebuildable, table *unison.Table[*Node
 	if i != -1 {
 		row := table.RowFromIndex(i)
 		if target = row.Data(); target != zero {
-			if row.CanHaveChildren() {
+			if row.CanHaveChildren() && mode != SiblingInsertMode {
 				// Target is container, append to end of that container
 				SetParents(items, target)
 				row.dataAsNode.SetChildren(append(row.dataAsNode.NodeChildren(), items...))
 			} else {
-				// Target isn't a container. If it has a parent, insert after the target within that parent.
+				// Target isn't a container or a sibling was requested. If it has a parent, insert after the target within
+				// that parent.
 				parent := row.Parent()
 				if parentData := parent.Data(); parentData != zero {
 					SetParents(items, parentData)
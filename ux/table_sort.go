@@ -0,0 +1,88 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/unison"
+)
+
+// tableSortStatesKey is the key used in the ClientData() of a dockable to hold the last sort state of each of its
+// tables with a RefKey, keyed by column ID, so that the sort survives the table being torn down and rebuilt.
+const tableSortStatesKey = "table_sort_states"
+
+// tableSortStates returns the sort states held by the dockable containing the table. If the dockable doesn't have any
+// yet, they will be created if create is true, otherwise nil will be returned.
+func tableSortStates(table unison.Paneler, create bool) map[string]map[int]unison.SortState {
+	dockable := unison.Ancestor[unison.Dockable](table)
+	if dockable == nil {
+		return nil
+	}
+	clientData := dockable.AsPanel().ClientData()
+	if states, ok := clientData[tableSortStatesKey].(map[string]map[int]unison.SortState); ok {
+		return states
+	}
+	if !create {
+		return nil
+	}
+	states := make(map[string]map[int]unison.SortState)
+	clientData[tableSortStatesKey] = states
+	return states
+}
+
+func installTableSortTracking[T model.NodeTypes](header *unison.TableHeader[*Node[T]], table *unison.Table[*Node[T]]) {
+	for _, hdr := range header.ColumnHeaders {
+		panel := hdr.AsPanel()
+		if original := panel.MouseUpCallback; original != nil {
+			panel.MouseUpCallback = func(where unison.Point, button int, mod unison.Modifiers) bool {
+				result := original(where, button, mod)
+				recordTableSort(header, table)
+				return result
+			}
+		}
+	}
+}
+
+func recordTableSort[T model.NodeTypes](header *unison.TableHeader[*Node[T]], table *unison.Table[*Node[T]]) {
+	if table.RefKey == "" {
+		return
+	}
+	all := tableSortStates(table, header.HasSort())
+	if all == nil {
+		return
+	}
+	if !header.HasSort() {
+		delete(all, table.RefKey)
+		return
+	}
+	states := make(map[int]unison.SortState, len(header.ColumnHeaders))
+	for i, hdr := range header.ColumnHeaders {
+		states[table.Columns[i].ID] = hdr.SortState()
+	}
+	all[table.RefKey] = states
+}
+
+// restoreTableSort restores the last recorded sort state for the table's RefKey within its dockable, if any, and then
+// re-sorts the rows. This should be called after the root rows have been regenerated.
+func restoreTableSort[T model.NodeTypes](header *unison.TableHeader[*Node[T]], table *unison.Table[*Node[T]]) {
+	if states, ok := tableSortStates(table, false)[table.RefKey]; ok && table.RefKey != "" {
+		for i, hdr := range header.ColumnHeaders {
+			if state, exists := states[table.Columns[i].ID]; exists {
+				state.Sortable = hdr.SortState().Sortable
+				hdr.SetSortState(state)
+			}
+		}
+	}
+	if header.HasSort() {
+		header.ApplySort()
+	}
+}
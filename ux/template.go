@@ -94,6 +94,7 @@ func NewTemplate(filePath string, template *model.Template) *Template {
 		needsSaveAsPrompt: true,
 	}
 	d.Self = d
+	d.template.RefreshLibraryStatus()
 	d.targetMgr = NewTargetMgr(d)
 	d.SetLayout(&unison.FlexLayout{
 		Columns: 1,
@@ -212,11 +213,13 @@ func NewTemplate(filePath string, template *model.Template) *Template {
 	d.installNewItemCmdHandlers(NewTechniqueItemID, -1, d.Skills)
 	d.installNewItemCmdHandlers(NewSpellItemID, NewSpellContainerItemID, d.Spells)
 	d.installNewItemCmdHandlers(NewRitualMagicSpellItemID, -1, d.Spells)
+	d.InstallCmdHandlers(NewSpellHeadingItemID, unison.AlwaysEnabled,
+		func(_ any) { d.Spells.CreateItem(d, HeadingItemVariant, AutoInsertMode) })
 	d.installNewItemCmdHandlers(NewCarriedEquipmentItemID,
 		NewCarriedEquipmentContainerItemID, d.Equipment)
 	d.installNewItemCmdHandlers(NewNoteItemID, NewNoteContainerItemID, d.Notes)
 	d.InstallCmdHandlers(AddNaturalAttacksItemID, unison.AlwaysEnabled, func(_ any) {
-		InsertItems[*model.Trait](d, d.Traits.Table, d.template.TraitList, d.template.SetTraitList,
+		InsertItems[*model.Trait](d, d.Traits.Table, AutoInsertMode, d.template.TraitList, d.template.SetTraitList,
 			func(_ *unison.Table[*Node[*model.Trait]]) []*Node[*model.Trait] {
 				return d.Traits.provider.RootRows()
 			}, model.NewNaturalAttacks(nil, nil))
@@ -523,9 +526,9 @@ func (d *Template) installNewItemCmdHandlers(itemID, containerID int, creator it
 		variant = AlternateItemVariant
 	} else {
 		d.InstallCmdHandlers(containerID, unison.AlwaysEnabled,
-			func(_ any) { creator.CreateItem(d, ContainerItemVariant) })
+			func(_ any) { creator.CreateItem(d, ContainerItemVariant, AutoInsertMode) })
 	}
-	d.InstallCmdHandlers(itemID, unison.AlwaysEnabled, func(_ any) { creator.CreateItem(d, variant) })
+	d.InstallCmdHandlers(itemID, unison.AlwaysEnabled, func(_ any) { creator.CreateItem(d, variant, AutoInsertMode) })
 }
 
 // Entity implements gurps.EntityProvider
@@ -585,6 +588,7 @@ func (d *Template) Modified() bool {
 
 // MarkModified implements widget.ModifiableRoot.
 func (d *Template) MarkModified(_ unison.Paneler) {
+	d.template.RefreshLibraryStatus()
 	if dc := unison.Ancestor[*unison.DockContainer](d); dc != nil {
 		dc.UpdateTitle(d)
 	}
@@ -639,6 +643,16 @@ func (d *Template) save(forceSaveAs bool) bool {
 	return success
 }
 
+func (d *Template) recoveryData() (crc uint64, needsSaveAs bool, saver func(filePath string) error) {
+	return d.template.CRC64(), d.needsSaveAsPrompt, d.template.Save
+}
+
+func (d *Template) restoreFromRecovery(originalPath string, needsSaveAs bool) {
+	d.crc = 0
+	d.needsSaveAsPrompt = needsSaveAs
+	d.SetBackingFilePath(originalPath)
+}
+
 func (d *Template) createLists() {
 	h, v := d.scroll.Position()
 	var refocusOnKey string
@@ -751,6 +765,7 @@ func (d *Template) SheetSettingsUpdated(entity *model.Entity, blockLayout bool)
 func (d *Template) Rebuild(full bool) {
 	h, v := d.scroll.Position()
 	focusRefKey := d.targetMgr.CurrentFocusRef()
+	d.template.RefreshLibraryStatus()
 	if full {
 		traitsSelMap := d.Traits.RecordSelection()
 		skillsSelMap := d.Skills.RecordSelection()
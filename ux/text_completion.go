@@ -0,0 +1,57 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/unison"
+)
+
+// installTextCompletion chains onto the field's ModifiedCallback so that typing at the end of the text completes it
+// with the first matching choice, leaving the completed portion selected so that further typing replaces it.
+func installTextCompletion(field *unison.Field, choices func() []string) {
+	previous := field.ModifiedCallback
+	completing := false
+	field.ModifiedCallback = func(before, after *unison.FieldState) {
+		if previous != nil {
+			previous(before, after)
+		}
+		if completing {
+			return
+		}
+		typed := []rune(after.Text)
+		if len(typed) <= len([]rune(before.Text)) || after.SelectionStart != len(typed) ||
+			after.SelectionEnd != len(typed) {
+			return
+		}
+		if completion := completeText(string(typed), choices()); completion != "" {
+			completing = true
+			field.SetText(completion)
+			field.SetSelection(len(typed), len([]rune(completion)))
+			completing = false
+		}
+	}
+}
+
+// completeText returns the first choice that starts with the text, ignoring case, retaining the case of the text that
+// was typed. Returns an empty string if no choice extends the text.
+func completeText(text string, choices []string) string {
+	typed := []rune(text)
+	lower := strings.ToLower(text)
+	for _, one := range choices {
+		if runes := []rune(one); len(runes) > len(typed) && strings.HasPrefix(strings.ToLower(one), lower) {
+			return text + string(runes[len(typed):])
+		}
+	}
+	return ""
+}
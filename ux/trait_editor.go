@@ -97,7 +97,7 @@ func initTraitEditor(e *editor[*model.Trait, *model.TraitEditData], content *uni
 	if e.target.Container() {
 		content.AddChild(modifiersPanel)
 	} else {
-		content.AddChild(newPrereqPanel(e.target.Entity, &e.editorData.Prereq))
+		content.AddChild(newPrereqPanel(e.target.Entity, e.target, &e.editorData.Prereq))
 		content.AddChild(newFeaturesPanel(e.target.Entity, e.target, &e.editorData.Features))
 		content.AddChild(modifiersPanel)
 		for _, wt := range model.AllWeaponType {
@@ -106,9 +106,13 @@ func initTraitEditor(e *editor[*model.Trait, *model.TraitEditData], content *uni
 		content.AddChild(newStudyPanel(e.target.Entity, &e.editorData.Study))
 	}
 	e.InstallCmdHandlers(NewTraitModifierItemID, unison.AlwaysEnabled,
-		func(_ any) { modifiersPanel.provider.CreateItem(e, modifiersPanel.table, NoItemVariant) })
+		func(_ any) {
+			modifiersPanel.provider.CreateItem(e, modifiersPanel.table, NoItemVariant, AutoInsertMode)
+		})
 	e.InstallCmdHandlers(NewTraitContainerModifierItemID, unison.AlwaysEnabled,
-		func(_ any) { modifiersPanel.provider.CreateItem(e, modifiersPanel.table, ContainerItemVariant) })
+		func(_ any) {
+			modifiersPanel.provider.CreateItem(e, modifiersPanel.table, ContainerItemVariant, AutoInsertMode)
+		})
 	return func() {
 		if perLevelField != nil {
 			adjustFieldBlank(perLevelField, !e.editorData.CanLevel)
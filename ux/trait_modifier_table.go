@@ -50,8 +50,11 @@ func NewTraitModifierTableDockableFromFile(filePath string) (unison.Dockable, er
 // NewTraitModifierTableDockable creates a new unison.Dockable for trait modifier list files.
 func NewTraitModifierTableDockable(filePath string, modifiers []*model.TraitModifier) *TableDockable[*model.TraitModifier] {
 	provider := &traitModifierListProvider{modifiers: modifiers}
-	return NewTableDockable(filePath, model.TraitModifiersExt,
+	d := NewTableDockable(filePath, model.TraitModifiersExt,
 		NewTraitModifiersProvider(provider, false),
 		func(path string) error { return model.SaveTraitModifiers(provider.TraitModifierList(), path) },
 		NewTraitModifierItemID, NewTraitContainerModifierItemID)
+	d.InstallCmdHandlers(ExportAsMarkdownItemID, unison.AlwaysEnabled, func(_ any) { exportTraitModifiersReference(d) })
+	InstallMergeLibraryCmdHandler(d, model.NewTraitModifiersFromFile, model.TraitModifierMergeKey)
+	return d
 }
@@ -26,7 +26,9 @@ const traitModifierDragKey = "trait_modifier"
 var _ TableProvider[*model.TraitModifier] = &traitModifiersProvider{}
 
 type traitModifiersProvider struct {
+	tagCache
 	table     *unison.Table[*Node[*model.TraitModifier]]
+	nodes     NodeCache[*model.TraitModifier]
 	provider  model.TraitModifierListProvider
 	forEditor bool
 }
@@ -43,7 +45,19 @@ func (p *traitModifiersProvider) RefKey() string {
 	return traitModifierDragKey
 }
 
+func (p *traitModifiersProvider) Count(predicate func(*model.TraitModifier) bool) int {
+	return model.Count(predicate, p.RootData()...)
+}
+
 func (p *traitModifiersProvider) AllTags() []string {
+	if p.forEditor {
+		// The lists embedded in editors are small and are not notified of modifications, so don't cache them.
+		return p.computeAllTags()
+	}
+	return p.tagCache.get(p.computeAllTags)
+}
+
+func (p *traitModifiersProvider) computeAllTags() []string {
 	set := make(map[string]struct{})
 	model.Traverse(func(modifier *model.TraitModifier) bool {
 		for _, tag := range modifier.Tags {
@@ -65,12 +79,7 @@ func (p *traitModifiersProvider) RootRowCount() int {
 }
 
 func (p *traitModifiersProvider) RootRows() []*Node[*model.TraitModifier] {
-	data := p.provider.TraitModifierList()
-	rows := make([]*Node[*model.TraitModifier], 0, len(data))
-	for _, one := range data {
-		rows = append(rows, NewNode[*model.TraitModifier](p.table, nil, one, false))
-	}
-	return rows
+	return p.nodes.Rows(p.table, nil, p.provider.TraitModifierList(), false)
 }
 
 func (p *traitModifiersProvider) SetRootRows(rows []*Node[*model.TraitModifier]) {
@@ -162,9 +171,9 @@ func (p *traitModifiersProvider) OpenEditor(owner Rebuildable, table *unison.Tab
 	})
 }
 
-func (p *traitModifiersProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*model.TraitModifier]], variant ItemVariant) {
+func (p *traitModifiersProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*model.TraitModifier]], variant ItemVariant, mode InsertMode) {
 	item := model.NewTraitModifier(p.Entity(), nil, variant == ContainerItemVariant)
-	InsertItems[*model.TraitModifier](owner, table, p.provider.TraitModifierList, p.provider.SetTraitModifierList,
+	InsertItems[*model.TraitModifier](owner, table, mode, p.provider.TraitModifierList, p.provider.SetTraitModifierList,
 		func(_ *unison.Table[*Node[*model.TraitModifier]]) []*Node[*model.TraitModifier] {
 			return p.RootRows()
 		}, item)
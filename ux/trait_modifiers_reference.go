@@ -0,0 +1,85 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+// ExportTraitModifiersReference returns a one-page Markdown reference of the trait modifiers supplied by the provider.
+// Containers are emitted as headings, with the modifiers they directly hold listed in a table beneath them.
+func ExportTraitModifiersReference(provider TableProvider[*model.TraitModifier]) []byte {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "# %s\n\n", i18n.Text("Trait Modifiers"))
+	writeTraitModifiersReferenceGroup(&buffer, provider.RootRows(), 2)
+	return buffer.Bytes()
+}
+
+func writeTraitModifiersReferenceGroup(buffer *bytes.Buffer, rows []*Node[*model.TraitModifier], level int) {
+	var containers []*Node[*model.TraitModifier]
+	wroteHeader := false
+	for _, row := range rows {
+		if row.CanHaveChildren() {
+			containers = append(containers, row)
+			continue
+		}
+		if !wroteHeader {
+			fmt.Fprintf(buffer, "| %s | %s | %s |\n| --- | --- | --- |\n", i18n.Text("Trait Modifier"),
+				i18n.Text("Cost Modifier"), i18n.Text("Ref"))
+			wroteHeader = true
+		}
+		fmt.Fprintf(buffer, "| %s | %s | %s |\n", traitModifierReferenceCell(row, model.TraitModifierDescriptionColumn),
+			traitModifierReferenceCell(row, model.TraitModifierCostColumn),
+			traitModifierReferenceCell(row, model.TraitModifierReferenceColumn))
+	}
+	if wroteHeader {
+		buffer.WriteByte('\n')
+	}
+	headingLevel := level
+	if headingLevel > 6 {
+		headingLevel = 6
+	}
+	for _, container := range containers {
+		fmt.Fprintf(buffer, "%s %s\n\n", strings.Repeat("#", headingLevel),
+			traitModifierReferenceCell(container, model.TraitModifierDescriptionColumn))
+		writeTraitModifiersReferenceGroup(buffer, container.Children(), level+1)
+	}
+}
+
+func traitModifierReferenceCell(row *Node[*model.TraitModifier], columnID int) string {
+	var data model.CellData
+	row.Data().CellData(columnID, &data)
+	return model.MarkdownCellText(&data)
+}
+
+func exportTraitModifiersReference(d *TableDockable[*model.TraitModifier]) {
+	dialog := unison.NewSaveDialog()
+	dialog.SetInitialDirectory(filepath.Dir(d.BackingFilePath()))
+	dialog.SetAllowedExtensions("md")
+	if dialog.RunModal() {
+		if filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), "md", false); ok {
+			model.GlobalSettings().SetLastDir(model.DefaultLastDirKey, filepath.Dir(filePath))
+			if err := os.WriteFile(filePath, ExportTraitModifiersReference(d.provider), 0o640); err != nil {
+				unison.ErrorDialogWithError(i18n.Text("Unable to export as Markdown!"), errs.Wrap(err))
+			}
+		}
+	}
+}
@@ -27,6 +27,7 @@ var _ TableProvider[*model.Trait] = &traitsProvider{}
 
 type traitsProvider struct {
 	table    *unison.Table[*Node[*model.Trait]]
+	nodes    NodeCache[*model.Trait]
 	provider model.TraitListProvider
 	forPage  bool
 }
@@ -43,6 +44,10 @@ func (p *traitsProvider) RefKey() string {
 	return model.BlockLayoutTraitsKey
 }
 
+func (p *traitsProvider) Count(predicate func(*model.Trait) bool) int {
+	return model.Count(predicate, p.RootData()...)
+}
+
 func (p *traitsProvider) AllTags() []string {
 	set := make(map[string]struct{})
 	model.Traverse(func(trait *model.Trait) bool {
@@ -65,12 +70,7 @@ func (p *traitsProvider) RootRowCount() int {
 }
 
 func (p *traitsProvider) RootRows() []*Node[*model.Trait] {
-	data := p.provider.TraitList()
-	rows := make([]*Node[*model.Trait], 0, len(data))
-	for _, one := range data {
-		rows = append(rows, NewNode[*model.Trait](p.table, nil, one, p.forPage))
-	}
-	return rows
+	return p.nodes.Rows(p.table, nil, p.provider.TraitList(), p.forPage)
 }
 
 func (p *traitsProvider) SetRootRows(rows []*Node[*model.Trait]) {
@@ -146,6 +146,9 @@ func (p *traitsProvider) Headers() []unison.TableColumnHeader[*Node[*model.Trait
 			headers = append(headers, NewEditorListHeader[*model.Trait](i18n.Text("Tags"), "", p.forPage))
 		case model.TraitReferenceColumn:
 			headers = append(headers, NewEditorPageRefHeader[*model.Trait](p.forPage))
+		case model.TraitPrereqsColumn:
+			headers = append(headers, NewEditorListHeader[*model.Trait](i18n.Text("Prereqs"),
+				i18n.Text("Prerequisites"), p.forPage))
 		}
 	}
 	return headers
@@ -155,12 +158,12 @@ func (p *traitsProvider) SyncHeader(_ []unison.TableColumnHeader[*Node[*model.Tr
 }
 
 func (p *traitsProvider) ColumnIDs() []int {
-	columnIDs := append(make([]int, 0, 4),
+	columnIDs := append(make([]int, 0, 5),
 		model.TraitDescriptionColumn,
 		model.TraitPointsColumn,
 	)
 	if !p.forPage {
-		columnIDs = append(columnIDs, model.TraitTagsColumn)
+		columnIDs = append(columnIDs, model.TraitTagsColumn, model.TraitPrereqsColumn)
 	}
 	return append(columnIDs, model.TraitReferenceColumn)
 }
@@ -177,9 +180,9 @@ func (p *traitsProvider) OpenEditor(owner Rebuildable, table *unison.Table[*Node
 	OpenEditor[*model.Trait](table, func(item *model.Trait) { EditTrait(owner, item) })
 }
 
-func (p *traitsProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*model.Trait]], variant ItemVariant) {
+func (p *traitsProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*model.Trait]], variant ItemVariant, mode InsertMode) {
 	item := model.NewTrait(p.Entity(), nil, variant == ContainerItemVariant)
-	InsertItems[*model.Trait](owner, table, p.provider.TraitList, p.provider.SetTraitList,
+	InsertItems[*model.Trait](owner, table, mode, p.provider.TraitList, p.provider.SetTraitList,
 		func(_ *unison.Table[*Node[*model.Trait]]) []*Node[*model.Trait] { return p.RootRows() }, item)
 	EditTrait(owner, item)
 }
@@ -0,0 +1,60 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"time"
+
+	"github.com/richardwilkes/unison"
+)
+
+// undoGroup merges a sequence of undo edits into the first of them, so that they can be undone in a single step. Edits
+// are merged when they follow each other within the window, or at any pace while the group is held open. Only edits
+// posted through the same undoGroup are merged, and only when nothing else has been posted in between.
+type undoGroup[T any] struct {
+	window  time.Duration
+	held    bool
+	fresh   bool
+	lastAt  time.Time
+	pending *unison.UndoEdit[T]
+}
+
+func newUndoGroup[T any](window time.Duration) *undoGroup[T] {
+	return &undoGroup[T]{window: window}
+}
+
+// setHeld opens or closes the group. Either way, the next edit starts a new group.
+func (g *undoGroup[T]) setHeld(held bool) {
+	g.held = held
+	g.fresh = true
+}
+
+// add posts the edit to the undo manager, merging it into the previous edit if it is part of the same group.
+func (g *undoGroup[T]) add(mgr *unison.UndoManager, edit *unison.UndoEdit[T]) {
+	if mgr == nil {
+		return
+	}
+	edit.AbsorbFunc = g.absorb
+	g.pending = edit
+	mgr.Add(edit)
+	g.pending = nil
+	g.fresh = false
+	g.lastAt = time.Now()
+}
+
+func (g *undoGroup[T]) absorb(e *unison.UndoEdit[T], other unison.Undoable) bool {
+	if g.pending == nil || other != g.pending || g.fresh || (!g.held && time.Since(g.lastAt) > g.window) {
+		return false
+	}
+	e.AfterData = g.pending.AfterData
+	return true
+}
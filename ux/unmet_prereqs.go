@@ -0,0 +1,68 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"github.com/google/uuid"
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/unison"
+)
+
+func canSelectNextUnmetPrereq[T model.NodeTypes](table *unison.Table[*Node[T]]) bool {
+	for _, row := range flattenRows(table.RootRows()) {
+		if model.HasUnmetPrereqs(row.Data()) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectNextUnmetPrereq selects the next row after the current selection whose prerequisites are not met, wrapping
+// around to the start of the table if needed. Closed containers are opened to reveal the row.
+func selectNextUnmetPrereq[T model.NodeTypes](table *unison.Table[*Node[T]]) {
+	rows := flattenRows(table.RootRows())
+	if len(rows) == 0 {
+		return
+	}
+	start := 0
+	if last := table.LastSelectedRowIndex(); last != -1 {
+		current := table.RowFromIndex(last).UUID()
+		for i, row := range rows {
+			if row.UUID() == current {
+				start = i + 1
+				break
+			}
+		}
+	}
+	for i := range rows {
+		row := rows[(start+i)%len(rows)]
+		if model.HasUnmetPrereqs(row.Data()) {
+			table.DiscloseRow(row, false)
+			table.SetSelectionMap(map[uuid.UUID]bool{row.UUID(): true})
+			if index := table.RowToIndex(row); index != -1 {
+				table.ScrollRowIntoView(index)
+			}
+			return
+		}
+	}
+}
+
+func flattenRows[T model.NodeTypes](rows []*Node[T]) []*Node[T] {
+	var result []*Node[T]
+	for _, row := range rows {
+		result = append(result, row)
+		if row.CanHaveChildren() {
+			result = append(result, flattenRows(row.Children())...)
+		}
+	}
+	return result
+}
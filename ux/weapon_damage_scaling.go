@@ -0,0 +1,109 @@
+/*
+ * Copyright ©1998-2022 by Richard A. Wilkes. All rights reserved.
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, version 2.0. If a copy of the MPL was not distributed with
+ * this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+ *
+ * This Source Code Form is "Incompatible With Secondary Licenses", as
+ * defined by the Mozilla Public License, version 2.0.
+ */
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+type weaponDamageUndoEdit = *unison.UndoEdit[*weaponDamageList]
+
+type weaponDamageList struct {
+	Table *unison.Table[*Node[*model.Weapon]]
+	List  []*weaponDamageAdjuster
+}
+
+func (a *weaponDamageList) Apply() {
+	for _, one := range a.List {
+		one.Apply()
+	}
+	a.Finish()
+}
+
+func (a *weaponDamageList) Finish() {
+	if entity := a.List[0].Target.Entity(); entity != nil {
+		entity.Recalculate()
+	}
+	a.Table.SyncToModel()
+	MarkModified(a.Table)
+}
+
+type weaponDamageAdjuster struct {
+	Target *model.Weapon
+	Damage *model.WeaponDamage
+}
+
+func newWeaponDamageAdjuster(target *model.Weapon) *weaponDamageAdjuster {
+	return &weaponDamageAdjuster{
+		Target: target,
+		Damage: target.Damage.Clone(target),
+	}
+}
+
+func (a *weaponDamageAdjuster) Apply() {
+	a.Target.Damage = *a.Damage.Clone(a.Target)
+}
+
+func installScaleWeaponDamageHandler(table *unison.Table[*Node[*model.Weapon]]) {
+	table.InstallCmdHandlers(ScaleWeaponDamageItemID,
+		func(_ any) bool { return table.HasSelection() },
+		func(_ any) { scaleWeaponDamage(table) })
+}
+
+func scaleWeaponDamage(table *unison.Table[*Node[*model.Weapon]]) {
+	popup := unison.NewPopupMenu[model.DamageScaling]()
+	for _, one := range model.AllDamageScaling {
+		popup.AddItem(one)
+	}
+	popup.SelectIndex(0)
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	label := unison.NewLabel()
+	label.Text = i18n.Text("Scale damage of selected weapons by")
+	panel.AddChild(label)
+	panel.AddChild(popup)
+	if unison.QuestionDialogWithPanel(panel) != unison.ModalResponseOK {
+		return
+	}
+	scaling, ok := popup.Selected()
+	if !ok {
+		return
+	}
+	before := &weaponDamageList{Table: table}
+	after := &weaponDamageList{Table: table}
+	for _, row := range table.SelectedRows(false) {
+		if w := row.Data(); w != nil {
+			before.List = append(before.List, newWeaponDamageAdjuster(w))
+			scaling.Apply(&w.Damage)
+			after.List = append(after.List, newWeaponDamageAdjuster(w))
+		}
+	}
+	if len(before.List) > 0 {
+		if mgr := unison.UndoManagerFor(table); mgr != nil {
+			mgr.Add(&unison.UndoEdit[*weaponDamageList]{
+				ID:         unison.NextUndoID(),
+				EditName:   i18n.Text("Scale Damage"),
+				UndoFunc:   func(edit weaponDamageUndoEdit) { edit.BeforeData.Apply() },
+				RedoFunc:   func(edit weaponDamageUndoEdit) { edit.AfterData.Apply() },
+				BeforeData: before,
+				AfterData:  after,
+			})
+		}
+		before.Finish()
+	}
+}
@@ -12,8 +12,14 @@
 package ux
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/richardwilkes/gcs/v5/model"
 	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/rpgtools/dice"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/unison"
 )
@@ -31,10 +37,23 @@ func EditWeapon(owner Rebuildable, w *model.Weapon) {
 }
 
 func initWeaponEditor(e *editor[*model.Weapon, *model.Weapon], content *unison.Panel) func() {
+	var defaults *defaultsPanel
+	addWeaponLibraryPicker(e, content, func() { defaults.rebuild() })
 	addLabelAndStringField(content, i18n.Text("Usage"), "", &e.editorData.Usage)
 	addNotesLabelAndField(content, &e.editorData.UsageNotes)
 	addLabelAndStringField(content, i18n.Text("Minimum ST"), "", &e.editorData.MinimumStrength)
-	addLabelAndPopup(content, i18n.Text("Base Damage"), "", model.AllStrengthDamage, &e.editorData.Damage.StrengthType)
+	baseDamageLabel := i18n.Text("Base Damage")
+	wrapper := addFlowWrapper(content, baseDamageLabel, 3)
+	addPopup(wrapper, model.AllStrengthDamage, &e.editorData.Damage.StrengthType)
+	wrapper.AddChild(NewNonEditableField(func(field *NonEditableField) {
+		field.Text = strengthDamagePreview(e.editorData)
+		field.MarkForLayoutAndRedraw()
+	}))
+	tableButton := unison.NewButton()
+	tableButton.Text = i18n.Text("Damage by ST…")
+	tableButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Show the damage this weapon does across a range of ST values"))
+	tableButton.ClickCallback = func() { showWeaponDamageTable(e.editorData) }
+	wrapper.AddChild(tableButton)
 	addLabelAndNullableDice(content, i18n.Text("Damage Modifier"), "", &e.editorData.Damage.Base)
 	addLabelAndDecimalField(content, nil, "", i18n.Text("Damage Modifier Per Die"), "", &e.editorData.Damage.ModifierPerDie,
 		fxp.Min, fxp.Max)
@@ -47,7 +66,13 @@ func initWeaponEditor(e *editor[*model.Weapon, *model.Weapon], content *unison.P
 	switch e.editorData.Type {
 	case model.MeleeWeaponType:
 		addLabelAndStringField(content, i18n.Text("Reach"), "", &e.editorData.Reach)
+		content.AddChild(unison.NewPanel())
+		addCheckBox(content, i18n.Text("Reach increases by 1 when wielded two-handed"), &e.editorData.ReachTwoHanded)
+		addLabelAndStringField(content, i18n.Text("Reach Notes"),
+			i18n.Text("Conditions that alter the reach, such as a minimum ST or posture"), &e.editorData.ReachNotes)
 		addLabelAndStringField(content, i18n.Text("Parry Modifier"), "", &e.editorData.Parry)
+		addLabelAndStringField(content, i18n.Text("Parry Notes"),
+			i18n.Text("Conditions that alter the parry, such as a minimum ST or posture"), &e.editorData.ParryNotes)
 		addLabelAndStringField(content, i18n.Text("Block Modifier"), "", &e.editorData.Block)
 	case model.RangedWeaponType:
 		addLabelAndStringField(content, i18n.Text("Accuracy"), "", &e.editorData.Accuracy)
@@ -57,6 +82,159 @@ func initWeaponEditor(e *editor[*model.Weapon, *model.Weapon], content *unison.P
 		addLabelAndStringField(content, i18n.Text("Shots"), "", &e.editorData.Shots)
 		addLabelAndStringField(content, i18n.Text("Bulk"), "", &e.editorData.Bulk)
 	}
-	content.AddChild(newDefaultsPanel(e.editorData.Entity(), &e.editorData.Defaults))
+	addWeaponStatBlock(e, content)
+	defaults = newDefaultsPanel(e.editorData.Entity(), &e.editorData.Defaults)
+	defaults.addBestDefaultPreview(e.editorData.BestDefault)
+	content.AddChild(defaults)
 	return nil
 }
+
+func addWeaponLibraryPicker(e *editor[*model.Weapon, *model.Weapon], content *unison.Panel, filled func()) {
+	wrapper := addFlowWrapper(content, i18n.Text("Library"), 2)
+	search := unison.NewField()
+	search.Watermark = i18n.Text("Search")
+	search.SetMinimumTextWidthUsing("Something reasonable")
+	search.Tooltip = unison.NewTooltipWithText(i18n.Text("Limit the choices to weapons whose names contain this text"))
+	wrapper.AddChild(search)
+	placeholder := &model.WeaponLibraryEntry{Name: i18n.Text("Choose a weapon to fill in the fields below…")}
+	popup := unison.NewPopupMenu[*model.WeaponLibraryEntry]()
+	popup.Tooltip = unison.NewTooltipWithText(i18n.Text("Fill in the fields below from a weapon found in the libraries"))
+	populate := func() {
+		popup.RemoveAllItems()
+		popup.AddItem(placeholder)
+		filter := strings.ToLower(strings.TrimSpace(search.Text()))
+		for _, entry := range model.GlobalWeaponLibraryIndex().Entries(e.editorData.Type) {
+			if filter == "" || strings.Contains(strings.ToLower(entry.Name), filter) {
+				popup.AddItem(entry)
+			}
+		}
+		popup.Select(placeholder)
+	}
+	populate()
+	search.ModifiedCallback = func(_, _ *unison.FieldState) { populate() }
+	popup.ChoiceMadeCallback = func(_ *unison.PopupMenu[*model.WeaponLibraryEntry], _ int, item *model.WeaponLibraryEntry) {
+		if item.Weapon != nil {
+			e.editorData.FillFromLibraryWeapon(item.Weapon)
+			filled()
+			e.MarkModified(nil)
+		}
+	}
+	popup.MouseDownCallback = func(where unison.Point, button, clickCount int, mod unison.Modifiers) bool {
+		if popup.ItemCount() == 1 {
+			// The index may have finished building in the background since the last time the choices were gathered.
+			populate()
+		}
+		return popup.DefaultMouseDown(where, button, clickCount, mod)
+	}
+	wrapper.AddChild(popup)
+}
+
+func addWeaponStatBlock(e *editor[*model.Weapon, *model.Weapon], content *unison.Panel) {
+	wrapper := addFlowWrapper(content, i18n.Text("Stat Block"), 2)
+	wrapper.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: unison.FillAlignment,
+		HGrab:  true,
+	})
+	preview := NewNonEditableField(func(field *NonEditableField) {
+		field.Text = e.editorData.StatBlock()
+		field.MarkForLayoutAndRedraw()
+	})
+	preview.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: unison.FillAlignment,
+		HGrab:  true,
+	})
+	wrapper.AddChild(preview)
+	copyButton := unison.NewSVGButton(svg.Copy)
+	copyButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Copy stat block"))
+	copyButton.ClickCallback = func() { unison.GlobalClipboard.SetText(e.editorData.StatBlock()) }
+	wrapper.AddChild(copyButton)
+}
+
+// strengthDamagePreview returns the thrust or swing damage the weapon's owning character currently gets for the
+// selected strength damage type, or an empty string if there is no character or no strength-based damage.
+func strengthDamagePreview(w *model.Weapon) string {
+	pc := w.PC()
+	if pc == nil {
+		return ""
+	}
+	st := fxp.As[int](pc.StrengthOrZero() + pc.StrikingStrengthBonus)
+	var damage *dice.Dice
+	switch w.Damage.StrengthType {
+	case model.ThrustStrengthDamage, model.LeveledThrustStrengthDamage:
+		damage = pc.ThrustFor(st)
+	case model.SwingStrengthDamage, model.LeveledSwingStrengthDamage:
+		damage = pc.SwingFor(st)
+	default:
+		return ""
+	}
+	return fmt.Sprintf(i18n.Text("%s at ST %d"), damage.String(), st)
+}
+
+func showWeaponDamageTable(w *model.Weapon) {
+	minST := 8
+	maxST := 20
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  5,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	table := unison.NewPanel()
+	table.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing * 2,
+	})
+	table.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  5,
+		HAlign: unison.FillAlignment,
+		HGrab:  true,
+	})
+	var rows []model.WeaponDamageTableRow
+	update := func() {
+		rows = w.DamageTable(minST, maxST)
+		table.RemoveAllChildren()
+		for _, title := range []string{i18n.Text("ST"), i18n.Text("Damage")} {
+			label := unison.NewLabel()
+			label.Text = title
+			label.Font = unison.SystemFont
+			table.AddChild(label)
+		}
+		for _, row := range rows {
+			label := unison.NewLabel()
+			label.Text = strconv.Itoa(row.ST)
+			label.HAlign = unison.EndAlignment
+			table.AddChild(label)
+			label = unison.NewLabel()
+			label.Text = row.Damage.String()
+			table.AddChild(label)
+		}
+		if wnd := table.Window(); wnd != nil {
+			wnd.Pack()
+		}
+		table.MarkForLayoutAndRedraw()
+	}
+	title := i18n.Text("From ST")
+	panel.AddChild(NewFieldLeadingLabel(title))
+	panel.AddChild(NewIntegerField(nil, "", title, func() int { return minST }, func(v int) {
+		minST = v
+		update()
+	}, 1, 100, false, false))
+	title = i18n.Text("To ST")
+	panel.AddChild(NewFieldLeadingLabel(title))
+	panel.AddChild(NewIntegerField(nil, "", title, func() int { return maxST }, func(v int) {
+		maxST = v
+		update()
+	}, 1, 100, false, false))
+	copyButton := unison.NewSVGButton(svg.Copy)
+	copyButton.Tooltip = unison.NewTooltipWithText(i18n.Text("Copy the table as tab-separated text"))
+	copyButton.ClickCallback = func() { unison.GlobalClipboard.SetText(model.DamageTableText(rows)) }
+	panel.AddChild(copyButton)
+	panel.AddChild(table)
+	update()
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{unison.NewOKButtonInfo()})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create damage table dialog"), err)
+		return
+	}
+	dialog.RunModal()
+}
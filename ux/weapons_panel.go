@@ -49,6 +49,7 @@ func newWeaponsPanel(cmdRoot Rebuildable, weaponOwner model.WeaponOwner, weaponT
 	p.provider = NewWeaponsProvider(p, p.weaponType, false)
 	p.table = newEditorTable(p.AsPanel(), p.provider)
 	p.table.RefKey = weaponType.Key() + "-" + uuid.New().String()
+	installScaleWeaponDamageHandler(p.table)
 	var id int
 	switch weaponType {
 	case model.MeleeWeaponType:
@@ -59,7 +60,7 @@ func newWeaponsPanel(cmdRoot Rebuildable, weaponOwner model.WeaponOwner, weaponT
 		return p
 	}
 	cmdRoot.AsPanel().InstallCmdHandlers(id, unison.AlwaysEnabled,
-		func(_ any) { p.provider.CreateItem(cmdRoot, p.table, NoItemVariant) })
+		func(_ any) { p.provider.CreateItem(cmdRoot, p.table, NoItemVariant, AutoInsertMode) })
 	return p
 }
 
@@ -23,6 +23,7 @@ var _ TableProvider[*model.Weapon] = &weaponsProvider{}
 
 type weaponsProvider struct {
 	table      *unison.Table[*Node[*model.Weapon]]
+	nodes      NodeCache[*model.Weapon]
 	provider   model.WeaponListProvider
 	weaponType model.WeaponType
 	forPage    bool
@@ -41,6 +42,10 @@ func (p *weaponsProvider) RefKey() string {
 	return p.weaponType.Key()
 }
 
+func (p *weaponsProvider) Count(predicate func(*model.Weapon) bool) int {
+	return model.Count(predicate, p.RootData()...)
+}
+
 func (p *weaponsProvider) AllTags() []string {
 	return nil
 }
@@ -54,12 +59,7 @@ func (p *weaponsProvider) RootRowCount() int {
 }
 
 func (p *weaponsProvider) RootRows() []*Node[*model.Weapon] {
-	data := p.provider.Weapons(p.weaponType)
-	rows := make([]*Node[*model.Weapon], 0, len(data))
-	for _, one := range data {
-		rows = append(rows, NewNode[*model.Weapon](p.table, nil, one, p.forPage))
-	}
-	return rows
+	return p.nodes.Rows(p.table, nil, p.provider.Weapons(p.weaponType), p.forPage)
 }
 
 func (p *weaponsProvider) SetRootRows(rows []*Node[*model.Weapon]) {
@@ -196,10 +196,10 @@ func (p *weaponsProvider) OpenEditor(owner Rebuildable, table *unison.Table[*Nod
 	}
 }
 
-func (p *weaponsProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*model.Weapon]], _ ItemVariant) {
+func (p *weaponsProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*model.Weapon]], _ ItemVariant, mode InsertMode) {
 	if !p.forPage {
 		wpn := model.NewWeapon(p.provider.WeaponOwner(), p.weaponType)
-		InsertItems[*model.Weapon](owner, table,
+		InsertItems[*model.Weapon](owner, table, mode,
 			func() []*model.Weapon { return p.provider.Weapons(p.weaponType) },
 			func(list []*model.Weapon) { p.provider.SetWeapons(p.weaponType, list) },
 			func(_ *unison.Table[*Node[*model.Weapon]]) []*Node[*model.Weapon] { return p.RootRows() },
@@ -235,5 +235,10 @@ func (p *weaponsProvider) ContextMenuItems() []ContextMenuItem {
 	case model.RangedWeaponType:
 		list = append(list, ContextMenuItem{i18n.Text("New Ranged Weapon"), NewRangedWeaponItemID})
 	}
+	if p.forPage {
+		list = append(list, ContextMenuItem{i18n.Text("Resolve Attack…"), ResolveAttackItemID},
+			ContextMenuItem{i18n.Text("Roll Damage…"), RollDamageItemID})
+	}
+	list = append(list, ContextMenuItem{i18n.Text("Scale Damage…"), ScaleWeaponDamageItemID})
 	return AppendDefaultContextMenuItems(list)
 }
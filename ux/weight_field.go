@@ -36,10 +36,10 @@ func NewWeightField(targetMgr *TargetMgr, targetKey, undoTitle string, entity *m
 		}
 	}
 	format := func(value model.Weight) string {
-		return model.SheetSettingsFor(entity).DefaultWeightUnits.Format(value)
+		return model.DisplayNumberFormatter().FormatWeight(value, model.SheetSettingsFor(entity).DefaultWeightUnits)
 	}
 	extract := func(s string) (model.Weight, error) {
-		return model.WeightFromString(s, model.SheetSettingsFor(entity).DefaultWeightUnits)
+		return model.DisplayNumberFormatter().ExtractWeight(s, model.SheetSettingsFor(entity).DefaultWeightUnits)
 	}
 	f := NewNumericField[model.Weight](targetMgr, targetKey, undoTitle, getPrototypes, get, set, format, extract, min, max)
 	f.RuneTypedCallback = f.DefaultRuneTyped
@@ -12,11 +12,14 @@
 package ux
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model"
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/unison"
 )
@@ -329,13 +332,20 @@ func addLabelAndNullableDice(parent *unison.Panel, labelText, tooltip string, fi
 		func() string { return data },
 		func(value string) {
 			data = value
-			if value == "" {
+			if strings.TrimSpace(value) == "" {
 				*fieldData = nil
-			} else {
-				*fieldData = dice.New(data)
+			} else if d, err := model.ParseDice(value); err == nil {
+				*fieldData = d
 			}
 			MarkModified(parent)
 		})
+	field.ValidateCallback = func() bool {
+		if strings.TrimSpace(field.Text()) == "" {
+			return true
+		}
+		_, err := model.ParseDice(field.Text())
+		return err == nil
+	}
 	if tooltip != "" {
 		label.Tooltip = unison.NewTooltipWithText(tooltip)
 		field.Tooltip = unison.NewTooltipWithText(tooltip)
@@ -464,11 +474,26 @@ func addStringCriteriaPanel(parent *unison.Panel, prefix, notPrefix, undoTitle s
 	popup.SelectionChangedCallback = func(p *unison.PopupMenu[string]) {
 		strCriteria.Compare = model.AllStringCompareTypes[p.SelectedIndex()]
 		adjustFieldBlank(criteriaField, strCriteria.Compare == model.AnyString)
+		criteriaField.Validate()
 		MarkModified(panel)
 	}
 	panel.AddChild(popup)
 	criteriaField = addStringField(panel, undoTitle, "", &strCriteria.Qualifier)
+	criteriaField.ValidateCallback = func() bool {
+		criteria := *strCriteria
+		criteria.Qualifier = criteriaField.Text()
+		err := criteria.Validate()
+		criteriaField.Tooltip = nil
+		if err != nil {
+			var e *errs.Error
+			if errors.As(err, &e) {
+				criteriaField.Tooltip = unison.NewTooltipWithText(e.Message())
+			}
+		}
+		return err == nil
+	}
 	adjustFieldBlank(criteriaField, strCriteria.Compare == model.AnyString)
+	criteriaField.Validate()
 	parent.AddChild(panel)
 	return popup, criteriaField
 }
@@ -483,28 +508,58 @@ func addNumericCriteriaPanel(parent *unison.Panel, targetMgr *TargetMgr, targetK
 		parent.AddChild(unison.NewPanel())
 	}
 	panel := unison.NewPanel()
-	panel.SetLayout(&unison.FlexLayout{
-		Columns:  2,
+	layout := &unison.FlexLayout{
+		Columns:  4,
 		HSpacing: unison.StdHSpacing,
 		VSpacing: unison.StdVSpacing,
 		VAlign:   unison.MiddleAlignment,
-	})
+	}
+	panel.SetLayout(layout)
 	panel.SetLayoutData(&unison.FlexLayoutData{
 		HSpan:  hSpan,
 		HAlign: unison.FillAlignment,
 		HGrab:  true,
 	})
 	popup = unison.NewPopupMenu[string]()
-	for _, one := range model.PrefixedNumericCompareTypeChoices(prefix) {
+	for _, one := range model.PrefixedNumericCompareTypeChoices(prefix, model.AllNumericCompareTypes) {
 		popup.AddItem(one)
 	}
 	popup.SelectIndex(model.ExtractNumericCompareTypeIndex(string(numCriteria.Compare)))
+	andLabel := unison.NewLabel()
+	andLabel.Text = i18n.Text("and")
+	var maxField unison.Paneler
+	adjustRange := func() {
+		between := numCriteria.Compare == model.BetweenNumber
+		if between == (andLabel.Parent() != nil) {
+			return
+		}
+		if between {
+			panel.AddChild(andLabel)
+			panel.AddChild(maxField)
+		} else {
+			andLabel.RemoveFromParent()
+			maxField.AsPanel().RemoveFromParent()
+		}
+		layout.Columns = len(panel.Children())
+		panel.MarkForLayoutRecursivelyUpward()
+		panel.MarkForRedraw()
+	}
 	popup.SelectionChangedCallback = func(p *unison.PopupMenu[string]) {
 		numCriteria.Compare = model.AllNumericCompareTypes[p.SelectedIndex()]
+		if numCriteria.Compare == model.BetweenNumber && numCriteria.MaxQualifier < numCriteria.Qualifier {
+			numCriteria.MaxQualifier = numCriteria.Qualifier
+			maxField.(Syncer).Sync()
+		}
 		adjustFieldBlank(field, numCriteria.Compare == model.AnyNumber)
+		adjustRange()
 		MarkModified(panel)
 	}
 	panel.AddChild(popup)
+	maxUndoTitle := fmt.Sprintf(i18n.Text("%s Maximum"), undoTitle)
+	maxTargetKey := ""
+	if targetKey != "" {
+		maxTargetKey = targetKey + ".max"
+	}
 	if integerOnly {
 		field = NewIntegerField(targetMgr, targetKey, undoTitle,
 			func() int { return fxp.As[int](numCriteria.Qualifier) },
@@ -513,17 +568,29 @@ func addNumericCriteriaPanel(parent *unison.Panel, targetMgr *TargetMgr, targetK
 				MarkModified(panel)
 			}, fxp.As[int](min), fxp.As[int](max), false, false)
 		panel.AddChild(field)
+		panel.AddChild(andLabel)
+		maxField = NewIntegerField(targetMgr, maxTargetKey, maxUndoTitle,
+			func() int { return fxp.As[int](numCriteria.MaxQualifier) },
+			func(value int) {
+				numCriteria.MaxQualifier = fxp.From(value)
+				MarkModified(panel)
+			}, fxp.As[int](min), fxp.As[int](max), false, false)
+		panel.AddChild(maxField)
 	} else {
 		field = addDecimalField(panel, targetMgr, targetKey, undoTitle, "", &numCriteria.Qualifier, min, max)
+		panel.AddChild(andLabel)
+		maxField = addDecimalField(panel, targetMgr, maxTargetKey, maxUndoTitle, "", &numCriteria.MaxQualifier,
+			min, max)
 	}
 	adjustFieldBlank(field, numCriteria.Compare == model.AnyNumber)
+	adjustRange()
 	parent.AddChild(panel)
 	return popup, field
 }
 
 func addWeightCriteriaPanel(parent *unison.Panel, targetMgr *TargetMgr, targetKey string, entity *model.Entity, weightCriteria *model.WeightCriteria) {
 	popup := unison.NewPopupMenu[string]()
-	for _, one := range model.PrefixedNumericCompareTypeChoices(i18n.Text("which")) {
+	for _, one := range model.PrefixedNumericCompareTypeChoices(i18n.Text("which"), model.SingleValueNumericCompareTypes) {
 		popup.AddItem(one)
 	}
 	popup.SelectIndex(model.ExtractNumericCompareTypeIndex(string(weightCriteria.Compare)))
@@ -531,7 +598,7 @@ func addWeightCriteriaPanel(parent *unison.Panel, targetMgr *TargetMgr, targetKe
 	field := addWeightField(parent, targetMgr, targetKey, i18n.Text("Weight Qualifier"), "", entity,
 		&weightCriteria.Qualifier, false)
 	popup.SelectionChangedCallback = func(p *unison.PopupMenu[string]) {
-		weightCriteria.Compare = model.AllNumericCompareTypes[p.SelectedIndex()]
+		weightCriteria.Compare = model.SingleValueNumericCompareTypes[p.SelectedIndex()]
 		adjustFieldBlank(field, weightCriteria.Compare == model.AnyNumber)
 		MarkModified(parent)
 	}